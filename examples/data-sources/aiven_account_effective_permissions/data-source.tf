@@ -0,0 +1,10 @@
+data "aiven_account_effective_permissions" "foo" {
+  account_id = aiven_account.<ACCOUNT_RESOURCE>.account_id
+}
+
+output "project_admins" {
+  value = [
+    for p in data.aiven_account_effective_permissions.foo.permissions :
+    "${p.project_name}: ${p.user_email}" if p.permission == "admin"
+  ]
+}
@@ -0,0 +1,7 @@
+data "aiven_billing_group_invoices" "bg1" {
+  billing_group_id = aiven_billing_group.bg1.id
+}
+
+output "remaining_credits" {
+  value = [for c in data.aiven_billing_group_invoices.bg1.credits : c.remaining_value]
+}
@@ -0,0 +1,13 @@
+data "aiven_mirrormaker_replication_flow_preview" "f1" {
+  project        = aiven_project.kafka-mm-project1.project
+  service_name   = aiven_kafka_mirrormaker.mm.service_name
+  source_cluster = "source"
+
+  topics = [
+    "orders.*",
+  ]
+}
+
+output "replicated_topics" {
+  value = data.aiven_mirrormaker_replication_flow_preview.f1.matched_topics
+}
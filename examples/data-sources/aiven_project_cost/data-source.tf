@@ -0,0 +1,7 @@
+data "aiven_project_cost" "pr1" {
+  project = aiven_project.pr1.project
+}
+
+output "service_costs" {
+  value = { for s in data.aiven_project_cost.pr1.services : s.service_name => "${s.amount} ${data.aiven_project_cost.pr1.currency}" }
+}
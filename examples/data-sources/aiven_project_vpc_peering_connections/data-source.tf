@@ -0,0 +1,10 @@
+data "aiven_project_vpc_peering_connections" "peerings" {
+  vpc_id = aiven_project_vpc.vpc.id
+}
+
+output "peering_imports" {
+  value = {
+    for pc in data.aiven_project_vpc_peering_connections.peerings.peering_connections :
+    pc.peer_vpc => "${pc.resource_type}: ${pc.import_id}"
+  }
+}
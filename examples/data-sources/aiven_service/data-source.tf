@@ -0,0 +1,4 @@
+data "aiven_service" "svc" {
+  project      = data.aiven_project.pr1.project
+  service_name = "my-redis1"
+}
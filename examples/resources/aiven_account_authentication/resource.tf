@@ -7,3 +7,12 @@ resource "aiven_account_authentication" "foo" {
     saml_entity_id = "https://example.com/00000"
     saml_idp_url = "https://example.com/sso/saml"
 }
+
+# The certificate, entity id and IdP URL can be read from the metadata of the IdP instead
+resource "aiven_account_authentication" "bar" {
+    account_id = aiven_account.<ACCOUNT_RESOURCE>.account_id
+    name = "auth-2"
+    type = "saml"
+    enabled = true
+    saml_idp_metadata_file = "${path.module}/idp-metadata.xml"
+}
@@ -0,0 +1 @@
+terraform import aiven_account_team_members.foo account_id/team_id
@@ -0,0 +1,9 @@
+resource "aiven_account_team_members" "foo" {
+  account_id = aiven_account.<ACCOUNT_RESOURCE>.account_id
+  team_id = aiven_account_team.<TEAM_RESOURCE>.team_id
+  user_emails = [
+    "user+1@example.com",
+    "user+2@example.com",
+  ]
+  resend_invites_after = "168h"
+}
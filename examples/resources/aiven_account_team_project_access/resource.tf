@@ -0,0 +1,9 @@
+resource "aiven_account_team_project_access" "foo" {
+  account_id = aiven_account.<ACCOUNT_RESOURCE>.account_id
+  team_id = aiven_account_team.<TEAM_RESOURCE>.team_id
+  projects = {
+    "project-1" = "admin"
+    "project-2" = "developer"
+    "project-3" = "read_only"
+  }
+}
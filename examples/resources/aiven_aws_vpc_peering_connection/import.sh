@@ -1 +1,3 @@
 terraform import aiven_aws_vpc_peering_connection.foo project_name/vpc_id/aws_account_id/aws_vpc_id/aws_vpc_region
+# or by the AWS VPC ID when it's peered to the project VPC only once
+terraform import aiven_aws_vpc_peering_connection.foo project_name/vpc_id/aws_vpc_id
@@ -1 +1,3 @@
 terraform import aiven_azure_vpc_peering_connection.foo project_name/vpc_id/azure_subscription_id/vnet_name
+# or by the VNet name when it's peered to the project VPC only once
+terraform import aiven_azure_vpc_peering_connection.foo project_name/vpc_id/vnet_name
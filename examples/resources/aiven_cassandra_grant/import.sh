@@ -0,0 +1 @@
+terraform import aiven_cassandra_grant.reader project/service_name/role
@@ -0,0 +1,15 @@
+resource "aiven_cassandra_grant" "reader" {
+  project      = aiven_cassandra.cassandra.project
+  service_name = aiven_cassandra.cassandra.service_name
+  role         = aiven_cassandra_user.reader.username
+
+  permission {
+    permission = "SELECT"
+    keyspace   = aiven_cassandra_keyspace.events.name
+  }
+  permission {
+    permission = "MODIFY"
+    keyspace   = aiven_cassandra_table.events.keyspace
+    table      = aiven_cassandra_table.events.name
+  }
+}
@@ -0,0 +1 @@
+terraform import aiven_cassandra_keyspace.events project/service_name/name
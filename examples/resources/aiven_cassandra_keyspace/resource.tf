@@ -0,0 +1,9 @@
+resource "aiven_cassandra_keyspace" "events" {
+  project      = aiven_cassandra.cassandra.project
+  service_name = aiven_cassandra.cassandra.service_name
+  name         = "events"
+
+  datacenters = {
+    aiven = 3
+  }
+}
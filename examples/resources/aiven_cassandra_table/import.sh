@@ -0,0 +1 @@
+terraform import aiven_cassandra_table.events project/service_name/keyspace/name
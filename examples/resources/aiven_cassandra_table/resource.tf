@@ -0,0 +1,32 @@
+resource "aiven_cassandra_table" "events" {
+  project      = aiven_cassandra_keyspace.events.project
+  service_name = aiven_cassandra_keyspace.events.service_name
+  keyspace     = aiven_cassandra_keyspace.events.name
+  name         = "events"
+
+  column {
+    name = "host"
+    type = "text"
+  }
+  column {
+    name = "day"
+    type = "date"
+  }
+  column {
+    name = "ts"
+    type = "timestamp"
+  }
+  column {
+    name = "payload"
+    type = "map<text, int>"
+  }
+
+  partition_key = ["host", "day"]
+
+  clustering_key {
+    name  = "ts"
+    order = "DESC"
+  }
+
+  default_time_to_live = 604800
+}
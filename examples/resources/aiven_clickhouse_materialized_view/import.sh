@@ -0,0 +1 @@
+terraform import aiven_clickhouse_materialized_view.daily project/service_name/database/name
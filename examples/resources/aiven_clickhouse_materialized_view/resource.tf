@@ -0,0 +1,8 @@
+resource "aiven_clickhouse_materialized_view" "daily" {
+  project      = aiven_clickhouse.clickhouse.project
+  service_name = aiven_clickhouse.clickhouse.service_name
+  database     = aiven_clickhouse_database.analytics.name
+  name         = "events_daily_mv"
+  to_table     = aiven_clickhouse_table.events_daily.name
+  query        = "SELECT toDate(timestamp) AS day, kind, count() AS events FROM analytics.events GROUP BY day, kind"
+}
@@ -0,0 +1 @@
+terraform import aiven_clickhouse_quota.analysts project/service_name/name
@@ -0,0 +1,17 @@
+resource "aiven_clickhouse_quota" "analysts" {
+  project      = aiven_clickhouse.clickhouse.project
+  service_name = aiven_clickhouse.clickhouse.service_name
+  name         = "analysts"
+  keyed_by     = "user_name"
+
+  interval {
+    duration       = 3600
+    max_queries    = 1000
+    max_read_bytes = 100000000000
+  }
+  interval {
+    duration = 86400
+  }
+
+  roles = [aiven_clickhouse_role.analyst.role]
+}
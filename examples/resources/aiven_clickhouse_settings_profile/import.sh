@@ -0,0 +1 @@
+terraform import aiven_clickhouse_settings_profile.analysts project/service_name/name
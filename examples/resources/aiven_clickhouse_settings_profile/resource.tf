@@ -0,0 +1,18 @@
+resource "aiven_clickhouse_settings_profile" "analysts" {
+  project      = aiven_clickhouse.clickhouse.project
+  service_name = aiven_clickhouse.clickhouse.service_name
+  name         = "analysts"
+
+  setting {
+    name  = "max_memory_usage"
+    value = "10000000000"
+    max   = "20000000000"
+  }
+  setting {
+    name     = "readonly"
+    value    = "1"
+    readonly = true
+  }
+
+  roles = [aiven_clickhouse_role.analyst.role]
+}
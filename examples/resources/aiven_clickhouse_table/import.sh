@@ -0,0 +1 @@
+terraform import aiven_clickhouse_table.events project/service_name/database/name
@@ -0,0 +1,25 @@
+resource "aiven_clickhouse_table" "events" {
+  project      = aiven_clickhouse.clickhouse.project
+  service_name = aiven_clickhouse.clickhouse.service_name
+  database     = aiven_clickhouse_database.analytics.name
+  name         = "events"
+  engine       = "MergeTree"
+  order_by     = "(id, timestamp)"
+  partition_by = "toYYYYMM(timestamp)"
+  ttl          = "timestamp + INTERVAL 30 DAY"
+
+  column {
+    name = "id"
+    type = "UInt64"
+  }
+  column {
+    name               = "timestamp"
+    type               = "DateTime"
+    default_expression = "now()"
+  }
+  column {
+    name    = "kind"
+    type    = "LowCardinality(String)"
+    comment = "type of the event"
+  }
+}
@@ -0,0 +1 @@
+terraform import aiven_flink_application.app project/service_name/application_id
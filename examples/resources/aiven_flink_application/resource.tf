@@ -0,0 +1,5 @@
+resource "aiven_flink_application" "app" {
+  project      = aiven_flink.flink.project
+  service_name = aiven_flink.flink.service_name
+  name         = "<APPLICATION_NAME>"
+}
@@ -0,0 +1 @@
+terraform import aiven_flink_application_deployment.deployment project/service_name/application_id/deployment_id
@@ -0,0 +1,7 @@
+resource "aiven_flink_application_deployment" "deployment" {
+  project        = aiven_flink_application_version.v.project
+  service_name   = aiven_flink_application_version.v.service_name
+  application_id = aiven_flink_application_version.v.application_id
+  version_id     = aiven_flink_application_version.v.application_version_id
+  parallelism    = 2
+}
@@ -0,0 +1 @@
+terraform import aiven_flink_application_version.v project/service_name/application_id/application_version_id
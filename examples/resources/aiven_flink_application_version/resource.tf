@@ -0,0 +1,61 @@
+resource "aiven_flink_application_version" "v" {
+  project        = aiven_flink_application.app.project
+  service_name   = aiven_flink_application.app.service_name
+  application_id = aiven_flink_application.app.application_id
+
+  # keep the deployed version until the new one is running
+  lifecycle {
+    create_before_destroy = true
+  }
+
+  statement = <<EOF
+    INSERT INTO cpu_high SELECT * FROM cpu_in WHERE cpu > 75;
+    INSERT INTO cpu_low SELECT * FROM cpu_in WHERE cpu < 5;
+  EOF
+
+  source {
+    integration_id = aiven_service_integration.flink_kafka.integration_id
+    create_table   = <<EOF
+      CREATE TABLE cpu_in (
+        cpu INT,
+        node INT
+      ) WITH (
+        'connector' = 'kafka',
+        'properties.bootstrap.servers' = '',
+        'scan.startup.mode' = 'earliest-offset',
+        'topic' = 'cpu_in',
+        'value.format' = 'json'
+      )
+    EOF
+  }
+
+  sink {
+    integration_id = aiven_service_integration.flink_kafka.integration_id
+    create_table   = <<EOF
+      CREATE TABLE cpu_high (
+        cpu INT,
+        node INT
+      ) WITH (
+        'connector' = 'kafka',
+        'properties.bootstrap.servers' = '',
+        'topic' = 'cpu_high',
+        'value.format' = 'json'
+      )
+    EOF
+  }
+
+  sink {
+    integration_id = aiven_service_integration.flink_kafka.integration_id
+    create_table   = <<EOF
+      CREATE TABLE cpu_low (
+        cpu INT,
+        node INT
+      ) WITH (
+        'connector' = 'kafka',
+        'properties.bootstrap.servers' = '',
+        'topic' = 'cpu_low',
+        'value.format' = 'json'
+      )
+    EOF
+  }
+}
@@ -17,3 +17,31 @@ resource "aiven_flink_table" "table" {
       WATERMARK FOR `+"`occurred_at`"+` AS `+"`occurred_at`"+` - INTERVAL '5' SECOND
     EOF
 }
+
+resource "aiven_flink_table" "table_columns" {
+  project          = data.aiven_project.pr1.project
+  service_name     = aiven_flink.flink.service_name
+  table_name       = "<TABLE_NAME>"
+  integration_id   = aiven_service_integration.flink_kafka.service_id
+  kafka_topic      = aiven_kafka_topic.table_topic.topic_name
+  kafka_key_format = "json"
+  kafka_key_fields = ["node"]
+
+  # the columns can be described as blocks instead of schema_sql
+  column {
+    name     = "node"
+    type     = "INT"
+    nullable = false
+  }
+
+  column {
+    name = "cpu"
+    type = "INT"
+  }
+
+  column {
+    name      = "occurred_at"
+    type      = "TIMESTAMP(3)"
+    watermark = "occurred_at - INTERVAL '5' SECOND"
+  }
+}
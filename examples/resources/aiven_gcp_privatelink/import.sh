@@ -0,0 +1 @@
+terraform import aiven_gcp_privatelink.foo project/service_name
\ No newline at end of file
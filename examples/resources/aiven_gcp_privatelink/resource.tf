@@ -0,0 +1,4 @@
+resource "aiven_gcp_privatelink" "foo" {
+  project      = data.aiven_project.foo.project
+  service_name = aiven_kafka.bar.service_name
+}
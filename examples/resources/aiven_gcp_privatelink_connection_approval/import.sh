@@ -0,0 +1 @@
+terraform import aiven_gcp_privatelink_connection_approval.approval project/service_name
\ No newline at end of file
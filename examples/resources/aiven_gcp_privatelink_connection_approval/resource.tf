@@ -0,0 +1,45 @@
+resource "aiven_pg" "default" {
+  service_name   = "postgres"
+  project        = var.aiven_project_id
+  project_vpc_id = var.aiven_project_vpc_id
+  cloud_name     = var.region
+  plan           = var.plan
+
+  pg_user_config {
+    privatelink_access {
+      pg        = true
+      pgbouncer = true
+    }
+  }
+}
+
+resource "aiven_gcp_privatelink" "privatelink" {
+  project      = var.aiven_project_id
+  service_name = aiven_pg.default.service_name
+}
+
+resource "google_compute_address" "endpoint" {
+  name         = "postgres-endpoint"
+  region       = var.gcp_region
+  subnetwork   = var.gcp_subnetwork_id
+  address_type = "INTERNAL"
+}
+
+resource "google_compute_forwarding_rule" "endpoint" {
+  name                  = "postgres-endpoint"
+  region                = var.gcp_region
+  network               = var.gcp_network_id
+  ip_address            = google_compute_address.endpoint.id
+  target                = aiven_gcp_privatelink.privatelink.google_service_attachment
+  load_balancing_scheme = ""
+}
+
+resource "aiven_gcp_privatelink_connection_approval" "approval" {
+  project         = var.aiven_project_id
+  service_name    = aiven_pg.default.service_name
+  user_ip_address = google_compute_address.endpoint.address
+
+  depends_on = [
+    google_compute_forwarding_rule.endpoint,
+  ]
+}
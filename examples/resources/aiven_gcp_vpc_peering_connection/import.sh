@@ -1 +1,3 @@
 terraform import aiven_gcp_vpc_peering_connection.foo project_name/vpc_id/gcp_project_id/peer_vpc
+# or by the GCP VPC network name when it's peered to the project VPC only once
+terraform import aiven_gcp_vpc_peering_connection.foo project_name/vpc_id/peer_vpc
@@ -3,3 +3,10 @@ resource "aiven_gcp_vpc_peering_connection" "foo" {
   gcp_project_id = "xxxx"
   peer_vpc       = "xxxx"
 }
+
+# peer the GCP network back to the Aiven VPC network
+resource "google_compute_network_peering" "aiven" {
+  name         = "aiven"
+  network      = "projects/xxxx/global/networks/xxxx"
+  peer_network = aiven_gcp_vpc_peering_connection.foo.self_link
+}
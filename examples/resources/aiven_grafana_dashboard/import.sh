@@ -0,0 +1 @@
+terraform import aiven_grafana_dashboard.metrics project/service_name/uid
@@ -0,0 +1,6 @@
+resource "aiven_grafana_dashboard" "metrics" {
+  project      = aiven_grafana.grafana1.project
+  service_name = aiven_grafana.grafana1.service_name
+  folder_uid   = aiven_grafana_folder.team.uid
+  config_json  = file("${path.module}/dashboards/metrics.json")
+}
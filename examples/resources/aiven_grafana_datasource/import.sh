@@ -0,0 +1 @@
+terraform import aiven_grafana_datasource.pg project/service_name/uid
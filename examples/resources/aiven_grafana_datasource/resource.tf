@@ -0,0 +1,13 @@
+resource "aiven_pg_user" "grafana" {
+  project      = aiven_pg.pg1.project
+  service_name = aiven_pg.pg1.service_name
+  username     = "grafana"
+}
+
+resource "aiven_grafana_datasource" "pg" {
+  project             = aiven_grafana.grafana1.project
+  service_name        = aiven_grafana.grafana1.service_name
+  name                = "pg"
+  source_service_name = aiven_pg.pg1.service_name
+  source_service_user = aiven_pg_user.grafana.username
+}
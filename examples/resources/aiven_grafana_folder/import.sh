@@ -0,0 +1 @@
+terraform import aiven_grafana_folder.team project/service_name/uid
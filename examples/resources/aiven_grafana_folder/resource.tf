@@ -0,0 +1,5 @@
+resource "aiven_grafana_folder" "team" {
+  project      = aiven_grafana.grafana1.project
+  service_name = aiven_grafana.grafana1.service_name
+  title        = "Team"
+}
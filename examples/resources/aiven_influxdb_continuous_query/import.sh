@@ -0,0 +1 @@
+terraform import aiven_influxdb_continuous_query.cpu_1h project/service_name/database_name/name
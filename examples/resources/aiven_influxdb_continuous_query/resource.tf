@@ -0,0 +1,14 @@
+resource "aiven_influxdb_continuous_query" "cpu_1h" {
+  project        = aiven_influxdb_database.metrics.project
+  service_name   = aiven_influxdb_database.metrics.service_name
+  database_name  = aiven_influxdb_database.metrics.database_name
+  name           = "cpu_1h"
+  resample_every = "30m"
+
+  query = <<EOT
+SELECT mean("usage_user") AS "usage_user"
+  INTO "${aiven_influxdb_retention_policy.one_year.name}"."cpu_1h"
+  FROM "${aiven_influxdb_retention_policy.one_month.name}"."cpu"
+  GROUP BY time(1h), *
+EOT
+}
@@ -0,0 +1 @@
+terraform import aiven_influxdb_retention_policy.one_month project/service_name/database_name/name
@@ -0,0 +1,9 @@
+resource "aiven_influxdb_retention_policy" "one_month" {
+  project              = aiven_influxdb_database.metrics.project
+  service_name         = aiven_influxdb_database.metrics.service_name
+  database_name        = aiven_influxdb_database.metrics.database_name
+  name                 = "one_month"
+  duration             = "30d"
+  shard_group_duration = "1d"
+  default              = true
+}
@@ -0,0 +1 @@
+terraform import aiven_m3db_namespace.hourly project/service_name/name
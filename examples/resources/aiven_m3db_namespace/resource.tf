@@ -0,0 +1,12 @@
+resource "aiven_m3db_namespace" "hourly" {
+  project      = aiven_m3db.m3.project
+  service_name = aiven_m3db.m3.service_name
+  name         = "hourly"
+  type         = "aggregated"
+  resolution   = "1h"
+
+  retention_options {
+    retention_period_duration = "720h"
+    blocksize_duration        = "12h"
+  }
+}
@@ -0,0 +1 @@
+terraform import aiven_opensearch_acl.foo project/service_name
@@ -0,0 +1,27 @@
+resource "aiven_opensearch_acl" "foo" {
+  project      = aiven_opensearch_acl_config.foo.project
+  service_name = aiven_opensearch_acl_config.foo.service_name
+
+  acl {
+    username = aiven_opensearch_user.foo.username
+
+    rule {
+      index      = "logs-*"
+      permission = "read"
+    }
+
+    rule {
+      index      = "metrics-*"
+      permission = "readwrite"
+    }
+  }
+
+  acl {
+    username = "admin-*"
+
+    rule {
+      index      = "*"
+      permission = "admin"
+    }
+  }
+}
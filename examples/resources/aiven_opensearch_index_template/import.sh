@@ -0,0 +1 @@
+terraform import aiven_opensearch_index_template.logs project/service_name/name
@@ -0,0 +1,20 @@
+resource "aiven_opensearch_index_template" "logs" {
+  project      = aiven_opensearch.bar.project
+  service_name = aiven_opensearch.bar.service_name
+  name         = "logs"
+  body = jsonencode({
+    index_patterns = ["logs-*"]
+    priority       = 10
+    template = {
+      settings = {
+        number_of_shards   = 1
+        number_of_replicas = 1
+      }
+      mappings = {
+        properties = {
+          "@timestamp" = { type = "date" }
+        }
+      }
+    }
+  })
+}
@@ -0,0 +1 @@
+terraform import aiven_opensearch_ism_policy.logs project/service_name/policy_id
@@ -0,0 +1,22 @@
+resource "aiven_opensearch_ism_policy" "logs" {
+  project      = aiven_opensearch.bar.project
+  service_name = aiven_opensearch.bar.service_name
+  policy_id    = "logs"
+  policy = jsonencode({
+    description   = "Delete the logs after 30 days"
+    default_state = "hot"
+    states = [
+      {
+        name        = "hot"
+        actions     = []
+        transitions = [{ state_name = "delete", conditions = { min_index_age = "30d" } }]
+      },
+      {
+        name        = "delete"
+        actions     = [{ delete = {} }]
+        transitions = []
+      }
+    ]
+    ism_template = [{ index_patterns = ["logs-*"] }]
+  })
+}
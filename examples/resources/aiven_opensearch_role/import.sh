@@ -0,0 +1 @@
+AIVEN_OPENSEARCH_SECURITY_ADMIN_PASSWORD=... terraform import aiven_opensearch_role.readers project/service_name/role_name
@@ -0,0 +1,20 @@
+resource "aiven_opensearch_role" "readers" {
+  project             = aiven_opensearch_security_config.foo.project
+  service_name        = aiven_opensearch_security_config.foo.service_name
+  admin_password      = aiven_opensearch_security_config.foo.admin_password
+  role_name           = "readers"
+  cluster_permissions = ["cluster_monitor"]
+
+  index_permission {
+    index_patterns  = ["logs-*"]
+    allowed_actions = ["read"]
+    dls             = jsonencode({ term = { team = "a" } })
+    fls             = ["~secret"]
+    masked_fields   = ["email"]
+  }
+
+  tenant_permission {
+    tenant_patterns = ["team_a"]
+    allowed_actions = ["kibana_all_read"]
+  }
+}
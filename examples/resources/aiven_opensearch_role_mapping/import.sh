@@ -0,0 +1 @@
+AIVEN_OPENSEARCH_SECURITY_ADMIN_PASSWORD=... terraform import aiven_opensearch_role_mapping.readers project/service_name/role_name
@@ -0,0 +1,8 @@
+resource "aiven_opensearch_role_mapping" "readers" {
+  project        = aiven_opensearch_role.readers.project
+  service_name   = aiven_opensearch_role.readers.service_name
+  admin_password = aiven_opensearch_security_config.foo.admin_password
+  role_name      = aiven_opensearch_role.readers.role_name
+  users          = [aiven_opensearch_user.foo.username]
+  backend_roles  = ["team-a"]
+}
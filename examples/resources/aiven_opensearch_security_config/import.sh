@@ -0,0 +1 @@
+terraform import aiven_opensearch_security_config.foo project/service_name
@@ -0,0 +1,5 @@
+resource "aiven_opensearch_security_config" "foo" {
+  project        = aiven_opensearch.bar.project
+  service_name   = aiven_opensearch.bar.service_name
+  admin_password = var.opensearch_security_admin_password
+}
@@ -0,0 +1 @@
+terraform import aiven_opensearch_snapshot_repository.backups project/service_name/name
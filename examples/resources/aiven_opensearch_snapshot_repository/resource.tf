@@ -0,0 +1,10 @@
+resource "aiven_opensearch_snapshot_repository" "backups" {
+  project      = aiven_opensearch.bar.project
+  service_name = aiven_opensearch.bar.service_name
+  name         = "backups"
+  type         = "s3"
+  settings = {
+    bucket    = "example-opensearch-snapshots"
+    base_path = "example_service_name"
+  }
+}
@@ -7,3 +7,16 @@ resource "aiven_project_vpc" "myvpc" {
     create = "5m"
   }
 }
+
+resource "aiven_project_vpc" "migrated" {
+  project      = aiven_project.myproject.project
+  cloud_name   = "google-europe-west1"
+  network_cidr = "10.1.0.0/24"
+
+  # move the services and peering connections to a new VPC when the range or cloud change
+  migrate_services = true
+
+  timeouts {
+    update = "2h"
+  }
+}
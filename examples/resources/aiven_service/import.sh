@@ -0,0 +1 @@
+terraform import aiven_service.svc project/service_name
@@ -0,0 +1,17 @@
+resource "aiven_service" "svc" {
+  project                 = data.aiven_project.pr1.project
+  service_type            = "redis"
+  cloud_name              = "google-europe-west1"
+  plan                    = "business-4"
+  service_name            = "my-redis1"
+  maintenance_window_dow  = "monday"
+  maintenance_window_time = "10:00:00"
+
+  user_config = jsonencode({
+    redis_maxmemory_policy = "allkeys-random"
+
+    public_access = {
+      redis = true
+    }
+  })
+}
@@ -1 +1,3 @@
-terraform import aiven_transit_gateway_vpc_attachment.attachment project/vpc_id/peer_cloud_account/peer_vpc/peer_region
+terraform import aiven_transit_gateway_vpc_attachment.attachment project/vpc_id/peer_cloud_account/peer_vpc
+# or by the transit gateway ID when it's attached to the project VPC only once
+terraform import aiven_transit_gateway_vpc_attachment.attachment project/vpc_id/peer_vpc
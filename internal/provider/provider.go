@@ -21,6 +21,7 @@ import (
 	"github.com/aiven/terraform-provider-aiven/internal/service/pg"
 	"github.com/aiven/terraform-provider-aiven/internal/service/project"
 	"github.com/aiven/terraform-provider-aiven/internal/service/redis"
+	"github.com/aiven/terraform-provider-aiven/internal/service/service"
 	"github.com/aiven/terraform-provider-aiven/internal/service/service_component"
 	"github.com/aiven/terraform-provider-aiven/internal/service/service_integration"
 	"github.com/aiven/terraform-provider-aiven/internal/service/service_user"
@@ -54,6 +55,7 @@ func Provider() *schema.Provider {
 			"aiven_database":          database.DatasourceDatabase(),        // Deprecated
 			"aiven_service_user":      service_user.DatasourceServiceUser(), // Deprecated
 			"aiven_service_component": service_component.DatasourceServiceComponent(),
+			"aiven_service":           service.DatasourceService(),
 
 			// influxdb
 			"aiven_influxdb":          influxdb.DatasourceInfluxDB(),
@@ -82,26 +84,32 @@ func Provider() *schema.Provider {
 			"aiven_cassandra_user": cassandra.DatasourceCassandraUser(),
 
 			// account
-			"aiven_account":                account.DatasourceAccount(),
-			"aiven_account_team":           account.DatasourceAccountTeam(),
-			"aiven_account_team_project":   account.DatasourceAccountTeamProject(),
-			"aiven_account_team_member":    account.DatasourceAccountTeamMember(),
-			"aiven_account_authentication": account.DatasourceAccountAuthentication(),
+			"aiven_account":                       account.DatasourceAccount(),
+			"aiven_account_team":                  account.DatasourceAccountTeam(),
+			"aiven_account_team_project":          account.DatasourceAccountTeamProject(),
+			"aiven_account_effective_permissions": account.DatasourceAccountEffectivePermissions(),
+			"aiven_account_team_member":           account.DatasourceAccountTeamMember(),
+			"aiven_account_team_members":          account.DatasourceAccountTeamMembers(),
+			"aiven_account_authentication":        account.DatasourceAccountAuthentication(),
 
 			// project
-			"aiven_project":       project.DatasourceProject(),
-			"aiven_project_user":  project.DatasourceProjectUser(),
-			"aiven_billing_group": project.DatasourceBillingGroup(),
+			"aiven_project":                project.DatasourceProject(),
+			"aiven_project_cost":           project.DatasourceProjectCost(),
+			"aiven_project_user":           project.DatasourceProjectUser(),
+			"aiven_billing_group":          project.DatasourceBillingGroup(),
+			"aiven_billing_group_invoices": project.DatasourceBillingGroupInvoices(),
 
 			// vpc
-			"aiven_aws_privatelink":                vpc.DatasourceAWSPrivatelink(),
-			"aiven_aws_vpc_peering_connection":     vpc.DatasourceAWSVPCPeeringConnection(),
-			"aiven_azure_privatelink":              vpc.DatasourceAzurePrivatelink(),
-			"aiven_azure_vpc_peering_connection":   vpc.DatasourceAzureVPCPeeringConnection(),
-			"aiven_gcp_vpc_peering_connection":     vpc.DatasourceGCPVPCPeeringConnection(),
-			"aiven_project_vpc":                    vpc.DatasourceProjectVPC(),
-			"aiven_transit_gateway_vpc_attachment": vpc.DatasourceTransitGatewayVPCAttachment(),
-			"aiven_vpc_peering_connection":         vpc.DatasourceVPCPeeringConnection(), // Deprecated
+			"aiven_aws_privatelink":                 vpc.DatasourceAWSPrivatelink(),
+			"aiven_aws_vpc_peering_connection":      vpc.DatasourceAWSVPCPeeringConnection(),
+			"aiven_azure_privatelink":               vpc.DatasourceAzurePrivatelink(),
+			"aiven_azure_vpc_peering_connection":    vpc.DatasourceAzureVPCPeeringConnection(),
+			"aiven_gcp_privatelink":                 vpc.DatasourceGCPPrivatelink(),
+			"aiven_gcp_vpc_peering_connection":      vpc.DatasourceGCPVPCPeeringConnection(),
+			"aiven_project_vpc":                     vpc.DatasourceProjectVPC(),
+			"aiven_project_vpc_peering_connections": vpc.DatasourceProjectVPCPeeringConnections(),
+			"aiven_transit_gateway_vpc_attachment":  vpc.DatasourceTransitGatewayVPCAttachment(),
+			"aiven_vpc_peering_connection":          vpc.DatasourceVPCPeeringConnection(), // Deprecated
 
 			// service integrations
 			"aiven_service_integration":          service_integration.DatasourceServiceIntegration(),
@@ -113,7 +121,8 @@ func Provider() *schema.Provider {
 			"aiven_m3aggregator": m3db.DatasourceM3Aggregator(),
 
 			// flink
-			"aiven_flink": flink.DatasourceFlink(),
+			"aiven_flink":             flink.DatasourceFlink(),
+			"aiven_flink_application": flink.DatasourceFlinkApplication(),
 
 			// opensearch
 			"aiven_opensearch":            opensearch.DatasourceOpensearch(),
@@ -122,17 +131,18 @@ func Provider() *schema.Provider {
 			"aiven_opensearch_acl_rule":   opensearch.DatasourceOpensearchACLRule(),
 
 			// kafka
-			"aiven_kafka":                        kafka.DatasourceKafka(),
-			"aiven_kafka_user":                   kafka.DatasourceKafkaUser(),
-			"aiven_kafka_acl":                    kafka.DatasourceKafkaACL(),
-			"aiven_kafka_schema_registry_acl":    kafka.DatasourceKafkaSchemaRegistryACL(),
-			"aiven_kafka_topic":                  kafka.DatasourceKafkaTopic(),
-			"aiven_kafka_schema":                 kafka.DatasourceKafkaSchema(),
-			"aiven_kafka_schema_configuration":   kafka.DatasourceKafkaSchemaConfiguration(),
-			"aiven_kafka_connector":              kafka.DatasourceKafkaConnector(),
-			"aiven_mirrormaker_replication_flow": kafka.DatasourceMirrorMakerReplicationFlowTopic(),
-			"aiven_kafka_connect":                kafka.DatasourceKafkaConnect(),
-			"aiven_kafka_mirrormaker":            kafka.DatasourceKafkaMirrormaker(),
+			"aiven_kafka":                                kafka.DatasourceKafka(),
+			"aiven_kafka_user":                           kafka.DatasourceKafkaUser(),
+			"aiven_kafka_acl":                            kafka.DatasourceKafkaACL(),
+			"aiven_kafka_schema_registry_acl":            kafka.DatasourceKafkaSchemaRegistryACL(),
+			"aiven_kafka_topic":                          kafka.DatasourceKafkaTopic(),
+			"aiven_kafka_schema":                         kafka.DatasourceKafkaSchema(),
+			"aiven_kafka_schema_configuration":           kafka.DatasourceKafkaSchemaConfiguration(),
+			"aiven_kafka_connector":                      kafka.DatasourceKafkaConnector(),
+			"aiven_mirrormaker_replication_flow":         kafka.DatasourceMirrorMakerReplicationFlowTopic(),
+			"aiven_mirrormaker_replication_flow_preview": kafka.DatasourceMirrorMakerReplicationFlowPreview(),
+			"aiven_kafka_connect":                        kafka.DatasourceKafkaConnect(),
+			"aiven_kafka_mirrormaker":                    kafka.DatasourceKafkaMirrormaker(),
 
 			// clickhouse
 			"aiven_clickhouse":          clickhouse.DatasourceClickhouse(),
@@ -145,14 +155,20 @@ func Provider() *schema.Provider {
 			"aiven_database":        database.ResourceDatabase(),        // Deprecated
 			"aiven_service_user":    service_user.ResourceServiceUser(), // Deprecated
 			"aiven_static_ip":       static_ip.ResourceStaticIP(),
+			"aiven_service":         service.ResourceService(),
 
 			// influxdb
-			"aiven_influxdb":          influxdb.ResourceInfluxDB(),
-			"aiven_influxdb_user":     influxdb.ResourceInfluxDBUser(),
-			"aiven_influxdb_database": influxdb.ResourceInfluxDBDatabase(),
+			"aiven_influxdb":                  influxdb.ResourceInfluxDB(),
+			"aiven_influxdb_user":             influxdb.ResourceInfluxDBUser(),
+			"aiven_influxdb_database":         influxdb.ResourceInfluxDBDatabase(),
+			"aiven_influxdb_retention_policy": influxdb.ResourceInfluxDBRetentionPolicy(),
+			"aiven_influxdb_continuous_query": influxdb.ResourceInfluxDBContinuousQuery(),
 
 			// grafana
-			"aiven_grafana": grafana.ResourceGrafana(),
+			"aiven_grafana":            grafana.ResourceGrafana(),
+			"aiven_grafana_folder":     grafana.ResourceGrafanaFolder(),
+			"aiven_grafana_dashboard":  grafana.ResourceGrafanaDashboard(),
+			"aiven_grafana_datasource": grafana.ResourceGrafanaDatasource(),
 
 			// mysql
 			"aiven_mysql":          mysql.ResourceMySQL(),
@@ -169,15 +185,20 @@ func Provider() *schema.Provider {
 			"aiven_pg_database": pg.ResourcePGDatabase(),
 
 			// cassandra
-			"aiven_cassandra":      cassandra.ResourceCassandra(),
-			"aiven_cassandra_user": cassandra.ResourceCassandraUser(),
+			"aiven_cassandra":          cassandra.ResourceCassandra(),
+			"aiven_cassandra_user":     cassandra.ResourceCassandraUser(),
+			"aiven_cassandra_keyspace": cassandra.ResourceCassandraKeyspace(),
+			"aiven_cassandra_table":    cassandra.ResourceCassandraTable(),
+			"aiven_cassandra_grant":    cassandra.ResourceCassandraGrant(),
 
 			// account
-			"aiven_account":                account.ResourceAccount(),
-			"aiven_account_team":           account.ResourceAccountTeam(),
-			"aiven_account_team_project":   account.ResourceAccountTeamProject(),
-			"aiven_account_team_member":    account.ResourceAccountTeamMember(),
-			"aiven_account_authentication": account.ResourceAccountAuthentication(),
+			"aiven_account":                     account.ResourceAccount(),
+			"aiven_account_team":                account.ResourceAccountTeam(),
+			"aiven_account_team_project":        account.ResourceAccountTeamProject(),
+			"aiven_account_team_project_access": account.ResourceAccountTeamProjectAccess(),
+			"aiven_account_team_member":         account.ResourceAccountTeamMember(),
+			"aiven_account_team_members":        account.ResourceAccountTeamMembers(),
+			"aiven_account_authentication":      account.ResourceAccountAuthentication(),
 
 			// project
 			"aiven_project":       project.ResourceProject(),
@@ -188,6 +209,8 @@ func Provider() *schema.Provider {
 			"aiven_aws_privatelink":                       vpc.ResourceAWSPrivatelink(),
 			"aiven_azure_privatelink":                     vpc.ResourceAzurePrivatelink(),
 			"aiven_azure_privatelink_connection_approval": vpc.ResourceAzurePrivatelinkConnectionApproval(),
+			"aiven_gcp_privatelink":                       vpc.ResourceGCPPrivatelink(),
+			"aiven_gcp_privatelink_connection_approval":   vpc.ResourceGCPPrivatelinkConnectionApproval(),
 			"aiven_aws_vpc_peering_connection":            vpc.ResourceAWSVPCPeeringConnection(),
 			"aiven_azure_vpc_peering_connection":          vpc.ResourceAzureVPCPeeringConnection(),
 			"aiven_gcp_vpc_peering_connection":            vpc.ResourceGCPVPCPeeringConnection(),
@@ -200,20 +223,31 @@ func Provider() *schema.Provider {
 			"aiven_service_integration_endpoint": service_integration.ResourceServiceIntegrationEndpoint(),
 
 			// m3db
-			"aiven_m3db":         m3db.ResourceM3DB(),
-			"aiven_m3db_user":    m3db.ResourceM3DBUser(),
-			"aiven_m3aggregator": m3db.ResourceM3Aggregator(),
+			"aiven_m3db":           m3db.ResourceM3DB(),
+			"aiven_m3db_user":      m3db.ResourceM3DBUser(),
+			"aiven_m3db_namespace": m3db.ResourceM3DBNamespace(),
+			"aiven_m3aggregator":   m3db.ResourceM3Aggregator(),
 
 			// flink
-			"aiven_flink":       flink.ResourceFlink(),
-			"aiven_flink_table": flink.ResourceFlinkTable(),
-			"aiven_flink_job":   flink.ResourceFlinkJob(),
+			"aiven_flink":                        flink.ResourceFlink(),
+			"aiven_flink_table":                  flink.ResourceFlinkTable(),
+			"aiven_flink_job":                    flink.ResourceFlinkJob(),
+			"aiven_flink_application":            flink.ResourceFlinkApplication(),
+			"aiven_flink_application_version":    flink.ResourceFlinkApplicationVersion(),
+			"aiven_flink_application_deployment": flink.ResourceFlinkApplicationDeployment(),
 
 			// opensearch
-			"aiven_opensearch":            opensearch.ResourceOpensearch(),
-			"aiven_opensearch_user":       opensearch.ResourceOpensearchUser(),
-			"aiven_opensearch_acl_config": opensearch.ResourceOpensearchACLConfig(),
-			"aiven_opensearch_acl_rule":   opensearch.ResourceOpensearchACLRule(),
+			"aiven_opensearch":                     opensearch.ResourceOpensearch(),
+			"aiven_opensearch_user":                opensearch.ResourceOpensearchUser(),
+			"aiven_opensearch_acl_config":          opensearch.ResourceOpensearchACLConfig(),
+			"aiven_opensearch_acl":                 opensearch.ResourceOpensearchACL(),
+			"aiven_opensearch_acl_rule":            opensearch.ResourceOpensearchACLRule(),
+			"aiven_opensearch_security_config":     opensearch.ResourceOpensearchSecurityConfig(),
+			"aiven_opensearch_role":                opensearch.ResourceOpensearchRole(),
+			"aiven_opensearch_role_mapping":        opensearch.ResourceOpensearchRoleMapping(),
+			"aiven_opensearch_index_template":      opensearch.ResourceOpensearchIndexTemplate(),
+			"aiven_opensearch_ism_policy":          opensearch.ResourceOpensearchISMPolicy(),
+			"aiven_opensearch_snapshot_repository": opensearch.ResourceOpensearchSnapshotRepository(),
 
 			// kafka
 			"aiven_kafka":                        kafka.ResourceKafka(),
@@ -229,11 +263,15 @@ func Provider() *schema.Provider {
 			"aiven_kafka_mirrormaker":            kafka.ResourceKafkaMirrormaker(),
 
 			// clickhouse
-			"aiven_clickhouse":          clickhouse.ResourceClickhouse(),
-			"aiven_clickhouse_database": clickhouse.ResourceClickhouseDatabase(),
-			"aiven_clickhouse_user":     clickhouse.ResourceClickhouseUser(),
-			"aiven_clickhouse_role":     clickhouse.ResourceClickhouseRole(),
-			"aiven_clickhouse_grant":    clickhouse.ResourceClickhouseGrant(),
+			"aiven_clickhouse":                   clickhouse.ResourceClickhouse(),
+			"aiven_clickhouse_database":          clickhouse.ResourceClickhouseDatabase(),
+			"aiven_clickhouse_user":              clickhouse.ResourceClickhouseUser(),
+			"aiven_clickhouse_role":              clickhouse.ResourceClickhouseRole(),
+			"aiven_clickhouse_grant":             clickhouse.ResourceClickhouseGrant(),
+			"aiven_clickhouse_table":             clickhouse.ResourceClickhouseTable(),
+			"aiven_clickhouse_materialized_view": clickhouse.ResourceClickhouseMaterializedView(),
+			"aiven_clickhouse_settings_profile":  clickhouse.ResourceClickhouseSettingsProfile(),
+			"aiven_clickhouse_quota":             clickhouse.ResourceClickhouseQuota(),
 		},
 	}
 
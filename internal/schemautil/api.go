@@ -0,0 +1,88 @@
+package schemautil
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/aiven/aiven-go-client"
+)
+
+// apiURL is the base URL of the Aiven API, it honours the same AIVEN_WEB_URL
+// override as the aiven-go-client does.
+func apiURL() string {
+	if v, ok := os.LookupEnv("AIVEN_WEB_URL"); ok {
+		return v + "/v1"
+	}
+	return "https://api.aiven.io/v1"
+}
+
+// BuildAPIPath escapes and joins API path segments the same way aiven-go-client does
+func BuildAPIPath(parts ...string) string {
+	escaped := make([]string, len(parts))
+	for i, p := range parts {
+		escaped[i] = url.PathEscape(p)
+	}
+	return "/" + strings.Join(escaped, "/")
+}
+
+// APIRequest performs a request against an Aiven API endpoint that is not (yet) covered
+// by aiven-go-client. Non-2xx responses are returned as aiven.Error, so aiven.IsNotFound
+// and friends keep working. When out is not nil the response body is decoded into it.
+func APIRequest(ctx context.Context, client *aiven.Client, method, path string, in, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		b, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewBuffer(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, apiURL()+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", client.UserAgent)
+	req.Header.Set("Authorization", "aivenv1 "+client.APIKey)
+
+	rsp, err := client.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer rsp.Body.Close()
+
+	b, err := io.ReadAll(rsp.Body)
+	if err != nil {
+		return err
+	}
+
+	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
+		return aiven.Error{Message: string(b), Status: rsp.StatusCode}
+	}
+
+	if len(b) == 0 {
+		return nil
+	}
+
+	var r aiven.APIResponse
+	if err := json.Unmarshal(b, &r); err != nil {
+		return fmt.Errorf("cannot unmarshal JSON `%s`, error: %w", b, err)
+	}
+	if err := r.GetError(); err != nil {
+		return err
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	return json.Unmarshal(b, out)
+}
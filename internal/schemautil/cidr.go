@@ -0,0 +1,147 @@
+package schemautil
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// reservedVPCAddresses is the number of addresses of a VPC range which can't be used by service
+// nodes: the network and broadcast addresses plus the ones cloud providers keep for the router,
+// DNS and future use
+const reservedVPCAddresses = 5
+
+// ParseIPv4CIDR parses an IPv4 network range, ranges with host bits set are rejected since the API
+// would silently use a different network than the configured one
+func ParseIPv4CIDR(s string) (*net.IPNet, error) {
+	ip, n, err := net.ParseCIDR(s)
+	if err != nil {
+		return nil, fmt.Errorf("%q is not a valid CIDR network range", s)
+	}
+	if ip.To4() == nil {
+		return nil, fmt.Errorf("%q is not an IPv4 network range", s)
+	}
+	if !ip.Equal(n.IP) {
+		return nil, fmt.Errorf("%q has host bits set, did you mean %q?", s, n.String())
+	}
+	return n, nil
+}
+
+// ValidateIPv4CIDR is a schema.SchemaValidateFunc for IPv4 network ranges
+func ValidateIPv4CIDR(i interface{}, k string) (warnings []string, errors []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+	if _, err := ParseIPv4CIDR(v); err != nil {
+		return nil, []error{fmt.Errorf("%s: %w", k, err)}
+	}
+	return nil, nil
+}
+
+// CIDRsOverlap tells if two network ranges share any address
+func CIDRsOverlap(a, b *net.IPNet) bool {
+	return a.Contains(b.IP) || b.Contains(a.IP)
+}
+
+// CheckCIDRsDoNotOverlap parses the ranges and checks that none of them overlap with each other
+func CheckCIDRsDoNotOverlap(attribute string, cidrs []string) ([]*net.IPNet, error) {
+	networks := make([]*net.IPNet, len(cidrs))
+	var errs []string
+	for i, c := range cidrs {
+		n, err := ParseIPv4CIDR(c)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s.%d: %s", attribute, i, err))
+			continue
+		}
+		for j := 0; j < i; j++ {
+			if networks[j] != nil && CIDRsOverlap(networks[j], n) {
+				errs = append(errs, fmt.Sprintf("%s.%d: %s overlaps with %s.%d %s", attribute, i, c, attribute, j, cidrs[j]))
+			}
+		}
+		networks[i] = n
+	}
+
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("%s", strings.Join(errs, "\n"))
+	}
+	return networks, nil
+}
+
+// VPCCIDRCapacity returns the number of service nodes a VPC network range can hold
+func VPCCIDRCapacity(n *net.IPNet) int {
+	ones, bits := n.Mask.Size()
+	return 1<<(bits-ones) - reservedVPCAddresses
+}
+
+// CustomizeDiffCheckProjectVPCCapacity checks that the project VPC of a service has room for the
+// nodes of the service next to the nodes of the other services running in it
+func CustomizeDiffCheckProjectVPCCapacity(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("project_vpc_id") || !d.NewValueKnown("plan") || !d.NewValueKnown("service_type") {
+		return nil
+	}
+
+	vpcID, err := GetProjectVPCIdPointer(d)
+	if err != nil || vpcID == nil {
+		return err
+	}
+
+	client := m.(*aiven.Client)
+	project := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+
+	vpc, err := client.VPCs.Get(project, *vpcID)
+	if err != nil {
+		if aiven.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("unable to get project VPC %s: %w", *vpcID, err)
+	}
+
+	network, err := ParseIPv4CIDR(vpc.NetworkCIDR)
+	if err != nil {
+		return nil
+	}
+
+	var plan struct {
+		NodeCount int `json:"node_count"`
+	}
+	path := BuildAPIPath("project", project, "service-types", d.Get("service_type").(string), "plans", d.Get("plan").(string))
+	if err := APIRequest(ctx, client, http.MethodGet, path, nil, &plan); err != nil {
+		if aiven.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("unable to get service plan: %w", err)
+	}
+
+	services, err := client.Services.List(project)
+	if err != nil {
+		return fmt.Errorf("unable to list services of project %s: %w", project, err)
+	}
+
+	used := 0
+	for _, s := range services {
+		if s.Name != serviceName && s.ProjectVPCID != nil && *s.ProjectVPCID == *vpcID {
+			used += s.NodeCount
+		}
+	}
+
+	// a service which has no node count in the plan still gets at least one node
+	planned := plan.NodeCount
+	if planned == 0 {
+		planned = 1
+	}
+
+	capacity := VPCCIDRCapacity(network)
+	if used+planned > capacity {
+		return fmt.Errorf("project VPC %s (%s) can hold %d service nodes, %d are used by other services and "+
+			"plan %s needs %d more", d.Get("project_vpc_id"), vpc.NetworkCIDR, capacity, used, d.Get("plan"), planned)
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package schemautil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseIPv4CIDR(t *testing.T) {
+	tests := []struct {
+		cidr    string
+		wantErr string
+	}{
+		{"10.0.0.0/8", ""},
+		{"192.168.0.0/24", ""},
+		{"192.168.0.1/24", `has host bits set, did you mean "192.168.0.0/24"?`},
+		{"192.168.0.0", "is not a valid CIDR network range"},
+		{"192.168.0.0/33", "is not a valid CIDR network range"},
+		{"fd00::/8", "is not an IPv4 network range"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.cidr, func(t *testing.T) {
+			_, err := ParseIPv4CIDR(tt.cidr)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestCheckCIDRsDoNotOverlap(t *testing.T) {
+	networks, err := CheckCIDRsDoNotOverlap("cidrs", []string{"10.0.0.0/16", "10.1.0.0/16", "172.16.0.0/12"})
+	require.NoError(t, err)
+	assert.Len(t, networks, 3)
+
+	_, err = CheckCIDRsDoNotOverlap("cidrs", []string{"10.0.0.0/16", "10.1.0.0/16", "10.0.128.0/24", "10.0.0.0/8"})
+	require.Error(t, err)
+	assert.Equal(t, "cidrs.2: 10.0.128.0/24 overlaps with cidrs.0 10.0.0.0/16\n"+
+		"cidrs.3: 10.0.0.0/8 overlaps with cidrs.0 10.0.0.0/16\n"+
+		"cidrs.3: 10.0.0.0/8 overlaps with cidrs.1 10.1.0.0/16\n"+
+		"cidrs.3: 10.0.0.0/8 overlaps with cidrs.2 10.0.128.0/24", err.Error())
+
+	_, err = CheckCIDRsDoNotOverlap("cidrs", []string{"10.0.0.0/16", "10.0.0.1"})
+	assert.EqualError(t, err, `cidrs.1: "10.0.0.1" is not a valid CIDR network range`)
+}
+
+func TestVPCCIDRCapacity(t *testing.T) {
+	for cidr, want := range map[string]int{
+		"10.0.0.0/16":    65531,
+		"10.0.0.0/24":    251,
+		"10.0.0.0/29":    3,
+		"10.0.0.0/30":    -1,
+		"192.168.0.0/28": 11,
+	} {
+		n, err := ParseIPv4CIDR(cidr)
+		require.NoError(t, err)
+		assert.Equal(t, want, VPCCIDRCapacity(n), cidr)
+	}
+}
@@ -25,6 +25,14 @@ func DiskSpaceShouldNotBeEmpty(_ context.Context, _, new, _ interface{}) bool {
 	return new.(string) != ""
 }
 
+func ProjectVPCShouldNotBeEmpty(_ context.Context, _, new, _ interface{}) bool {
+	return new.(string) != ""
+}
+
+func PlanShouldNotBeEmpty(_ context.Context, _, new, _ interface{}) bool {
+	return new.(string) != ""
+}
+
 func TagsShouldNotBeEmpty(_ context.Context, _, new, _ interface{}) bool {
 	return len(new.(*schema.Set).List()) != 0
 }
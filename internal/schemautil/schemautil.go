@@ -214,6 +214,15 @@ func ValidateDurationString(v interface{}, k string) (ws []string, errors []erro
 	return
 }
 
+// ValidateM3DurationString is a ValidateFunc that ensures a string is an M3 duration,
+// a whole number followed by one of the units s, m, h or d, e.g. `48h`
+func ValidateM3DurationString(v interface{}, k string) (ws []string, errors []error) {
+	if ok, _ := regexp.MatchString("^[0-9]+[smhd]$", v.(string)); !ok || len(v.(string)) > 16 {
+		errors = append(errors, fmt.Errorf("%q: invalid M3 duration, it must match ^[0-9]+[smhd]$ and be at most 16 characters long", k))
+	}
+	return
+}
+
 // ValidateHumanByteSizeString is a ValidateFunc that ensures a string parses
 // as units.Bytes format
 func ValidateHumanByteSizeString(v interface{}, k string) (ws []string, errors []error) {
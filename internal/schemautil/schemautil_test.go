@@ -49,6 +49,32 @@ func Test_validateDurationString(t *testing.T) {
 	}
 }
 
+func Test_validateM3DurationString(t *testing.T) {
+	tests := []struct {
+		v          string
+		wantErrors bool
+	}{
+		{"48h", false},
+		{"30s", false},
+		{"2d", false},
+		{"0m", false},
+		{"", true},
+		{"1h30m", true},
+		{"1.5h", true},
+		{"10w", true},
+		{"h", true},
+		{"12345678901234567s", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.v, func(t *testing.T) {
+			_, gotErrors := ValidateM3DurationString(tt.v, "retention_period_duration")
+			if tt.wantErrors != (len(gotErrors) > 0) {
+				t.Errorf("ValidateM3DurationString(%q) gotErrors = %v", tt.v, gotErrors)
+			}
+		})
+	}
+}
+
 func Test_splitResourceID(t *testing.T) {
 	type args struct {
 		resourceID string
@@ -288,7 +288,7 @@ func ResourceServiceCreateWrapper(serviceType string) schema.CreateContextFunc {
 			if err := d.Set(ServiceTypeClickhouse, []map[string]interface{}{}); err != nil {
 				return diag.FromErr(err)
 			}
-			return resourceServiceCreate(ctx, d, m)
+			return resourceServiceCreate(ctx, d, m, false)
 		}
 	}
 
@@ -300,11 +300,17 @@ func ResourceServiceCreateWrapper(serviceType string) schema.CreateContextFunc {
 			return diag.Errorf("error setting an empty %s field: %s", serviceType, err)
 		}
 
-		return resourceServiceCreate(ctx, d, m)
+		return resourceServiceCreate(ctx, d, m, false)
 	}
 }
 
 func ResourceServiceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return resourceServiceRead(ctx, d, m, false)
+}
+
+// resourceServiceRead reads a service, generic is set for the aiven_service resource which keeps
+// the user config as JSON instead of a typed `<service_type>_user_config` block
+func resourceServiceRead(ctx context.Context, d *schema.ResourceData, m interface{}, generic bool) diag.Diagnostics {
 	client := m.(*aiven.Client)
 
 	projectName, serviceName, err := SplitResourceID2(d.Id())
@@ -325,7 +331,7 @@ func ResourceServiceRead(ctx context.Context, d *schema.ResourceData, m interfac
 		return diag.Errorf("unable to get service plan parameters: %s", err)
 	}
 
-	err = copyServicePropertiesFromAPIResponseToTerraform(d, s, servicePlanParams, projectName)
+	err = copyServicePropertiesFromAPIResponseToTerraform(d, s, servicePlanParams, projectName, generic)
 	if err != nil {
 		return diag.Errorf("unable to copy api response into terraform schema: %s", err)
 	}
@@ -350,7 +356,7 @@ func ResourceServiceRead(ctx context.Context, d *schema.ResourceData, m interfac
 	return nil
 }
 
-func resourceServiceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+func resourceServiceCreate(ctx context.Context, d *schema.ResourceData, m interface{}, generic bool) diag.Diagnostics {
 	client := m.(*aiven.Client)
 
 	serviceType := d.Get("service_type").(string)
@@ -381,6 +387,11 @@ func resourceServiceCreate(ctx context.Context, d *schema.ResourceData, m interf
 		return diag.Errorf("error getting project VPC ID: %s", err)
 	}
 
+	userConfig, err := serviceUserConfigFromSchema(d, serviceType, true, generic)
+	if err != nil {
+		return diag.Errorf("error reading user config: %s", err)
+	}
+
 	_, err = client.Services.Create(
 		project,
 		aiven.CreateServiceRequest{
@@ -393,7 +404,7 @@ func resourceServiceCreate(ctx context.Context, d *schema.ResourceData, m interf
 			ServiceType:           serviceType,
 			TerminationProtection: d.Get("termination_protection").(bool),
 			DiskSpaceMB:           diskSpace,
-			UserConfig:            ConvertTerraformUserConfigToAPICompatibleFormat(templates.UserConfigSchemaService, serviceType, true, d),
+			UserConfig:            userConfig,
 			StaticIPs:             FlattenToString(d.Get("static_ips").(*schema.Set).List()),
 		},
 	)
@@ -417,10 +428,14 @@ func resourceServiceCreate(ctx context.Context, d *schema.ResourceData, m interf
 
 	d.SetId(BuildResourceID(project, s.Name))
 
-	return ResourceServiceRead(ctx, d, m)
+	return resourceServiceRead(ctx, d, m, generic)
 }
 
 func ResourceServiceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return resourceServiceUpdate(ctx, d, m, false)
+}
+
+func resourceServiceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}, generic bool) diag.Diagnostics {
 	client := m.(*aiven.Client)
 
 	var karapace *bool
@@ -460,6 +475,11 @@ func ResourceServiceUpdate(ctx context.Context, d *schema.ResourceData, m interf
 		return diag.Errorf("error getting project VPC ID: %s", err)
 	}
 
+	userConfig, err := serviceUserConfigFromSchema(d, d.Get("service_type").(string), false, generic)
+	if err != nil {
+		return diag.Errorf("error reading user config: %s", err)
+	}
+
 	if _, err := client.Services.Update(
 		projectName,
 		serviceName,
@@ -472,7 +492,7 @@ func ResourceServiceUpdate(ctx context.Context, d *schema.ResourceData, m interf
 			TerminationProtection: d.Get("termination_protection").(bool),
 			DiskSpaceMB:           diskSpace,
 			Karapace:              karapace,
-			UserConfig:            ConvertTerraformUserConfigToAPICompatibleFormat(templates.UserConfigSchemaService, d.Get("service_type").(string), false, d),
+			UserConfig:            userConfig,
 		},
 	); err != nil {
 		return diag.Errorf("error updating (%s) service: %s", serviceName, err)
@@ -500,7 +520,7 @@ func ResourceServiceUpdate(ctx context.Context, d *schema.ResourceData, m interf
 		return diag.Errorf("error setting service tags: %s", err)
 	}
 
-	return ResourceServiceRead(ctx, d, m)
+	return resourceServiceRead(ctx, d, m, generic)
 }
 
 func getDefaultDiskSpaceIfNotSet(ctx context.Context, d *schema.ResourceData, client *aiven.Client) (int, error) {
@@ -553,9 +573,11 @@ func copyServicePropertiesFromAPIResponseToTerraform(
 	s *aiven.Service,
 	servicePlanParams PlanParameters,
 	project string,
+	generic bool,
 ) error {
 	serviceType := d.Get("service_type").(string)
-	if _, ok := d.GetOk("service_type"); !ok {
+	_, hasServiceType := d.GetOk("service_type")
+	if !hasServiceType {
 		serviceType = s.Type
 	}
 
@@ -618,9 +640,16 @@ func copyServicePropertiesFromAPIResponseToTerraform(
 			return err
 		}
 	}
-	userConfig := ConvertAPIUserConfigToTerraformCompatibleFormat(templates.UserConfigSchemaService, serviceType, s.UserConfig)
-	if err := d.Set(serviceType+"_user_config", NormalizeIpFilter(d.Get(serviceType+"_user_config"), userConfig)); err != nil {
-		return fmt.Errorf("cannot set `%s_user_config` : %s; Please make sure that all Aiven services have unique s names", serviceType, err)
+	if generic {
+		// the service type is only missing from the state on import and in data sources
+		if err := setGenericServiceUserConfig(d, s.UserConfig, !hasServiceType); err != nil {
+			return fmt.Errorf("cannot set `user_config` : %s", err)
+		}
+	} else {
+		userConfig := ConvertAPIUserConfigToTerraformCompatibleFormat(templates.UserConfigSchemaService, serviceType, s.UserConfig)
+		if err := d.Set(serviceType+"_user_config", NormalizeIpFilter(d.Get(serviceType+"_user_config"), userConfig)); err != nil {
+			return fmt.Errorf("cannot set `%s_user_config` : %s; Please make sure that all Aiven services have unique s names", serviceType, err)
+		}
 	}
 
 	params := s.URIParams
@@ -633,37 +662,23 @@ func copyServicePropertiesFromAPIResponseToTerraform(
 		return err
 	}
 
-	password, passwordOK := params["password"]
-	username, usernameOK := params["user"]
-	if passwordOK {
-		if err := d.Set("service_password", password); err != nil {
-			return err
-		}
-	}
-	if usernameOK {
-		if err := d.Set("service_username", username); err != nil {
-			return err
-		}
+	username, password := ServiceAdminCredentials(s)
+	if err := d.Set("service_username", username); err != nil {
+		return err
 	}
-
-	// for some services, for example Kafka URIParams does not provide default user credentials
-	if !passwordOK || !usernameOK {
-		for _, u := range s.Users {
-			if u.Username == "avnadmin" {
-				if err := d.Set("service_username", u.Username); err != nil {
-					return err
-				}
-				if err := d.Set("service_password", u.Password); err != nil {
-					return err
-				}
-			}
-		}
+	if err := d.Set("service_password", password); err != nil {
+		return err
 	}
 
 	if err := d.Set("components", FlattenServiceComponents(s)); err != nil {
 		return fmt.Errorf("cannot set `components` : %s", err)
 	}
 
+	// the generic resource has no service type specific connection info blocks
+	if generic {
+		return nil
+	}
+
 	return copyConnectionInfoFromAPIResponseToTerraform(d, serviceType, s.ConnectionInfo, s.Metadata)
 }
 
@@ -769,6 +784,10 @@ func ResourceReadHandleNotFound(err error, d *schema.ResourceData) error {
 }
 
 func DatasourceServiceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return datasourceServiceRead(ctx, d, m, false)
+}
+
+func datasourceServiceRead(ctx context.Context, d *schema.ResourceData, m interface{}, generic bool) diag.Diagnostics {
 	client := m.(*aiven.Client)
 
 	projectName := d.Get("project").(string)
@@ -782,7 +801,7 @@ func DatasourceServiceRead(ctx context.Context, d *schema.ResourceData, m interf
 
 	for _, service := range services {
 		if service.Name == serviceName {
-			return ResourceServiceRead(ctx, d, m)
+			return resourceServiceRead(ctx, d, m, generic)
 		}
 	}
 
@@ -0,0 +1,122 @@
+package schemautil
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/aiven/aiven-go-client"
+)
+
+// ServiceAdminCredentials returns the admin credentials of the service, for some services, for example Kafka,
+// URIParams does not provide them and the avnadmin user is used instead
+func ServiceAdminCredentials(s *aiven.Service) (username, password string) {
+	password, passwordOK := s.URIParams["password"]
+	username, usernameOK := s.URIParams["user"]
+	if passwordOK && usernameOK {
+		return username, password
+	}
+
+	for _, u := range s.Users {
+		if u.Username == "avnadmin" {
+			return u.Username, u.Password
+		}
+	}
+	return username, password
+}
+
+// NotFoundError is returned for entities that are missing from the API of a service rather than the Aiven API,
+// aiven.IsNotFound and ResourceReadHandleNotFound treat it like a 404 of the Aiven API
+func NotFoundError(kind, name string) error {
+	return aiven.Error{Message: fmt.Sprintf("%s %s not found", kind, name), Status: http.StatusNotFound}
+}
+
+// ServiceHTTPClient calls the HTTP API of a service, for example the REST API of Opensearch, with the admin
+// credentials of the service
+type ServiceHTTPClient struct {
+	URL      string
+	Username string
+	Password string
+	Client   *http.Client
+	// ErrorMessage reads the message of an error response, the whole body is the message when it is nil or
+	// returns an empty string
+	ErrorMessage func(body []byte) string
+}
+
+// NewServiceHTTPClient returns a client for the HTTP API served on the host and port of the service
+func NewServiceHTTPClient(client *aiven.Client, project, serviceName string) (*ServiceHTTPClient, error) {
+	s, err := client.Services.Get(project, serviceName)
+	if err != nil {
+		return nil, err
+	}
+
+	username, password := ServiceAdminCredentials(s)
+	return &ServiceHTTPClient{
+		URL:      fmt.Sprintf("https://%s:%s", s.URIParams["host"], s.URIParams["port"]),
+		Username: username,
+		Password: password,
+		Client:   client.Client,
+	}, nil
+}
+
+// Request sends the body with its content type and returns the body of the response. Non-2xx responses are
+// returned as aiven.Error, so aiven.IsNotFound and friends keep working
+func (c *ServiceHTTPClient) Request(ctx context.Context, method, path, contentType string, body io.Reader) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.URL+path, body)
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(c.Username, c.Password)
+
+	rsp, err := c.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer rsp.Body.Close()
+
+	b, err := io.ReadAll(rsp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
+		message := ""
+		if c.ErrorMessage != nil {
+			message = c.ErrorMessage(b)
+		}
+		if message == "" {
+			message = string(b)
+		}
+		return b, aiven.Error{Message: message, Status: rsp.StatusCode}
+	}
+	return b, nil
+}
+
+// JSONRequest sends in as JSON and decodes the response into out, in and out can be nil
+func (c *ServiceHTTPClient) JSONRequest(ctx context.Context, method, path string, in, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		b, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewBuffer(b)
+	}
+
+	b, err := c.Request(ctx, method, path, "application/json", body)
+	if err != nil {
+		return err
+	}
+
+	if out == nil || len(b) == 0 {
+		return nil
+	}
+	return json.Unmarshal(b, out)
+}
@@ -0,0 +1,87 @@
+package schemautil
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ServiceAdminCredentials(t *testing.T) {
+	users := []*aiven.ServiceUser{
+		{Username: "alice", Password: "a"},
+		{Username: "avnadmin", Password: "admin"},
+	}
+
+	username, password := ServiceAdminCredentials(&aiven.Service{
+		URIParams: map[string]string{"user": "owner", "password": "secret"},
+		Users:     users,
+	})
+	assert.Equal(t, "owner", username)
+	assert.Equal(t, "secret", password)
+
+	// Kafka has no credentials in URIParams
+	username, password = ServiceAdminCredentials(&aiven.Service{
+		URIParams: map[string]string{"host": "kafka"},
+		Users:     users,
+	})
+	assert.Equal(t, "avnadmin", username)
+	assert.Equal(t, "admin", password)
+}
+
+func Test_ServiceHTTPClient(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, password, _ := r.BasicAuth(); user != "avnadmin" || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`unauthorized`))
+			return
+		}
+		switch r.URL.Path {
+		case "/echo":
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			b, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			_, _ = w.Write([]byte(`{"got": ` + string(b) + `}`))
+		case "/empty":
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message": "no such thing"}`))
+		}
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	c := &ServiceHTTPClient{URL: srv.URL, Username: "avnadmin", Password: "secret", Client: srv.Client()}
+
+	var out struct {
+		Got map[string]int `json:"got"`
+	}
+	require.NoError(t, c.JSONRequest(ctx, http.MethodPost, "/echo", map[string]int{"a": 1}, &out))
+	assert.Equal(t, map[string]int{"a": 1}, out.Got)
+	require.NoError(t, c.JSONRequest(ctx, http.MethodGet, "/empty", nil, &out))
+
+	err := c.JSONRequest(ctx, http.MethodGet, "/missing", nil, nil)
+	assert.True(t, aiven.IsNotFound(err))
+	assert.Equal(t, `{"message": "no such thing"}`, err.(aiven.Error).Message)
+
+	c.ErrorMessage = func(b []byte) string {
+		if strings.Contains(string(b), "no such thing") {
+			return "no such thing"
+		}
+		return ""
+	}
+	err = c.JSONRequest(ctx, http.MethodGet, "/missing", nil, nil)
+	assert.Equal(t, aiven.Error{Message: "no such thing", Status: http.StatusNotFound}, err)
+
+	c.Password = "wrong"
+	_, err = c.Request(ctx, http.MethodGet, "/empty", "", nil)
+	assert.Equal(t, aiven.Error{Message: "unauthorized", Status: http.StatusUnauthorized}, err)
+
+	assert.True(t, aiven.IsNotFound(NotFoundError("role", "reader")))
+}
@@ -0,0 +1,348 @@
+package schemautil
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil/templates"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ResourceGenericServiceCreate creates a service of the generic aiven_service resource
+func ResourceGenericServiceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return resourceServiceCreate(ctx, d, m, true)
+}
+
+// ResourceGenericServiceRead reads a service of the generic aiven_service resource
+func ResourceGenericServiceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return resourceServiceRead(ctx, d, m, true)
+}
+
+// ResourceGenericServiceUpdate updates a service of the generic aiven_service resource
+func ResourceGenericServiceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return resourceServiceUpdate(ctx, d, m, true)
+}
+
+// DatasourceGenericServiceRead reads a service of the generic aiven_service data source
+func DatasourceGenericServiceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return datasourceServiceRead(ctx, d, m, true)
+}
+
+// serviceUserConfigFromSchema returns the user config in the API format, the generic service
+// resource keeps it JSON encoded in `user_config`
+func serviceUserConfigFromSchema(d *schema.ResourceData, serviceType string, newResource, generic bool) (map[string]interface{}, error) {
+	if !generic {
+		return ConvertTerraformUserConfigToAPICompatibleFormat(templates.UserConfigSchemaService, serviceType, newResource, d), nil
+	}
+
+	userConfig, err := decodeGenericServiceUserConfig(d.Get("user_config").(string))
+	if err != nil || userConfig == nil {
+		return nil, err
+	}
+
+	// create only options are rejected on update, same as for the typed user config blocks
+	if definition, ok := genericServiceUserConfigSchema(serviceType); ok && !newResource {
+		properties, _ := definition["properties"].(map[string]interface{})
+		for k := range userConfig {
+			if p, ok := properties[k].(map[string]interface{}); ok && p["create_only"] == true {
+				delete(userConfig, k)
+			}
+		}
+	}
+
+	return userConfig, nil
+}
+
+func decodeGenericServiceUserConfig(s string) (map[string]interface{}, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+
+	var userConfig map[string]interface{}
+	if err := json.Unmarshal([]byte(s), &userConfig); err != nil {
+		return nil, fmt.Errorf("user_config must be a JSON object: %w", err)
+	}
+
+	return userConfig, nil
+}
+
+// genericServiceUserConfigSchema returns the embedded user config schema of a service type,
+// service types released after the provider have none
+func genericServiceUserConfigSchema(serviceType string) (map[string]interface{}, bool) {
+	definition, ok := templates.GetUserConfigSchema(templates.UserConfigSchemaService)[serviceType].(map[string]interface{})
+	return definition, ok
+}
+
+// setGenericServiceUserConfig stores the API user config as JSON. The API returns every option,
+// including the defaults, so only the options already in the state are kept. The whole user
+// config is stored when the state is empty, i.e. on import.
+func setGenericServiceUserConfig(d *schema.ResourceData, apiUserConfig map[string]interface{}, emptyState bool) error {
+	current, err := decodeGenericServiceUserConfig(d.Get("user_config").(string))
+	if err != nil {
+		return err
+	}
+
+	userConfig := apiUserConfig
+	if !emptyState {
+		userConfig = filterUserConfigByKeys(apiUserConfig, current)
+	}
+
+	if len(userConfig) == 0 {
+		return d.Set("user_config", "")
+	}
+
+	b, err := json.Marshal(userConfig)
+	if err != nil {
+		return err
+	}
+
+	return d.Set("user_config", string(b))
+}
+
+// filterUserConfigByKeys keeps the options of the API user config which are set in keys, nested
+// objects are filtered recursively
+func filterUserConfigByKeys(api, keys map[string]interface{}) map[string]interface{} {
+	r := make(map[string]interface{}, len(keys))
+	for k, v := range keys {
+		apiValue, ok := api[k]
+		if !ok {
+			continue
+		}
+
+		vm, vok := v.(map[string]interface{})
+		am, aok := apiValue.(map[string]interface{})
+		if vok && aok {
+			r[k] = filterUserConfigByKeys(am, vm)
+			continue
+		}
+		r[k] = apiValue
+	}
+	return r
+}
+
+// GenericServiceUserConfigDiffSuppressFunc compares the JSON encoded user configs semantically
+func GenericServiceUserConfigDiffSuppressFunc(_, old, new string, _ *schema.ResourceData) bool {
+	o, err := decodeGenericServiceUserConfig(old)
+	if err != nil {
+		return false
+	}
+	n, err := decodeGenericServiceUserConfig(new)
+	if err != nil {
+		return false
+	}
+	return reflect.DeepEqual(o, n) || (len(o) == 0 && len(n) == 0)
+}
+
+// CustomizeDiffGenericServiceUserConfig validates `user_config` against the embedded user config
+// schema of `service_type`. Unknown service types are not validated, which lets the generic
+// resource manage service types released after the provider.
+func CustomizeDiffGenericServiceUserConfig(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown("service_type") || !d.NewValueKnown("user_config") {
+		return nil
+	}
+
+	userConfig, err := decodeGenericServiceUserConfig(d.Get("user_config").(string))
+	if err != nil {
+		return err
+	}
+
+	definition, ok := genericServiceUserConfigSchema(d.Get("service_type").(string))
+	if !ok || userConfig == nil {
+		return nil
+	}
+
+	errs := validateUserConfigValue("user_config", userConfig, definition)
+	if len(errs) == 0 {
+		return nil
+	}
+
+	msgs := make([]string, len(errs))
+	for i, e := range errs {
+		msgs[i] = e.Error()
+	}
+	sort.Strings(msgs)
+
+	return fmt.Errorf("invalid user config for service type %s:\n%s", d.Get("service_type"), strings.Join(msgs, "\n"))
+}
+
+// validateUserConfigValue checks a decoded JSON value against a user config schema definition
+func validateUserConfigValue(path string, value interface{}, definition map[string]interface{}) []error {
+	types := userConfigSchemaTypes(definition["type"])
+
+	if value == nil {
+		if types["null"] {
+			return nil
+		}
+		return []error{fmt.Errorf("%s: must not be null", path)}
+	}
+
+	var errs []error
+	switch v := value.(type) {
+	case string:
+		if !types["string"] {
+			return []error{userConfigTypeError(path, value, types)}
+		}
+		errs = append(errs, validateUserConfigString(path, v, definition)...)
+	case bool:
+		if !types["boolean"] {
+			return []error{userConfigTypeError(path, value, types)}
+		}
+	case float64:
+		switch {
+		case types["number"]:
+		case types["integer"] && v == math.Trunc(v):
+		default:
+			return []error{userConfigTypeError(path, value, types)}
+		}
+		errs = append(errs, validateUserConfigNumber(path, v, definition)...)
+	case []interface{}:
+		if !types["array"] {
+			return []error{userConfigTypeError(path, value, types)}
+		}
+		if maxItems, ok := userConfigSchemaNumber(definition["max_items"]); ok && float64(len(v)) > maxItems {
+			errs = append(errs, fmt.Errorf("%s: must have at most %v items", path, maxItems))
+		}
+		if items, ok := definition["items"].(map[string]interface{}); ok {
+			for i, item := range v {
+				errs = append(errs, validateUserConfigArrayItem(fmt.Sprintf("%s.%d", path, i), item, items)...)
+			}
+		}
+	case map[string]interface{}:
+		if !types["object"] {
+			return []error{userConfigTypeError(path, value, types)}
+		}
+		properties, _ := definition["properties"].(map[string]interface{})
+		for k, item := range v {
+			itemDefinition, ok := properties[k].(map[string]interface{})
+			if !ok {
+				errs = append(errs, fmt.Errorf("%s.%s: unknown user config option", path, k))
+				continue
+			}
+			errs = append(errs, validateUserConfigValue(path+"."+k, item, itemDefinition)...)
+		}
+	default:
+		return []error{fmt.Errorf("%s: unsupported value %v", path, value)}
+	}
+
+	if enum, ok := definition["enum"].([]interface{}); ok && len(enum) > 0 {
+		var allowed []string
+		for _, e := range enum {
+			if e, ok := e.(map[string]interface{}); ok {
+				allowed = append(allowed, fmt.Sprint(e["value"]))
+			}
+		}
+		if !userConfigEnumContains(allowed, value) {
+			errs = append(errs, fmt.Errorf("%s: expected one of %s, got %v", path, strings.Join(allowed, ", "), value))
+		}
+	}
+
+	return errs
+}
+
+// validateUserConfigArrayItem checks an array item, which may have alternative definitions
+func validateUserConfigArrayItem(path string, value interface{}, definition map[string]interface{}) []error {
+	oneOf, ok := definition["one_of"].([]interface{})
+	if !ok || len(oneOf) == 0 {
+		return validateUserConfigValue(path, value, definition)
+	}
+
+	var errs []error
+	for _, alternative := range oneOf {
+		alternative, ok := alternative.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		errs = validateUserConfigValue(path, value, alternative)
+		if len(errs) == 0 {
+			return nil
+		}
+	}
+	return errs
+}
+
+func validateUserConfigString(path, v string, definition map[string]interface{}) []error {
+	var errs []error
+	if maxLength, ok := userConfigSchemaNumber(definition["max_length"]); ok && float64(len(v)) > maxLength {
+		errs = append(errs, fmt.Errorf("%s: must be at most %v characters long", path, maxLength))
+	}
+	if minLength, ok := userConfigSchemaNumber(definition["min_length"]); ok && float64(len(v)) < minLength {
+		errs = append(errs, fmt.Errorf("%s: must be at least %v characters long", path, minLength))
+	}
+	if pattern, ok := definition["pattern"].(string); ok {
+		// the patterns are written for the API, skip the ones RE2 does not support
+		if re, err := regexp.Compile(pattern); err == nil && !re.MatchString(v) {
+			msg := fmt.Sprintf("must match %s", pattern)
+			if userError, ok := definition["user_error"].(string); ok {
+				msg = userError
+			}
+			errs = append(errs, fmt.Errorf("%s: %s", path, msg))
+		}
+	}
+	return errs
+}
+
+func validateUserConfigNumber(path string, v float64, definition map[string]interface{}) []error {
+	var errs []error
+	if minimum, ok := userConfigSchemaNumber(definition["minimum"]); ok && v < minimum {
+		errs = append(errs, fmt.Errorf("%s: must be at least %v, got %v", path, minimum, v))
+	}
+	if maximum, ok := userConfigSchemaNumber(definition["maximum"]); ok && v > maximum {
+		errs = append(errs, fmt.Errorf("%s: must be at most %v, got %v", path, maximum, v))
+	}
+	return errs
+}
+
+func userConfigTypeError(path string, value interface{}, types map[string]bool) error {
+	var expected []string
+	for t := range types {
+		if t != "null" {
+			expected = append(expected, t)
+		}
+	}
+	sort.Strings(expected)
+	return fmt.Errorf("%s: expected %s, got %v", path, strings.Join(expected, " or "), value)
+}
+
+func userConfigEnumContains(allowed []string, value interface{}) bool {
+	s := fmt.Sprint(value)
+	for _, a := range allowed {
+		if a == s {
+			return true
+		}
+	}
+	return false
+}
+
+// userConfigSchemaTypes returns the type of a definition, which is either a string or a list
+func userConfigSchemaTypes(t interface{}) map[string]bool {
+	types := make(map[string]bool)
+	switch t := t.(type) {
+	case string:
+		types[t] = true
+	case []interface{}:
+		for _, v := range t {
+			if v, ok := v.(string); ok {
+				types[v] = true
+			}
+		}
+	}
+	return types
+}
+
+// userConfigSchemaNumber reads a numeric constraint, the YAML schema has both ints and floats
+func userConfigSchemaNumber(v interface{}) (float64, bool) {
+	switch v := v.(type) {
+	case int:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
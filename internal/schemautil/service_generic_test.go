@@ -0,0 +1,90 @@
+package schemautil
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_validateUserConfigValue(t *testing.T) {
+	definition, ok := genericServiceUserConfigSchema("redis")
+	require.True(t, ok)
+
+	tests := []struct {
+		name       string
+		userConfig string
+		wantErrors int
+	}{
+		{"empty", `{}`, 0},
+		{"valid", `{"redis_maxmemory_policy": "allkeys-lru", "redis_timeout": 600, "migration": {"host": "my.server.com", "port": 6379}}`, 0},
+		{"null", `{"redis_maxmemory_policy": null}`, 0},
+		{"ip filter strings and objects", `{"ip_filter": ["10.0.0.0/8", {"network": "10.20.0.0/16", "description": "office"}]}`, 0},
+		{"unknown option", `{"redis_foo": 1}`, 1},
+		{"unknown nested option", `{"migration": {"foo": "bar"}}`, 1},
+		{"wrong type", `{"redis_timeout": "600"}`, 1},
+		{"not an integer", `{"redis_timeout": 1.5}`, 1},
+		{"not null", `{"redis_timeout": null}`, 1},
+		{"enum", `{"redis_maxmemory_policy": "most-recent"}`, 1},
+		{"minimum", `{"redis_io_threads": 0}`, 1},
+		{"maximum", `{"redis_io_threads": 33, "migration": {"port": 65536}}`, 2},
+		{"max length", `{"migration": {"host": "` + strings.Repeat("a", 256) + `"}}`, 1},
+		{"array item", `{"ip_filter": [true]}`, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var userConfig map[string]interface{}
+			require.NoError(t, json.Unmarshal([]byte(tt.userConfig), &userConfig))
+
+			errs := validateUserConfigValue("user_config", userConfig, definition)
+			assert.Len(t, errs, tt.wantErrors, "%v", errs)
+		})
+	}
+}
+
+func Test_filterUserConfigByKeys(t *testing.T) {
+	api := map[string]interface{}{
+		"redis_timeout":          float64(300),
+		"redis_maxmemory_policy": "noeviction",
+		"migration": map[string]interface{}{
+			"host": "my.server.com",
+			"port": float64(6379),
+			"ssl":  true,
+		},
+	}
+	keys := map[string]interface{}{
+		"redis_timeout": float64(600),
+		"redis_ssl":     true,
+		"migration": map[string]interface{}{
+			"host": "other.server.com",
+		},
+	}
+
+	assert.Equal(t, map[string]interface{}{
+		"redis_timeout": float64(300),
+		"migration": map[string]interface{}{
+			"host": "my.server.com",
+		},
+	}, filterUserConfigByKeys(api, keys))
+}
+
+func Test_GenericServiceUserConfigDiffSuppressFunc(t *testing.T) {
+	tests := []struct {
+		name     string
+		old, new string
+		want     bool
+	}{
+		{"formatting", `{"a":1,"b":{"c":"d"}}`, "{\n  \"b\": {\"c\": \"d\"},\n  \"a\": 1\n}", true},
+		{"empty", "", "{}", true},
+		{"changed", `{"a":1}`, `{"a":2}`, false},
+		{"added", `{"a":1}`, `{"a":1,"b":2}`, false},
+		{"invalid", `{"a":1}`, `{"a":1`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, GenericServiceUserConfigDiffSuppressFunc("user_config", tt.old, tt.new, nil))
+		})
+	}
+}
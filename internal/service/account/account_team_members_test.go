@@ -0,0 +1,77 @@
+package account
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_diffAccountTeamMembers(t *testing.T) {
+	now := time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC)
+	old := now.Add(-10 * 24 * time.Hour)
+	recent := now.Add(-time.Hour)
+
+	membership := &accountTeamMembership{
+		members: []aiven.AccountTeamMember{
+			{UserId: "u1", UserEmail: "alice@example.com"},
+			{UserId: "u2", UserEmail: "bob@example.com"},
+		},
+		invites: []aiven.AccountTeamInvite{
+			{UserEmail: "Carol@example.com", CreateTime: &recent},
+			{UserEmail: "dave@example.com", CreateTime: &old},
+			{UserEmail: "eve@example.com", CreateTime: &recent},
+			// an accepted invitation may still be listed for a short while
+			{UserEmail: "alice@example.com", CreateTime: &old},
+		},
+	}
+	userEmails := []string{"alice@example.com", "carol@example.com", "dave@example.com", "frank@example.com"}
+
+	changes := diffAccountTeamMembers(membership, userEmails, 0, now)
+	assert.Equal(t, []string{"frank@example.com"}, changes.invite)
+	assert.Equal(t, []string{"eve@example.com"}, changes.deleteInvites)
+	assert.Equal(t, []aiven.AccountTeamMember{{UserId: "u2", UserEmail: "bob@example.com"}}, changes.deleteMembers)
+
+	// the invitation of dave is pending for longer than a week
+	changes = diffAccountTeamMembers(membership, userEmails, 7*24*time.Hour, now)
+	assert.Equal(t, []string{"dave@example.com", "frank@example.com"}, changes.invite)
+	assert.Equal(t, []string{"dave@example.com", "eve@example.com"}, changes.deleteInvites)
+
+	changes = diffAccountTeamMembers(membership, nil, 0, now)
+	assert.Empty(t, changes.invite)
+	assert.Len(t, changes.deleteInvites, 4)
+	assert.Len(t, changes.deleteMembers, 2)
+}
+
+func Test_accountTeamMembersToSchema(t *testing.T) {
+	created := time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC)
+	membership := &accountTeamMembership{
+		members: []aiven.AccountTeamMember{{UserId: "u1", UserEmail: "bob@example.com", RealName: "Bob", CreateTime: &created}},
+		invites: []aiven.AccountTeamInvite{
+			{UserEmail: "alice@example.com", InvitedByUserEmail: "bob@example.com"},
+			{UserEmail: "bob@example.com"},
+		},
+	}
+
+	assert.Equal(t, []map[string]interface{}{
+		{
+			"user_email":            "alice@example.com",
+			"user_id":               "",
+			"real_name":             "",
+			"accepted":              false,
+			"status":                "pending",
+			"invited_by_user_email": "bob@example.com",
+			"create_time":           "",
+		},
+		{
+			"user_email":            "bob@example.com",
+			"user_id":               "u1",
+			"real_name":             "Bob",
+			"accepted":              true,
+			"status":                "accepted",
+			"invited_by_user_email": "",
+			"create_time":           created.String(),
+		},
+	}, accountTeamMembersToSchema(membership))
+}
@@ -0,0 +1,62 @@
+package account
+
+import (
+	"testing"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_diffAccountTeamProjects(t *testing.T) {
+	current := []aiven.AccountTeamProject{
+		{ProjectName: "kept", TeamType: "admin"},
+		{ProjectName: "changed", TeamType: "read_only"},
+		{ProjectName: "removed", TeamType: "developer"},
+	}
+	wanted := map[string]string{
+		"kept":    "admin",
+		"changed": "operator",
+		"new-b":   "developer",
+		"new-a":   "read_only",
+	}
+
+	changes := diffAccountTeamProjects(current, wanted)
+	assert.Equal(t, []aiven.AccountTeamProject{
+		{ProjectName: "new-a", TeamType: "read_only"},
+		{ProjectName: "new-b", TeamType: "developer"},
+	}, changes.create)
+	assert.Equal(t, []aiven.AccountTeamProject{{ProjectName: "changed", TeamType: "operator"}}, changes.update)
+	assert.Equal(t, []string{"removed"}, changes.remove)
+}
+
+func Test_validateAccountTeamProjectAccess(t *testing.T) {
+	_, errs := validateAccountTeamProjectAccess(map[string]interface{}{"foo": "admin", "bar": "read_only"}, "projects")
+	assert.Empty(t, errs)
+
+	_, errs = validateAccountTeamProjectAccess(map[string]interface{}{"foo": "owner"}, "projects")
+	assert.Len(t, errs, 1)
+}
+
+func Test_effectiveAccountPermissions(t *testing.T) {
+	grants := []accountPermissionGrant{
+		{projectName: "p1", userEmail: "alice@example.com", memberType: "read_only", teamID: "t2", teamName: "viewers"},
+		{projectName: "p1", userEmail: "Alice@example.com", memberType: "operator", teamID: "t1", teamName: "ops"},
+		{projectName: "p1", userEmail: "alice@example.com", memberType: "developer"},
+		{projectName: "p0", userEmail: "bob@example.com", memberType: "admin"},
+	}
+
+	assert.Equal(t, []accountProjectPermission{
+		{
+			projectName: "p0",
+			userEmail:   "bob@example.com",
+			permission:  "admin",
+			grants:      []accountPermissionGrant{grants[3]},
+		},
+		{
+			projectName: "p1",
+			userEmail:   "alice@example.com",
+			permission:  "operator",
+			grants:      []accountPermissionGrant{grants[2], grants[1], grants[0]},
+		},
+	}, effectiveAccountPermissions(grants))
+}
@@ -0,0 +1,273 @@
+package account
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	accountPermissionSourceTeam        = "team"
+	accountPermissionSourceProjectUser = "project_user"
+)
+
+func DatasourceAccountEffectivePermissions() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceAccountEffectivePermissionsRead,
+		Description: `
+The Account Effective Permissions data source computes the effective permission of every user on the projects of an
+Aiven Account, combining the roles of the account teams the user is a member of with the direct project memberships
+of ` + "`aiven_project_user`" + `. Pending invitations don't grant any permission and are left out.
+`,
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The unique account id",
+			},
+			"project_names": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Limit the permissions to these projects. By default all the projects of the account and of its teams are used.",
+			},
+			"permissions": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Effective permissions, one per project and user, sorted by project name and user email",
+				Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+					"project_name": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Project name",
+					},
+					"user_email": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "User email address",
+					},
+					"permission": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: schemautil.Complex("The most privileged role of the user in the project.").PossibleValues(schemautil.StringSliceToInterfaceSlice(accountTeamTypes)...).Build(),
+					},
+					"grants": {
+						Type:        schema.TypeList,
+						Computed:    true,
+						Description: "Every team role and direct membership the permission is granted by",
+						Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+							"source": {
+								Type:        schema.TypeString,
+								Computed:    true,
+								Description: schemautil.Complex("Where the role comes from.").PossibleValues(accountPermissionSourceTeam, accountPermissionSourceProjectUser).Build(),
+							},
+							"team_id": {
+								Type:        schema.TypeString,
+								Computed:    true,
+								Description: "Account team id, empty for direct project memberships",
+							},
+							"team_name": {
+								Type:        schema.TypeString,
+								Computed:    true,
+								Description: "Account team name, empty for direct project memberships",
+							},
+							"member_type": {
+								Type:        schema.TypeString,
+								Computed:    true,
+								Description: "Role granted by the team or the project membership",
+							},
+						}},
+					},
+				}},
+			},
+		},
+	}
+}
+
+// accountPermissionGrant is a role of a user in a project, granted by a team or a direct project membership
+type accountPermissionGrant struct {
+	projectName string
+	userEmail   string
+	memberType  string
+	teamID      string
+	teamName    string
+}
+
+// accountProjectPermission is the effective permission of a user in a project
+type accountProjectPermission struct {
+	projectName string
+	userEmail   string
+	permission  string
+	grants      []accountPermissionGrant
+}
+
+// effectiveAccountPermissions groups the grants by project and user, the effective permission is the most
+// privileged role. Emails are compared case-insensitively.
+func effectiveAccountPermissions(grants []accountPermissionGrant) []accountProjectPermission {
+	byKey := make(map[string]*accountProjectPermission)
+	var keys []string
+	for _, g := range grants {
+		key := g.projectName + "/" + strings.ToLower(g.userEmail)
+		p, ok := byKey[key]
+		if !ok {
+			p = &accountProjectPermission{projectName: g.projectName, userEmail: g.userEmail, permission: g.memberType}
+			byKey[key] = p
+			keys = append(keys, key)
+		}
+		if accountTeamTypeRank(g.memberType) > accountTeamTypeRank(p.permission) {
+			p.permission = g.memberType
+		}
+		p.grants = append(p.grants, g)
+	}
+	sort.Strings(keys)
+
+	r := make([]accountProjectPermission, 0, len(keys))
+	for _, key := range keys {
+		p := byKey[key]
+		// direct memberships first, then teams by name
+		sort.SliceStable(p.grants, func(i, j int) bool {
+			if p.grants[i].teamName != p.grants[j].teamName {
+				return p.grants[i].teamName < p.grants[j].teamName
+			}
+			return p.grants[i].teamID < p.grants[j].teamID
+		})
+		r = append(r, *p)
+	}
+	return r
+}
+
+// listAccountPermissionGrants collects the team roles and the direct project memberships of the account,
+// limited to the given projects when there are any
+func listAccountPermissionGrants(client *aiven.Client, accountID string, projectNames []string) ([]accountPermissionGrant, error) {
+	inScope := make(map[string]bool)
+	for _, p := range projectNames {
+		inScope[p] = true
+	}
+	limited := len(projectNames) > 0
+
+	teams, err := client.AccountTeams.List(accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	var grants []accountPermissionGrant
+	projects := make(map[string]bool)
+	for _, team := range teams.Teams {
+		rp, err := client.AccountTeamProjects.List(accountID, team.Id)
+		if err != nil {
+			return nil, err
+		}
+		if len(rp.Projects) == 0 {
+			continue
+		}
+
+		rm, err := client.AccountTeamMembers.List(accountID, team.Id)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, p := range rp.Projects {
+			if limited && !inScope[p.ProjectName] {
+				continue
+			}
+			projects[p.ProjectName] = true
+			for _, member := range rm.Members {
+				grants = append(grants, accountPermissionGrant{
+					projectName: p.ProjectName,
+					userEmail:   member.UserEmail,
+					memberType:  p.TeamType,
+					teamID:      team.Id,
+					teamName:    team.Name,
+				})
+			}
+		}
+	}
+
+	if limited {
+		projects = inScope
+	} else {
+		list, err := client.Projects.List()
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range list {
+			if p.AccountId == accountID {
+				projects[p.Name] = true
+			}
+		}
+	}
+
+	for project := range projects {
+		users, _, err := client.ProjectUsers.List(project)
+		if err != nil {
+			return nil, err
+		}
+		for _, u := range users {
+			// the memberships coming from the teams are already listed
+			if u.TeamId != "" {
+				continue
+			}
+			grants = append(grants, accountPermissionGrant{
+				projectName: project,
+				userEmail:   u.Email,
+				memberType:  u.MemberType,
+			})
+		}
+	}
+
+	return grants, nil
+}
+
+func accountProjectPermissionsToSchema(permissions []accountProjectPermission) []map[string]interface{} {
+	r := make([]map[string]interface{}, 0, len(permissions))
+	for _, p := range permissions {
+		var grants []map[string]interface{}
+		for _, g := range p.grants {
+			source := accountPermissionSourceTeam
+			if g.teamID == "" {
+				source = accountPermissionSourceProjectUser
+			}
+			grants = append(grants, map[string]interface{}{
+				"source":      source,
+				"team_id":     g.teamID,
+				"team_name":   g.teamName,
+				"member_type": g.memberType,
+			})
+		}
+
+		r = append(r, map[string]interface{}{
+			"project_name": p.projectName,
+			"user_email":   p.userEmail,
+			"permission":   p.permission,
+			"grants":       grants,
+		})
+	}
+	return r
+}
+
+func datasourceAccountEffectivePermissionsRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+	accountID := d.Get("account_id").(string)
+
+	grants, err := listAccountPermissionGrants(
+		client,
+		accountID,
+		schemautil.FlattenToString(d.Get("project_names").(*schema.Set).List()),
+	)
+	if err != nil {
+		return diag.Errorf("cannot get account %s permissions: %s", accountID, err)
+	}
+
+	d.SetId(accountID)
+	if err := d.Set("permissions", accountProjectPermissionsToSchema(effectiveAccountPermissions(grants))); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package account
+
+import (
+	"context"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DatasourceAccountTeamMembers() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceAccountTeamMembersRead,
+		Description: "The Account Team Members data source lists the members and the pending invitations of an existing Aiven Account Team, e.g. to reconcile them with an identity provider.",
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The unique account id",
+			},
+			"team_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "An account team id",
+			},
+			"total_results": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of members and pending invitations",
+			},
+			"members": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Members and pending invitations of the team",
+				Elem:        aivenAccountTeamMembersMemberSchema,
+			},
+		},
+	}
+}
+
+func datasourceAccountTeamMembersRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+	accountID := d.Get("account_id").(string)
+	teamID := d.Get("team_id").(string)
+
+	membership, err := getAccountTeamMembership(client, accountID, teamID)
+	if err != nil {
+		return diag.Errorf("cannot get account team %s members: %s", teamID, err)
+	}
+
+	members := accountTeamMembersToSchema(membership)
+	d.SetId(schemautil.BuildResourceID(accountID, teamID))
+	if err := d.Set("members", members); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("total_results", len(members)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
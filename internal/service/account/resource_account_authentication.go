@@ -3,6 +3,7 @@ package account
 import (
 	"context"
 	"strings"
+	"time"
 
 	"github.com/aiven/aiven-go-client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -43,14 +44,27 @@ var aivenAccountAuthenticationSchema = map[string]*schema.Schema{
 	"saml_certificate": {
 		Type:             schema.TypeString,
 		Optional:         true,
-		Description:      "SAML Certificate",
+		Computed:         true,
+		Description:      "SAML Certificate. It's computed from the IdP metadata when `saml_idp_metadata_xml` or `saml_idp_metadata_file` is set, it's a plain optional field otherwise. The certificate is validated at plan time and a warning is shown on refresh when it's about to expire.",
 		DiffSuppressFunc: schemautil.TrimSpaceDiffSuppressFunc,
+		ConflictsWith:    []string{"saml_idp_metadata_xml", "saml_idp_metadata_file"},
+	},
+	"saml_idp_metadata_xml": {
+		Type:          schema.TypeString,
+		Optional:      true,
+		Description:   "SAML metadata XML document of the IdP. It's used to populate `saml_certificate`, `saml_idp_url` and `saml_entity_id`.",
+		ConflictsWith: []string{"saml_idp_metadata_file"},
+	},
+	"saml_idp_metadata_file": {
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "Path of a local file with the SAML metadata XML document of the IdP. It's read at plan time and used like `saml_idp_metadata_xml`.",
 	},
 	"saml_digest_algorithm": {
 		Type:        schema.TypeString,
 		Optional:    true,
-		Default:     "sha256",
-		Description: "Digest algorithm. This is an advanced option that typically does not need to be set.",
+		Computed:    true,
+		Description: "Digest algorithm. This is an advanced option that typically does not need to be set. When it is not set, the first DigestMethod the IdP metadata advertises is used, `sha256` without metadata.",
 	},
 	"saml_field_mapping": {
 		Type:        schema.TypeSet,
@@ -93,15 +107,17 @@ var aivenAccountAuthenticationSchema = map[string]*schema.Schema{
 		Description: "Set to 'true' to enable IdP initiated login",
 	},
 	"saml_idp_url": {
-		Type:        schema.TypeString,
-		Optional:    true,
-		Description: "SAML Idp URL",
+		Type:          schema.TypeString,
+		Optional:      true,
+		Computed:      true,
+		Description:   "SAML Idp URL. It's computed from the IdP metadata when `saml_idp_metadata_xml` or `saml_idp_metadata_file` is set, it's a plain optional field otherwise.",
+		ConflictsWith: []string{"saml_idp_metadata_xml", "saml_idp_metadata_file"},
 	},
 	"saml_signature_algorithm": {
 		Type:        schema.TypeString,
 		Optional:    true,
-		Default:     "rsa-sha256",
-		Description: "Signature algorithm. This is an advanced option that typically does not need to be set.",
+		Computed:    true,
+		Description: "Signature algorithm. This is an advanced option that typically does not need to be set. When it is not set, the first SigningMethod the IdP metadata advertises is used, `rsa-sha256` without metadata.",
 	},
 	"saml_variant": {
 		Type:        schema.TypeString,
@@ -109,9 +125,11 @@ var aivenAccountAuthenticationSchema = map[string]*schema.Schema{
 		Description: "SAML server variant",
 	},
 	"saml_entity_id": {
-		Type:        schema.TypeString,
-		Optional:    true,
-		Description: "SAML Entity id",
+		Type:          schema.TypeString,
+		Optional:      true,
+		Computed:      true,
+		Description:   "SAML Entity id. It's computed from the IdP metadata when `saml_idp_metadata_xml` or `saml_idp_metadata_file` is set, it's a plain optional field otherwise.",
+		ConflictsWith: []string{"saml_idp_metadata_xml", "saml_idp_metadata_file"},
 	},
 	"authentication_id": {
 		Type:        schema.TypeString,
@@ -147,6 +165,7 @@ func ResourceAccountAuthentication() *schema.Resource {
 		ReadContext:   resourceAccountAuthenticationRead,
 		UpdateContext: resourceAccountAuthenticationUpdate,
 		DeleteContext: resourceAccountAuthenticationDelete,
+		CustomizeDiff: customizeDiffAccountAuthenticationSAML,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -254,7 +273,7 @@ func resourceAccountAuthenticationRead(_ context.Context, d *schema.ResourceData
 		return diag.FromErr(err)
 	}
 
-	return nil
+	return samlCertificateExpiryDiagnostics(r.AuthenticationMethod.SAMLCertificate, time.Now())
 }
 
 func resourceAccountAuthenticationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
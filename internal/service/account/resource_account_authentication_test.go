@@ -6,6 +6,7 @@ import (
 	"crypto/rsa"
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"encoding/base64"
 	"encoding/pem"
 	"fmt"
 	"log"
@@ -117,12 +118,82 @@ func TestAccAivenAccountAuthentication_saml_invalid_certificate(t *testing.T) {
 			},
 		},
 		ErrorCheck: func(err error) error {
-			assert.ErrorContains(t, err, "Certificate is no longer valid")
+			assert.ErrorContains(t, err, "saml_certificate expired on")
 			return nil
 		},
 	})
 }
 
+func TestAccAivenAccountAuthentication_saml_idp_metadata(t *testing.T) {
+	cert, err := genX509Certificate(time.Now())
+	assert.NoError(t, err)
+
+	block, _ := pem.Decode([]byte(cert))
+	metadata := fmt.Sprintf(`<md:EntityDescriptor xmlns:md="urn:oasis:names:tc:SAML:2.0:metadata" xmlns:ds="http://www.w3.org/2000/09/xmldsig#" entityID="https://idp.example.com/metadata">
+  <md:IDPSSODescriptor protocolSupportEnumeration="urn:oasis:names:tc:SAML:2.0:protocol">
+    <md:KeyDescriptor use="signing">
+      <ds:KeyInfo><ds:X509Data><ds:X509Certificate>%s</ds:X509Certificate></ds:X509Data></ds:KeyInfo>
+    </md:KeyDescriptor>
+    <md:SingleSignOnService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect" Location="https://idp.example.com/sso"/>
+  </md:IDPSSODescriptor>
+</md:EntityDescriptor>`, base64.StdEncoding.EncodeToString(block.Bytes))
+
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	resourceName := "aiven_account_authentication.method"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acc.TestAccPreCheck(t) },
+		ProviderFactories: acc.TestAccProviderFactories,
+		CheckDestroy:      testAccCheckAivenAccountAuthenticationResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAccountAuthenticationResourceSAMLMetadata(rName, metadata),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAivenAccountAuthenticationAttributes(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "saml_certificate", cert),
+					resource.TestCheckResourceAttr(resourceName, "saml_entity_id", "https://idp.example.com/metadata"),
+					resource.TestCheckResourceAttr(resourceName, "saml_idp_url", "https://idp.example.com/sso"),
+				),
+			},
+			{
+				Config:   testAccAccountAuthenticationResourceSAMLMetadata(rName, metadata),
+				PlanOnly: true,
+			},
+			{
+				// without the metadata the populated fields are cleared like any optional field
+				Config: testAccAccountAuthenticationResourceSAMLMetadata(rName, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "saml_certificate", ""),
+					resource.TestCheckResourceAttr(resourceName, "saml_entity_id", ""),
+					resource.TestCheckResourceAttr(resourceName, "saml_idp_url", ""),
+				),
+			},
+		},
+	})
+}
+
+func testAccAccountAuthenticationResourceSAMLMetadata(rName, metadata string) string {
+	metadataXML := ""
+	if metadata != "" {
+		metadataXML = fmt.Sprintf(`saml_idp_metadata_xml = <<-EOT
+  %s
+  EOT`, metadata)
+	}
+
+	return fmt.Sprintf(`
+resource "aiven_account" "user" {
+  name = "test-acc-account-%[1]s"
+}
+
+resource "aiven_account_authentication" "method" {
+  account_id = aiven_account.user.account_id
+  type       = "saml"
+  name       = "test-acc-auth-method-%[1]s"
+  %[2]s
+}
+`, rName, metadataXML)
+}
+
 func TestAccAivenAccountAuthentication_auto_join_team_id(t *testing.T) {
 	resourceName := "aiven_account_authentication.foo"
 	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
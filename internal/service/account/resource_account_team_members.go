@@ -0,0 +1,390 @@
+package account
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var aivenAccountTeamMembersMemberSchema = &schema.Resource{Schema: map[string]*schema.Schema{
+	"user_email": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "User email address",
+	},
+	"user_id": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "User ID, empty until the invitation is accepted",
+	},
+	"real_name": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "User real name, empty until the invitation is accepted",
+	},
+	"accepted": {
+		Type:        schema.TypeBool,
+		Computed:    true,
+		Description: "Whether the user accepted the invitation and is a member of the team",
+	},
+	"status": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: schemautil.Complex("Membership status.").PossibleValues("accepted", "pending").Build(),
+	},
+	"invited_by_user_email": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The email address that invited the user, empty once the invitation is accepted",
+	},
+	"create_time": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Time of the invitation or, once accepted, of joining the team",
+	},
+}}
+
+var aivenAccountTeamMembersSchema = map[string]*schema.Schema{
+	"account_id": {
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: schemautil.Complex("The unique account id").ForceNew().Build(),
+	},
+	"team_id": {
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: schemautil.Complex("An account team id").ForceNew().Build(),
+	},
+	"user_emails": {
+		Type:        schema.TypeSet,
+		Required:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Description: "Email addresses of all the members of the team. Missing users are invited, users who are not in the set are removed from the team and their pending invitations are deleted.",
+	},
+	"resend_invites_after": {
+		Type:         schema.TypeString,
+		Optional:     true,
+		ValidateFunc: schemautil.ValidateDurationString,
+		Description:  "Resend the invitations which are pending for longer than this duration, e.g. `168h`. Invitations which expired and are no longer returned by the API are always resent.",
+	},
+	"members": {
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "Members and pending invitations of the team",
+		Elem:        aivenAccountTeamMembersMemberSchema,
+	},
+}
+
+func ResourceAccountTeamMembers() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+The Account Team Members resource manages all the members of an Aiven Account Team authoritatively.
+
+The users of ` + "`user_emails`" + ` who are neither members of the team nor invited get an email
+invitation, the members and invitations of other users are removed. Unlike ` + "`aiven_account_team_member`" + `
+it must not be combined with other resources managing the members of the same team.
+`,
+		CreateContext: resourceAccountTeamMembersUpdate,
+		ReadContext:   resourceAccountTeamMembersRead,
+		UpdateContext: resourceAccountTeamMembersUpdate,
+		DeleteContext: resourceAccountTeamMembersDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: aivenAccountTeamMembersSchema,
+	}
+}
+
+// accountTeamMembership is the member list and the pending invitations of a team
+type accountTeamMembership struct {
+	members []aiven.AccountTeamMember
+	invites []aiven.AccountTeamInvite
+}
+
+func getAccountTeamMembership(client *aiven.Client, accountID, teamID string) (*accountTeamMembership, error) {
+	rm, err := client.AccountTeamMembers.List(accountID, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	ri, err := client.AccountTeamInvites.List(accountID, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &accountTeamMembership{members: rm.Members, invites: ri.Invites}, nil
+}
+
+// accountTeamMembersChanges is what has to be done to turn the membership of a team into the wanted one
+type accountTeamMembersChanges struct {
+	invite        []string
+	deleteInvites []string
+	deleteMembers []aiven.AccountTeamMember
+}
+
+// expiredInvite tells if a pending invitation created at createTime has to be resent
+func expiredInvite(createTime *time.Time, resendAfter time.Duration, now time.Time) bool {
+	return resendAfter > 0 && createTime != nil && now.Sub(*createTime) > resendAfter
+}
+
+// diffAccountTeamMembers compares the membership of a team with the wanted user emails, emails
+// are compared case-insensitively
+func diffAccountTeamMembers(
+	membership *accountTeamMembership,
+	userEmails []string,
+	resendAfter time.Duration,
+	now time.Time,
+) *accountTeamMembersChanges {
+	wanted := make(map[string]bool, len(userEmails))
+	for _, e := range userEmails {
+		wanted[strings.ToLower(e)] = true
+	}
+
+	changes := &accountTeamMembersChanges{}
+	present := make(map[string]bool)
+	for _, m := range membership.members {
+		email := strings.ToLower(m.UserEmail)
+		if !wanted[email] {
+			changes.deleteMembers = append(changes.deleteMembers, m)
+			continue
+		}
+		present[email] = true
+	}
+
+	for _, i := range membership.invites {
+		email := strings.ToLower(i.UserEmail)
+		if present[email] {
+			continue
+		}
+		if !wanted[email] || expiredInvite(i.CreateTime, resendAfter, now) {
+			changes.deleteInvites = append(changes.deleteInvites, i.UserEmail)
+			continue
+		}
+		present[email] = true
+	}
+
+	for _, e := range userEmails {
+		if !present[strings.ToLower(e)] {
+			changes.invite = append(changes.invite, e)
+		}
+	}
+	sort.Strings(changes.invite)
+
+	return changes
+}
+
+func resourceAccountTeamMembersUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+	accountID := d.Get("account_id").(string)
+	teamID := d.Get("team_id").(string)
+
+	membership, err := getAccountTeamMembership(client, accountID, teamID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	resendAfter, _ := time.ParseDuration(d.Get("resend_invites_after").(string))
+	changes := diffAccountTeamMembers(
+		membership,
+		schemautil.FlattenToString(d.Get("user_emails").(*schema.Set).List()),
+		resendAfter,
+		time.Now(),
+	)
+
+	for _, member := range changes.deleteMembers {
+		if err := client.AccountTeamMembers.Delete(accountID, teamID, member.UserId); err != nil && !aiven.IsNotFound(err) {
+			return diag.Errorf("cannot remove %s from account team %s: %s", member.UserEmail, teamID, err)
+		}
+	}
+
+	for _, email := range changes.deleteInvites {
+		if err := client.AccountTeamInvites.Delete(accountID, teamID, email); err != nil && !aiven.IsNotFound(err) {
+			return diag.Errorf("cannot delete account team %s invitation of %s: %s", teamID, email, err)
+		}
+	}
+
+	for _, email := range changes.invite {
+		if err := client.AccountTeamMembers.Invite(accountID, teamID, email); err != nil {
+			return diag.Errorf("cannot invite %s to account team %s: %s", email, teamID, err)
+		}
+	}
+
+	d.SetId(schemautil.BuildResourceID(accountID, teamID))
+
+	return resourceAccountTeamMembersRead(ctx, d, m)
+}
+
+func resourceAccountTeamMembersRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	accountID, teamID, err := schemautil.SplitResourceID2(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	membership, err := getAccountTeamMembership(client, accountID, teamID)
+	if err != nil {
+		return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
+	}
+
+	if err := d.Set("account_id", accountID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("team_id", teamID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// the configured spelling of the emails is kept, invitations to resend are left out to plan them
+	configured := make(map[string]string)
+	for _, e := range schemautil.FlattenToString(d.Get("user_emails").(*schema.Set).List()) {
+		configured[strings.ToLower(e)] = e
+	}
+	resendAfter, _ := time.ParseDuration(d.Get("resend_invites_after").(string))
+	now := time.Now()
+
+	var userEmails []string
+	for _, member := range listAccountTeamMembers(membership) {
+		if !member.accepted && expiredInvite(member.createTime, resendAfter, now) {
+			continue
+		}
+		email := member.userEmail
+		if e, ok := configured[strings.ToLower(email)]; ok {
+			email = e
+		}
+		userEmails = append(userEmails, email)
+	}
+
+	if err := d.Set("user_emails", userEmails); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("members", accountTeamMembersToSchema(membership)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceAccountTeamMembersDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	accountID, teamID, err := schemautil.SplitResourceID2(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	membership, err := getAccountTeamMembership(client, accountID, teamID)
+	if err != nil {
+		if aiven.IsNotFound(err) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	// only the users managed by the resource are removed
+	changes := diffAccountTeamMembers(membership, nil, 0, time.Now())
+	managed := make(map[string]bool)
+	for _, e := range schemautil.FlattenToString(d.Get("user_emails").(*schema.Set).List()) {
+		managed[strings.ToLower(e)] = true
+	}
+
+	for _, member := range changes.deleteMembers {
+		if !managed[strings.ToLower(member.UserEmail)] {
+			continue
+		}
+		if err := client.AccountTeamMembers.Delete(accountID, teamID, member.UserId); err != nil && !aiven.IsNotFound(err) {
+			return diag.Errorf("cannot remove %s from account team %s: %s", member.UserEmail, teamID, err)
+		}
+	}
+
+	for _, email := range changes.deleteInvites {
+		if !managed[strings.ToLower(email)] {
+			continue
+		}
+		if err := client.AccountTeamInvites.Delete(accountID, teamID, email); err != nil && !aiven.IsNotFound(err) {
+			return diag.Errorf("cannot delete account team %s invitation of %s: %s", teamID, email, err)
+		}
+	}
+
+	return nil
+}
+
+// accountTeamMemberStatus is a member or a pending invitation of a team
+type accountTeamMemberStatus struct {
+	userEmail          string
+	userID             string
+	realName           string
+	accepted           bool
+	invitedByUserEmail string
+	createTime         *time.Time
+}
+
+// listAccountTeamMembers merges the members and the pending invitations of a team, an invitation
+// disappears once accepted but both may be listed for a short while
+func listAccountTeamMembers(membership *accountTeamMembership) []accountTeamMemberStatus {
+	var r []accountTeamMemberStatus
+	members := make(map[string]bool)
+	for _, m := range membership.members {
+		members[strings.ToLower(m.UserEmail)] = true
+		r = append(r, accountTeamMemberStatus{
+			userEmail:  m.UserEmail,
+			userID:     m.UserId,
+			realName:   m.RealName,
+			accepted:   true,
+			createTime: m.CreateTime,
+		})
+	}
+
+	for _, i := range membership.invites {
+		if members[strings.ToLower(i.UserEmail)] {
+			continue
+		}
+		r = append(r, accountTeamMemberStatus{
+			userEmail:          i.UserEmail,
+			invitedByUserEmail: i.InvitedByUserEmail,
+			createTime:         i.CreateTime,
+		})
+	}
+
+	sort.Slice(r, func(a, b int) bool {
+		return r[a].userEmail < r[b].userEmail
+	})
+
+	return r
+}
+
+// accountTeamMembersToSchema returns the members list of the schema
+func accountTeamMembersToSchema(membership *accountTeamMembership) []map[string]interface{} {
+	var r []map[string]interface{}
+	for _, m := range listAccountTeamMembers(membership) {
+		status := "pending"
+		if m.accepted {
+			status = "accepted"
+		}
+
+		var createTime string
+		if m.createTime != nil {
+			createTime = m.createTime.String()
+		}
+
+		r = append(r, map[string]interface{}{
+			"user_email":            m.userEmail,
+			"user_id":               m.userID,
+			"real_name":             m.realName,
+			"accepted":              m.accepted,
+			"status":                status,
+			"invited_by_user_email": m.invitedByUserEmail,
+			"create_time":           createTime,
+		})
+	}
+	return r
+}
@@ -0,0 +1,77 @@
+package account_test
+
+import (
+	"fmt"
+	"testing"
+
+	acc "github.com/aiven/terraform-provider-aiven/internal/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAivenAccountTeamMembers_basic(t *testing.T) {
+	resourceName := "aiven_account_team_members.foo"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acc.TestAccPreCheck(t) },
+		ProviderFactories: acc.TestAccProviderFactories,
+		CheckDestroy:      testAccCheckAivenAccountResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAccountTeamMembersResource(rName, "a", "b"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "user_emails.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "members.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "members.0.accepted", "false"),
+					resource.TestCheckResourceAttr(resourceName, "members.0.status", "pending"),
+				),
+			},
+			{
+				Config: testAccAccountTeamMembersResource(rName, "b", "c"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "user_emails.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "members.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "members.0.user_email", fmt.Sprintf("ivan.savciuc+%s-b@aiven.fi", rName)),
+					resource.TestCheckResourceAttr(resourceName, "members.1.user_email", fmt.Sprintf("ivan.savciuc+%s-c@aiven.fi", rName)),
+					resource.TestCheckResourceAttr("data.aiven_account_team_members.members", "total_results", "2"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"resend_invites_after",
+				},
+			},
+		},
+	})
+}
+
+func testAccAccountTeamMembersResource(name string, users ...string) string {
+	return fmt.Sprintf(`
+resource "aiven_account" "foo" {
+  name = "test-acc-ac-%[1]s"
+}
+
+resource "aiven_account_team" "foo" {
+  account_id = aiven_account.foo.account_id
+  name       = "test-acc-team-%[1]s"
+}
+
+resource "aiven_account_team_members" "foo" {
+  account_id = aiven_account_team.foo.account_id
+  team_id    = aiven_account_team.foo.team_id
+  user_emails = [
+    "ivan.savciuc+%[1]s-%[2]s@aiven.fi",
+    "ivan.savciuc+%[1]s-%[3]s@aiven.fi",
+  ]
+  resend_invites_after = "168h"
+}
+
+data "aiven_account_team_members" "members" {
+  account_id = aiven_account_team_members.foo.account_id
+  team_id    = aiven_account_team_members.foo.team_id
+}`, name, users[0], users[1])
+}
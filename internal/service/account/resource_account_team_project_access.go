@@ -0,0 +1,200 @@
+package account
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// accountTeamTypes are the roles a team may have in a project, from the least to the most privileged
+var accountTeamTypes = []string{"read_only", "developer", "operator", "admin"}
+
+var aivenAccountTeamProjectAccessSchema = map[string]*schema.Schema{
+	"account_id": {
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: schemautil.Complex("The unique account id").ForceNew().Build(),
+	},
+	"team_id": {
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: schemautil.Complex("An account team id").ForceNew().Build(),
+	},
+	"projects": {
+		Type:         schema.TypeMap,
+		Required:     true,
+		Elem:         &schema.Schema{Type: schema.TypeString},
+		ValidateFunc: validateAccountTeamProjectAccess,
+		Description: schemautil.Complex("Roles of the team in projects, keyed by the project name. The team is removed from the projects which are not in the map.").
+			PossibleValues(schemautil.StringSliceToInterfaceSlice(accountTeamTypes)...).Build(),
+	},
+}
+
+func ResourceAccountTeamProjectAccess() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+The Account Team Project Access resource manages the roles of an Aiven Account Team in all its projects authoritatively.
+
+The projects should have an ` + "`account_id`" + ` property set equal to the account of the team.
+Unlike ` + "`aiven_account_team_project`" + ` it must not be combined with other resources linking projects
+to the same team.
+`,
+		CreateContext: resourceAccountTeamProjectAccessUpdate,
+		ReadContext:   resourceAccountTeamProjectAccessRead,
+		UpdateContext: resourceAccountTeamProjectAccessUpdate,
+		DeleteContext: resourceAccountTeamProjectAccessDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: aivenAccountTeamProjectAccessSchema,
+	}
+}
+
+func validateAccountTeamProjectAccess(i interface{}, k string) (warnings []string, errs []error) {
+	for project, teamType := range i.(map[string]interface{}) {
+		if accountTeamTypeRank(teamType.(string)) < 0 {
+			errs = append(errs, fmt.Errorf("%s: invalid role %q of project %s, expected one of %v", k, teamType, project, accountTeamTypes))
+		}
+	}
+	return
+}
+
+// accountTeamTypeRank returns the privilege level of a team type or project member type, -1 if unknown
+func accountTeamTypeRank(teamType string) int {
+	for i, t := range accountTeamTypes {
+		if t == teamType {
+			return i
+		}
+	}
+	return -1
+}
+
+// accountTeamProjectsChanges is what has to be done to turn the projects of a team into the wanted ones
+type accountTeamProjectsChanges struct {
+	create []aiven.AccountTeamProject
+	update []aiven.AccountTeamProject
+	remove []string
+}
+
+// diffAccountTeamProjects compares the projects of a team with the wanted project to role map
+func diffAccountTeamProjects(current []aiven.AccountTeamProject, wanted map[string]string) *accountTeamProjectsChanges {
+	changes := &accountTeamProjectsChanges{}
+	linked := make(map[string]bool, len(current))
+	for _, p := range current {
+		linked[p.ProjectName] = true
+		teamType, ok := wanted[p.ProjectName]
+		switch {
+		case !ok:
+			changes.remove = append(changes.remove, p.ProjectName)
+		case teamType != p.TeamType:
+			changes.update = append(changes.update, aiven.AccountTeamProject{ProjectName: p.ProjectName, TeamType: teamType})
+		}
+	}
+
+	for project, teamType := range wanted {
+		if !linked[project] {
+			changes.create = append(changes.create, aiven.AccountTeamProject{ProjectName: project, TeamType: teamType})
+		}
+	}
+	sort.Slice(changes.create, func(i, j int) bool {
+		return changes.create[i].ProjectName < changes.create[j].ProjectName
+	})
+
+	return changes
+}
+
+func resourceAccountTeamProjectAccessUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+	accountID := d.Get("account_id").(string)
+	teamID := d.Get("team_id").(string)
+
+	r, err := client.AccountTeamProjects.List(accountID, teamID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	wanted := make(map[string]string)
+	for project, teamType := range d.Get("projects").(map[string]interface{}) {
+		wanted[project] = teamType.(string)
+	}
+
+	changes := diffAccountTeamProjects(r.Projects, wanted)
+	for _, project := range changes.remove {
+		if err := client.AccountTeamProjects.Delete(accountID, teamID, project); err != nil && !aiven.IsNotFound(err) {
+			return diag.Errorf("cannot remove account team %s from project %s: %s", teamID, project, err)
+		}
+	}
+
+	for _, p := range changes.update {
+		if err := client.AccountTeamProjects.Update(accountID, teamID, p); err != nil {
+			return diag.Errorf("cannot update account team %s role in project %s: %s", teamID, p.ProjectName, err)
+		}
+	}
+
+	for _, p := range changes.create {
+		if err := client.AccountTeamProjects.Create(accountID, teamID, p); err != nil {
+			return diag.Errorf("cannot add account team %s to project %s: %s", teamID, p.ProjectName, err)
+		}
+	}
+
+	d.SetId(schemautil.BuildResourceID(accountID, teamID))
+
+	return resourceAccountTeamProjectAccessRead(ctx, d, m)
+}
+
+func resourceAccountTeamProjectAccessRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	accountID, teamID, err := schemautil.SplitResourceID2(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	r, err := client.AccountTeamProjects.List(accountID, teamID)
+	if err != nil {
+		return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
+	}
+
+	projects := make(map[string]string, len(r.Projects))
+	for _, p := range r.Projects {
+		projects[p.ProjectName] = p.TeamType
+	}
+
+	if err := d.Set("account_id", accountID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("team_id", teamID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("projects", projects); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceAccountTeamProjectAccessDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	accountID, teamID, err := schemautil.SplitResourceID2(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	for project := range d.Get("projects").(map[string]interface{}) {
+		if err := client.AccountTeamProjects.Delete(accountID, teamID, project); err != nil && !aiven.IsNotFound(err) {
+			return diag.Errorf("cannot remove account team %s from project %s: %s", teamID, project, err)
+		}
+	}
+
+	return nil
+}
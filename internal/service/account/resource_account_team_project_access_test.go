@@ -0,0 +1,80 @@
+package account_test
+
+import (
+	"fmt"
+	"testing"
+
+	acc "github.com/aiven/terraform-provider-aiven/internal/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAivenAccountTeamProjectAccess_basic(t *testing.T) {
+	resourceName := "aiven_account_team_project_access.foo"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acc.TestAccPreCheck(t) },
+		ProviderFactories: acc.TestAccProviderFactories,
+		CheckDestroy:      testAccCheckAivenAccountResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAccountTeamProjectAccessResource(rName, "admin", "read_only"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "projects.%", "2"),
+					resource.TestCheckResourceAttr(resourceName, fmt.Sprintf("projects.test-acc-pr-%s-a", rName), "admin"),
+					resource.TestCheckResourceAttr(resourceName, fmt.Sprintf("projects.test-acc-pr-%s-b", rName), "read_only"),
+				),
+			},
+			{
+				Config: testAccAccountTeamProjectAccessResource(rName, "developer", "operator"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, fmt.Sprintf("projects.test-acc-pr-%s-a", rName), "developer"),
+					resource.TestCheckResourceAttr(resourceName, fmt.Sprintf("projects.test-acc-pr-%s-b", rName), "operator"),
+					resource.TestCheckResourceAttrPair("data.aiven_account_effective_permissions.perm", "account_id", "aiven_account.foo", "account_id"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccAccountTeamProjectAccessResource(name, roleA, roleB string) string {
+	return fmt.Sprintf(`
+resource "aiven_account" "foo" {
+  name = "test-acc-ac-%[1]s"
+}
+
+resource "aiven_account_team" "foo" {
+  account_id = aiven_account.foo.account_id
+  name       = "test-acc-team-%[1]s"
+}
+
+resource "aiven_project" "a" {
+  project    = "test-acc-pr-%[1]s-a"
+  account_id = aiven_account_team.foo.account_id
+}
+
+resource "aiven_project" "b" {
+  project    = "test-acc-pr-%[1]s-b"
+  account_id = aiven_account_team.foo.account_id
+}
+
+resource "aiven_account_team_project_access" "foo" {
+  account_id = aiven_account.foo.account_id
+  team_id    = aiven_account_team.foo.team_id
+  projects = {
+    (aiven_project.a.project) = "%[2]s"
+    (aiven_project.b.project) = "%[3]s"
+  }
+}
+
+data "aiven_account_effective_permissions" "perm" {
+  account_id    = aiven_account_team_project_access.foo.account_id
+  project_names = keys(aiven_account_team_project_access.foo.projects)
+}`, name, roleA, roleB)
+}
@@ -0,0 +1,391 @@
+package account
+
+import (
+	"context"
+	"crypto/dsa" //nolint:staticcheck // DSA is a valid SAML signature algorithm
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	samlBindingHTTPRedirect = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect"
+	samlBindingHTTPPost     = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST"
+
+	// samlCertificateMinRSAKeySize is the smallest RSA key accepted for the IdP certificate
+	samlCertificateMinRSAKeySize = 2048
+
+	// samlCertificateExpiryWarning is how long before the expiry of the IdP certificate a warning is shown
+	samlCertificateExpiryWarning = 30 * 24 * time.Hour
+)
+
+// samlDigestAlgorithms maps the XML digest algorithm URIs to the saml_digest_algorithm values
+var samlDigestAlgorithms = map[string]string{
+	"http://www.w3.org/2000/09/xmldsig#sha1":        "sha1",
+	"http://www.w3.org/2001/04/xmlenc#sha256":       "sha256",
+	"http://www.w3.org/2001/04/xmldsig-more#sha384": "sha384",
+	"http://www.w3.org/2001/04/xmlenc#sha512":       "sha512",
+}
+
+// samlSignatureAlgorithms maps the XML signature algorithm URIs to the saml_signature_algorithm values
+var samlSignatureAlgorithms = map[string]string{
+	"http://www.w3.org/2000/09/xmldsig#rsa-sha1":          "rsa-sha1",
+	"http://www.w3.org/2000/09/xmldsig#dsa-sha1":          "dsa-sha1",
+	"http://www.w3.org/2001/04/xmldsig-more#rsa-sha256":   "rsa-sha256",
+	"http://www.w3.org/2001/04/xmldsig-more#rsa-sha384":   "rsa-sha384",
+	"http://www.w3.org/2001/04/xmldsig-more#rsa-sha512":   "rsa-sha512",
+	"http://www.w3.org/2001/04/xmldsig-more#ecdsa-sha256": "ecdsa-sha256",
+}
+
+// samlCertificateDigests maps the certificate signature algorithms to the saml_digest_algorithm values
+var samlCertificateDigests = map[x509.SignatureAlgorithm]string{
+	x509.SHA1WithRSA:      "sha1",
+	x509.DSAWithSHA1:      "sha1",
+	x509.ECDSAWithSHA1:    "sha1",
+	x509.SHA256WithRSA:    "sha256",
+	x509.DSAWithSHA256:    "sha256",
+	x509.ECDSAWithSHA256:  "sha256",
+	x509.SHA256WithRSAPSS: "sha256",
+	x509.SHA384WithRSA:    "sha384",
+	x509.ECDSAWithSHA384:  "sha384",
+	x509.SHA384WithRSAPSS: "sha384",
+	x509.SHA512WithRSA:    "sha512",
+	x509.ECDSAWithSHA512:  "sha512",
+	x509.SHA512WithRSAPSS: "sha512",
+}
+
+type samlMetadataKeyDescriptor struct {
+	Use          string   `xml:"use,attr"`
+	Certificates []string `xml:"KeyInfo>X509Data>X509Certificate"`
+}
+
+type samlMetadataEndpoint struct {
+	Binding  string `xml:"Binding,attr"`
+	Location string `xml:"Location,attr"`
+}
+
+type samlMetadataAlgorithm struct {
+	Algorithm string `xml:"Algorithm,attr"`
+}
+
+type samlMetadataIDPSSODescriptor struct {
+	KeyDescriptors      []samlMetadataKeyDescriptor `xml:"KeyDescriptor"`
+	SingleSignOnService []samlMetadataEndpoint      `xml:"SingleSignOnService"`
+}
+
+type samlMetadataEntityDescriptor struct {
+	EntityID         string                         `xml:"entityID,attr"`
+	IDPSSODescriptor []samlMetadataIDPSSODescriptor `xml:"IDPSSODescriptor"`
+	DigestMethods    []samlMetadataAlgorithm        `xml:"Extensions>DigestMethod"`
+	SigningMethods   []samlMetadataAlgorithm        `xml:"Extensions>SigningMethod"`
+}
+
+type samlMetadataEntitiesDescriptor struct {
+	EntityDescriptors []samlMetadataEntityDescriptor `xml:"EntityDescriptor"`
+}
+
+// samlIdPMetadata is the part of an IdP metadata document the account authentication is configured with
+type samlIdPMetadata struct {
+	entityID            string
+	idpURL              string
+	certificate         string
+	digestAlgorithms    []string
+	signatureAlgorithms []string
+}
+
+// parseSAMLIdPMetadata parses an IdP metadata document, either a single EntityDescriptor or an
+// EntitiesDescriptor with the IdP as one of its entities
+func parseSAMLIdPMetadata(metadata string) (*samlIdPMetadata, error) {
+	var entities samlMetadataEntitiesDescriptor
+	var root struct{ XMLName xml.Name }
+	if err := xml.Unmarshal([]byte(metadata), &root); err != nil {
+		return nil, fmt.Errorf("invalid SAML metadata: %w", err)
+	}
+
+	switch root.XMLName.Local {
+	case "EntityDescriptor":
+		var e samlMetadataEntityDescriptor
+		if err := xml.Unmarshal([]byte(metadata), &e); err != nil {
+			return nil, fmt.Errorf("invalid SAML metadata: %w", err)
+		}
+		entities.EntityDescriptors = append(entities.EntityDescriptors, e)
+	case "EntitiesDescriptor":
+		if err := xml.Unmarshal([]byte(metadata), &entities); err != nil {
+			return nil, fmt.Errorf("invalid SAML metadata: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("invalid SAML metadata: unexpected root element %s", root.XMLName.Local)
+	}
+
+	for _, e := range entities.EntityDescriptors {
+		if len(e.IDPSSODescriptor) == 0 {
+			continue
+		}
+		idp := e.IDPSSODescriptor[0]
+
+		r := &samlIdPMetadata{entityID: e.EntityID}
+		for _, binding := range []string{samlBindingHTTPRedirect, samlBindingHTTPPost} {
+			for _, s := range idp.SingleSignOnService {
+				if s.Binding == binding && r.idpURL == "" {
+					r.idpURL = s.Location
+				}
+			}
+		}
+		if r.idpURL == "" {
+			return nil, fmt.Errorf("SAML metadata of %s has no HTTP-Redirect or HTTP-POST SingleSignOnService", e.EntityID)
+		}
+
+		for _, k := range idp.KeyDescriptors {
+			if (k.Use == "" || k.Use == "signing") && len(k.Certificates) > 0 {
+				cert, err := samlCertificateToPEM(k.Certificates[0])
+				if err != nil {
+					return nil, fmt.Errorf("SAML metadata of %s: %w", e.EntityID, err)
+				}
+				r.certificate = cert
+				break
+			}
+		}
+		if r.certificate == "" {
+			return nil, fmt.Errorf("SAML metadata of %s has no signing certificate", e.EntityID)
+		}
+
+		for _, m := range e.DigestMethods {
+			if a, ok := samlDigestAlgorithms[m.Algorithm]; ok {
+				r.digestAlgorithms = append(r.digestAlgorithms, a)
+			}
+		}
+		for _, m := range e.SigningMethods {
+			if a, ok := samlSignatureAlgorithms[m.Algorithm]; ok {
+				r.signatureAlgorithms = append(r.signatureAlgorithms, a)
+			}
+		}
+
+		return r, nil
+	}
+
+	return nil, fmt.Errorf("SAML metadata has no IDPSSODescriptor")
+}
+
+// samlCertificateToPEM converts the base64 DER certificate of the metadata to PEM
+func samlCertificateToPEM(s string) (string, error) {
+	der, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(s), ""))
+	if err != nil {
+		return "", fmt.Errorf("invalid X509Certificate: %w", err)
+	}
+	return strings.TrimSpace(string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))), nil
+}
+
+// parseSAMLCertificate parses a PEM encoded certificate
+func parseSAMLCertificate(s string) (*x509.Certificate, error) {
+	block, _ := pem.Decode([]byte(strings.TrimSpace(s)))
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, fmt.Errorf("saml_certificate is not a PEM encoded certificate")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// validateSAMLCertificate checks the validity period and the key of the IdP certificate and that
+// it's signed with the digest and key type of the configured algorithms
+func validateSAMLCertificate(cert *x509.Certificate, digestAlgorithm, signatureAlgorithm string, now time.Time) error {
+	if now.After(cert.NotAfter) {
+		return fmt.Errorf("saml_certificate expired on %s", cert.NotAfter.Format(time.RFC3339))
+	}
+	if now.Before(cert.NotBefore) {
+		return fmt.Errorf("saml_certificate is not valid before %s", cert.NotBefore.Format(time.RFC3339))
+	}
+
+	var keyType string
+	switch k := cert.PublicKey.(type) {
+	case *rsa.PublicKey:
+		keyType = "rsa"
+		if size := k.N.BitLen(); size < samlCertificateMinRSAKeySize {
+			return fmt.Errorf("saml_certificate RSA key size %d is smaller than %d bits", size, samlCertificateMinRSAKeySize)
+		}
+	case *dsa.PublicKey:
+		keyType = "dsa"
+	case *ecdsa.PublicKey:
+		keyType = "ecdsa"
+	default:
+		return fmt.Errorf("saml_certificate has an unsupported %s public key", cert.PublicKeyAlgorithm)
+	}
+
+	if signatureAlgorithm != "" && !strings.HasPrefix(signatureAlgorithm, keyType+"-") {
+		return fmt.Errorf("saml_signature_algorithm %s doesn't match the %s key of saml_certificate", signatureAlgorithm, keyType)
+	}
+
+	if digest, ok := samlCertificateDigests[cert.SignatureAlgorithm]; ok && digestAlgorithm != "" && digest != digestAlgorithm {
+		return fmt.Errorf("saml_digest_algorithm %s doesn't match the %s signature of saml_certificate", digestAlgorithm, cert.SignatureAlgorithm)
+	}
+
+	return nil
+}
+
+// samlCertificateExpiryDiagnostics warns when the IdP certificate expires soon or has already expired
+func samlCertificateExpiryDiagnostics(certificate string, now time.Time) diag.Diagnostics {
+	if strings.TrimSpace(certificate) == "" {
+		return nil
+	}
+
+	cert, err := parseSAMLCertificate(certificate)
+	if err != nil {
+		return nil
+	}
+
+	switch {
+	case now.After(cert.NotAfter):
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  "SAML certificate expired",
+			Detail:   fmt.Sprintf("saml_certificate expired on %s, users can't log in until it's renewed", cert.NotAfter.Format(time.RFC3339)),
+		}}
+	case cert.NotAfter.Sub(now) < samlCertificateExpiryWarning:
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  "SAML certificate expires soon",
+			Detail:   fmt.Sprintf("saml_certificate expires on %s, renew it at the IdP and update the account authentication", cert.NotAfter.Format(time.RFC3339)),
+		}}
+	}
+
+	return nil
+}
+
+// samlMetadataFromSchema returns the metadata document of saml_idp_metadata_xml or saml_idp_metadata_file,
+// an empty string when none is set or the value is not known yet
+func samlMetadataFromSchema(d *schema.ResourceDiff) (string, error) {
+	if !d.NewValueKnown("saml_idp_metadata_xml") || !d.NewValueKnown("saml_idp_metadata_file") {
+		return "", nil
+	}
+
+	if metadata := d.Get("saml_idp_metadata_xml").(string); metadata != "" {
+		return metadata, nil
+	}
+
+	if path := d.Get("saml_idp_metadata_file").(string); path != "" {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("cannot read saml_idp_metadata_file: %w", err)
+		}
+		return string(b), nil
+	}
+
+	return "", nil
+}
+
+// setNewIfChanged sets a new value of a computed attribute unless it only differs by surrounding whitespace
+func setNewIfChanged(d *schema.ResourceDiff, key, value string) error {
+	if strings.TrimSpace(d.Get(key).(string)) == strings.TrimSpace(value) {
+		return nil
+	}
+	return d.SetNew(key, value)
+}
+
+// samlAlgorithm returns the algorithm the IdP advertises first, its preferred one, or the default when it
+// advertises none
+func samlAlgorithm(advertised []string, defaultAlgorithm string) string {
+	if len(advertised) > 0 {
+		return advertised[0]
+	}
+	return defaultAlgorithm
+}
+
+// customizeDiffAccountAuthenticationSAML populates the SAML settings from the IdP metadata, or clears them when
+// there is no metadata and they are not configured, and validates the IdP certificate whenever it or the
+// algorithms change. The algorithms which are not configured are the ones the metadata advertises or the defaults
+func customizeDiffAccountAuthenticationSAML(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	metadata, err := samlMetadataFromSchema(d)
+	if err != nil {
+		return err
+	}
+
+	var idp *samlIdPMetadata
+	if metadata != "" {
+		if idp, err = parseSAMLIdPMetadata(metadata); err != nil {
+			return err
+		}
+	}
+
+	if config := d.GetRawConfig(); !config.IsNull() && d.NewValueKnown("saml_idp_metadata_xml") && d.NewValueKnown("saml_idp_metadata_file") {
+		var digestAlgorithms, signatureAlgorithms []string
+		if idp != nil {
+			digestAlgorithms, signatureAlgorithms = idp.digestAlgorithms, idp.signatureAlgorithms
+		}
+		for k, v := range map[string]string{
+			"saml_digest_algorithm":    samlAlgorithm(digestAlgorithms, "sha256"),
+			"saml_signature_algorithm": samlAlgorithm(signatureAlgorithms, "rsa-sha256"),
+		} {
+			if config.GetAttr(k).IsNull() {
+				if err := setNewIfChanged(d, k, v); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	digestAlgorithm := d.Get("saml_digest_algorithm").(string)
+	signatureAlgorithm := d.Get("saml_signature_algorithm").(string)
+
+	if idp != nil {
+		if len(idp.digestAlgorithms) > 0 && !containsString(idp.digestAlgorithms, digestAlgorithm) {
+			return fmt.Errorf("saml_digest_algorithm %s is not supported by the IdP, expected one of %v", digestAlgorithm, idp.digestAlgorithms)
+		}
+		if len(idp.signatureAlgorithms) > 0 && !containsString(idp.signatureAlgorithms, signatureAlgorithm) {
+			return fmt.Errorf("saml_signature_algorithm %s is not supported by the IdP, expected one of %v", signatureAlgorithm, idp.signatureAlgorithms)
+		}
+
+		for k, v := range map[string]string{
+			"saml_entity_id":   idp.entityID,
+			"saml_idp_url":     idp.idpURL,
+			"saml_certificate": idp.certificate,
+		} {
+			if err := setNewIfChanged(d, k, v); err != nil {
+				return err
+			}
+		}
+	} else if config := d.GetRawConfig(); !config.IsNull() {
+		// the fields are only computed from the metadata, without it they are plain optional ones
+		// and taking them out of the configuration clears them
+		for _, k := range []string{"saml_entity_id", "saml_idp_url", "saml_certificate"} {
+			if config.GetAttr(k).IsNull() && d.Get(k).(string) != "" {
+				if err := d.SetNew(k, ""); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	// a certificate which is already in use doesn't block the plan, it's warned about on refresh instead
+	if !d.HasChanges("saml_certificate", "saml_digest_algorithm", "saml_signature_algorithm") {
+		return nil
+	}
+
+	certificate := d.Get("saml_certificate").(string)
+	if !d.NewValueKnown("saml_certificate") || strings.TrimSpace(certificate) == "" {
+		return nil
+	}
+
+	cert, err := parseSAMLCertificate(certificate)
+	if err != nil {
+		return err
+	}
+
+	return validateSAMLCertificate(cert, digestAlgorithm, signatureAlgorithm, time.Now())
+}
+
+func containsString(s []string, v string) bool {
+	for _, i := range s {
+		if i == v {
+			return true
+		}
+	}
+	return false
+}
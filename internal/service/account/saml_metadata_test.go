@@ -0,0 +1,173 @@
+package account
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSAMLCertificate(t *testing.T, key interface{}, notBefore, notAfter time.Time) *x509.Certificate {
+	t.Helper()
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "idp.example.com"},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+	}
+
+	var pub interface{}
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		pub = &k.PublicKey
+		template.SignatureAlgorithm = x509.SHA256WithRSA
+	case *ecdsa.PrivateKey:
+		pub = &k.PublicKey
+		template.SignatureAlgorithm = x509.ECDSAWithSHA256
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, pub, key)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert
+}
+
+func newTestSAMLMetadata(cert *x509.Certificate) string {
+	return fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<md:EntityDescriptor xmlns:md="urn:oasis:names:tc:SAML:2.0:metadata" xmlns:ds="http://www.w3.org/2000/09/xmldsig#"
+    xmlns:alg="urn:oasis:names:tc:SAML:metadata:algsupport" entityID="https://idp.example.com/metadata">
+  <md:Extensions>
+    <alg:DigestMethod Algorithm="http://www.w3.org/2001/04/xmlenc#sha256"/>
+    <alg:SigningMethod Algorithm="http://www.w3.org/2001/04/xmldsig-more#rsa-sha256"/>
+  </md:Extensions>
+  <md:IDPSSODescriptor protocolSupportEnumeration="urn:oasis:names:tc:SAML:2.0:protocol">
+    <md:KeyDescriptor use="encryption">
+      <ds:KeyInfo><ds:X509Data><ds:X509Certificate>Zm9v</ds:X509Certificate></ds:X509Data></ds:KeyInfo>
+    </md:KeyDescriptor>
+    <md:KeyDescriptor use="signing">
+      <ds:KeyInfo>
+        <ds:X509Data>
+          <ds:X509Certificate>
+            %s
+          </ds:X509Certificate>
+        </ds:X509Data>
+      </ds:KeyInfo>
+    </md:KeyDescriptor>
+    <md:SingleSignOnService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST" Location="https://idp.example.com/sso/post"/>
+    <md:SingleSignOnService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect" Location="https://idp.example.com/sso/redirect"/>
+  </md:IDPSSODescriptor>
+</md:EntityDescriptor>`, base64.StdEncoding.EncodeToString(cert.Raw))
+}
+
+func Test_parseSAMLIdPMetadata(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	now := time.Now()
+	cert := newTestSAMLCertificate(t, key, now.Add(-time.Hour), now.Add(365*24*time.Hour))
+
+	metadata := newTestSAMLMetadata(cert)
+	for name, doc := range map[string]string{
+		"EntityDescriptor": metadata,
+		"EntitiesDescriptor": `<md:EntitiesDescriptor xmlns:md="urn:oasis:names:tc:SAML:2.0:metadata">
+  <md:EntityDescriptor entityID="https://sp.example.com"><md:SPSSODescriptor/></md:EntityDescriptor>` +
+			metadata[strings.Index(metadata, "<md:EntityDescriptor"):] + `
+</md:EntitiesDescriptor>`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			idp, err := parseSAMLIdPMetadata(doc)
+			require.NoError(t, err)
+			assert.Equal(t, "https://idp.example.com/metadata", idp.entityID)
+			assert.Equal(t, "https://idp.example.com/sso/redirect", idp.idpURL)
+			assert.Equal(t, []string{"sha256"}, idp.digestAlgorithms)
+			assert.Equal(t, []string{"rsa-sha256"}, idp.signatureAlgorithms)
+
+			parsed, err := parseSAMLCertificate(idp.certificate)
+			require.NoError(t, err)
+			assert.Equal(t, cert.Raw, parsed.Raw)
+		})
+	}
+
+	_, err = parseSAMLIdPMetadata(`<md:EntityDescriptor xmlns:md="urn:oasis:names:tc:SAML:2.0:metadata" entityID="foo"/>`)
+	assert.EqualError(t, err, "SAML metadata has no IDPSSODescriptor")
+
+	_, err = parseSAMLIdPMetadata(`<html/>`)
+	assert.EqualError(t, err, "invalid SAML metadata: unexpected root element html")
+}
+
+func Test_samlAlgorithm(t *testing.T) {
+	assert.Equal(t, "sha512", samlAlgorithm([]string{"sha512", "sha256"}, "sha256"))
+	assert.Equal(t, "rsa-sha256", samlAlgorithm(nil, "rsa-sha256"))
+}
+
+func Test_validateSAMLCertificate(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	weakKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	now := time.Now()
+	valid := newTestSAMLCertificate(t, rsaKey, now.Add(-time.Hour), now.Add(time.Hour))
+
+	assert.NoError(t, validateSAMLCertificate(valid, "sha256", "rsa-sha256", now))
+	assert.EqualError(t,
+		validateSAMLCertificate(newTestSAMLCertificate(t, rsaKey, now.Add(-2*time.Hour), now.Add(-time.Hour)), "sha256", "rsa-sha256", now),
+		fmt.Sprintf("saml_certificate expired on %s", now.Add(-time.Hour).UTC().Truncate(time.Second).Format(time.RFC3339)),
+	)
+	assert.ErrorContains(t,
+		validateSAMLCertificate(newTestSAMLCertificate(t, rsaKey, now.Add(time.Hour), now.Add(2*time.Hour)), "sha256", "rsa-sha256", now),
+		"saml_certificate is not valid before",
+	)
+	assert.EqualError(t,
+		validateSAMLCertificate(newTestSAMLCertificate(t, weakKey, now.Add(-time.Hour), now.Add(time.Hour)), "sha256", "rsa-sha256", now),
+		"saml_certificate RSA key size 1024 is smaller than 2048 bits",
+	)
+	assert.EqualError(t,
+		validateSAMLCertificate(valid, "sha1", "rsa-sha256", now),
+		"saml_digest_algorithm sha1 doesn't match the SHA256-RSA signature of saml_certificate",
+	)
+	assert.EqualError(t,
+		validateSAMLCertificate(newTestSAMLCertificate(t, ecKey, now.Add(-time.Hour), now.Add(time.Hour)), "sha256", "rsa-sha256", now),
+		"saml_signature_algorithm rsa-sha256 doesn't match the ecdsa key of saml_certificate",
+	)
+}
+
+func Test_samlCertificateExpiryDiagnostics(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	now := time.Now()
+
+	pemOf := func(cert *x509.Certificate) string {
+		s, err := samlCertificateToPEM(base64.StdEncoding.EncodeToString(cert.Raw))
+		require.NoError(t, err)
+		return s
+	}
+
+	assert.Nil(t, samlCertificateExpiryDiagnostics("", now))
+	assert.Nil(t, samlCertificateExpiryDiagnostics(pemOf(newTestSAMLCertificate(t, key, now.Add(-time.Hour), now.Add(365*24*time.Hour))), now))
+
+	diags := samlCertificateExpiryDiagnostics(pemOf(newTestSAMLCertificate(t, key, now.Add(-time.Hour), now.Add(7*24*time.Hour))), now)
+	require.Len(t, diags, 1)
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+	assert.Equal(t, "SAML certificate expires soon", diags[0].Summary)
+
+	diags = samlCertificateExpiryDiagnostics(pemOf(newTestSAMLCertificate(t, key, now.Add(-2*time.Hour), now.Add(-time.Hour))), now)
+	require.Len(t, diags, 1)
+	assert.Equal(t, "SAML certificate expired", diags[0].Summary)
+}
@@ -0,0 +1,50 @@
+package cassandra
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+	"github.com/gocql/gocql"
+)
+
+// newCassandraSession connects to the service with the admin credentials of the service, the connection
+// is verified with the CA of the project
+func newCassandraSession(client *aiven.Client, project, serviceName string) (*gocql.Session, error) {
+	s, err := client.Services.Get(project, serviceName)
+	if err != nil {
+		return nil, err
+	}
+
+	username, password := schemautil.ServiceAdminCredentials(s)
+
+	port, err := strconv.Atoi(s.URIParams["port"])
+	if err != nil {
+		return nil, fmt.Errorf("cannot read the port of the Cassandra service %s: %w", serviceName, err)
+	}
+
+	ca, err := client.CA.Get(project)
+	if err != nil {
+		return nil, err
+	}
+	rootCAs := x509.NewCertPool()
+	if !rootCAs.AppendCertsFromPEM([]byte(ca)) {
+		return nil, fmt.Errorf("cannot read the CA certificate of the project %s", project)
+	}
+
+	cluster := gocql.NewCluster(s.URIParams["host"])
+	cluster.Port = port
+	cluster.Authenticator = gocql.PasswordAuthenticator{Username: username, Password: password}
+	cluster.SslOpts = &gocql.SslOptions{Config: &tls.Config{RootCAs: rootCAs, MinVersion: tls.VersionTLS12}}
+	cluster.Consistency = gocql.Quorum
+	cluster.Timeout = 30 * time.Second
+	cluster.ConnectTimeout = 30 * time.Second
+	// the nodes are reached through the address of the service, their own addresses may not be reachable
+	cluster.DisableInitialHostLookup = true
+
+	return cluster.CreateSession()
+}
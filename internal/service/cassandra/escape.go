@@ -0,0 +1,15 @@
+package cassandra
+
+import (
+	"strings"
+)
+
+// quoteIdentifier returns the identifier as a quoted CQL identifier, which keeps its case
+func quoteIdentifier(identifier string) string {
+	return `"` + strings.ReplaceAll(identifier, `"`, `""`) + `"`
+}
+
+// quoteString returns the value as a CQL string literal
+func quoteString(value string) string {
+	return `'` + strings.ReplaceAll(value, `'`, `''`) + `'`
+}
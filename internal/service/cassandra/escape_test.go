@@ -0,0 +1,20 @@
+package cassandra
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuoteIdentifier(t *testing.T) {
+	assert.Equal(t, `"events"`, quoteIdentifier("events"))
+	assert.Equal(t, `"MixedCase"`, quoteIdentifier("MixedCase"))
+	assert.Equal(t, `"a""b"`, quoteIdentifier(`a"b`))
+	assert.Equal(t, `""`, quoteIdentifier(""))
+}
+
+func TestQuoteString(t *testing.T) {
+	assert.Equal(t, `'aiven'`, quoteString("aiven"))
+	assert.Equal(t, `'it''s'`, quoteString("it's"))
+	assert.Equal(t, `''''''`, quoteString("''"))
+}
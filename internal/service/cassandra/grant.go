@@ -0,0 +1,228 @@
+package cassandra
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/gocql/gocql"
+)
+
+// Permission is a permission of a role on all keyspaces, on a keyspace or on a table
+type Permission struct {
+	Keyspace   string
+	Table      string
+	Permission string
+}
+
+// keyspacePermissions and tablePermissions are the permissions ALL stands for on all keyspaces or a keyspace, and
+// on a table
+var (
+	keyspacePermissions = []string{"CREATE", "ALTER", "DROP", "SELECT", "MODIFY", "AUTHORIZE"}
+	tablePermissions    = []string{"ALTER", "DROP", "SELECT", "MODIFY", "AUTHORIZE"}
+)
+
+// ReadPermissions returns the permissions of the role on the data, one per permission and resource
+func ReadPermissions(session *gocql.Session, role string) ([]Permission, error) {
+	query := "SELECT resource, permissions FROM system_auth.role_permissions WHERE role = ?"
+
+	log.Println("[DEBUG] Cassandra: read permissions query: ", query)
+	permissions := make(map[string][]string)
+	var resource string
+	var names []string
+	iter := session.Query(query, role).Iter()
+	for iter.Scan(&resource, &names) {
+		permissions[resource] = names
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+
+	return permissionsFromResources(permissions), nil
+}
+
+// ApplyPermissions grants and revokes the permissions of the role to go from the old permissions to the new ones
+func ApplyPermissions(session *gocql.Session, role string, old, new []Permission) error {
+	for _, query := range permissionsDelta(role, old, new) {
+		log.Println("[DEBUG] Cassandra: permission query: ", query)
+		if err := session.Query(query).Exec(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func grantPermissionStatement(role string, p Permission) string {
+	return fmt.Sprintf("GRANT %s ON %s TO %s", p.Permission, permissionResource(p), quoteIdentifier(role))
+}
+
+func revokePermissionStatement(role string, p Permission) string {
+	return fmt.Sprintf("REVOKE %s ON %s FROM %s", p.Permission, permissionResource(p), quoteIdentifier(role))
+}
+
+func permissionResource(p Permission) string {
+	switch {
+	case p.Keyspace == "":
+		return "ALL KEYSPACES"
+	case p.Table == "":
+		return fmt.Sprintf("KEYSPACE %s", quoteIdentifier(p.Keyspace))
+	}
+	return fmt.Sprintf("TABLE %s.%s", quoteIdentifier(p.Keyspace), quoteIdentifier(p.Table))
+}
+
+// permissionsFromResources returns the permissions of system_auth.role_permissions, where the resources of the
+// data are data, data/<keyspace> and data/<keyspace>/<table>, the other resources are left out
+func permissionsFromResources(resources map[string][]string) []Permission {
+	permissions := make([]Permission, 0)
+	for resource, names := range resources {
+		parts := strings.SplitN(resource, "/", 3)
+		if parts[0] != "data" {
+			continue
+		}
+		p := Permission{}
+		if len(parts) > 1 {
+			p.Keyspace = parts[1]
+		}
+		if len(parts) > 2 {
+			p.Table = parts[2]
+		}
+		for _, n := range names {
+			p.Permission = n
+			permissions = append(permissions, p)
+		}
+	}
+
+	sort.Slice(permissions, func(i, j int) bool {
+		a, b := permissions[i], permissions[j]
+		if a.Keyspace != b.Keyspace {
+			return a.Keyspace < b.Keyspace
+		}
+		if a.Table != b.Table {
+			return a.Table < b.Table
+		}
+		return a.Permission < b.Permission
+	})
+	return permissions
+}
+
+// onResource returns true when the permission is on the resource of the other permission or on a resource above it
+func (p Permission) onResource(other Permission) bool {
+	switch {
+	case p.Keyspace == "":
+		return true
+	case p.Table == "":
+		return p.Keyspace == other.Keyspace
+	}
+	return p.Keyspace == other.Keyspace && p.Table == other.Table
+}
+
+// expand returns the permissions ALL stands for on the resource of the permission
+func (p Permission) expand() []string {
+	if strings.ToUpper(p.Permission) != "ALL" {
+		return []string{strings.ToUpper(p.Permission)}
+	}
+	if p.Table != "" {
+		return tablePermissions
+	}
+	return keyspacePermissions
+}
+
+// validate returns an error when the permission can't be granted on its resource, CREATE is only granted on
+// keyspaces and a table needs its keyspace
+func (p Permission) validate() error {
+	if p.Table == "" {
+		return nil
+	}
+	if p.Keyspace == "" {
+		return fmt.Errorf("the permission %s on table %s requires its keyspace", p.Permission, p.Table)
+	}
+	name := strings.ToUpper(p.Permission)
+	if name == "ALL" {
+		return nil
+	}
+	for _, t := range tablePermissions {
+		if name == t {
+			return nil
+		}
+	}
+	return fmt.Errorf("the permission %s can't be granted on table %s.%s, only ALL, %s can", p.Permission, p.Keyspace, p.Table, strings.Join(tablePermissions, ", "))
+}
+
+// permissionsInclude returns true when the permissions give the permission, on its resource or on a resource above it
+func permissionsInclude(permissions []Permission, permission Permission) bool {
+	for _, name := range permission.expand() {
+		included := false
+		for _, p := range permissions {
+			if p.onResource(permission) && (strings.ToUpper(p.Permission) == name || strings.ToUpper(p.Permission) == "ALL") {
+				included = true
+				break
+			}
+		}
+		if !included {
+			return false
+		}
+	}
+	return true
+}
+
+// effectivePermissions returns the configured permissions that are in effect, as they are configured, followed by the
+// permissions of the role that the configured ones don't account for, system_auth.role_permissions has ALL as the
+// permissions it stands for
+func effectivePermissions(configured, permissions []Permission) []Permission {
+	res := make([]Permission, 0)
+	for _, c := range configured {
+		if permissionsInclude(permissions, c) {
+			res = append(res, c)
+		}
+	}
+
+	inEffect := append([]Permission{}, res...)
+	for _, p := range permissions {
+		if !permissionsInclude(inEffect, p) {
+			res = append(res, p)
+		}
+	}
+	return res
+}
+
+// permissionsDelta returns the statements to go from the old permissions to the new ones, the removed permissions
+// are revoked before the added ones are granted, and the permissions that revoking ALL takes away are granted again
+func permissionsDelta(role string, old, new []Permission) []string {
+	key := func(p Permission) Permission {
+		p.Permission = strings.ToUpper(p.Permission)
+		return p
+	}
+	oldKeys := make(map[Permission]bool)
+	for _, p := range old {
+		oldKeys[key(p)] = true
+	}
+	newKeys := make(map[Permission]bool)
+	for _, p := range new {
+		newKeys[key(p)] = true
+	}
+
+	var statements []string
+	var revoked []Permission
+	for _, p := range old {
+		if !newKeys[key(p)] {
+			statements = append(statements, revokePermissionStatement(role, p))
+			revoked = append(revoked, key(p))
+		}
+	}
+
+	for _, p := range new {
+		if !oldKeys[key(p)] {
+			statements = append(statements, grantPermissionStatement(role, p))
+			continue
+		}
+		// a revocation only takes away the permissions on its own resource
+		for _, r := range revoked {
+			if r.Keyspace == p.Keyspace && r.Table == p.Table && (r.Permission == "ALL" || key(p).Permission == "ALL") {
+				statements = append(statements, grantPermissionStatement(role, p))
+				break
+			}
+		}
+	}
+	return statements
+}
@@ -0,0 +1,87 @@
+package cassandra
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPermissionStatements(t *testing.T) {
+	assert.Equal(t, `GRANT SELECT ON ALL KEYSPACES TO "reader"`, grantPermissionStatement("reader", Permission{Permission: "SELECT"}))
+	assert.Equal(t, `GRANT MODIFY ON KEYSPACE "ks" TO "writer"`, grantPermissionStatement("writer", Permission{Keyspace: "ks", Permission: "MODIFY"}))
+	assert.Equal(t, `REVOKE ALL ON TABLE "ks"."t" FROM "a""b"`, revokePermissionStatement(`a"b`, Permission{Keyspace: "ks", Table: "t", Permission: "ALL"}))
+}
+
+func TestPermissionsFromResources(t *testing.T) {
+	assert.Equal(t, []Permission{
+		{Permission: "SELECT"},
+		{Keyspace: "ks", Permission: "MODIFY"},
+		{Keyspace: "ks", Permission: "SELECT"},
+		{Keyspace: "ks", Table: "t", Permission: "DROP"},
+	}, permissionsFromResources(map[string][]string{
+		"data/ks/t":  {"DROP"},
+		"data":       {"SELECT"},
+		"data/ks":    {"SELECT", "MODIFY"},
+		"roles/user": {"ALTER"},
+	}))
+}
+
+func TestEffectivePermissions(t *testing.T) {
+	configured := []Permission{{Keyspace: "ks", Permission: "ALL"}, {Keyspace: "ks", Table: "t", Permission: "select"}}
+	permissions := []Permission{
+		{Keyspace: "ks", Permission: "ALTER"},
+		{Keyspace: "ks", Permission: "AUTHORIZE"},
+		{Keyspace: "ks", Permission: "CREATE"},
+		{Keyspace: "ks", Permission: "DROP"},
+		{Keyspace: "ks", Permission: "MODIFY"},
+		{Keyspace: "ks", Permission: "SELECT"},
+		{Keyspace: "other", Permission: "SELECT"},
+	}
+
+	// the table permission is in effect through the keyspace permission
+	assert.Equal(t, []Permission{
+		{Keyspace: "ks", Permission: "ALL"},
+		{Keyspace: "ks", Table: "t", Permission: "select"},
+		{Keyspace: "other", Permission: "SELECT"},
+	}, effectivePermissions(configured, permissions))
+
+	// ALL is not in effect without all the permissions it stands for
+	assert.Equal(t, []Permission{
+		{Keyspace: "ks", Permission: "SELECT"},
+	}, effectivePermissions([]Permission{{Keyspace: "ks", Permission: "ALL"}}, []Permission{{Keyspace: "ks", Permission: "SELECT"}}))
+}
+
+func TestPermissionsDelta(t *testing.T) {
+	assert.Empty(t, permissionsDelta("r", []Permission{{Permission: "SELECT"}}, []Permission{{Permission: "select"}}))
+
+	assert.Equal(t, []string{
+		`REVOKE SELECT ON KEYSPACE "ks" FROM "r"`,
+		`GRANT MODIFY ON KEYSPACE "ks" TO "r"`,
+	}, permissionsDelta("r",
+		[]Permission{{Keyspace: "ks", Permission: "SELECT"}},
+		[]Permission{{Keyspace: "ks", Permission: "MODIFY"}},
+	))
+
+	// revoking ALL takes away SELECT on the same keyspace, which is granted again
+	assert.Equal(t, []string{
+		`REVOKE ALL ON KEYSPACE "ks" FROM "r"`,
+		`GRANT SELECT ON KEYSPACE "ks" TO "r"`,
+	}, permissionsDelta("r",
+		[]Permission{{Keyspace: "ks", Permission: "ALL"}, {Keyspace: "ks", Permission: "SELECT"}},
+		[]Permission{{Keyspace: "ks", Permission: "SELECT"}},
+	))
+
+	assert.Equal(t, []string{
+		`REVOKE SELECT ON ALL KEYSPACES FROM "r"`,
+	}, permissionsDelta("r", []Permission{{Permission: "SELECT"}}, nil))
+}
+
+func TestPermissionValidate(t *testing.T) {
+	assert.NoError(t, Permission{Permission: "CREATE"}.validate())
+	assert.NoError(t, Permission{Keyspace: "ks", Permission: "CREATE"}.validate())
+	assert.NoError(t, Permission{Keyspace: "ks", Table: "t", Permission: "select"}.validate())
+	assert.NoError(t, Permission{Keyspace: "ks", Table: "t", Permission: "ALL"}.validate())
+	assert.EqualError(t, Permission{Keyspace: "ks", Table: "t", Permission: "CREATE"}.validate(),
+		"the permission CREATE can't be granted on table ks.t, only ALL, ALTER, DROP, SELECT, MODIFY, AUTHORIZE can")
+	assert.EqualError(t, Permission{Table: "t", Permission: "SELECT"}.validate(), "the permission SELECT on table t requires its keyspace")
+}
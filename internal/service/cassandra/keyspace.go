@@ -0,0 +1,121 @@
+package cassandra
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+	"github.com/gocql/gocql"
+)
+
+const (
+	simpleStrategy          = "SimpleStrategy"
+	networkTopologyStrategy = "NetworkTopologyStrategy"
+)
+
+type Keyspace struct {
+	Name string
+	// Class is the replication strategy, SimpleStrategy or NetworkTopologyStrategy
+	Class string
+	// ReplicationFactor is the replication factor of SimpleStrategy
+	ReplicationFactor int
+	// Datacenters has the replication factor of each datacenter of NetworkTopologyStrategy
+	Datacenters   map[string]int
+	DurableWrites bool
+}
+
+func CreateKeyspace(session *gocql.Session, keyspace Keyspace) error {
+	query := createKeyspaceStatement(keyspace)
+
+	log.Println("[DEBUG] Cassandra: create keyspace query: ", query)
+	return session.Query(query).Exec()
+}
+
+func AlterKeyspace(session *gocql.Session, keyspace Keyspace) error {
+	query := alterKeyspaceStatement(keyspace)
+
+	log.Println("[DEBUG] Cassandra: alter keyspace query: ", query)
+	return session.Query(query).Exec()
+}
+
+func ReadKeyspace(session *gocql.Session, name string) (*Keyspace, error) {
+	query := "SELECT replication, durable_writes FROM system_schema.keyspaces WHERE keyspace_name = ?"
+
+	log.Println("[DEBUG] Cassandra: read keyspace query: ", query)
+	var replication map[string]string
+	var durableWrites bool
+	if err := session.Query(query, name).Scan(&replication, &durableWrites); err != nil {
+		if err == gocql.ErrNotFound {
+			return nil, schemautil.NotFoundError("keyspace", name)
+		}
+		return nil, err
+	}
+
+	keyspace, err := keyspaceFromReplication(name, replication)
+	if err != nil {
+		return nil, err
+	}
+	keyspace.DurableWrites = durableWrites
+	return keyspace, nil
+}
+
+func DropKeyspace(session *gocql.Session, name string) error {
+	query := fmt.Sprintf("DROP KEYSPACE IF EXISTS %s", quoteIdentifier(name))
+
+	log.Println("[DEBUG] Cassandra: drop keyspace query: ", query)
+	return session.Query(query).Exec()
+}
+
+func createKeyspaceStatement(keyspace Keyspace) string {
+	return fmt.Sprintf("CREATE KEYSPACE %s WITH %s", quoteIdentifier(keyspace.Name), keyspaceOptions(keyspace))
+}
+
+func alterKeyspaceStatement(keyspace Keyspace) string {
+	return fmt.Sprintf("ALTER KEYSPACE %s WITH %s", quoteIdentifier(keyspace.Name), keyspaceOptions(keyspace))
+}
+
+// keyspaceOptions returns the replication map and the durable writes of the keyspace, the datacenters are sorted
+// so that the statement is stable
+func keyspaceOptions(keyspace Keyspace) string {
+	replication := []string{fmt.Sprintf("'class': %s", quoteString(keyspace.Class))}
+	if keyspace.Class == simpleStrategy {
+		replication = append(replication, fmt.Sprintf("'replication_factor': %d", keyspace.ReplicationFactor))
+	} else {
+		datacenters := make([]string, 0, len(keyspace.Datacenters))
+		for dc := range keyspace.Datacenters {
+			datacenters = append(datacenters, dc)
+		}
+		sort.Strings(datacenters)
+		for _, dc := range datacenters {
+			replication = append(replication, fmt.Sprintf("%s: %d", quoteString(dc), keyspace.Datacenters[dc]))
+		}
+	}
+
+	return fmt.Sprintf("replication = {%s} AND durable_writes = %t", strings.Join(replication, ", "), keyspace.DurableWrites)
+}
+
+// keyspaceFromReplication reads the replication map of system_schema.keyspaces, which has the full class name
+// and the replication factors as strings
+func keyspaceFromReplication(name string, replication map[string]string) (*Keyspace, error) {
+	keyspace := &Keyspace{Name: name, Datacenters: make(map[string]int)}
+	for k, v := range replication {
+		if k == "class" {
+			keyspace.Class = v[strings.LastIndex(v, ".")+1:]
+			continue
+		}
+
+		factor, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("replication factor %s of keyspace %s is not a number: %w", k, name, err)
+		}
+		if k == "replication_factor" {
+			keyspace.ReplicationFactor = factor
+		} else {
+			keyspace.Datacenters[k] = factor
+		}
+	}
+	return keyspace, nil
+}
@@ -0,0 +1,54 @@
+package cassandra
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateKeyspaceStatement(t *testing.T) {
+	assert.Equal(t,
+		`CREATE KEYSPACE "events" WITH replication = {'class': 'SimpleStrategy', 'replication_factor': 3} AND durable_writes = true`,
+		createKeyspaceStatement(Keyspace{Name: "events", Class: simpleStrategy, ReplicationFactor: 3, DurableWrites: true}),
+	)
+	assert.Equal(t,
+		`CREATE KEYSPACE "events" WITH replication = {'class': 'NetworkTopologyStrategy', 'aiven': 3, 'backup': 1} AND durable_writes = false`,
+		createKeyspaceStatement(Keyspace{
+			Name:        "events",
+			Class:       networkTopologyStrategy,
+			Datacenters: map[string]int{"backup": 1, "aiven": 3},
+		}),
+	)
+}
+
+func TestAlterKeyspaceStatement(t *testing.T) {
+	assert.Equal(t,
+		`ALTER KEYSPACE "it""s" WITH replication = {'class': 'NetworkTopologyStrategy', 'aiven': 2} AND durable_writes = true`,
+		alterKeyspaceStatement(Keyspace{
+			Name:          `it"s`,
+			Class:         networkTopologyStrategy,
+			Datacenters:   map[string]int{"aiven": 2},
+			DurableWrites: true,
+		}),
+	)
+}
+
+func TestKeyspaceFromReplication(t *testing.T) {
+	keyspace, err := keyspaceFromReplication("events", map[string]string{
+		"class": "org.apache.cassandra.locator.NetworkTopologyStrategy",
+		"aiven": "3",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &Keyspace{Name: "events", Class: networkTopologyStrategy, Datacenters: map[string]int{"aiven": 3}}, keyspace)
+
+	keyspace, err = keyspaceFromReplication("events", map[string]string{
+		"class":              "org.apache.cassandra.locator.SimpleStrategy",
+		"replication_factor": "2",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &Keyspace{Name: "events", Class: simpleStrategy, ReplicationFactor: 2, Datacenters: map[string]int{}}, keyspace)
+
+	_, err = keyspaceFromReplication("events", map[string]string{"class": "SimpleStrategy", "replication_factor": "x"})
+	assert.Error(t, err)
+}
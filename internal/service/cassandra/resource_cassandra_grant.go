@@ -0,0 +1,210 @@
+package cassandra
+
+import (
+	"context"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var aivenCassandraGrantSchema = map[string]*schema.Schema{
+	"project":      schemautil.CommonSchemaProjectReference,
+	"service_name": schemautil.CommonSchemaServiceNameReference,
+	"role": {
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: schemautil.Complex("The user or role to grant the permissions to.").ForceNew().Referenced().Build(),
+	},
+	"permission": {
+		Type:        schema.TypeSet,
+		Required:    true,
+		MinItems:    1,
+		Description: "The permissions of the role on the data.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"permission": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringInSlice(append([]string{"ALL"}, keyspacePermissions...), false),
+					Description:  schemautil.Complex("The permission to grant.").PossibleValues(schemautil.StringSliceToInterfaceSlice(append([]string{"ALL"}, keyspacePermissions...))...).Build(),
+				},
+				"keyspace": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "The keyspace of the permission, the permission is on all keyspaces when it is not set",
+				},
+				"table": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "The table of the keyspace of the permission, the permission is on the whole keyspace when it is not set. CREATE can't be granted on a table.",
+				},
+			},
+		},
+	},
+}
+
+func ResourceCassandraGrant() *schema.Resource {
+	return &schema.Resource{
+		Description: `The Cassandra Grant resource allows the management of the permissions of users and roles on the data of Aiven Cassandra services.
+
+Notes:
+* All the permissions of the role on the data are managed by the resource, the permissions that are not configured are revoked.
+* Cassandra stores ALL as the permissions it stands for, these are not seen as changes.
+`,
+		CreateContext: resourceCassandraGrantCreate,
+		ReadContext:   resourceCassandraGrantRead,
+		UpdateContext: resourceCassandraGrantUpdate,
+		DeleteContext: resourceCassandraGrantDelete,
+		CustomizeDiff: resourceCassandraGrantCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: aivenCassandraGrantSchema,
+	}
+}
+
+func resourceCassandraGrantCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	for _, p := range permissionsFromSchema(d.Get("permission")) {
+		if err := p.validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func resourceCassandraGrantCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+	role := d.Get("role").(string)
+
+	session, err := newCassandraSession(client, projectName, serviceName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer session.Close()
+
+	// the permissions the role already has are revoked unless they are configured
+	current, err := ReadPermissions(session, role)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := ApplyPermissions(session, role, current, permissionsFromSchema(d.Get("permission"))); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(schemautil.BuildResourceID(projectName, serviceName, role))
+
+	return resourceCassandraGrantRead(ctx, d, m)
+}
+
+func resourceCassandraGrantRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName, serviceName, role, err := schemautil.SplitResourceID3(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	session, err := newCassandraSession(client, projectName, serviceName)
+	if err != nil {
+		return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
+	}
+	defer session.Close()
+
+	permissions, err := ReadPermissions(session, role)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	// the permissions of the state are kept as they are when Cassandra shows them differently but they are in effect
+	permissions = effectivePermissions(permissionsFromSchema(d.Get("permission")), permissions)
+
+	if err := d.Set("project", projectName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("service_name", serviceName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("role", role); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("permission", permissionsToSchema(permissions)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceCassandraGrantUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName, serviceName, role, err := schemautil.SplitResourceID3(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	session, err := newCassandraSession(client, projectName, serviceName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer session.Close()
+
+	o, n := d.GetChange("permission")
+	if err := ApplyPermissions(session, role, permissionsFromSchema(o), permissionsFromSchema(n)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceCassandraGrantRead(ctx, d, m)
+}
+
+func resourceCassandraGrantDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName, serviceName, role, err := schemautil.SplitResourceID3(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	session, err := newCassandraSession(client, projectName, serviceName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer session.Close()
+
+	if err := ApplyPermissions(session, role, permissionsFromSchema(d.Get("permission")), nil); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func permissionsFromSchema(v interface{}) []Permission {
+	permissions := make([]Permission, 0)
+	for _, p := range v.(*schema.Set).List() {
+		p := p.(map[string]interface{})
+		permissions = append(permissions, Permission{
+			Keyspace:   p["keyspace"].(string),
+			Table:      p["table"].(string),
+			Permission: p["permission"].(string),
+		})
+	}
+	return permissions
+}
+
+func permissionsToSchema(permissions []Permission) []map[string]interface{} {
+	res := make([]map[string]interface{}, 0, len(permissions))
+	for _, p := range permissions {
+		res = append(res, map[string]interface{}{
+			"keyspace":   p.Keyspace,
+			"table":      p.Table,
+			"permission": p.Permission,
+		})
+	}
+	return res
+}
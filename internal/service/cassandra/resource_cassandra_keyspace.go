@@ -0,0 +1,214 @@
+package cassandra
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var aivenCassandraKeyspaceSchema = map[string]*schema.Schema{
+	"project":      schemautil.CommonSchemaProjectReference,
+	"service_name": schemautil.CommonSchemaServiceNameReference,
+	"name": {
+		Type:         schema.TypeString,
+		Required:     true,
+		ForceNew:     true,
+		ValidateFunc: validation.StringMatch(regexp.MustCompile(`^\w{1,48}$`), "must be at most 48 letters, digits and underscores"),
+		Description:  schemautil.Complex("The name of the keyspace.").ForceNew().Referenced().Build(),
+	},
+	"replication_class": {
+		Type:         schema.TypeString,
+		Optional:     true,
+		Default:      networkTopologyStrategy,
+		ValidateFunc: validation.StringInSlice([]string{simpleStrategy, networkTopologyStrategy}, false),
+		Description:  schemautil.Complex("The replication strategy of the keyspace.").DefaultValue(networkTopologyStrategy).PossibleValues(simpleStrategy, networkTopologyStrategy).Build(),
+	},
+	"replication_factor": {
+		Type:         schema.TypeInt,
+		Optional:     true,
+		ValidateFunc: validation.IntAtLeast(1),
+		Description:  "The replication factor of the keyspace with `SimpleStrategy`.",
+	},
+	"datacenters": {
+		Type:        schema.TypeMap,
+		Optional:    true,
+		Elem:        &schema.Schema{Type: schema.TypeInt},
+		Description: "The replication factor of each datacenter with `NetworkTopologyStrategy`, the datacenter of an Aiven service is `aiven`.",
+	},
+	"durable_writes": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     true,
+		Description: schemautil.Complex("Write the changes of the keyspace to the commit log.").DefaultValue(true).Build(),
+	},
+	"termination_protection": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: schemautil.Complex(`It is a Terraform client-side deletion protection, which prevents the keyspace from being dropped by Terraform.`).DefaultValue(false).Build(),
+	},
+}
+
+func ResourceCassandraKeyspace() *schema.Resource {
+	return &schema.Resource{
+		Description:   "The Cassandra Keyspace resource allows the creation and management of keyspaces in Aiven Cassandra services.",
+		CreateContext: resourceCassandraKeyspaceCreate,
+		ReadContext:   resourceCassandraKeyspaceRead,
+		UpdateContext: resourceCassandraKeyspaceUpdate,
+		DeleteContext: resourceCassandraKeyspaceDelete,
+		CustomizeDiff: resourceCassandraKeyspaceCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: aivenCassandraKeyspaceSchema,
+	}
+}
+
+func resourceCassandraKeyspaceCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	switch d.Get("replication_class").(string) {
+	case simpleStrategy:
+		if d.Get("replication_factor").(int) == 0 || len(d.Get("datacenters").(map[string]interface{})) > 0 {
+			return fmt.Errorf("%s requires replication_factor and no datacenters", simpleStrategy)
+		}
+	case networkTopologyStrategy:
+		if d.Get("replication_factor").(int) != 0 || len(d.Get("datacenters").(map[string]interface{})) == 0 {
+			return fmt.Errorf("%s requires datacenters and no replication_factor", networkTopologyStrategy)
+		}
+	}
+	return nil
+}
+
+func resourceCassandraKeyspaceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+	keyspace := keyspaceFromSchema(d)
+
+	session, err := newCassandraSession(client, projectName, serviceName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer session.Close()
+
+	if err := CreateKeyspace(session, keyspace); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(schemautil.BuildResourceID(projectName, serviceName, keyspace.Name))
+
+	return resourceCassandraKeyspaceRead(ctx, d, m)
+}
+
+func resourceCassandraKeyspaceRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName, serviceName, name, err := schemautil.SplitResourceID3(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	session, err := newCassandraSession(client, projectName, serviceName)
+	if err != nil {
+		return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
+	}
+	defer session.Close()
+
+	keyspace, err := ReadKeyspace(session, name)
+	if err != nil {
+		return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
+	}
+
+	if err := d.Set("project", projectName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("service_name", serviceName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("name", name); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("replication_class", keyspace.Class); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("replication_factor", keyspace.ReplicationFactor); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("datacenters", keyspace.Datacenters); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("durable_writes", keyspace.DurableWrites); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceCassandraKeyspaceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName, serviceName, _, err := schemautil.SplitResourceID3(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.HasChanges("replication_class", "replication_factor", "datacenters", "durable_writes") {
+		session, err := newCassandraSession(client, projectName, serviceName)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		defer session.Close()
+
+		if err := AlterKeyspace(session, keyspaceFromSchema(d)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceCassandraKeyspaceRead(ctx, d, m)
+}
+
+func resourceCassandraKeyspaceDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName, serviceName, name, err := schemautil.SplitResourceID3(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.Get("termination_protection").(bool) {
+		return diag.Errorf("cannot drop keyspace %s, termination_protection is enabled", name)
+	}
+
+	session, err := newCassandraSession(client, projectName, serviceName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer session.Close()
+
+	if err := DropKeyspace(session, name); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func keyspaceFromSchema(d *schema.ResourceData) Keyspace {
+	keyspace := Keyspace{
+		Name:              d.Get("name").(string),
+		Class:             d.Get("replication_class").(string),
+		ReplicationFactor: d.Get("replication_factor").(int),
+		Datacenters:       make(map[string]int),
+		DurableWrites:     d.Get("durable_writes").(bool),
+	}
+	for dc, factor := range d.Get("datacenters").(map[string]interface{}) {
+		keyspace.Datacenters[dc] = factor.(int)
+	}
+	return keyspace
+}
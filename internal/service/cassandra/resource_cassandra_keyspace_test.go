@@ -0,0 +1,152 @@
+package cassandra_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	acc "github.com/aiven/terraform-provider-aiven/internal/acctest"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAivenCassandraKeyspaceTableAndGrant(t *testing.T) {
+	serviceName := fmt.Sprintf("test-acc-sr-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	projectName := os.Getenv("AIVEN_PROJECT_NAME")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acc.TestAccPreCheck(t) },
+		ProviderFactories: acc.TestAccProviderFactories,
+		CheckDestroy:      acc.TestAccCheckAivenServiceResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCassandraKeyspaceResource(projectName, serviceName, 2, "", "SELECT"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aiven_cassandra_keyspace.events", "replication_class", "NetworkTopologyStrategy"),
+					resource.TestCheckResourceAttr("aiven_cassandra_keyspace.events", "datacenters.aiven", "2"),
+					resource.TestCheckResourceAttr("aiven_cassandra_keyspace.events", "durable_writes", "true"),
+					resource.TestCheckResourceAttr("aiven_cassandra_table.events", "column.#", "4"),
+					resource.TestCheckResourceAttr("aiven_cassandra_table.events", "partition_key.#", "2"),
+					resource.TestCheckResourceAttr("aiven_cassandra_table.events", "clustering_key.0.order", "DESC"),
+					resource.TestCheckResourceAttr("aiven_cassandra_grant.reader", "permission.#", "2"),
+				),
+			},
+			{
+				// the keyspace, the table and the grant are changed in place
+				Config: testAccCassandraKeyspaceResource(projectName, serviceName, 3, `
+  column {
+    name = "source"
+    type = "varchar"
+  }`, "ALL"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aiven_cassandra_keyspace.events", "datacenters.aiven", "3"),
+					resource.TestCheckResourceAttr("aiven_cassandra_table.events", "column.#", "5"),
+					resource.TestCheckTypeSetElemNestedAttrs("aiven_cassandra_table.events", "column.*", map[string]string{
+						"name": "source",
+						"type": "varchar",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("aiven_cassandra_grant.reader", "permission.*", map[string]string{
+						"permission": "ALL",
+						"keyspace":   "events",
+						"table":      "",
+					}),
+				),
+			},
+			{
+				ResourceName:      "aiven_cassandra_keyspace.events",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"termination_protection",
+				},
+			},
+			{
+				ResourceName:      "aiven_cassandra_table.events",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"termination_protection",
+					// system_schema has the canonical types
+					"column",
+				},
+			},
+		},
+	})
+}
+
+func testAccCassandraKeyspaceResource(projectName, serviceName string, replicationFactor int, extraColumns, keyspacePermission string) string {
+	return fmt.Sprintf(`
+resource "aiven_cassandra" "bar" {
+  project                 = "%s"
+  cloud_name              = "google-europe-west1"
+  plan                    = "startup-4"
+  service_name            = "%s"
+  maintenance_window_dow  = "monday"
+  maintenance_window_time = "10:00:00"
+}
+
+resource "aiven_cassandra_user" "reader" {
+  project      = aiven_cassandra.bar.project
+  service_name = aiven_cassandra.bar.service_name
+  username     = "reader"
+}
+
+resource "aiven_cassandra_keyspace" "events" {
+  project      = aiven_cassandra.bar.project
+  service_name = aiven_cassandra.bar.service_name
+  name         = "events"
+
+  datacenters = {
+    aiven = %d
+  }
+}
+
+resource "aiven_cassandra_table" "events" {
+  project      = aiven_cassandra_keyspace.events.project
+  service_name = aiven_cassandra_keyspace.events.service_name
+  keyspace     = aiven_cassandra_keyspace.events.name
+  name         = "events"
+  comment      = "events by host and day"
+
+  column {
+    name = "host"
+    type = "text"
+  }
+  column {
+    name = "day"
+    type = "date"
+  }
+  column {
+    name = "ts"
+    type = "timestamp"
+  }
+  column {
+    name = "payload"
+    type = "map<text, int>"
+  }%s
+
+  partition_key = ["host", "day"]
+
+  clustering_key {
+    name  = "ts"
+    order = "DESC"
+  }
+}
+
+resource "aiven_cassandra_grant" "reader" {
+  project      = aiven_cassandra.bar.project
+  service_name = aiven_cassandra.bar.service_name
+  role         = aiven_cassandra_user.reader.username
+
+  permission {
+    permission = "%s"
+    keyspace   = aiven_cassandra_keyspace.events.name
+  }
+  permission {
+    permission = "SELECT"
+    keyspace   = aiven_cassandra_table.events.keyspace
+    table      = aiven_cassandra_table.events.name
+  }
+}`, projectName, serviceName, replicationFactor, extraColumns, keyspacePermission)
+}
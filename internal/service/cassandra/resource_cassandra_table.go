@@ -0,0 +1,365 @@
+package cassandra
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var aivenCassandraTableSchema = map[string]*schema.Schema{
+	"project":      schemautil.CommonSchemaProjectReference,
+	"service_name": schemautil.CommonSchemaServiceNameReference,
+	"keyspace": {
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: schemautil.Complex("The keyspace of the table.").ForceNew().Referenced().Build(),
+	},
+	"name": {
+		Type:         schema.TypeString,
+		Required:     true,
+		ForceNew:     true,
+		ValidateFunc: validation.StringMatch(regexp.MustCompile(`^\w{1,48}$`), "must be at most 48 letters, digits and underscores"),
+		Description:  schemautil.Complex("The name of the table.").ForceNew().Build(),
+	},
+	"column": {
+		Type:        schema.TypeSet,
+		Required:    true,
+		MinItems:    1,
+		Description: "The columns of the table, including the columns of the primary key. Columns are added and dropped in place, changing the type of a column recreates the table.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "The name of the column",
+				},
+				"type": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "The CQL type of the column, e.g. `text` or `map<text, int>`",
+				},
+			},
+		},
+	},
+	"partition_key": {
+		Type:        schema.TypeList,
+		Required:    true,
+		ForceNew:    true,
+		MinItems:    1,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Description: schemautil.Complex("The columns of the partition key, in order.").ForceNew().Build(),
+	},
+	"clustering_key": {
+		Type:        schema.TypeList,
+		Optional:    true,
+		ForceNew:    true,
+		Description: schemautil.Complex("The clustering columns of the primary key, in order.").ForceNew().Build(),
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Type:        schema.TypeString,
+					Required:    true,
+					ForceNew:    true,
+					Description: schemautil.Complex("The name of the column").ForceNew().Build(),
+				},
+				"order": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					ForceNew:     true,
+					Default:      "ASC",
+					ValidateFunc: validation.StringInSlice([]string{"ASC", "DESC"}, false),
+					Description:  schemautil.Complex("The clustering order of the column").DefaultValue("ASC").ForceNew().Build(),
+				},
+			},
+		},
+	},
+	"comment": {
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "The comment of the table.",
+	},
+	"default_time_to_live": {
+		Type:         schema.TypeInt,
+		Optional:     true,
+		ValidateFunc: validation.IntAtLeast(0),
+		Description:  "The default time to live of the rows in seconds, they don't expire when it is 0.",
+	},
+	"termination_protection": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: schemautil.Complex(`It is a Terraform client-side deletion protection, which prevents the table from being dropped by Terraform, including when a change recreates it.`).DefaultValue(false).Build(),
+	},
+}
+
+func ResourceCassandraTable() *schema.Resource {
+	return &schema.Resource{
+		Description:   "The Cassandra Table resource allows the creation and management of tables in Aiven Cassandra services.",
+		CreateContext: resourceCassandraTableCreate,
+		ReadContext:   resourceCassandraTableRead,
+		UpdateContext: resourceCassandraTableUpdate,
+		DeleteContext: resourceCassandraTableDelete,
+		CustomizeDiff: resourceCassandraTableCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: aivenCassandraTableSchema,
+	}
+}
+
+// resourceCassandraTableCustomizeDiff checks that the primary key is made of columns of the table, and recreates
+// the table when the type of a column changes
+func resourceCassandraTableCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	table := Table{
+		Columns:       columnsFromSchema(d.Get("column")),
+		PartitionKey:  partitionKeyFromSchema(d.Get("partition_key")),
+		ClusteringKey: clusteringKeyFromSchema(d.Get("clustering_key")),
+	}
+	columns := make(map[string]bool)
+	for _, c := range table.Columns {
+		columns[c.Name] = true
+	}
+	for _, k := range table.PartitionKey {
+		if !columns[k] {
+			return fmt.Errorf("partition key column %s is not a column of the table", k)
+		}
+	}
+	for _, k := range table.ClusteringKey {
+		if !columns[k.Name] {
+			return fmt.Errorf("clustering key column %s is not a column of the table", k.Name)
+		}
+	}
+
+	if d.Id() == "" || !d.HasChange("column") {
+		return nil
+	}
+	o, n := d.GetChange("column")
+	if columnTypesChanged(columnsFromSchema(o), columnsFromSchema(n)) {
+		return d.ForceNew("column")
+	}
+	return nil
+}
+
+func resourceCassandraTableCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+	table := tableFromSchema(d)
+
+	session, err := newCassandraSession(client, projectName, serviceName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer session.Close()
+
+	if err := CreateTable(session, table); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(schemautil.BuildResourceID(projectName, serviceName, table.Keyspace, table.Name))
+
+	return resourceCassandraTableRead(ctx, d, m)
+}
+
+func resourceCassandraTableRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName, serviceName, keyspace, name, err := schemautil.SplitResourceID4(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	session, err := newCassandraSession(client, projectName, serviceName)
+	if err != nil {
+		return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
+	}
+	defer session.Close()
+
+	table, err := ReadTable(session, keyspace, name)
+	if err != nil {
+		return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
+	}
+
+	// the types are shown in their canonical form, e.g. varchar becomes text
+	configuredTypes := make(map[string]string)
+	for _, c := range columnsFromSchema(d.Get("column")) {
+		configuredTypes[c.Name] = c.Type
+	}
+	for i, c := range table.Columns {
+		if t, ok := configuredTypes[c.Name]; ok && normalizeType(t) == normalizeType(c.Type) {
+			table.Columns[i].Type = t
+		}
+	}
+
+	if err := d.Set("project", projectName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("service_name", serviceName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("keyspace", keyspace); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("name", name); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("column", columnsToSchema(table.Columns)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("partition_key", table.PartitionKey); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("clustering_key", clusteringKeyToSchema(table.ClusteringKey)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("comment", table.Comment); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("default_time_to_live", table.DefaultTTL); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceCassandraTableUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName, serviceName, keyspace, name, err := schemautil.SplitResourceID4(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	oldColumns, newColumns := d.GetChange("column")
+	oldComment, newComment := d.GetChange("comment")
+	oldTTL, newTTL := d.GetChange("default_time_to_live")
+
+	old := Table{
+		Keyspace:   keyspace,
+		Name:       name,
+		Columns:    columnsFromSchema(oldColumns),
+		Comment:    oldComment.(string),
+		DefaultTTL: oldTTL.(int),
+	}
+	new := Table{
+		Keyspace:   keyspace,
+		Name:       name,
+		Columns:    columnsFromSchema(newColumns),
+		Comment:    newComment.(string),
+		DefaultTTL: newTTL.(int),
+	}
+
+	if statements := alterTableStatements(old, new); len(statements) > 0 {
+		session, err := newCassandraSession(client, projectName, serviceName)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		defer session.Close()
+
+		if err := AlterTable(session, old, new); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceCassandraTableRead(ctx, d, m)
+}
+
+func resourceCassandraTableDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName, serviceName, keyspace, name, err := schemautil.SplitResourceID4(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.Get("termination_protection").(bool) {
+		return diag.Errorf("cannot drop table %s.%s, termination_protection is enabled", keyspace, name)
+	}
+
+	session, err := newCassandraSession(client, projectName, serviceName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer session.Close()
+
+	if err := DropTable(session, keyspace, name); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func tableFromSchema(d *schema.ResourceData) Table {
+	return Table{
+		Keyspace:      d.Get("keyspace").(string),
+		Name:          d.Get("name").(string),
+		Columns:       columnsFromSchema(d.Get("column")),
+		PartitionKey:  partitionKeyFromSchema(d.Get("partition_key")),
+		ClusteringKey: clusteringKeyFromSchema(d.Get("clustering_key")),
+		Comment:       d.Get("comment").(string),
+		DefaultTTL:    d.Get("default_time_to_live").(int),
+	}
+}
+
+func columnsFromSchema(v interface{}) []Column {
+	columns := make([]Column, 0)
+	for _, c := range v.(*schema.Set).List() {
+		c := c.(map[string]interface{})
+		columns = append(columns, Column{
+			Name: c["name"].(string),
+			Type: c["type"].(string),
+		})
+	}
+	return columns
+}
+
+func columnsToSchema(columns []Column) []map[string]interface{} {
+	res := make([]map[string]interface{}, 0, len(columns))
+	for _, c := range columns {
+		res = append(res, map[string]interface{}{
+			"name": c.Name,
+			"type": c.Type,
+		})
+	}
+	return res
+}
+
+func partitionKeyFromSchema(v interface{}) []string {
+	res := make([]string, 0)
+	for _, k := range v.([]interface{}) {
+		res = append(res, k.(string))
+	}
+	return res
+}
+
+func clusteringKeyFromSchema(v interface{}) []ClusteringColumn {
+	res := make([]ClusteringColumn, 0)
+	for _, k := range v.([]interface{}) {
+		k := k.(map[string]interface{})
+		res = append(res, ClusteringColumn{
+			Name:  k["name"].(string),
+			Order: k["order"].(string),
+		})
+	}
+	return res
+}
+
+func clusteringKeyToSchema(columns []ClusteringColumn) []map[string]interface{} {
+	res := make([]map[string]interface{}, 0, len(columns))
+	for _, c := range columns {
+		res = append(res, map[string]interface{}{
+			"name":  c.Name,
+			"order": c.Order,
+		})
+	}
+	return res
+}
@@ -0,0 +1,244 @@
+package cassandra
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+	"github.com/gocql/gocql"
+)
+
+type Column struct {
+	Name string
+	Type string
+}
+
+type ClusteringColumn struct {
+	Name string
+	// Order is ASC or DESC
+	Order string
+}
+
+type Table struct {
+	Keyspace      string
+	Name          string
+	Columns       []Column
+	PartitionKey  []string
+	ClusteringKey []ClusteringColumn
+	Comment       string
+	// DefaultTTL is the default time to live of the rows in seconds, 0 means that they don't expire
+	DefaultTTL int
+}
+
+// columnRow is a row of system_schema.columns
+type columnRow struct {
+	name            string
+	kind            string
+	position        int
+	clusteringOrder string
+	columnType      string
+}
+
+func CreateTable(session *gocql.Session, table Table) error {
+	query := createTableStatement(table)
+
+	log.Println("[DEBUG] Cassandra: create table query: ", query)
+	return session.Query(query).Exec()
+}
+
+// AlterTable adds and drops the columns and changes the options of the table
+func AlterTable(session *gocql.Session, old, new Table) error {
+	for _, query := range alterTableStatements(old, new) {
+		log.Println("[DEBUG] Cassandra: alter table query: ", query)
+		if err := session.Query(query).Exec(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func ReadTable(session *gocql.Session, keyspace, name string) (*Table, error) {
+	query := "SELECT comment, default_time_to_live FROM system_schema.tables WHERE keyspace_name = ? AND table_name = ?"
+
+	log.Println("[DEBUG] Cassandra: read table query: ", query)
+	table := &Table{Keyspace: keyspace, Name: name}
+	if err := session.Query(query, keyspace, name).Scan(&table.Comment, &table.DefaultTTL); err != nil {
+		if err == gocql.ErrNotFound {
+			return nil, schemautil.NotFoundError("table", keyspace+"."+name)
+		}
+		return nil, err
+	}
+
+	query = "SELECT column_name, kind, position, clustering_order, type FROM system_schema.columns WHERE keyspace_name = ? AND table_name = ?"
+
+	log.Println("[DEBUG] Cassandra: read table columns query: ", query)
+	var rows []columnRow
+	var row columnRow
+	iter := session.Query(query, keyspace, name).Iter()
+	for iter.Scan(&row.name, &row.kind, &row.position, &row.clusteringOrder, &row.columnType) {
+		rows = append(rows, row)
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+
+	table.Columns, table.PartitionKey, table.ClusteringKey = columnsFromRows(rows)
+	return table, nil
+}
+
+func DropTable(session *gocql.Session, keyspace, name string) error {
+	query := fmt.Sprintf("DROP TABLE IF EXISTS %s.%s", quoteIdentifier(keyspace), quoteIdentifier(name))
+
+	log.Println("[DEBUG] Cassandra: drop table query: ", query)
+	return session.Query(query).Exec()
+}
+
+func createTableStatement(table Table) string {
+	b := new(strings.Builder)
+
+	definitions := make([]string, 0, len(table.Columns)+1)
+	for _, c := range sortedColumns(table.Columns) {
+		definitions = append(definitions, fmt.Sprintf("%s %s", quoteIdentifier(c.Name), c.Type))
+	}
+
+	partitionKey := make([]string, 0, len(table.PartitionKey))
+	for _, k := range table.PartitionKey {
+		partitionKey = append(partitionKey, quoteIdentifier(k))
+	}
+	primaryKey := []string{fmt.Sprintf("(%s)", strings.Join(partitionKey, ", "))}
+	clusteringOrder := make([]string, 0, len(table.ClusteringKey))
+	for _, k := range table.ClusteringKey {
+		primaryKey = append(primaryKey, quoteIdentifier(k.Name))
+		clusteringOrder = append(clusteringOrder, fmt.Sprintf("%s %s", quoteIdentifier(k.Name), k.Order))
+	}
+	definitions = append(definitions, fmt.Sprintf("PRIMARY KEY (%s)", strings.Join(primaryKey, ", ")))
+
+	b.WriteString(fmt.Sprintf("CREATE TABLE %s.%s (%s)", quoteIdentifier(table.Keyspace), quoteIdentifier(table.Name), strings.Join(definitions, ", ")))
+
+	options := tableOptions(table)
+	if len(clusteringOrder) > 0 {
+		options = append([]string{fmt.Sprintf("CLUSTERING ORDER BY (%s)", strings.Join(clusteringOrder, ", "))}, options...)
+	}
+	if len(options) > 0 {
+		b.WriteString(" WITH ")
+		b.WriteString(strings.Join(options, " AND "))
+	}
+
+	return b.String()
+}
+
+func tableOptions(table Table) []string {
+	var options []string
+	if table.Comment != "" {
+		options = append(options, fmt.Sprintf("comment = %s", quoteString(table.Comment)))
+	}
+	if table.DefaultTTL != 0 {
+		options = append(options, fmt.Sprintf("default_time_to_live = %d", table.DefaultTTL))
+	}
+	return options
+}
+
+// alterTableStatements returns the statements to go from the old table to the new one, only the regular columns
+// can be added or dropped, the type of a column can't be changed
+func alterTableStatements(old, new Table) []string {
+	table := fmt.Sprintf("%s.%s", quoteIdentifier(new.Keyspace), quoteIdentifier(new.Name))
+
+	oldColumns := make(map[string]bool)
+	for _, c := range old.Columns {
+		oldColumns[c.Name] = true
+	}
+	newColumns := make(map[string]bool)
+	for _, c := range new.Columns {
+		newColumns[c.Name] = true
+	}
+
+	var statements []string
+
+	var added []string
+	for _, c := range sortedColumns(new.Columns) {
+		if !oldColumns[c.Name] {
+			added = append(added, fmt.Sprintf("%s %s", quoteIdentifier(c.Name), c.Type))
+		}
+	}
+	if len(added) > 0 {
+		statements = append(statements, fmt.Sprintf("ALTER TABLE %s ADD (%s)", table, strings.Join(added, ", ")))
+	}
+
+	var dropped []string
+	for _, c := range sortedColumns(old.Columns) {
+		if !newColumns[c.Name] {
+			dropped = append(dropped, quoteIdentifier(c.Name))
+		}
+	}
+	if len(dropped) > 0 {
+		statements = append(statements, fmt.Sprintf("ALTER TABLE %s DROP (%s)", table, strings.Join(dropped, ", ")))
+	}
+
+	var options []string
+	if old.Comment != new.Comment {
+		options = append(options, fmt.Sprintf("comment = %s", quoteString(new.Comment)))
+	}
+	if old.DefaultTTL != new.DefaultTTL {
+		options = append(options, fmt.Sprintf("default_time_to_live = %d", new.DefaultTTL))
+	}
+	if len(options) > 0 {
+		statements = append(statements, fmt.Sprintf("ALTER TABLE %s WITH %s", table, strings.Join(options, " AND ")))
+	}
+
+	return statements
+}
+
+// columnTypesChanged returns true when a column that is in both the old and the new columns has another type
+func columnTypesChanged(old, new []Column) bool {
+	types := make(map[string]string)
+	for _, c := range old {
+		types[c.Name] = normalizeType(c.Type)
+	}
+	for _, c := range new {
+		if t, ok := types[c.Name]; ok && t != normalizeType(c.Type) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeType returns the type the way system_schema.columns shows it, lowercase without spaces and with
+// varchar as text
+func normalizeType(t string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.Join(strings.Fields(t), "")), "varchar", "text")
+}
+
+// columnsFromRows returns the columns sorted by name, and the partition and clustering keys in their order
+func columnsFromRows(rows []columnRow) ([]Column, []string, []ClusteringColumn) {
+	var partitionKey, clusteringKey []columnRow
+	columns := make([]Column, 0, len(rows))
+	for _, r := range rows {
+		columns = append(columns, Column{Name: r.name, Type: r.columnType})
+		switch r.kind {
+		case "partition_key":
+			partitionKey = append(partitionKey, r)
+		case "clustering":
+			clusteringKey = append(clusteringKey, r)
+		}
+	}
+	sort.Slice(partitionKey, func(i, j int) bool { return partitionKey[i].position < partitionKey[j].position })
+	sort.Slice(clusteringKey, func(i, j int) bool { return clusteringKey[i].position < clusteringKey[j].position })
+
+	partitionKeyNames := make([]string, 0, len(partitionKey))
+	for _, r := range partitionKey {
+		partitionKeyNames = append(partitionKeyNames, r.name)
+	}
+	clusteringColumns := make([]ClusteringColumn, 0, len(clusteringKey))
+	for _, r := range clusteringKey {
+		clusteringColumns = append(clusteringColumns, ClusteringColumn{Name: r.name, Order: strings.ToUpper(r.clusteringOrder)})
+	}
+	return sortedColumns(columns), partitionKeyNames, clusteringColumns
+}
+
+func sortedColumns(columns []Column) []Column {
+	res := append([]Column{}, columns...)
+	sort.Slice(res, func(i, j int) bool { return res[i].Name < res[j].Name })
+	return res
+}
@@ -0,0 +1,85 @@
+package cassandra
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateTableStatement(t *testing.T) {
+	assert.Equal(t,
+		`CREATE TABLE "ks"."users" ("id" uuid, "name" text, PRIMARY KEY (("id")))`,
+		createTableStatement(Table{
+			Keyspace:     "ks",
+			Name:         "users",
+			Columns:      []Column{{Name: "name", Type: "text"}, {Name: "id", Type: "uuid"}},
+			PartitionKey: []string{"id"},
+		}),
+	)
+	assert.Equal(t,
+		`CREATE TABLE "ks"."events" ("day" date, "host" text, "payload" map<text, int>, "ts" timestamp, `+
+			`PRIMARY KEY (("host", "day"), "ts")) WITH CLUSTERING ORDER BY ("ts" DESC) AND comment = 'it''s' AND default_time_to_live = 3600`,
+		createTableStatement(Table{
+			Keyspace: "ks",
+			Name:     "events",
+			Columns: []Column{
+				{Name: "ts", Type: "timestamp"},
+				{Name: "host", Type: "text"},
+				{Name: "day", Type: "date"},
+				{Name: "payload", Type: "map<text, int>"},
+			},
+			PartitionKey:  []string{"host", "day"},
+			ClusteringKey: []ClusteringColumn{{Name: "ts", Order: "DESC"}},
+			Comment:       "it's",
+			DefaultTTL:    3600,
+		}),
+	)
+}
+
+func TestAlterTableStatements(t *testing.T) {
+	old := Table{
+		Keyspace: "ks",
+		Name:     "users",
+		Columns:  []Column{{Name: "id", Type: "uuid"}, {Name: "name", Type: "text"}, {Name: "age", Type: "int"}},
+	}
+
+	assert.Empty(t, alterTableStatements(old, old))
+
+	new := old
+	new.Columns = []Column{{Name: "id", Type: "uuid"}, {Name: "name", Type: "text"}, {Name: "email", Type: "text"}, {Name: "city", Type: "text"}}
+	new.Comment = "users"
+	new.DefaultTTL = 60
+	assert.Equal(t, []string{
+		`ALTER TABLE "ks"."users" ADD ("city" text, "email" text)`,
+		`ALTER TABLE "ks"."users" DROP ("age")`,
+		`ALTER TABLE "ks"."users" WITH comment = 'users' AND default_time_to_live = 60`,
+	}, alterTableStatements(old, new))
+}
+
+func TestColumnTypesChanged(t *testing.T) {
+	old := []Column{{Name: "id", Type: "uuid"}, {Name: "tags", Type: "map<text, int>"}}
+
+	assert.False(t, columnTypesChanged(old, []Column{{Name: "id", Type: "UUID"}, {Name: "tags", Type: "map<varchar,int>"}}))
+	assert.False(t, columnTypesChanged(old, []Column{{Name: "id", Type: "uuid"}, {Name: "name", Type: "text"}}))
+	assert.True(t, columnTypesChanged(old, []Column{{Name: "id", Type: "timeuuid"}}))
+}
+
+func TestColumnsFromRows(t *testing.T) {
+	columns, partitionKey, clusteringKey := columnsFromRows([]columnRow{
+		{name: "value", kind: "regular", position: -1, clusteringOrder: "none", columnType: "text"},
+		{name: "ts", kind: "clustering", position: 1, clusteringOrder: "desc", columnType: "timestamp"},
+		{name: "day", kind: "partition_key", position: 1, clusteringOrder: "none", columnType: "date"},
+		{name: "seq", kind: "clustering", position: 0, clusteringOrder: "asc", columnType: "int"},
+		{name: "host", kind: "partition_key", position: 0, clusteringOrder: "none", columnType: "text"},
+	})
+
+	assert.Equal(t, []Column{
+		{Name: "day", Type: "date"},
+		{Name: "host", Type: "text"},
+		{Name: "seq", Type: "int"},
+		{Name: "ts", Type: "timestamp"},
+		{Name: "value", Type: "text"},
+	}, columns)
+	assert.Equal(t, []string{"host", "day"}, partitionKey)
+	assert.Equal(t, []ClusteringColumn{{Name: "seq", Order: "ASC"}, {Name: "ts", Order: "DESC"}}, clusteringKey)
+}
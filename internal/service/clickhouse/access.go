@@ -0,0 +1,61 @@
+package clickhouse
+
+import (
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/aiven/aiven-go-client"
+)
+
+// toClause returns the TO clause that assigns a settings profile or a quota to users and roles,
+// an empty assignment is rendered as TO NONE so that ALTER statements clear it
+func toClause(users, roles []string) string {
+	names := make([]string, 0, len(users)+len(roles))
+	for _, n := range append(append([]string{}, users...), roles...) {
+		names = append(names, escape(n))
+	}
+	if len(names) == 0 {
+		return "TO NONE"
+	}
+	sort.Strings(names)
+	return "TO " + strings.Join(names, ", ")
+}
+
+// ReadRoleNames returns the names of the roles of the service
+func ReadRoleNames(client *aiven.Client, projectName, serviceName string) (map[string]bool, error) {
+	query := "SELECT name FROM system.roles"
+
+	log.Println("[DEBUG] Clickhouse: read role names query: ", query)
+	r, err := client.ClickHouseQuery.Query(projectName, serviceName, defaultDatabase, query)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := rowsFromAPIResponse(r, "name")
+	if err != nil {
+		return nil, err
+	}
+
+	roles := make(map[string]bool, len(rows))
+	for _, row := range rows {
+		roles[row.getString("name")] = true
+		if row.err != nil {
+			return nil, row.err
+		}
+	}
+	return roles, nil
+}
+
+// splitAssignees splits the apply_to_list of the system tables, which has both users and roles, in users and roles
+func splitAssignees(names []string, roleNames map[string]bool) ([]string, []string) {
+	users, roles := make([]string, 0), make([]string, 0)
+	for _, n := range names {
+		if roleNames[n] {
+			roles = append(roles, n)
+		} else {
+			users = append(users, n)
+		}
+	}
+	return users, roles
+}
@@ -0,0 +1,18 @@
+package clickhouse
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToClause(t *testing.T) {
+	assert.Equal(t, "TO `analyst`, `bob`, `o\\`brien`", toClause([]string{"bob", "o`brien"}, []string{"analyst"}))
+	assert.Equal(t, "TO NONE", toClause(nil, nil))
+}
+
+func TestSplitAssignees(t *testing.T) {
+	users, roles := splitAssignees([]string{"bob", "analyst", "alice"}, map[string]bool{"analyst": true, "writer": true})
+	assert.Equal(t, []string{"bob", "alice"}, users)
+	assert.Equal(t, []string{"analyst"}, roles)
+}
@@ -10,20 +10,29 @@ func escape(identifier string) string {
 }
 
 func escapeBytes(identifier []byte) string {
+	return escapeQuoted(identifier, '`')
+}
+
+// escapeStringLiteral quotes a value to be used as a string literal, e.g. in a WHERE clause
+func escapeStringLiteral(value string) string {
+	return escapeQuoted([]byte(value), '\'')
+}
+
+func escapeQuoted(identifier []byte, quote byte) string {
 	var (
 		escapeMap = map[byte]string{
-			0:    "\\0",
-			'\b': "\\b",
-			'\f': "\\f",
-			'\r': "\\r",
-			'\n': "\\n",
-			'\t': "\\t",
-			'\\': "\\\\",
-			'`':  "\\`",
+			0:     "\\0",
+			'\b':  "\\b",
+			'\f':  "\\f",
+			'\r':  "\\r",
+			'\n':  "\\n",
+			'\t':  "\\t",
+			'\\':  "\\\\",
+			quote: "\\" + string(quote),
 		}
 	)
 	buf := new(bytes.Buffer)
-	buf.WriteByte('`')
+	buf.WriteByte(quote)
 
 	for i := range identifier {
 		b := identifier[i]
@@ -38,6 +47,6 @@ func escapeBytes(identifier []byte) string {
 		}
 	}
 
-	buf.WriteByte('`')
+	buf.WriteByte(quote)
 	return buf.String()
 }
@@ -40,3 +40,29 @@ func TestEscapeBytes(t *testing.T) {
 		})
 	}
 }
+
+func TestEscapeStringLiteral(t *testing.T) {
+	testdata := []struct {
+		in  string
+		out string
+	}{
+		{
+			in:  "O'sullivan",
+			out: "'O\\'sullivan'",
+		},
+		{
+			in:  "back`tick",
+			out: "'back`tick'",
+		},
+		{
+			in:  "line\nbreak \\",
+			out: "'line\\nbreak \\\\'",
+		},
+	}
+
+	for _, test := range testdata {
+		t.Run("", func(t *testing.T) {
+			assert.Equal(t, test.out, escapeStringLiteral(test.in))
+		})
+	}
+}
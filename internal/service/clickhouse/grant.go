@@ -88,26 +88,48 @@ func CreatePrivilegeGrant(client *aiven.Client, projectName, serviceName string,
 }
 
 func ReadPrivilegeGrants(client *aiven.Client, projectName, serviceName string, grantee Grantee) ([]PrivilegeGrant, error) {
+	grants, _, err := readPrivilegeGrantsAndPartialRevokes(client, projectName, serviceName, grantee)
+	return grants, err
+}
+
+// readPrivilegeGrantsAndPartialRevokes returns the privilege grants of the grantee and the partial revokes that take away
+// a part of them
+func readPrivilegeGrantsAndPartialRevokes(client *aiven.Client, projectName, serviceName string, grantee Grantee) ([]PrivilegeGrant, []PrivilegeGrant, error) {
 	query := readPrivilegeGrantsStatement()
 
 	log.Println("[DEBUG] Clickhouse: read privilege grant query: ", query)
 	r, err := client.ClickHouseQuery.Query(projectName, serviceName, defaultDatabase, query)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	privilegeGrants, err := privilegeGrantsFromAPIResponse(r)
+	privilegeGrants, partialRevokes, err := privilegeGrantsFromAPIResponse(r)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
+	return filterPrivilegeGrants(privilegeGrants, grantee), filterPrivilegeGrants(partialRevokes, grantee), nil
+}
+
+func filterPrivilegeGrants(grants []PrivilegeGrant, grantee Grantee) []PrivilegeGrant {
 	res := make([]PrivilegeGrant, 0)
-	for _, grant := range privilegeGrants {
+	for _, grant := range grants {
 		if !grant.Grantee.equals(grantee) {
 			continue
 		}
 		res = append(res, grant)
 	}
-	return res, err
+	return res
+}
+
+// executeStatements runs the statements in order, it stops at the first error
+func executeStatements(client *aiven.Client, projectName, serviceName string, statements []string) error {
+	for _, query := range statements {
+		log.Println("[DEBUG] Clickhouse: grant query: ", query)
+		if _, err := client.ClickHouseQuery.Query(projectName, serviceName, defaultDatabase, query); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func RevokePrivilegeGrant(client *aiven.Client, projectName, serviceName string, grant PrivilegeGrant) error {
@@ -133,20 +155,8 @@ func readRoleGrantsStatement() string {
 func createPrivilegeGrantStatement(grant PrivilegeGrant) string {
 	b := new(strings.Builder)
 
-	b.WriteString("GRANT")
-	b.WriteString(" ")
-	b.WriteString(grant.Privilege)
-
-	if len(grant.Column) > 0 {
-		b.WriteString(fmt.Sprintf("(%s)", escape(grant.Column)))
-	}
-
-	b.WriteString(fmt.Sprintf(" ON %s", escape(grant.Database)))
-
-	if len(grant.Table) > 0 {
-		b.WriteString(fmt.Sprintf(".%s", escape(grant.Table)))
-	}
-
+	b.WriteString("GRANT ")
+	b.WriteString(privilegeGrantTarget(grant))
 	b.WriteString(fmt.Sprintf(" TO %s", escape(userOrRole(grant.Grantee))))
 
 	if grant.WithGrant {
@@ -157,10 +167,17 @@ func createPrivilegeGrantStatement(grant PrivilegeGrant) string {
 }
 
 func revokePrivilegeGrantStatement(grant PrivilegeGrant) string {
+	return fmt.Sprintf("REVOKE %s FROM %s", privilegeGrantTarget(grant), escape(userOrRole(grant.Grantee)))
+}
+
+func revokePrivilegeGrantOptionStatement(grant PrivilegeGrant) string {
+	return fmt.Sprintf("REVOKE GRANT OPTION FOR %s FROM %s", privilegeGrantTarget(grant), escape(userOrRole(grant.Grantee)))
+}
+
+// privilegeGrantTarget returns the privilege with the database, table and column it is granted on, e.g. SELECT(`c`) ON `db`.`t`
+func privilegeGrantTarget(grant PrivilegeGrant) string {
 	b := new(strings.Builder)
 
-	b.WriteString("REVOKE")
-	b.WriteString(" ")
 	b.WriteString(grant.Privilege)
 
 	if len(grant.Column) > 0 {
@@ -173,7 +190,6 @@ func revokePrivilegeGrantStatement(grant PrivilegeGrant) string {
 		b.WriteString(fmt.Sprintf(".%s", escape(grant.Table)))
 	}
 
-	b.WriteString(fmt.Sprintf(" FROM %s", escape(userOrRole(grant.Grantee))))
 	return b.String()
 }
 
@@ -232,7 +248,9 @@ func roleGrantsFromAPIResponse(r *aiven.ClickhouseQueryResponse) ([]RoleGrant, e
 	return grants, nil
 }
 
-func privilegeGrantsFromAPIResponse(r *aiven.ClickhouseQueryResponse) ([]PrivilegeGrant, error) {
+// privilegeGrantsFromAPIResponse returns the privilege grants and the partial revokes of system.grants, the grant option
+// of a partial revoke means that only the grant option is revoked
+func privilegeGrantsFromAPIResponse(r *aiven.ClickhouseQueryResponse) ([]PrivilegeGrant, []PrivilegeGrant, error) {
 	meta := r.Meta
 	data := r.Data
 	columnNameMap := make(map[string]int)
@@ -250,12 +268,13 @@ func privilegeGrantsFromAPIResponse(r *aiven.ClickhouseQueryResponse) ([]Privile
 		"grant_option",
 	} {
 		if _, ok := columnNameMap[columnName]; !ok {
-			return nil, fmt.Errorf("'system.grants' metadata is missing the '%s' column", columnName)
+			return nil, nil, fmt.Errorf("'system.grants' metadata is missing the '%s' column", columnName)
 		}
 	}
 
 	var err error
 	grants := make([]PrivilegeGrant, 0)
+	partialRevokes := make([]PrivilegeGrant, 0)
 	for i := range data {
 		column := data[i].([]interface{})
 
@@ -281,12 +300,7 @@ func privilegeGrantsFromAPIResponse(r *aiven.ClickhouseQueryResponse) ([]Privile
 			return s.String() == "1"
 		}
 
-		// skip partial revokes as we dont track them in the schema yet
-		if getBoolean("is_partial_revoke") {
-			continue
-		}
-
-		grants = append(grants, PrivilegeGrant{
+		grant := PrivilegeGrant{
 			Grantee: Grantee{
 				User: getMaybeString("user_name"),
 				Role: getMaybeString("role_name"),
@@ -296,11 +310,16 @@ func privilegeGrantsFromAPIResponse(r *aiven.ClickhouseQueryResponse) ([]Privile
 			Column:    getMaybeString("column"),
 			Privilege: getMaybeString("access_type"),
 			WithGrant: getBoolean("grant_option"),
-		})
+		}
+		if getBoolean("is_partial_revoke") {
+			partialRevokes = append(partialRevokes, grant)
+		} else {
+			grants = append(grants, grant)
+		}
 	}
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return grants, nil
+	return grants, partialRevokes, nil
 }
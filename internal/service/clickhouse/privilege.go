@@ -0,0 +1,341 @@
+package clickhouse
+
+import (
+	"strings"
+)
+
+// privilegeLevel is the narrowest level a privilege can be granted on, a grant on a wider level grants it on everything below
+type privilegeLevel int
+
+const (
+	globalLevel privilegeLevel = iota
+	databaseLevel
+	tableLevel
+	columnLevel
+	// groupLevel is the level of the privileges that only group other privileges
+	groupLevel
+)
+
+type privilege struct {
+	name    string
+	aliases []string
+	level   privilegeLevel
+	parent  string
+}
+
+// privilegeHierarchy is the subset of the ClickHouse access types that can be granted on databases, tables and columns,
+// with the groups above them. Granting a group grants the privileges below it that apply to the level of the grant, and
+// ClickHouse shows it as these privileges in system.grants unless all of them are granted. The views and dictionaries
+// are on the table level. It mirrors src/Access/Common/AccessType.h of ClickHouse 22.8, the access types missing from it
+// are handled as unknown privileges of ALL or SYSTEM.
+var privilegeHierarchy = []privilege{
+	{name: "ALL", aliases: []string{"ALL PRIVILEGES"}, level: groupLevel},
+
+	{name: "SHOW", level: groupLevel, parent: "ALL"},
+	{name: "SHOW DATABASES", aliases: []string{"SHOW CREATE DATABASE"}, level: databaseLevel, parent: "SHOW"},
+	{name: "SHOW TABLES", aliases: []string{"SHOW CREATE TABLE"}, level: tableLevel, parent: "SHOW"},
+	{name: "SHOW COLUMNS", level: columnLevel, parent: "SHOW"},
+	{name: "SHOW DICTIONARIES", aliases: []string{"SHOW CREATE DICTIONARY"}, level: tableLevel, parent: "SHOW"},
+
+	{name: "SELECT", level: columnLevel, parent: "ALL"},
+	{name: "INSERT", level: columnLevel, parent: "ALL"},
+
+	{name: "ALTER", level: groupLevel, parent: "ALL"},
+	{name: "ALTER TABLE", level: groupLevel, parent: "ALTER"},
+	{name: "ALTER UPDATE", aliases: []string{"UPDATE"}, level: columnLevel, parent: "ALTER TABLE"},
+	{name: "ALTER DELETE", aliases: []string{"DELETE"}, level: columnLevel, parent: "ALTER TABLE"},
+	{name: "ALTER COLUMN", level: groupLevel, parent: "ALTER TABLE"},
+	{name: "ALTER ADD COLUMN", aliases: []string{"ADD COLUMN"}, level: columnLevel, parent: "ALTER COLUMN"},
+	{name: "ALTER MODIFY COLUMN", aliases: []string{"MODIFY COLUMN"}, level: columnLevel, parent: "ALTER COLUMN"},
+	{name: "ALTER DROP COLUMN", aliases: []string{"DROP COLUMN"}, level: columnLevel, parent: "ALTER COLUMN"},
+	{name: "ALTER COMMENT COLUMN", aliases: []string{"COMMENT COLUMN"}, level: columnLevel, parent: "ALTER COLUMN"},
+	{name: "ALTER CLEAR COLUMN", aliases: []string{"CLEAR COLUMN"}, level: columnLevel, parent: "ALTER COLUMN"},
+	{name: "ALTER RENAME COLUMN", aliases: []string{"RENAME COLUMN"}, level: columnLevel, parent: "ALTER COLUMN"},
+	{name: "ALTER MATERIALIZE COLUMN", aliases: []string{"MATERIALIZE COLUMN"}, level: columnLevel, parent: "ALTER COLUMN"},
+	{name: "ALTER INDEX", aliases: []string{"INDEX"}, level: groupLevel, parent: "ALTER TABLE"},
+	{name: "ALTER ORDER BY", aliases: []string{"ALTER MODIFY ORDER BY", "MODIFY ORDER BY"}, level: tableLevel, parent: "ALTER INDEX"},
+	{name: "ALTER SAMPLE BY", aliases: []string{"ALTER MODIFY SAMPLE BY", "MODIFY SAMPLE BY"}, level: tableLevel, parent: "ALTER INDEX"},
+	{name: "ALTER ADD INDEX", aliases: []string{"ADD INDEX"}, level: tableLevel, parent: "ALTER INDEX"},
+	{name: "ALTER DROP INDEX", aliases: []string{"DROP INDEX"}, level: tableLevel, parent: "ALTER INDEX"},
+	{name: "ALTER MATERIALIZE INDEX", aliases: []string{"MATERIALIZE INDEX"}, level: tableLevel, parent: "ALTER INDEX"},
+	{name: "ALTER CLEAR INDEX", aliases: []string{"CLEAR INDEX"}, level: tableLevel, parent: "ALTER INDEX"},
+	{name: "ALTER CONSTRAINT", aliases: []string{"CONSTRAINT"}, level: groupLevel, parent: "ALTER TABLE"},
+	{name: "ALTER ADD CONSTRAINT", aliases: []string{"ADD CONSTRAINT"}, level: tableLevel, parent: "ALTER CONSTRAINT"},
+	{name: "ALTER DROP CONSTRAINT", aliases: []string{"DROP CONSTRAINT"}, level: tableLevel, parent: "ALTER CONSTRAINT"},
+	{name: "ALTER MODIFY COMMENT", aliases: []string{"MODIFY COMMENT"}, level: tableLevel, parent: "ALTER TABLE"},
+	{name: "ALTER TTL", aliases: []string{"ALTER MODIFY TTL", "MODIFY TTL"}, level: tableLevel, parent: "ALTER TABLE"},
+	{name: "ALTER MATERIALIZE TTL", aliases: []string{"MATERIALIZE TTL"}, level: tableLevel, parent: "ALTER TABLE"},
+	{name: "ALTER SETTINGS", aliases: []string{"ALTER SETTING", "ALTER MODIFY SETTING", "MODIFY SETTING", "RESET SETTING"}, level: tableLevel, parent: "ALTER TABLE"},
+	{name: "ALTER MOVE PARTITION", aliases: []string{"ALTER MOVE PART", "MOVE PARTITION", "MOVE PART"}, level: tableLevel, parent: "ALTER TABLE"},
+	{name: "ALTER FETCH PARTITION", aliases: []string{"ALTER FETCH PART", "FETCH PARTITION"}, level: tableLevel, parent: "ALTER TABLE"},
+	{name: "ALTER FREEZE PARTITION", aliases: []string{"FREEZE PARTITION", "UNFREEZE"}, level: tableLevel, parent: "ALTER TABLE"},
+	{name: "ALTER DATABASE", level: groupLevel, parent: "ALTER"},
+	{name: "ALTER DATABASE SETTINGS", aliases: []string{"ALTER DATABASE SETTING", "ALTER MODIFY DATABASE SETTING", "MODIFY DATABASE SETTING"}, level: databaseLevel, parent: "ALTER DATABASE"},
+	{name: "ALTER VIEW", level: groupLevel, parent: "ALTER"},
+	{name: "ALTER VIEW REFRESH", aliases: []string{"ALTER LIVE VIEW REFRESH", "REFRESH VIEW"}, level: tableLevel, parent: "ALTER VIEW"},
+	{name: "ALTER VIEW MODIFY QUERY", aliases: []string{"ALTER TABLE MODIFY QUERY"}, level: tableLevel, parent: "ALTER VIEW"},
+
+	{name: "CREATE", level: groupLevel, parent: "ALL"},
+	{name: "CREATE DATABASE", level: databaseLevel, parent: "CREATE"},
+	{name: "CREATE TABLE", level: tableLevel, parent: "CREATE"},
+	{name: "CREATE VIEW", level: tableLevel, parent: "CREATE"},
+	{name: "CREATE DICTIONARY", level: tableLevel, parent: "CREATE"},
+	{name: "CREATE TEMPORARY TABLE", level: globalLevel, parent: "CREATE"},
+	{name: "CREATE FUNCTION", level: globalLevel, parent: "CREATE"},
+
+	{name: "DROP", level: groupLevel, parent: "ALL"},
+	{name: "DROP DATABASE", level: databaseLevel, parent: "DROP"},
+	{name: "DROP TABLE", level: tableLevel, parent: "DROP"},
+	{name: "DROP VIEW", level: tableLevel, parent: "DROP"},
+	{name: "DROP DICTIONARY", level: tableLevel, parent: "DROP"},
+	{name: "DROP FUNCTION", level: globalLevel, parent: "DROP"},
+
+	{name: "TRUNCATE", aliases: []string{"TRUNCATE TABLE"}, level: tableLevel, parent: "ALL"},
+	{name: "OPTIMIZE", aliases: []string{"OPTIMIZE TABLE"}, level: tableLevel, parent: "ALL"},
+	{name: "KILL QUERY", level: globalLevel, parent: "ALL"},
+	{name: "SYSTEM", level: groupLevel, parent: "ALL"},
+	{name: "dictGet", aliases: []string{"dictHas", "dictGetHierarchy", "dictIsIn"}, level: tableLevel, parent: "ALL"},
+}
+
+var (
+	privilegesByName    = make(map[string]privilege)
+	privilegeCanonicals = make(map[string]string)
+	privilegeChildren   = make(map[string][]string)
+)
+
+func init() {
+	for _, p := range privilegeHierarchy {
+		privilegesByName[p.name] = p
+		privilegeCanonicals[strings.ToUpper(p.name)] = p.name
+		for _, a := range p.aliases {
+			privilegeCanonicals[strings.ToUpper(a)] = p.name
+		}
+		if p.parent != "" {
+			privilegeChildren[p.parent] = append(privilegeChildren[p.parent], p.name)
+		}
+	}
+}
+
+// canonicalPrivilege returns the name ClickHouse shows for the privilege, the unknown privileges are kept as they are
+func canonicalPrivilege(name string) string {
+	name = strings.Join(strings.Fields(name), " ")
+	if c, ok := privilegeCanonicals[strings.ToUpper(name)]; ok {
+		return c
+	}
+	return name
+}
+
+// privilegeParent returns the group of the privilege, the unknown privileges are in SYSTEM or in ALL
+func privilegeParent(name string) string {
+	if p, ok := privilegesByName[name]; ok {
+		return p.parent
+	}
+	if strings.HasPrefix(name, "SYSTEM ") {
+		return "SYSTEM"
+	}
+	if name != "ALL" {
+		return "ALL"
+	}
+	return ""
+}
+
+// privilegeIncludes returns true when granting the privilege a grants the privilege b
+func privilegeIncludes(a, b string) bool {
+	a, b = canonicalPrivilege(a), canonicalPrivilege(b)
+	for ; b != ""; b = privilegeParent(b) {
+		if a == b {
+			return true
+		}
+	}
+	return false
+}
+
+// privilegeLeaves returns the privileges granting the privilege on the level grants, the unknown privileges and the groups
+// without known privileges on the level are their own leaf
+func privilegeLeaves(name string, level privilegeLevel) []string {
+	name = canonicalPrivilege(name)
+	if leaves := collectPrivilegeLeaves(name, level); len(leaves) > 0 {
+		return leaves
+	}
+	return []string{name}
+}
+
+func collectPrivilegeLeaves(name string, level privilegeLevel) []string {
+	p, ok := privilegesByName[name]
+	if !ok || p.level != groupLevel {
+		if !ok || p.level >= level {
+			return []string{name}
+		}
+		return nil
+	}
+
+	var leaves []string
+	for _, c := range privilegeChildren[name] {
+		leaves = append(leaves, collectPrivilegeLeaves(c, level)...)
+	}
+	return leaves
+}
+
+// level returns the level of the database, table or column the privilege is granted on
+func (g PrivilegeGrant) level() privilegeLevel {
+	switch {
+	case g.Database == "":
+		return globalLevel
+	case g.Table == "":
+		return databaseLevel
+	case g.Column == "":
+		return tableLevel
+	}
+	return columnLevel
+}
+
+// onTarget returns true when the grant is on a database, table or column that includes the one of the other grant
+func (g PrivilegeGrant) onTarget(other PrivilegeGrant) bool {
+	switch g.level() {
+	case globalLevel:
+		return true
+	case databaseLevel:
+		return g.Database == other.Database
+	case tableLevel:
+		return g.Database == other.Database && g.Table == other.Table
+	}
+	return g.Database == other.Database && g.Table == other.Table && g.Column == other.Column
+}
+
+// overlaps returns true when the grants have a privilege in common on a database, table or column
+func (g PrivilegeGrant) overlaps(other PrivilegeGrant) bool {
+	return (g.onTarget(other) || other.onTarget(g)) &&
+		(privilegeIncludes(g.Privilege, other.Privilege) || privilegeIncludes(other.Privilege, g.Privilege))
+}
+
+// privilegeGrantsInclude returns true when the grants grant every privilege the grant grants, the aliases, the groups and the
+// wider databases or tables are taken into account
+func privilegeGrantsInclude(grants []PrivilegeGrant, grant PrivilegeGrant) bool {
+	for _, leaf := range privilegeLeaves(grant.Privilege, grant.level()) {
+		included := false
+		for _, g := range grants {
+			if privilegeIncludes(g.Privilege, leaf) && g.onTarget(grant) && (g.WithGrant || !grant.WithGrant) {
+				included = true
+				break
+			}
+		}
+		if !included {
+			return false
+		}
+	}
+	return true
+}
+
+// effectivePrivilegeGrants returns the configured grants that are in effect, as they are configured, followed by the grants of
+// the server that the configured grants don't account for. The configured grants that are partially revoked are not in effect.
+func effectivePrivilegeGrants(configured, grants, partialRevokes []PrivilegeGrant) []PrivilegeGrant {
+	res := make([]PrivilegeGrant, 0)
+	for _, c := range configured {
+		if !privilegeGrantsInclude(grants, c) {
+			continue
+		}
+
+		revoked := false
+		for _, r := range partialRevokes {
+			// a partial revoke of the grant option only matters when the grant option is configured
+			if c.overlaps(r) && (!r.WithGrant || c.WithGrant) {
+				revoked = true
+				break
+			}
+		}
+		if !revoked {
+			res = append(res, c)
+		}
+	}
+
+	inEffect := append([]PrivilegeGrant{}, res...)
+	for _, g := range grants {
+		if !privilegeGrantsInclude(inEffect, g) {
+			res = append(res, g)
+		}
+	}
+	return res
+}
+
+type privilegeGrantKey struct {
+	database  string
+	table     string
+	column    string
+	privilege string
+}
+
+func (g PrivilegeGrant) key() privilegeGrantKey {
+	return privilegeGrantKey{g.Database, g.Table, g.Column, canonicalPrivilege(g.Privilege)}
+}
+
+// privilegeGrantsDelta returns the statements to go from the old grants to the new ones. The removed grants are revoked before
+// the added ones are granted, and the grants that a revocation takes away are granted again.
+func privilegeGrantsDelta(old, new []PrivilegeGrant) []string {
+	oldByKey := make(map[privilegeGrantKey]PrivilegeGrant)
+	for _, g := range old {
+		oldByKey[g.key()] = g
+	}
+	newByKey := make(map[privilegeGrantKey]PrivilegeGrant)
+	for _, g := range new {
+		newByKey[g.key()] = g
+	}
+
+	type revocation struct {
+		grant           PrivilegeGrant
+		grantOptionOnly bool
+	}
+
+	var statements []string
+	var revocations []revocation
+	for _, g := range old {
+		n, ok := newByKey[g.key()]
+		switch {
+		case !ok:
+			statements = append(statements, revokePrivilegeGrantStatement(g))
+			revocations = append(revocations, revocation{grant: g})
+		case g.WithGrant && !n.WithGrant:
+			statements = append(statements, revokePrivilegeGrantOptionStatement(g))
+			revocations = append(revocations, revocation{grant: g, grantOptionOnly: true})
+		}
+	}
+
+	for _, g := range new {
+		o, ok := oldByKey[g.key()]
+		if !ok || (g.WithGrant && !o.WithGrant) {
+			statements = append(statements, createPrivilegeGrantStatement(g))
+			continue
+		}
+		for _, r := range revocations {
+			if r.grant.key() != g.key() && g.overlaps(r.grant) && (!r.grantOptionOnly || g.WithGrant) {
+				statements = append(statements, createPrivilegeGrantStatement(g))
+				break
+			}
+		}
+	}
+	return statements
+}
+
+// roleGrantsDelta returns the statements to go from the old role grants to the new ones
+func roleGrantsDelta(old, new []RoleGrant) []string {
+	oldRoles := make(map[string]bool)
+	for _, g := range old {
+		oldRoles[g.Role] = true
+	}
+	newRoles := make(map[string]bool)
+	for _, g := range new {
+		newRoles[g.Role] = true
+	}
+
+	var statements []string
+	for _, g := range old {
+		if !newRoles[g.Role] {
+			statements = append(statements, revokeRoleGrantStatement(g))
+		}
+	}
+	for _, g := range new {
+		if !oldRoles[g.Role] {
+			statements = append(statements, createRoleGrantStatement(g))
+		}
+	}
+	return statements
+}
@@ -0,0 +1,242 @@
+package clickhouse
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testSystemGrants parses system.grants rows of the role analyst, given as database, table, column, access type,
+// grant option and partial revoke
+func testSystemGrants(t *testing.T, rows ...[]interface{}) ([]PrivilegeGrant, []PrivilegeGrant) {
+	r := &aiven.ClickhouseQueryResponse{
+		Meta: []aiven.ClickhouseQueryColumnMeta{
+			{Name: "user_name", Type: "Nullable(String)"},
+			{Name: "role_name", Type: "Nullable(String)"},
+			{Name: "access_type", Type: "Enum16"},
+			{Name: "database", Type: "Nullable(String)"},
+			{Name: "table", Type: "Nullable(String)"},
+			{Name: "column", Type: "Nullable(String)"},
+			{Name: "is_partial_revoke", Type: "UInt8"},
+			{Name: "grant_option", Type: "UInt8"},
+		},
+	}
+	boolean := func(v interface{}) json.Number {
+		if v.(bool) {
+			return "1"
+		}
+		return "0"
+	}
+	for _, row := range rows {
+		r.Data = append(r.Data, []interface{}{nil, "analyst", row[3], row[0], row[1], row[2], boolean(row[5]), boolean(row[4])})
+	}
+
+	grants, partialRevokes, err := privilegeGrantsFromAPIResponse(r)
+	require.NoError(t, err)
+	return grants, partialRevokes
+}
+
+func testPrivilegeGrant(privilege, database, table, column string, withGrant bool) PrivilegeGrant {
+	return PrivilegeGrant{
+		Grantee:   Grantee{Role: "analyst"},
+		Database:  database,
+		Table:     table,
+		Column:    column,
+		Privilege: privilege,
+		WithGrant: withGrant,
+	}
+}
+
+func TestPrivilegeIncludes(t *testing.T) {
+	assert.True(t, privilegeIncludes("ALL", "SELECT"))
+	assert.True(t, privilegeIncludes("ALL PRIVILEGES", "SYSTEM MERGES"))
+	assert.True(t, privilegeIncludes("ALTER", "ALTER ADD COLUMN"))
+	assert.True(t, privilegeIncludes("ALTER COLUMN", "ADD COLUMN"))
+	assert.True(t, privilegeIncludes("SYSTEM", "SYSTEM FLUSH LOGS"))
+	assert.True(t, privilegeIncludes("MODIFY SETTING", "ALTER SETTINGS"))
+	assert.False(t, privilegeIncludes("ALTER COLUMN", "ALTER ADD INDEX"))
+	assert.False(t, privilegeIncludes("SELECT", "ALL"))
+	assert.False(t, privilegeIncludes("SELECT", "INSERT"))
+}
+
+func TestPrivilegeLeaves(t *testing.T) {
+	assert.Equal(t, []string{"SHOW TABLES", "SHOW COLUMNS", "SHOW DICTIONARIES"}, privilegeLeaves("SHOW", tableLevel))
+	assert.Equal(t, []string{"SHOW DATABASES", "SHOW TABLES", "SHOW COLUMNS", "SHOW DICTIONARIES"}, privilegeLeaves("SHOW", databaseLevel))
+	assert.Equal(t, []string{"ALTER UPDATE", "ALTER DELETE"}, privilegeLeaves("ALTER TABLE", columnLevel)[:2])
+	assert.Equal(t, []string{"ALTER SETTINGS"}, privilegeLeaves("MODIFY SETTING", tableLevel))
+	assert.Equal(t, []string{"SYSTEM MERGES"}, privilegeLeaves("SYSTEM MERGES", databaseLevel))
+	assert.NotContains(t, privilegeLeaves("ALL", databaseLevel), "KILL QUERY")
+	assert.NotContains(t, privilegeLeaves("ALL", tableLevel), "CREATE DATABASE")
+	assert.Contains(t, privilegeLeaves("ALL", tableLevel), "CREATE TABLE")
+}
+
+// systemGrantsOfAllOnDatabase are the rows ClickHouse 22.8 shows in system.grants for GRANT ALL ON analytics.*, the
+// groups granted in full on the database are collapsed and the global privileges of ALL are left out. They are written
+// out rather than derived from privilegeHierarchy so that the test catches a hierarchy that drifts from ClickHouse
+var systemGrantsOfAllOnDatabase = [][]interface{}{
+	{"analytics", nil, nil, "SHOW", false, false},
+	{"analytics", nil, nil, "SELECT", false, false},
+	{"analytics", nil, nil, "INSERT", false, false},
+	{"analytics", nil, nil, "ALTER", false, false},
+	{"analytics", nil, nil, "CREATE DATABASE", false, false},
+	{"analytics", nil, nil, "CREATE TABLE", false, false},
+	{"analytics", nil, nil, "CREATE VIEW", false, false},
+	{"analytics", nil, nil, "CREATE DICTIONARY", false, false},
+	{"analytics", nil, nil, "DROP DATABASE", false, false},
+	{"analytics", nil, nil, "DROP TABLE", false, false},
+	{"analytics", nil, nil, "DROP VIEW", false, false},
+	{"analytics", nil, nil, "DROP DICTIONARY", false, false},
+	{"analytics", nil, nil, "TRUNCATE", false, false},
+	{"analytics", nil, nil, "OPTIMIZE", false, false},
+	{"analytics", nil, nil, "SYSTEM MERGES", false, false},
+	{"analytics", nil, nil, "SYSTEM TTL MERGES", false, false},
+	{"analytics", nil, nil, "SYSTEM FETCHES", false, false},
+	{"analytics", nil, nil, "SYSTEM MOVES", false, false},
+	{"analytics", nil, nil, "SYSTEM SENDS", false, false},
+	{"analytics", nil, nil, "SYSTEM REPLICATION QUEUES", false, false},
+	{"analytics", nil, nil, "SYSTEM DROP REPLICA", false, false},
+	{"analytics", nil, nil, "SYSTEM SYNC REPLICA", false, false},
+	{"analytics", nil, nil, "SYSTEM RESTART REPLICA", false, false},
+	{"analytics", nil, nil, "SYSTEM RESTORE REPLICA", false, false},
+	{"analytics", nil, nil, "SYSTEM FLUSH DISTRIBUTED", false, false},
+	{"analytics", nil, nil, "dictGet", false, false},
+}
+
+func TestEffectivePrivilegeGrants(t *testing.T) {
+	all := testPrivilegeGrant("ALL", "analytics", "", "", false)
+	selectOnTable := testPrivilegeGrant("SELECT", "analytics", "events", "", false)
+	alter := testPrivilegeGrant("ALTER", "analytics", "events", "", true)
+
+	tests := []struct {
+		name       string
+		configured []PrivilegeGrant
+		rows       [][]interface{}
+		want       []PrivilegeGrant
+	}{
+		{
+			name:       "expanded ALL",
+			configured: []PrivilegeGrant{all},
+			rows:       systemGrantsOfAllOnDatabase,
+			want:       []PrivilegeGrant{all},
+		},
+		{
+			name:       "collapsed ALL",
+			configured: []PrivilegeGrant{all},
+			rows:       [][]interface{}{{"analytics", nil, nil, "ALL", false, false}},
+			want:       []PrivilegeGrant{all},
+		},
+		{
+			name:       "expanded group with grant option",
+			configured: []PrivilegeGrant{alter},
+			rows: [][]interface{}{
+				{"analytics", "events", nil, "ALTER TABLE", true, false},
+				{"analytics", "events", nil, "ALTER VIEW", true, false},
+			},
+			want: []PrivilegeGrant{alter},
+		},
+		{
+			name:       "missing grant option",
+			configured: []PrivilegeGrant{alter},
+			rows: [][]interface{}{
+				{"analytics", "events", nil, "ALTER TABLE", false, false},
+				{"analytics", "events", nil, "ALTER VIEW", true, false},
+			},
+			want: []PrivilegeGrant{
+				testPrivilegeGrant("ALTER TABLE", "analytics", "events", "", false),
+				testPrivilegeGrant("ALTER VIEW", "analytics", "events", "", true),
+			},
+		},
+		{
+			name:       "granted on the database",
+			configured: []PrivilegeGrant{selectOnTable},
+			rows:       [][]interface{}{{"analytics", nil, nil, "SELECT", false, false}},
+			want:       []PrivilegeGrant{selectOnTable, testPrivilegeGrant("SELECT", "analytics", "", "", false)},
+		},
+		{
+			name:       "granted out of band",
+			configured: []PrivilegeGrant{selectOnTable},
+			rows: [][]interface{}{
+				{"analytics", "events", nil, "SELECT", false, false},
+				{"analytics", "events", nil, "INSERT", false, false},
+			},
+			want: []PrivilegeGrant{selectOnTable, testPrivilegeGrant("INSERT", "analytics", "events", "", false)},
+		},
+		{
+			name:       "revoked out of band",
+			configured: []PrivilegeGrant{selectOnTable, alter},
+			rows:       [][]interface{}{{"analytics", "events", nil, "SELECT", false, false}},
+			want:       []PrivilegeGrant{selectOnTable},
+		},
+		{
+			name:       "imported",
+			configured: nil,
+			rows:       [][]interface{}{{"analytics", "events", "id", "SELECT", false, false}},
+			want:       []PrivilegeGrant{testPrivilegeGrant("SELECT", "analytics", "events", "id", false)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			grants, partialRevokes := testSystemGrants(t, tt.rows...)
+			assert.Equal(t, tt.want, effectivePrivilegeGrants(tt.configured, grants, partialRevokes))
+		})
+	}
+
+	// a partially revoked grant is not in effect, the grants of the server are shown instead
+	grants, partialRevokes := testSystemGrants(t, append(append([][]interface{}{}, systemGrantsOfAllOnDatabase...),
+		[]interface{}{"analytics", "secrets", nil, "SELECT", false, true},
+	)...)
+	require.Len(t, partialRevokes, 1)
+	got := effectivePrivilegeGrants([]PrivilegeGrant{all}, grants, partialRevokes)
+	assert.NotContains(t, got, all)
+	assert.Equal(t, grants, got)
+}
+
+func TestPrivilegeGrantsDelta(t *testing.T) {
+	all := testPrivilegeGrant("ALL", "analytics", "", "", false)
+	selectOnDatabase := testPrivilegeGrant("SELECT", "analytics", "", "", false)
+	selectOnTable := testPrivilegeGrant("SELECT", "analytics", "events", "", false)
+	insert := testPrivilegeGrant("INSERT", "analytics", "events", "", true)
+	insertWithoutGrantOption := testPrivilegeGrant("INSERT", "analytics", "events", "", false)
+
+	assert.Empty(t, privilegeGrantsDelta([]PrivilegeGrant{all, insert}, []PrivilegeGrant{insert, all}))
+
+	assert.Equal(t, []string{
+		"REVOKE ALL ON `analytics` FROM `analyst`",
+		"GRANT SELECT ON `analytics` TO `analyst`",
+	}, privilegeGrantsDelta([]PrivilegeGrant{all}, []PrivilegeGrant{selectOnDatabase}))
+
+	// revoking SELECT on the database revokes it on its tables too
+	assert.Equal(t, []string{
+		"REVOKE SELECT ON `analytics` FROM `analyst`",
+		"GRANT SELECT ON `analytics`.`events` TO `analyst`",
+	}, privilegeGrantsDelta([]PrivilegeGrant{selectOnDatabase, selectOnTable}, []PrivilegeGrant{selectOnTable}))
+
+	assert.Equal(t, []string{
+		"REVOKE GRANT OPTION FOR INSERT ON `analytics`.`events` FROM `analyst`",
+	}, privilegeGrantsDelta([]PrivilegeGrant{insert}, []PrivilegeGrant{insertWithoutGrantOption}))
+
+	assert.Equal(t, []string{
+		"GRANT INSERT ON `analytics`.`events` TO `analyst` WITH GRANT",
+	}, privilegeGrantsDelta([]PrivilegeGrant{insertWithoutGrantOption}, []PrivilegeGrant{insert}))
+
+	// revoking the grant option of ALL only takes the grant option away from the other grants
+	allWithGrantOption := testPrivilegeGrant("ALL", "analytics", "", "", true)
+	assert.Equal(t, []string{
+		"REVOKE GRANT OPTION FOR ALL ON `analytics` FROM `analyst`",
+		"GRANT INSERT ON `analytics`.`events` TO `analyst` WITH GRANT",
+	}, privilegeGrantsDelta([]PrivilegeGrant{allWithGrantOption, insert, selectOnTable}, []PrivilegeGrant{all, insert, selectOnTable}))
+}
+
+func TestRoleGrantsDelta(t *testing.T) {
+	grantee := Grantee{User: "bob"}
+	assert.Equal(t, []string{
+		"REVOKE `reader` FROM `bob`",
+		"GRANT `writer` TO `bob`",
+	}, roleGrantsDelta(
+		[]RoleGrant{{Grantee: grantee, Role: "reader"}, {Grantee: grantee, Role: "analyst"}},
+		[]RoleGrant{{Grantee: grantee, Role: "analyst"}, {Grantee: grantee, Role: "writer"}},
+	))
+}
@@ -0,0 +1,108 @@
+package clickhouse
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/aiven/aiven-go-client"
+)
+
+// queryRow is a row of a query response with its columns by name
+type queryRow struct {
+	columns map[string]interface{}
+	err     error
+}
+
+func (r *queryRow) getString(columnName string) string {
+	f := r.columns[columnName]
+	if f == nil {
+		return ""
+	}
+	s, ok := f.(string)
+	if !ok {
+		r.err = fmt.Errorf("column name '%s' was expected to be a string", columnName)
+		return ""
+	}
+	return s
+}
+
+// getStrings returns an Array(String) column
+func (r *queryRow) getStrings(columnName string) []string {
+	f := r.columns[columnName]
+	if f == nil {
+		return nil
+	}
+	l, ok := f.([]interface{})
+	if !ok {
+		r.err = fmt.Errorf("column name '%s' was expected to be an array", columnName)
+		return nil
+	}
+	res := make([]string, 0, len(l))
+	for _, v := range l {
+		s, ok := v.(string)
+		if !ok {
+			r.err = fmt.Errorf("column name '%s' was expected to be an array of strings", columnName)
+			return nil
+		}
+		res = append(res, s)
+	}
+	return res
+}
+
+// getNumber returns a numeric column and whether it is set, the 64 bit integers are quoted by ClickHouse
+func (r *queryRow) getNumber(columnName string) (float64, bool) {
+	var s string
+	switch f := r.columns[columnName].(type) {
+	case nil:
+		return 0, false
+	case json.Number:
+		s = f.String()
+	case string:
+		s = f
+	case float64:
+		return f, true
+	default:
+		r.err = fmt.Errorf("column name '%s' was expected to be a number", columnName)
+		return 0, false
+	}
+
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		r.err = fmt.Errorf("column name '%s' was expected to be a number: %w", columnName, err)
+		return 0, false
+	}
+	return v, true
+}
+
+func (r *queryRow) getBoolean(columnName string) bool {
+	v, _ := r.getNumber(columnName)
+	return v == 1
+}
+
+// rowsFromAPIResponse maps the rows of a query response by column name, the given columns must be in the metadata
+func rowsFromAPIResponse(r *aiven.ClickhouseQueryResponse, columnNames ...string) ([]*queryRow, error) {
+	columnNameMap := make(map[string]int)
+	for i, md := range r.Meta {
+		columnNameMap[md.Name] = i
+	}
+	for _, columnName := range columnNames {
+		if _, ok := columnNameMap[columnName]; !ok {
+			return nil, fmt.Errorf("query response metadata is missing the '%s' column", columnName)
+		}
+	}
+
+	rows := make([]*queryRow, 0, len(r.Data))
+	for i := range r.Data {
+		data, ok := r.Data[i].([]interface{})
+		if !ok || len(data) != len(r.Meta) {
+			return nil, fmt.Errorf("query response row %d doesn't match the metadata", i)
+		}
+		row := &queryRow{columns: make(map[string]interface{}, len(r.Meta))}
+		for columnName, j := range columnNameMap {
+			row.columns[columnName] = data[j]
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
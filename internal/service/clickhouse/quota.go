@@ -0,0 +1,206 @@
+package clickhouse
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+)
+
+// quotaLimits are the resources a quota limits, system.quota_limits has them prefixed with max_
+var quotaLimits = []string{
+	"queries",
+	"query_selects",
+	"query_inserts",
+	"errors",
+	"result_rows",
+	"result_bytes",
+	"read_rows",
+	"read_bytes",
+	"execution_time",
+}
+
+type QuotaInterval struct {
+	// Duration is the length of the interval in seconds
+	Duration   int
+	Randomized bool
+	// Limits has the maximum of the limited resources, the others are only tracked
+	Limits map[string]int
+}
+
+type Quota struct {
+	Name string
+	// KeyedBy is how the quota is tracked, e.g. user_name or client_key,ip_address, empty when it is not keyed
+	KeyedBy   string
+	Intervals []QuotaInterval
+	Users     []string
+	Roles     []string
+}
+
+func CreateQuota(client *aiven.Client, projectName, serviceName string, quota Quota) error {
+	query := createQuotaStatement(quota, false)
+
+	log.Println("[DEBUG] Clickhouse: create quota query: ", query)
+	_, err := client.ClickHouseQuery.Query(projectName, serviceName, defaultDatabase, query)
+	return err
+}
+
+// ReplaceQuota replaces the quota, ALTER QUOTA keeps the intervals that are not mentioned
+func ReplaceQuota(client *aiven.Client, projectName, serviceName string, quota Quota) error {
+	query := createQuotaStatement(quota, true)
+
+	log.Println("[DEBUG] Clickhouse: replace quota query: ", query)
+	_, err := client.ClickHouseQuery.Query(projectName, serviceName, defaultDatabase, query)
+	return err
+}
+
+func ReadQuota(client *aiven.Client, projectName, serviceName, name string) (*Quota, error) {
+	query := readQuotaStatement(name)
+
+	log.Println("[DEBUG] Clickhouse: read quota query: ", query)
+	r, err := client.ClickHouseQuery.Query(projectName, serviceName, defaultDatabase, query)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := rowsFromAPIResponse(r, "name", "keys", "apply_to_list")
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, schemautil.NotFoundError("quota", name)
+	}
+	quota := &Quota{
+		Name:    name,
+		KeyedBy: strings.Join(rows[0].getStrings("keys"), ","),
+	}
+	assignees := rows[0].getStrings("apply_to_list")
+	if rows[0].err != nil {
+		return nil, rows[0].err
+	}
+
+	query = readQuotaLimitsStatement(name)
+
+	log.Println("[DEBUG] Clickhouse: read quota limits query: ", query)
+	r, err = client.ClickHouseQuery.Query(projectName, serviceName, defaultDatabase, query)
+	if err != nil {
+		return nil, err
+	}
+	if quota.Intervals, err = quotaIntervalsFromAPIResponse(r); err != nil {
+		return nil, err
+	}
+
+	roleNames, err := ReadRoleNames(client, projectName, serviceName)
+	if err != nil {
+		return nil, err
+	}
+	quota.Users, quota.Roles = splitAssignees(assignees, roleNames)
+
+	return quota, nil
+}
+
+func DropQuota(client *aiven.Client, projectName, serviceName, name string) error {
+	query := dropQuotaStatement(name)
+
+	log.Println("[DEBUG] Clickhouse: drop quota query: ", query)
+	_, err := client.ClickHouseQuery.Query(projectName, serviceName, defaultDatabase, query)
+	return err
+}
+
+func createQuotaStatement(quota Quota, replace bool) string {
+	b := new(strings.Builder)
+
+	b.WriteString("CREATE QUOTA ")
+	if replace {
+		b.WriteString("OR REPLACE ")
+	}
+	b.WriteString(escape(quota.Name))
+
+	if quota.KeyedBy != "" {
+		b.WriteString(fmt.Sprintf(" KEYED BY %s", strings.ReplaceAll(quota.KeyedBy, ",", ", ")))
+	} else {
+		b.WriteString(" NOT KEYED")
+	}
+
+	intervals := make([]string, 0, len(quota.Intervals))
+	for _, i := range quota.Intervals {
+		interval := new(strings.Builder)
+		interval.WriteString("FOR ")
+		if i.Randomized {
+			interval.WriteString("RANDOMIZED ")
+		}
+		interval.WriteString(fmt.Sprintf("INTERVAL %d SECOND ", i.Duration))
+
+		var limits []string
+		for _, l := range quotaLimits {
+			if v, ok := i.Limits[l]; ok {
+				limits = append(limits, fmt.Sprintf("%s = %d", l, v))
+			}
+		}
+		if len(limits) > 0 {
+			interval.WriteString("MAX ")
+			interval.WriteString(strings.Join(limits, ", "))
+		} else {
+			interval.WriteString("TRACKING ONLY")
+		}
+		intervals = append(intervals, interval.String())
+	}
+	if len(intervals) > 0 {
+		b.WriteString(" ")
+		b.WriteString(strings.Join(intervals, ", "))
+	}
+
+	if len(quota.Users)+len(quota.Roles) > 0 {
+		b.WriteString(" ")
+		b.WriteString(toClause(quota.Users, quota.Roles))
+	}
+
+	return b.String()
+}
+
+func dropQuotaStatement(name string) string {
+	return fmt.Sprintf("DROP QUOTA IF EXISTS %s", escape(name))
+}
+
+func readQuotaStatement(name string) string {
+	return fmt.Sprintf("SELECT name, keys, apply_to_list FROM system.quotas WHERE name = %s", escapeStringLiteral(name))
+}
+
+func readQuotaLimitsStatement(name string) string {
+	return fmt.Sprintf("SELECT * FROM system.quota_limits WHERE quota_name = %s", escapeStringLiteral(name))
+}
+
+func quotaIntervalsFromAPIResponse(r *aiven.ClickhouseQueryResponse) ([]QuotaInterval, error) {
+	columnNames := []string{"duration", "is_randomized_interval"}
+	for _, l := range quotaLimits {
+		columnNames = append(columnNames, "max_"+l)
+	}
+	rows, err := rowsFromAPIResponse(r, columnNames...)
+	if err != nil {
+		return nil, err
+	}
+
+	intervals := make([]QuotaInterval, 0, len(rows))
+	for _, row := range rows {
+		duration, _ := row.getNumber("duration")
+		i := QuotaInterval{
+			Duration:   int(duration),
+			Randomized: row.getBoolean("is_randomized_interval"),
+			Limits:     make(map[string]int),
+		}
+		for _, l := range quotaLimits {
+			if v, ok := row.getNumber("max_" + l); ok {
+				i.Limits[l] = int(v)
+			}
+		}
+		if row.err != nil {
+			return nil, row.err
+		}
+		intervals = append(intervals, i)
+	}
+
+	sort.Slice(intervals, func(a, b int) bool { return intervals[a].Duration < intervals[b].Duration })
+	return intervals, nil
+}
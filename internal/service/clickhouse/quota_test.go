@@ -0,0 +1,68 @@
+package clickhouse
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateQuotaStatement(t *testing.T) {
+	quota := Quota{
+		Name:    "limited",
+		KeyedBy: "client_key,user_name",
+		Intervals: []QuotaInterval{
+			{Duration: 3600, Limits: map[string]int{"queries": 100, "read_bytes": 1000000}},
+			{Duration: 86400, Randomized: true, Limits: map[string]int{}},
+		},
+		Users: []string{"bob"},
+	}
+	assert.Equal(t,
+		"CREATE QUOTA `limited` KEYED BY client_key, user_name FOR INTERVAL 3600 SECOND MAX queries = 100, read_bytes = 1000000, "+
+			"FOR RANDOMIZED INTERVAL 86400 SECOND TRACKING ONLY TO `bob`",
+		createQuotaStatement(quota, false),
+	)
+
+	quota.KeyedBy = ""
+	quota.Intervals = quota.Intervals[:1]
+	quota.Users = nil
+	assert.Equal(t,
+		"CREATE QUOTA OR REPLACE `limited` NOT KEYED FOR INTERVAL 3600 SECOND MAX queries = 100, read_bytes = 1000000",
+		createQuotaStatement(quota, true),
+	)
+}
+
+func TestQuotaIntervalsFromAPIResponse(t *testing.T) {
+	meta := []aiven.ClickhouseQueryColumnMeta{
+		{Name: "quota_name", Type: "String"},
+		{Name: "duration", Type: "UInt32"},
+		{Name: "is_randomized_interval", Type: "UInt8"},
+	}
+	for _, l := range quotaLimits {
+		meta = append(meta, aiven.ClickhouseQueryColumnMeta{Name: "max_" + l, Type: "Nullable(UInt64)"})
+	}
+	row := func(duration json.Number, randomized float64, queries, readBytes interface{}) []interface{} {
+		// queries and read_bytes are the first and the eighth limits
+		return []interface{}{"limited", duration, randomized, queries, nil, nil, nil, nil, nil, nil, readBytes, nil}
+	}
+	r := &aiven.ClickhouseQueryResponse{
+		Meta: meta,
+		Data: []interface{}{
+			row("86400", 1, nil, nil),
+			row("3600", 0, "100", "1000000"),
+		},
+	}
+
+	intervals, err := quotaIntervalsFromAPIResponse(r)
+	require.NoError(t, err)
+	assert.Equal(t, []QuotaInterval{
+		{Duration: 3600, Limits: map[string]int{"queries": 100, "read_bytes": 1000000}},
+		{Duration: 86400, Randomized: true, Limits: map[string]int{}},
+	}, intervals)
+
+	r.Data = append(r.Data, []interface{}{"limited"})
+	_, err = quotaIntervalsFromAPIResponse(r)
+	assert.EqualError(t, err, "query response row 2 doesn't match the metadata")
+}
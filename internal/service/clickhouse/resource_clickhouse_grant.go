@@ -12,8 +12,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
-// TODO: set 'ForceNew' for now so we recreate the whole thing to manage updates, not great but at least converges
-
 var aivenClickhouseGrantSchema = map[string]*schema.Schema{
 	"project":      schemautil.CommonSchemaProjectReference,
 	"service_name": schemautil.CommonSchemaServiceNameReference,
@@ -33,59 +31,51 @@ var aivenClickhouseGrantSchema = map[string]*schema.Schema{
 		ConflictsWith: []string{"user"},
 	},
 	"privilege_grant": {
-		Description: schemautil.Complex("Configuration to grant a privilege.").ForceNew().Build(),
+		Description: "Configuration to grant a privilege.",
 		Type:        schema.TypeSet,
 		Optional:    true,
-		ForceNew:    true,
 		Elem: &schema.Resource{
 			Schema: map[string]*schema.Schema{
 				"privilege": {
-					Description:  schemautil.Complex("The privilege to grant, i.e. 'INSERT', 'SELECT', etc.").ForceNew().Build(),
+					Description:  "The privilege to grant, i.e. 'INSERT', 'SELECT', etc.",
 					Type:         schema.TypeString,
 					Optional:     true,
-					ForceNew:     true,
 					ValidateFunc: validation.StringMatch(regexp.MustCompile("^[A-Z ]+$"), "Must be a phrase of words that contain only uppercase letters."),
 				},
 				"database": {
-					Description: schemautil.Complex("The database that the grant refers to.").Referenced().ForceNew().Build(),
+					Description: schemautil.Complex("The database that the grant refers to.").Referenced().Build(),
 					Type:        schema.TypeString,
 					Required:    true,
-					ForceNew:    true,
 				},
 				"table": {
-					Description: schemautil.Complex("The table that the grant refers to.").ForceNew().Build(),
+					Description: "The table that the grant refers to.",
 					Type:        schema.TypeString,
 					Optional:    true,
-					ForceNew:    true,
 				},
 				"column": {
-					Description: schemautil.Complex("The column that the grant refers to.").ForceNew().Build(),
+					Description: "The column that the grant refers to.",
 					Type:        schema.TypeString,
 					Optional:    true,
-					ForceNew:    true,
 				},
 				"with_grant": {
-					Description: schemautil.Complex("If true then the grantee gets the ability to grant the privileges he received too").ForceNew().Build(),
+					Description: "If true then the grantee gets the ability to grant the privileges he received too",
 					Type:        schema.TypeBool,
 					Optional:    true,
-					ForceNew:    true,
 					Default:     false,
 				},
 			},
 		},
 	},
 	"role_grant": {
-		Description: schemautil.Complex("Configuration to grant a role.").ForceNew().Build(),
+		Description: "Configuration to grant a role.",
 		Type:        schema.TypeSet,
 		Optional:    true,
-		ForceNew:    true,
 		Elem: &schema.Resource{
 			Schema: map[string]*schema.Schema{
 				"role": {
-					Description: schemautil.Complex("The role that is to be granted.").Referenced().ForceNew().Build(),
+					Description: schemautil.Complex("The role that is to be granted.").Referenced().Build(),
 					Type:        schema.TypeString,
 					Optional:    true,
-					ForceNew:    true,
 				},
 			},
 		},
@@ -98,11 +88,13 @@ func ResourceClickhouseGrant() *schema.Resource {
 
 Notes:
 * Due to a ambiguity in the GRANT syntax in clickhouse you should not have users and roles with the same name. It is not clear if a grant refers to the user or the role.
-* Currently changes will first revoke all grants and then reissue the remaining grants for convergence.
+* The grants are compared with the effective grants of ClickHouse, which expands the groups of privileges like ALL to the privileges they contain, so that these expansions are not seen as changes.
+* Changes only grant and revoke the difference between the old and the new grants.
 `,
 		DeprecationMessage: betaDeprecationMessage,
 		CreateContext:      resourceClickhouseGrantCreate,
 		ReadContext:        resourceClickhouseGrantRead,
+		UpdateContext:      resourceClickhouseGrantUpdate,
 		DeleteContext:      resourceClickhouseGrantDelete,
 		Schema:             aivenClickhouseGrantSchema,
 	}
@@ -175,10 +167,12 @@ func resourceClickhouseGrantRead(_ context.Context, d *schema.ResourceData, m in
 
 	grantee := Grantee{User: d.Get("user").(string), Role: d.Get("role").(string)}
 
-	privilegeGrants, err := ReadPrivilegeGrants(client, projectName, serviceName, grantee)
+	privilegeGrants, partialRevokes, err := readPrivilegeGrantsAndPartialRevokes(client, projectName, serviceName, grantee)
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	// the grants of the state are kept as they are when ClickHouse shows them differently but they are in effect
+	privilegeGrants = effectivePrivilegeGrants(readPrivilegeGrantsFromSchema(d), privilegeGrants, partialRevokes)
 	if err = setPrivilegeGrantsInSchema(d, privilegeGrants); err != nil {
 		return diag.FromErr(err)
 	}
@@ -193,6 +187,32 @@ func resourceClickhouseGrantRead(_ context.Context, d *schema.ResourceData, m in
 	return nil
 }
 
+func resourceClickhouseGrantUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+	grantee := Grantee{User: d.Get("user").(string), Role: d.Get("role").(string)}
+
+	oldPrivilegeGrants, newPrivilegeGrants := d.GetChange("privilege_grant")
+	oldRoleGrants, newRoleGrants := d.GetChange("role_grant")
+
+	statements := privilegeGrantsDelta(
+		privilegeGrantsFromSchema(grantee, oldPrivilegeGrants.(*schema.Set)),
+		privilegeGrantsFromSchema(grantee, newPrivilegeGrants.(*schema.Set)),
+	)
+	statements = append(statements, roleGrantsDelta(
+		roleGrantsFromSchema(grantee, oldRoleGrants.(*schema.Set)),
+		roleGrantsFromSchema(grantee, newRoleGrants.(*schema.Set)),
+	)...)
+
+	if err := executeStatements(client, projectName, serviceName, statements); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceClickhouseGrantRead(ctx, d, m)
+}
+
 func resourceClickhouseGrantDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*aiven.Client)
 
@@ -214,17 +234,19 @@ func resourceClickhouseGrantDelete(_ context.Context, d *schema.ResourceData, m
 	return nil
 }
 
-func readPrivilegeGrantsFromSchema(d *schema.ResourceData) (grants []PrivilegeGrant) {
+func readPrivilegeGrantsFromSchema(d *schema.ResourceData) []PrivilegeGrant {
+	grantee := Grantee{User: d.Get("user").(string), Role: d.Get("role").(string)}
+	return privilegeGrantsFromSchema(grantee, d.Get("privilege_grant").(*schema.Set))
+}
+
+func privilegeGrantsFromSchema(grantee Grantee, set *schema.Set) (grants []PrivilegeGrant) {
 	grants = make([]PrivilegeGrant, 0)
 
-	for _, grant := range d.Get("privilege_grant").(*schema.Set).List() {
+	for _, grant := range set.List() {
 		grantVal := grant.(map[string]interface{})
 
 		grants = append(grants, PrivilegeGrant{
-			Grantee: Grantee{
-				User: d.Get("user").(string),
-				Role: d.Get("role").(string),
-			},
+			Grantee:   grantee,
 			Database:  grantVal["database"].(string),
 			Table:     grantVal["table"].(string),
 			Column:    grantVal["column"].(string),
@@ -256,18 +278,20 @@ func privilegeGrantToSchema(grant PrivilegeGrant) map[string]interface{} {
 	}
 }
 
-func readRoleGrantsFromSchema(d *schema.ResourceData) (grants []RoleGrant) {
+func readRoleGrantsFromSchema(d *schema.ResourceData) []RoleGrant {
+	grantee := Grantee{User: d.Get("user").(string), Role: d.Get("role").(string)}
+	return roleGrantsFromSchema(grantee, d.Get("role_grant").(*schema.Set))
+}
+
+func roleGrantsFromSchema(grantee Grantee, set *schema.Set) (grants []RoleGrant) {
 	grants = make([]RoleGrant, 0)
 
-	for _, grant := range d.Get("role_grant").(*schema.Set).List() {
+	for _, grant := range set.List() {
 		grantVal := grant.(map[string]interface{})
 
 		grants = append(grants, RoleGrant{
-			Grantee: Grantee{
-				User: d.Get("user").(string),
-				Role: d.Get("role").(string),
-			},
-			Role: grantVal["role"].(string),
+			Grantee: grantee,
+			Role:    grantVal["role"].(string),
 		})
 	}
 	return grants
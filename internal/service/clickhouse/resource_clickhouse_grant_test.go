@@ -20,7 +20,8 @@ func TestAccAivenClickhouseGrant(t *testing.T) {
 	serviceName := fmt.Sprintf("test-acc-ch-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
 	projectName := os.Getenv("AIVEN_PROJECT_NAME")
 
-	manifest := fmt.Sprintf(`
+	manifest := func(extraPrivilegeGrants string) string {
+		return fmt.Sprintf(`
 resource "aiven_clickhouse" "bar" {
   project                 = "%s"
   cloud_name              = "google-europe-west1"
@@ -53,6 +54,7 @@ resource "aiven_clickhouse_grant" "foo-role-grant" {
     table     = "test-table"
     column    = "test-column"
   }
+%s
 }
 
 resource "aiven_clickhouse_user" "foo-user" {
@@ -69,7 +71,8 @@ resource "aiven_clickhouse_grant" "foo-user-grant" {
   role_grant {
     role = aiven_clickhouse_role.foo-role.role
   }
-}`, projectName, serviceName)
+}`, projectName, serviceName, extraPrivilegeGrants)
+	}
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:          func() { acc.TestAccPreCheck(t) },
@@ -77,7 +80,7 @@ resource "aiven_clickhouse_grant" "foo-user-grant" {
 		CheckDestroy:      testAccCheckAivenClickhouseGrantResourceDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: manifest,
+				Config: manifest(""),
 				Check: resource.ComposeTestCheckFunc(
 					// privilege grant checks
 					resource.TestCheckResourceAttr("aiven_clickhouse_grant.foo-role-grant", "privilege_grant.0.privilege", "INSERT"),
@@ -89,6 +92,23 @@ resource "aiven_clickhouse_grant" "foo-user-grant" {
 					resource.TestCheckResourceAttr("aiven_clickhouse_grant.foo-user-grant", "role_grant.0.role", "foo-role"),
 				),
 			},
+			{
+				// ALL is expanded by ClickHouse, it is still in effect and it is granted in place
+				Config: manifest(`
+  privilege_grant {
+    privilege  = "ALL"
+    database   = aiven_clickhouse_database.testdb.name
+    with_grant = true
+  }`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aiven_clickhouse_grant.foo-role-grant", "privilege_grant.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("aiven_clickhouse_grant.foo-role-grant", "privilege_grant.*", map[string]string{
+						"privilege":  "ALL",
+						"database":   "test-db",
+						"with_grant": "true",
+					}),
+				),
+			},
 		},
 	})
 }
@@ -0,0 +1,156 @@
+package clickhouse
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var aivenClickhouseMaterializedViewSchema = map[string]*schema.Schema{
+	"project":      schemautil.CommonSchemaProjectReference,
+	"service_name": schemautil.CommonSchemaServiceNameReference,
+	"database": {
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: schemautil.Complex("The database of the view.").Referenced().ForceNew().Build(),
+	},
+	"name": {
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: schemautil.Complex("The name of the view.").ForceNew().Build(),
+	},
+	"query": {
+		Type:             schema.TypeString,
+		Required:         true,
+		ForceNew:         true,
+		DiffSuppressFunc: expressionDiffSuppressFunc,
+		Description:      schemautil.Complex("The SELECT query of the view.").ForceNew().Build(),
+	},
+	"to_table": {
+		Type:          schema.TypeString,
+		Optional:      true,
+		ForceNew:      true,
+		ConflictsWith: []string{"engine", "order_by", "populate"},
+		ExactlyOneOf:  []string{"to_table", "engine"},
+		Description:   schemautil.Complex("The table of the same database the view writes to.").Referenced().ForceNew().Build(),
+	},
+	"engine": {
+		Type:     schema.TypeString,
+		Optional: true,
+		ForceNew: true,
+		ValidateFunc: validation.StringMatch(
+			regexp.MustCompile(`^[A-Za-z]+(\(.*\))?$`), "must be the name of a table engine with its optional parameters",
+		),
+		Description: schemautil.Complex("The engine of the inner table of the view when it doesn't write to `to_table`.").ForceNew().Build(),
+	},
+	"order_by": {
+		Type:             schema.TypeString,
+		Optional:         true,
+		ForceNew:         true,
+		DiffSuppressFunc: expressionDiffSuppressFunc,
+		Description:      schemautil.Complex("The ORDER BY expression of the inner table of the view.").ForceNew().Build(),
+	},
+	"populate": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		ForceNew:    true,
+		Default:     false,
+		Description: schemautil.Complex("Fill the inner table of the view with the existing data when it is created.").DefaultValue(false).ForceNew().Build(),
+	},
+}
+
+func ResourceClickhouseMaterializedView() *schema.Resource {
+	return &schema.Resource{
+		Description:        "The Clickhouse Materialized View resource allows the creation and management of materialized views in Aiven Clickhouse services.",
+		DeprecationMessage: betaDeprecationMessage,
+		CreateContext:      resourceClickhouseMaterializedViewCreate,
+		ReadContext:        resourceClickhouseMaterializedViewRead,
+		DeleteContext:      resourceClickhouseMaterializedViewDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: aivenClickhouseMaterializedViewSchema,
+	}
+}
+
+func resourceClickhouseMaterializedViewCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+	view := MaterializedView{
+		Database: d.Get("database").(string),
+		Name:     d.Get("name").(string),
+		ToTable:  d.Get("to_table").(string),
+		Engine:   d.Get("engine").(string),
+		OrderBy:  d.Get("order_by").(string),
+		Populate: d.Get("populate").(bool),
+		Query:    d.Get("query").(string),
+	}
+
+	if err := CreateMaterializedView(client, projectName, serviceName, view); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(schemautil.BuildResourceID(projectName, serviceName, view.Database, view.Name))
+
+	return resourceClickhouseMaterializedViewRead(ctx, d, m)
+}
+
+func resourceClickhouseMaterializedViewRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName, serviceName, database, name, err := schemautil.SplitResourceID4(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	view, err := ReadMaterializedView(client, projectName, serviceName, database, name)
+	if err != nil {
+		return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
+	}
+
+	if err := d.Set("project", projectName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("service_name", serviceName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("database", database); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("name", name); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("to_table", view.ToTable); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("query", view.Query); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceClickhouseMaterializedViewDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName, serviceName, database, name, err := schemautil.SplitResourceID4(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := DropTable(client, projectName, serviceName, database, name); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
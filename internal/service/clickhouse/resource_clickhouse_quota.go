@@ -0,0 +1,209 @@
+package clickhouse
+
+import (
+	"context"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var aivenClickhouseQuotaSchema = map[string]*schema.Schema{
+	"project":      schemautil.CommonSchemaProjectReference,
+	"service_name": schemautil.CommonSchemaServiceNameReference,
+	"name": {
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: schemautil.Complex("The name of the quota.").ForceNew().Build(),
+	},
+	"keyed_by": {
+		Type:     schema.TypeString,
+		Optional: true,
+		ValidateFunc: validation.StringInSlice([]string{
+			"user_name", "ip_address", "forwarded_ip_address", "client_key", "client_key,user_name", "client_key,ip_address",
+		}, false),
+		Description: "How the quota is tracked, e.g. `user_name` to track each user separately. The quota is shared by everyone it is assigned to when it is not set.",
+	},
+	"interval": {
+		Type:        schema.TypeSet,
+		Required:    true,
+		MinItems:    1,
+		Description: "The intervals the usage is limited over.",
+		Elem: &schema.Resource{
+			Schema: quotaIntervalSchema(),
+		},
+	},
+	"users": assigneesSchema("users", "quota"),
+	"roles": assigneesSchema("roles", "quota"),
+}
+
+// quotaIntervalSchema returns the schema of an interval with a max_ field for each limited resource
+func quotaIntervalSchema() map[string]*schema.Schema {
+	s := map[string]*schema.Schema{
+		"duration": {
+			Type:         schema.TypeInt,
+			Required:     true,
+			ValidateFunc: validation.IntAtLeast(1),
+			Description:  "The length of the interval in seconds",
+		},
+		"randomized": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "If true then the start of the interval is randomized so that the intervals of different quotas don't end at the same time",
+		},
+	}
+	for _, l := range quotaLimits {
+		s["max_"+l] = &schema.Schema{
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(0),
+			Description:  "The maximum of `" + l + "` in the interval, it is only tracked when it is not set",
+		}
+	}
+	return s
+}
+
+func ResourceClickhouseQuota() *schema.Resource {
+	return &schema.Resource{
+		Description:        "The Clickhouse Quota resource allows the creation and management of quotas in Aiven Clickhouse services.",
+		DeprecationMessage: betaDeprecationMessage,
+		CreateContext:      resourceClickhouseQuotaCreate,
+		ReadContext:        resourceClickhouseQuotaRead,
+		UpdateContext:      resourceClickhouseQuotaUpdate,
+		DeleteContext:      resourceClickhouseQuotaDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: aivenClickhouseQuotaSchema,
+	}
+}
+
+func resourceClickhouseQuotaCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+	quota := quotaFromSchema(d)
+
+	if err := CreateQuota(client, projectName, serviceName, quota); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(schemautil.BuildResourceID(projectName, serviceName, quota.Name))
+
+	return resourceClickhouseQuotaRead(ctx, d, m)
+}
+
+func resourceClickhouseQuotaRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName, serviceName, name, err := schemautil.SplitResourceID3(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	quota, err := ReadQuota(client, projectName, serviceName, name)
+	if err != nil {
+		return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
+	}
+
+	if err := d.Set("project", projectName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("service_name", serviceName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("name", name); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("keyed_by", quota.KeyedBy); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("interval", quotaIntervalsToSchema(quota.Intervals)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("users", quota.Users); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("roles", quota.Roles); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceClickhouseQuotaUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName, serviceName, _, err := schemautil.SplitResourceID3(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := ReplaceQuota(client, projectName, serviceName, quotaFromSchema(d)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceClickhouseQuotaRead(ctx, d, m)
+}
+
+func resourceClickhouseQuotaDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName, serviceName, name, err := schemautil.SplitResourceID3(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := DropQuota(client, projectName, serviceName, name); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func quotaFromSchema(d *schema.ResourceData) Quota {
+	quota := Quota{
+		Name:      d.Get("name").(string),
+		KeyedBy:   d.Get("keyed_by").(string),
+		Intervals: make([]QuotaInterval, 0),
+		Users:     stringsFromSet(d.Get("users")),
+		Roles:     stringsFromSet(d.Get("roles")),
+	}
+	for _, i := range d.Get("interval").(*schema.Set).List() {
+		i := i.(map[string]interface{})
+		interval := QuotaInterval{
+			Duration:   i["duration"].(int),
+			Randomized: i["randomized"].(bool),
+			Limits:     make(map[string]int),
+		}
+		// an unset limit is read as 0, the resource is then only tracked
+		for _, l := range quotaLimits {
+			if v := i["max_"+l].(int); v > 0 {
+				interval.Limits[l] = v
+			}
+		}
+		quota.Intervals = append(quota.Intervals, interval)
+	}
+	return quota
+}
+
+func quotaIntervalsToSchema(intervals []QuotaInterval) []map[string]interface{} {
+	res := make([]map[string]interface{}, 0, len(intervals))
+	for _, i := range intervals {
+		interval := map[string]interface{}{
+			"duration":   i.Duration,
+			"randomized": i.Randomized,
+		}
+		for _, l := range quotaLimits {
+			interval["max_"+l] = i.Limits[l]
+		}
+		res = append(res, interval)
+	}
+	return res
+}
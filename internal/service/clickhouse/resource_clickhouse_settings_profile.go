@@ -0,0 +1,223 @@
+package clickhouse
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var aivenClickhouseSettingsProfileSchema = map[string]*schema.Schema{
+	"project":      schemautil.CommonSchemaProjectReference,
+	"service_name": schemautil.CommonSchemaServiceNameReference,
+	"name": {
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: schemautil.Complex("The name of the settings profile.").ForceNew().Build(),
+	},
+	"inherit_profiles": {
+		Type:        schema.TypeList,
+		Optional:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Description: "The settings profiles the profile inherits from, in order of precedence.",
+	},
+	"setting": {
+		Type:        schema.TypeSet,
+		Optional:    true,
+		Description: "The settings of the profile.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringMatch(regexp.MustCompile("^[a-z0-9_]+$"), "must be a setting name"),
+					Description:  "The name of the setting, e.g. `max_memory_usage`",
+				},
+				"value": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "The value of the setting",
+				},
+				"min": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "The minimum value users can change the setting to",
+				},
+				"max": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "The maximum value users can change the setting to",
+				},
+				"readonly": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+					Description: "If true then users can't change the setting",
+				},
+			},
+		},
+	},
+	"users": assigneesSchema("users", "settings profile"),
+	"roles": assigneesSchema("roles", "settings profile"),
+}
+
+func ResourceClickhouseSettingsProfile() *schema.Resource {
+	return &schema.Resource{
+		Description:        "The Clickhouse Settings Profile resource allows the creation and management of settings profiles in Aiven Clickhouse services.",
+		DeprecationMessage: betaDeprecationMessage,
+		CreateContext:      resourceClickhouseSettingsProfileCreate,
+		ReadContext:        resourceClickhouseSettingsProfileRead,
+		UpdateContext:      resourceClickhouseSettingsProfileUpdate,
+		DeleteContext:      resourceClickhouseSettingsProfileDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: aivenClickhouseSettingsProfileSchema,
+	}
+}
+
+// assigneesSchema is the schema of the users or the roles a settings profile or a quota is assigned to
+func assigneesSchema(kind, entity string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeSet,
+		Optional:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Description: "The " + kind + " the " + entity + " is assigned to.",
+	}
+}
+
+func resourceClickhouseSettingsProfileCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+	profile := settingsProfileFromSchema(d)
+
+	if err := CreateSettingsProfile(client, projectName, serviceName, profile); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(schemautil.BuildResourceID(projectName, serviceName, profile.Name))
+
+	return resourceClickhouseSettingsProfileRead(ctx, d, m)
+}
+
+func resourceClickhouseSettingsProfileRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName, serviceName, name, err := schemautil.SplitResourceID3(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	profile, err := ReadSettingsProfile(client, projectName, serviceName, name)
+	if err != nil {
+		return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
+	}
+
+	if err := d.Set("project", projectName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("service_name", serviceName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("name", name); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("inherit_profiles", profile.Inherit); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("setting", settingsProfileSettingsToSchema(profile.Settings)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("users", profile.Users); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("roles", profile.Roles); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceClickhouseSettingsProfileUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName, serviceName, _, err := schemautil.SplitResourceID3(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := AlterSettingsProfile(client, projectName, serviceName, settingsProfileFromSchema(d)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceClickhouseSettingsProfileRead(ctx, d, m)
+}
+
+func resourceClickhouseSettingsProfileDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName, serviceName, name, err := schemautil.SplitResourceID3(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := DropSettingsProfile(client, projectName, serviceName, name); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func settingsProfileFromSchema(d *schema.ResourceData) SettingsProfile {
+	profile := SettingsProfile{
+		Name:     d.Get("name").(string),
+		Inherit:  make([]string, 0),
+		Settings: make([]SettingsProfileSetting, 0),
+		Users:    stringsFromSet(d.Get("users")),
+		Roles:    stringsFromSet(d.Get("roles")),
+	}
+	for _, p := range d.Get("inherit_profiles").([]interface{}) {
+		profile.Inherit = append(profile.Inherit, p.(string))
+	}
+	for _, s := range d.Get("setting").(*schema.Set).List() {
+		s := s.(map[string]interface{})
+		profile.Settings = append(profile.Settings, SettingsProfileSetting{
+			Name:     s["name"].(string),
+			Value:    s["value"].(string),
+			Min:      s["min"].(string),
+			Max:      s["max"].(string),
+			Readonly: s["readonly"].(bool),
+		})
+	}
+	return profile
+}
+
+func settingsProfileSettingsToSchema(settings []SettingsProfileSetting) []map[string]interface{} {
+	res := make([]map[string]interface{}, 0, len(settings))
+	for _, s := range settings {
+		res = append(res, map[string]interface{}{
+			"name":     s.Name,
+			"value":    s.Value,
+			"min":      s.Min,
+			"max":      s.Max,
+			"readonly": s.Readonly,
+		})
+	}
+	return res
+}
+
+func stringsFromSet(v interface{}) []string {
+	res := make([]string, 0)
+	for _, s := range v.(*schema.Set).List() {
+		res = append(res, s.(string))
+	}
+	return res
+}
@@ -0,0 +1,160 @@
+package clickhouse_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	acc "github.com/aiven/terraform-provider-aiven/internal/acctest"
+	"github.com/aiven/terraform-provider-aiven/internal/service/clickhouse"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccAivenClickhouseSettingsProfileAndQuota(t *testing.T) {
+	serviceName := fmt.Sprintf("test-acc-ch-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	projectName := os.Getenv("AIVEN_PROJECT_NAME")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acc.TestAccPreCheck(t) },
+		ProviderFactories: acc.TestAccProviderFactories,
+		CheckDestroy:      testAccCheckAivenClickhouseSettingsProfileAndQuotaResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccClickhouseSettingsProfileResource(projectName, serviceName, "10000000000", 100),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aiven_clickhouse_settings_profile.analysts", "setting.#", "2"),
+					resource.TestCheckResourceAttr("aiven_clickhouse_settings_profile.analysts", "roles.#", "1"),
+					resource.TestCheckResourceAttr("aiven_clickhouse_settings_profile.analysts", "users.#", "0"),
+					resource.TestCheckResourceAttr("aiven_clickhouse_quota.analysts", "keyed_by", "user_name"),
+					resource.TestCheckResourceAttr("aiven_clickhouse_quota.analysts", "interval.#", "2"),
+					resource.TestCheckResourceAttr("aiven_clickhouse_quota.analysts", "users.#", "1"),
+				),
+			},
+			{
+				// the profile and the quota are changed in place
+				Config: testAccClickhouseSettingsProfileResource(projectName, serviceName, "20000000000", 200),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs("aiven_clickhouse_settings_profile.analysts", "setting.*", map[string]string{
+						"name":  "max_memory_usage",
+						"value": "20000000000",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("aiven_clickhouse_quota.analysts", "interval.*", map[string]string{
+						"duration":    "3600",
+						"max_queries": "200",
+					}),
+				),
+			},
+			{
+				ResourceName:      "aiven_clickhouse_settings_profile.analysts",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "aiven_clickhouse_quota.analysts",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccClickhouseSettingsProfileResource(projectName, serviceName, maxMemoryUsage string, maxQueries int) string {
+	return fmt.Sprintf(`
+resource "aiven_clickhouse" "bar" {
+  project                 = "%s"
+  cloud_name              = "google-europe-west1"
+  plan                    = "startup-beta-8"
+  service_name            = "%s"
+  maintenance_window_dow  = "monday"
+  maintenance_window_time = "10:00:00"
+}
+
+resource "aiven_clickhouse_role" "analyst" {
+  project      = aiven_clickhouse.bar.project
+  service_name = aiven_clickhouse.bar.service_name
+  role         = "analyst"
+}
+
+resource "aiven_clickhouse_user" "bob" {
+  project      = aiven_clickhouse.bar.project
+  service_name = aiven_clickhouse.bar.service_name
+  username     = "bob"
+}
+
+resource "aiven_clickhouse_settings_profile" "analysts" {
+  project      = aiven_clickhouse.bar.project
+  service_name = aiven_clickhouse.bar.service_name
+  name         = "analysts"
+
+  setting {
+    name  = "max_memory_usage"
+    value = "%s"
+  }
+  setting {
+    name     = "load_balancing"
+    value    = "random"
+    readonly = true
+  }
+
+  roles = [aiven_clickhouse_role.analyst.role]
+}
+
+resource "aiven_clickhouse_quota" "analysts" {
+  project      = aiven_clickhouse.bar.project
+  service_name = aiven_clickhouse.bar.service_name
+  name         = "analysts"
+  keyed_by     = "user_name"
+
+  interval {
+    duration    = 3600
+    max_queries = %d
+  }
+  interval {
+    duration   = 86400
+    randomized = true
+  }
+
+  users = [aiven_clickhouse_user.bob.username]
+}`, projectName, serviceName, maxMemoryUsage, maxQueries)
+}
+
+func testAccCheckAivenClickhouseSettingsProfileAndQuotaResourceDestroy(s *terraform.State) error {
+	c := acc.TestAccProvider.Meta().(*aiven.Client)
+
+	// loop through the resources in state, verifying each settings profile and quota is dropped
+	for _, rs := range s.RootModule().Resources {
+		var read func(*aiven.Client, string, string, string) error
+		switch rs.Type {
+		case "aiven_clickhouse_settings_profile":
+			read = func(c *aiven.Client, projectName, serviceName, name string) error {
+				_, err := clickhouse.ReadSettingsProfile(c, projectName, serviceName, name)
+				return err
+			}
+		case "aiven_clickhouse_quota":
+			read = func(c *aiven.Client, projectName, serviceName, name string) error {
+				_, err := clickhouse.ReadQuota(c, projectName, serviceName, name)
+				return err
+			}
+		default:
+			continue
+		}
+
+		projectName, serviceName, name, err := schemautil.SplitResourceID3(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		if err := read(c, projectName, serviceName, name); err == nil {
+			return fmt.Errorf("%s (%s) still exists", rs.Type, rs.Primary.ID)
+		} else if !aiven.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,303 @@
+package clickhouse
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var aivenClickhouseTableSchema = map[string]*schema.Schema{
+	"project":      schemautil.CommonSchemaProjectReference,
+	"service_name": schemautil.CommonSchemaServiceNameReference,
+	"database": {
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: schemautil.Complex("The database of the table.").Referenced().ForceNew().Build(),
+	},
+	"name": {
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: schemautil.Complex("The name of the table.").ForceNew().Build(),
+	},
+	"column": {
+		Type:        schema.TypeList,
+		Required:    true,
+		MinItems:    1,
+		Description: "The columns of the table. The changes are made in place with ALTER TABLE: the removed columns are dropped with their data, a renamed column is dropped and added again and ClickHouse refuses some changes, e.g. of the type of a column of the sorting key.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "The name of the column",
+				},
+				"type": {
+					Type:             schema.TypeString,
+					Required:         true,
+					DiffSuppressFunc: expressionDiffSuppressFunc,
+					Description:      "The type of the column with the canonical name ClickHouse uses, e.g. `UInt64` or `LowCardinality(String)`",
+				},
+				"default_expression": {
+					Type:             schema.TypeString,
+					Optional:         true,
+					DiffSuppressFunc: expressionDiffSuppressFunc,
+					Description:      "The DEFAULT expression of the column, e.g. `now()`",
+				},
+				"comment": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "The comment of the column",
+				},
+			},
+		},
+	},
+	"engine": {
+		Type:     schema.TypeString,
+		Required: true,
+		ForceNew: true,
+		ValidateFunc: validation.StringMatch(
+			regexp.MustCompile(`^[A-Za-z]+(\(.*\))?$`), "must be the name of a table engine with its optional parameters",
+		),
+		Description: schemautil.Complex("The table engine with its parameters, e.g. `MergeTree` or `ReplacingMergeTree(version)`. The MergeTree engines are replicated by the service.").ForceNew().Build(),
+	},
+	"order_by": {
+		Type:             schema.TypeString,
+		Optional:         true,
+		ForceNew:         true,
+		DiffSuppressFunc: expressionDiffSuppressFunc,
+		Description:      schemautil.Complex("The ORDER BY expression of the table, e.g. `(id, timestamp)`.").ForceNew().Build(),
+	},
+	"partition_by": {
+		Type:             schema.TypeString,
+		Optional:         true,
+		ForceNew:         true,
+		DiffSuppressFunc: expressionDiffSuppressFunc,
+		Description:      schemautil.Complex("The PARTITION BY expression of the table, e.g. `toYYYYMM(timestamp)`.").ForceNew().Build(),
+	},
+	"ttl": {
+		Type:             schema.TypeString,
+		Optional:         true,
+		DiffSuppressFunc: expressionDiffSuppressFunc,
+		Description:      "The TTL expression of the table, e.g. `timestamp + INTERVAL 30 DAY`. ClickHouse rewrites the expression, e.g. `INTERVAL 30 DAY` becomes `toIntervalDay(30)`, the configured one is kept while it is equivalent.",
+	},
+	"settings": {
+		Type:             schema.TypeMap,
+		Optional:         true,
+		Elem:             &schema.Schema{Type: schema.TypeString},
+		ValidateDiagFunc: validation.MapKeyMatch(regexp.MustCompile("^[a-z0-9_]+$"), "must be a setting name"),
+		Description:      "The settings of the table, e.g. `index_granularity = 8192`. Only the configured settings are tracked.",
+	},
+	"termination_protection": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: schemautil.Complex(`It is a Terraform client-side deletion protection, which prevents the table from being dropped by Terraform, including when a change recreates it. It doesn't prevent dropping columns.`).DefaultValue(false).Build(),
+	},
+}
+
+func ResourceClickhouseTable() *schema.Resource {
+	return &schema.Resource{
+		Description:        "The Clickhouse Table resource allows the creation and management of tables in Aiven Clickhouse services.",
+		DeprecationMessage: betaDeprecationMessage,
+		CreateContext:      resourceClickhouseTableCreate,
+		ReadContext:        resourceClickhouseTableRead,
+		UpdateContext:      resourceClickhouseTableUpdate,
+		DeleteContext:      resourceClickhouseTableDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: aivenClickhouseTableSchema,
+	}
+}
+
+func expressionDiffSuppressFunc(_, old, new string, _ *schema.ResourceData) bool {
+	return normalizeExpression(old) == normalizeExpression(new)
+}
+
+func resourceClickhouseTableCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+	table := Table{
+		Database:    d.Get("database").(string),
+		Name:        d.Get("name").(string),
+		Engine:      d.Get("engine").(string),
+		OrderBy:     d.Get("order_by").(string),
+		PartitionBy: d.Get("partition_by").(string),
+		TTL:         d.Get("ttl").(string),
+		Settings:    settingsFromSchema(d.Get("settings")),
+		Columns:     columnsFromSchema(d.Get("column")),
+	}
+
+	if err := CreateTable(client, projectName, serviceName, table); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(schemautil.BuildResourceID(projectName, serviceName, table.Database, table.Name))
+
+	return resourceClickhouseTableRead(ctx, d, m)
+}
+
+func resourceClickhouseTableRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName, serviceName, database, name, err := schemautil.SplitResourceID4(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	table, err := ReadTable(client, projectName, serviceName, database, name)
+	if err != nil {
+		return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
+	}
+
+	engine := d.Get("engine").(string)
+	if engineName(engine) != engineName(table.Engine) {
+		engine = table.Engine
+	}
+
+	// the TTL is rewritten by ClickHouse, e.g. INTERVAL 30 DAY becomes toIntervalDay(30)
+	ttl := d.Get("ttl").(string)
+	if normalizeExpression(ttl) != normalizeExpression(table.TTL) {
+		ttl = table.TTL
+	}
+
+	// the settings that are not configured are the defaults of ClickHouse
+	settings := make(map[string]string)
+	for k := range settingsFromSchema(d.Get("settings")) {
+		if v, ok := table.Settings[k]; ok {
+			settings[k] = v
+		}
+	}
+
+	if err := d.Set("project", projectName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("service_name", serviceName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("database", database); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("name", name); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("column", columnsToSchema(table.Columns)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("engine", engine); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("order_by", table.OrderBy); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("partition_by", table.PartitionBy); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("ttl", ttl); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("settings", settings); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceClickhouseTableUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName, serviceName, database, name, err := schemautil.SplitResourceID4(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	oldColumns, newColumns := d.GetChange("column")
+	oldTTL, newTTL := d.GetChange("ttl")
+	oldSettings, newSettings := d.GetChange("settings")
+
+	old := Table{
+		Database: database,
+		Name:     name,
+		TTL:      oldTTL.(string),
+		Settings: settingsFromSchema(oldSettings),
+		Columns:  columnsFromSchema(oldColumns),
+	}
+	new := Table{
+		Database: database,
+		Name:     name,
+		TTL:      newTTL.(string),
+		Settings: settingsFromSchema(newSettings),
+		Columns:  columnsFromSchema(newColumns),
+	}
+
+	if err := AlterTable(client, projectName, serviceName, old, new); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceClickhouseTableRead(ctx, d, m)
+}
+
+func resourceClickhouseTableDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName, serviceName, database, name, err := schemautil.SplitResourceID4(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.Get("termination_protection").(bool) {
+		return diag.Errorf("cannot drop table %s.%s, termination_protection is enabled", database, name)
+	}
+
+	if err := DropTable(client, projectName, serviceName, database, name); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func columnsFromSchema(v interface{}) []Column {
+	columns := make([]Column, 0)
+	for _, c := range v.([]interface{}) {
+		c := c.(map[string]interface{})
+		columns = append(columns, Column{
+			Name:              c["name"].(string),
+			Type:              c["type"].(string),
+			DefaultExpression: c["default_expression"].(string),
+			Comment:           c["comment"].(string),
+		})
+	}
+	return columns
+}
+
+func columnsToSchema(columns []Column) []map[string]interface{} {
+	res := make([]map[string]interface{}, 0, len(columns))
+	for _, c := range columns {
+		res = append(res, map[string]interface{}{
+			"name":               c.Name,
+			"type":               c.Type,
+			"default_expression": c.DefaultExpression,
+			"comment":            c.Comment,
+		})
+	}
+	return res
+}
+
+func settingsFromSchema(v interface{}) map[string]string {
+	settings := make(map[string]string)
+	for k, s := range v.(map[string]interface{}) {
+		settings[k] = fmt.Sprint(s)
+	}
+	return settings
+}
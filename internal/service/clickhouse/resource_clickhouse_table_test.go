@@ -0,0 +1,164 @@
+package clickhouse_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	acc "github.com/aiven/terraform-provider-aiven/internal/acctest"
+	"github.com/aiven/terraform-provider-aiven/internal/service/clickhouse"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccAivenClickhouseTable(t *testing.T) {
+	serviceName := fmt.Sprintf("test-acc-ch-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	projectName := os.Getenv("AIVEN_PROJECT_NAME")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acc.TestAccPreCheck(t) },
+		ProviderFactories: acc.TestAccProviderFactories,
+		CheckDestroy:      testAccCheckAivenClickhouseTableResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccClickhouseTableResource(projectName, serviceName, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aiven_clickhouse_table.events", "column.#", "3"),
+					resource.TestCheckResourceAttr("aiven_clickhouse_table.events", "column.1.default_expression", "now()"),
+					resource.TestCheckResourceAttr("aiven_clickhouse_table.events", "engine", "MergeTree"),
+					resource.TestCheckResourceAttr("aiven_clickhouse_table.events", "settings.index_granularity", "4096"),
+					resource.TestCheckResourceAttr("aiven_clickhouse_materialized_view.daily", "to_table", "events_daily"),
+				),
+			},
+			{
+				// the column changes are done in place
+				Config: testAccClickhouseTableResource(projectName, serviceName, `
+  column {
+    name    = "value"
+    type    = "Float64"
+    comment = "added later"
+  }`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aiven_clickhouse_table.events", "column.#", "4"),
+					resource.TestCheckResourceAttr("aiven_clickhouse_table.events", "column.3.name", "value"),
+					resource.TestCheckResourceAttr("aiven_clickhouse_table.events", "column.3.comment", "added later"),
+				),
+			},
+			{
+				ResourceName:            "aiven_clickhouse_table.events",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"engine", "ttl", "settings", "termination_protection"},
+			},
+		},
+	})
+}
+
+func testAccClickhouseTableResource(projectName, serviceName, extraColumns string) string {
+	return fmt.Sprintf(`
+resource "aiven_clickhouse" "bar" {
+  project                 = "%s"
+  cloud_name              = "google-europe-west1"
+  plan                    = "startup-beta-8"
+  service_name            = "%s"
+  maintenance_window_dow  = "monday"
+  maintenance_window_time = "10:00:00"
+}
+
+resource "aiven_clickhouse_database" "analytics" {
+  project      = aiven_clickhouse.bar.project
+  service_name = aiven_clickhouse.bar.service_name
+  name         = "analytics"
+}
+
+resource "aiven_clickhouse_table" "events" {
+  project      = aiven_clickhouse.bar.project
+  service_name = aiven_clickhouse.bar.service_name
+  database     = aiven_clickhouse_database.analytics.name
+  name         = "events"
+  engine       = "MergeTree"
+  order_by     = "(id, timestamp)"
+  partition_by = "toYYYYMM(timestamp)"
+  ttl          = "timestamp + INTERVAL 30 DAY"
+
+  settings = {
+    index_granularity = 4096
+  }
+
+  column {
+    name = "id"
+    type = "UInt64"
+  }
+  column {
+    name               = "timestamp"
+    type               = "DateTime"
+    default_expression = "now()"
+  }
+  column {
+    name = "kind"
+    type = "LowCardinality(String)"
+  }
+%s
+}
+
+resource "aiven_clickhouse_table" "events_daily" {
+  project      = aiven_clickhouse.bar.project
+  service_name = aiven_clickhouse.bar.service_name
+  database     = aiven_clickhouse_database.analytics.name
+  name         = "events_daily"
+  engine       = "SummingMergeTree"
+  order_by     = "(day, kind)"
+
+  column {
+    name = "day"
+    type = "Date"
+  }
+  column {
+    name = "kind"
+    type = "LowCardinality(String)"
+  }
+  column {
+    name = "events"
+    type = "UInt64"
+  }
+}
+
+resource "aiven_clickhouse_materialized_view" "daily" {
+  project      = aiven_clickhouse.bar.project
+  service_name = aiven_clickhouse.bar.service_name
+  database     = aiven_clickhouse_database.analytics.name
+  name         = "events_daily_mv"
+  to_table     = aiven_clickhouse_table.events_daily.name
+  query        = "SELECT toDate(timestamp) AS day, kind, count() AS events FROM analytics.events GROUP BY day, kind"
+
+  depends_on = [aiven_clickhouse_table.events]
+}`, projectName, serviceName, extraColumns)
+}
+
+func testAccCheckAivenClickhouseTableResourceDestroy(s *terraform.State) error {
+	c := acc.TestAccProvider.Meta().(*aiven.Client)
+
+	// loop through the resources in state, verifying each table and view is dropped
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aiven_clickhouse_table" && rs.Type != "aiven_clickhouse_materialized_view" {
+			continue
+		}
+
+		projectName, serviceName, database, name, err := schemautil.SplitResourceID4(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		if _, err := clickhouse.ReadTable(c, projectName, serviceName, database, name); err == nil {
+			return fmt.Errorf("clickhouse table (%s) still exists", rs.Primary.ID)
+		} else if !aiven.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+package clickhouse
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+)
+
+type SettingsProfileSetting struct {
+	Name     string
+	Value    string
+	Min      string
+	Max      string
+	Readonly bool
+}
+
+type SettingsProfile struct {
+	Name     string
+	Inherit  []string
+	Settings []SettingsProfileSetting
+	Users    []string
+	Roles    []string
+}
+
+func CreateSettingsProfile(client *aiven.Client, projectName, serviceName string, profile SettingsProfile) error {
+	query := createSettingsProfileStatement(profile)
+
+	log.Println("[DEBUG] Clickhouse: create settings profile query: ", query)
+	_, err := client.ClickHouseQuery.Query(projectName, serviceName, defaultDatabase, query)
+	return err
+}
+
+// AlterSettingsProfile replaces the settings and the assignment of the profile, the profile keeps its id
+// so that the users and roles that have it in their own settings keep it
+func AlterSettingsProfile(client *aiven.Client, projectName, serviceName string, profile SettingsProfile) error {
+	query := alterSettingsProfileStatement(profile)
+
+	log.Println("[DEBUG] Clickhouse: alter settings profile query: ", query)
+	_, err := client.ClickHouseQuery.Query(projectName, serviceName, defaultDatabase, query)
+	return err
+}
+
+func ReadSettingsProfile(client *aiven.Client, projectName, serviceName, name string) (*SettingsProfile, error) {
+	query := readSettingsProfileStatement(name)
+
+	log.Println("[DEBUG] Clickhouse: read settings profile query: ", query)
+	r, err := client.ClickHouseQuery.Query(projectName, serviceName, defaultDatabase, query)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := rowsFromAPIResponse(r, "name", "apply_to_list")
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, schemautil.NotFoundError("settings profile", name)
+	}
+	assignees := rows[0].getStrings("apply_to_list")
+	if rows[0].err != nil {
+		return nil, rows[0].err
+	}
+
+	query = readSettingsProfileElementsStatement(name)
+
+	log.Println("[DEBUG] Clickhouse: read settings profile elements query: ", query)
+	r, err = client.ClickHouseQuery.Query(projectName, serviceName, defaultDatabase, query)
+	if err != nil {
+		return nil, err
+	}
+	profile, err := settingsProfileFromAPIResponse(name, r)
+	if err != nil {
+		return nil, err
+	}
+
+	roleNames, err := ReadRoleNames(client, projectName, serviceName)
+	if err != nil {
+		return nil, err
+	}
+	profile.Users, profile.Roles = splitAssignees(assignees, roleNames)
+
+	return profile, nil
+}
+
+func DropSettingsProfile(client *aiven.Client, projectName, serviceName, name string) error {
+	query := dropSettingsProfileStatement(name)
+
+	log.Println("[DEBUG] Clickhouse: drop settings profile query: ", query)
+	_, err := client.ClickHouseQuery.Query(projectName, serviceName, defaultDatabase, query)
+	return err
+}
+
+func createSettingsProfileStatement(profile SettingsProfile) string {
+	b := new(strings.Builder)
+
+	b.WriteString(fmt.Sprintf("CREATE SETTINGS PROFILE %s", escape(profile.Name)))
+	if elements := settingsProfileElements(profile); elements != "" {
+		b.WriteString(" SETTINGS ")
+		b.WriteString(elements)
+	}
+	if len(profile.Users)+len(profile.Roles) > 0 {
+		b.WriteString(" ")
+		b.WriteString(toClause(profile.Users, profile.Roles))
+	}
+
+	return b.String()
+}
+
+func alterSettingsProfileStatement(profile SettingsProfile) string {
+	elements := settingsProfileElements(profile)
+	if elements == "" {
+		elements = "NONE"
+	}
+	return fmt.Sprintf("ALTER SETTINGS PROFILE %s SETTINGS %s %s", escape(profile.Name), elements, toClause(profile.Users, profile.Roles))
+}
+
+// settingsProfileElements returns the inherited profiles followed by the settings
+func settingsProfileElements(profile SettingsProfile) string {
+	elements := make([]string, 0, len(profile.Inherit)+len(profile.Settings))
+	for _, p := range profile.Inherit {
+		elements = append(elements, fmt.Sprintf("INHERIT %s", escape(p)))
+	}
+	for _, s := range profile.Settings {
+		b := new(strings.Builder)
+		b.WriteString(s.Name)
+		if s.Value != "" {
+			b.WriteString(fmt.Sprintf(" = %s", settingValue(s.Value)))
+		}
+		if s.Min != "" {
+			b.WriteString(fmt.Sprintf(" MIN %s", settingValue(s.Min)))
+		}
+		if s.Max != "" {
+			b.WriteString(fmt.Sprintf(" MAX %s", settingValue(s.Max)))
+		}
+		if s.Readonly {
+			b.WriteString(" READONLY")
+		}
+		elements = append(elements, b.String())
+	}
+	return strings.Join(elements, ", ")
+}
+
+func dropSettingsProfileStatement(name string) string {
+	return fmt.Sprintf("DROP SETTINGS PROFILE IF EXISTS %s", escape(name))
+}
+
+func readSettingsProfileStatement(name string) string {
+	return fmt.Sprintf("SELECT name, apply_to_list FROM system.settings_profiles WHERE name = %s", escapeStringLiteral(name))
+}
+
+func readSettingsProfileElementsStatement(name string) string {
+	return fmt.Sprintf("SELECT * FROM system.settings_profile_elements WHERE profile_name = %s ORDER BY index", escapeStringLiteral(name))
+}
+
+func settingsProfileFromAPIResponse(name string, r *aiven.ClickhouseQueryResponse) (*SettingsProfile, error) {
+	rows, err := rowsFromAPIResponse(r, "setting_name", "value", "min", "max", "inherit_profile")
+	if err != nil {
+		return nil, err
+	}
+
+	profile := &SettingsProfile{Name: name, Inherit: make([]string, 0), Settings: make([]SettingsProfileSetting, 0)}
+	for _, row := range rows {
+		if p := row.getString("inherit_profile"); p != "" {
+			profile.Inherit = append(profile.Inherit, p)
+			continue
+		}
+
+		s := SettingsProfileSetting{
+			Name:  row.getString("setting_name"),
+			Value: row.getString("value"),
+			Min:   row.getString("min"),
+			Max:   row.getString("max"),
+		}
+		// the readonly column was replaced by writability in ClickHouse 23
+		if _, ok := row.columns["writability"]; ok {
+			w := row.getString("writability")
+			s.Readonly = w == "CONST" || w == "READONLY"
+		} else {
+			s.Readonly = row.getBoolean("readonly")
+		}
+		if row.err != nil {
+			return nil, row.err
+		}
+		profile.Settings = append(profile.Settings, s)
+	}
+	return profile, nil
+}
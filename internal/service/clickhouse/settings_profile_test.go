@@ -0,0 +1,81 @@
+package clickhouse
+
+import (
+	"testing"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testSettingsProfile() SettingsProfile {
+	return SettingsProfile{
+		Name:    "analysts",
+		Inherit: []string{"default"},
+		Settings: []SettingsProfileSetting{
+			{Name: "max_memory_usage", Value: "10000000000", Max: "20000000000"},
+			{Name: "load_balancing", Value: "random", Readonly: true},
+		},
+		Users: []string{"bob"},
+		Roles: []string{"analyst"},
+	}
+}
+
+func TestCreateSettingsProfileStatement(t *testing.T) {
+	assert.Equal(t,
+		"CREATE SETTINGS PROFILE `analysts` SETTINGS INHERIT `default`, max_memory_usage = 10000000000 MAX 20000000000, "+
+			"load_balancing = 'random' READONLY TO `analyst`, `bob`",
+		createSettingsProfileStatement(testSettingsProfile()),
+	)
+	assert.Equal(t, "CREATE SETTINGS PROFILE `empty`", createSettingsProfileStatement(SettingsProfile{Name: "empty"}))
+}
+
+func TestAlterSettingsProfileStatement(t *testing.T) {
+	profile := testSettingsProfile()
+	profile.Inherit = nil
+	profile.Settings = profile.Settings[:1]
+	assert.Equal(t,
+		"ALTER SETTINGS PROFILE `analysts` SETTINGS max_memory_usage = 10000000000 MAX 20000000000 TO `analyst`, `bob`",
+		alterSettingsProfileStatement(profile),
+	)
+	assert.Equal(t, "ALTER SETTINGS PROFILE `empty` SETTINGS NONE TO NONE", alterSettingsProfileStatement(SettingsProfile{Name: "empty"}))
+}
+
+func TestSettingsProfileFromAPIResponse(t *testing.T) {
+	r := &aiven.ClickhouseQueryResponse{
+		Meta: []aiven.ClickhouseQueryColumnMeta{
+			{Name: "profile_name", Type: "Nullable(String)"},
+			{Name: "index", Type: "UInt64"},
+			{Name: "setting_name", Type: "Nullable(String)"},
+			{Name: "value", Type: "Nullable(String)"},
+			{Name: "min", Type: "Nullable(String)"},
+			{Name: "max", Type: "Nullable(String)"},
+			{Name: "readonly", Type: "Nullable(UInt8)"},
+			{Name: "inherit_profile", Type: "Nullable(String)"},
+		},
+		Data: []interface{}{
+			[]interface{}{"analysts", "0", nil, nil, nil, nil, nil, "default"},
+			[]interface{}{"analysts", "1", "max_memory_usage", "10000000000", nil, "20000000000", nil, nil},
+			[]interface{}{"analysts", "2", "load_balancing", "random", nil, nil, float64(1), nil},
+		},
+	}
+
+	profile, err := settingsProfileFromAPIResponse("analysts", r)
+	require.NoError(t, err)
+	assert.Equal(t, &SettingsProfile{
+		Name:    "analysts",
+		Inherit: []string{"default"},
+		Settings: []SettingsProfileSetting{
+			{Name: "max_memory_usage", Value: "10000000000", Max: "20000000000"},
+			{Name: "load_balancing", Value: "random", Readonly: true},
+		},
+	}, profile)
+
+	// newer versions replaced the readonly column with writability
+	r.Meta[6] = aiven.ClickhouseQueryColumnMeta{Name: "writability", Type: "Nullable(Enum8('WRITABLE' = 0, 'CONST' = 1))"}
+	r.Data[2].([]interface{})[6] = "CONST"
+	profile, err = settingsProfileFromAPIResponse("analysts", r)
+	require.NoError(t, err)
+	assert.True(t, profile.Settings[1].Readonly)
+	assert.False(t, profile.Settings[0].Readonly)
+}
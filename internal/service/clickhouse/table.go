@@ -0,0 +1,641 @@
+package clickhouse
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+)
+
+type Column struct {
+	Name              string
+	Type              string
+	DefaultExpression string
+	Comment           string
+}
+
+type Table struct {
+	Database    string
+	Name        string
+	Engine      string
+	OrderBy     string
+	PartitionBy string
+	TTL         string
+	Settings    map[string]string
+	Columns     []Column
+}
+
+type MaterializedView struct {
+	Database string
+	Name     string
+	ToTable  string
+	Engine   string
+	OrderBy  string
+	Populate bool
+	Query    string
+}
+
+// tableInfo is the row of system.tables of a table or a view
+type tableInfo struct {
+	Engine           string
+	EngineFull       string
+	SortingKey       string
+	PartitionKey     string
+	AsSelect         string
+	CreateTableQuery string
+}
+
+func CreateTable(client *aiven.Client, projectName, serviceName string, table Table) error {
+	query := createTableStatement(table)
+
+	log.Println("[DEBUG] Clickhouse: create table query: ", query)
+	_, err := client.ClickHouseQuery.Query(projectName, serviceName, table.Database, query)
+	return err
+}
+
+// AlterTable applies the changes that ClickHouse can do in place, see alterTableStatements
+func AlterTable(client *aiven.Client, projectName, serviceName string, old, new Table) error {
+	for _, query := range alterTableStatements(old, new) {
+		log.Println("[DEBUG] Clickhouse: alter table query: ", query)
+		if _, err := client.ClickHouseQuery.Query(projectName, serviceName, new.Database, query); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func ReadTable(client *aiven.Client, projectName, serviceName, database, name string) (*Table, error) {
+	info, err := readTableInfo(client, projectName, serviceName, database, name)
+	if err != nil {
+		return nil, err
+	}
+
+	query := readColumnsStatement(database, name)
+	log.Println("[DEBUG] Clickhouse: read columns query: ", query)
+	r, err := client.ClickHouseQuery.Query(projectName, serviceName, defaultDatabase, query)
+	if err != nil {
+		return nil, err
+	}
+	columns, err := columnsFromAPIResponse(r)
+	if err != nil {
+		return nil, err
+	}
+
+	table := &Table{
+		Database:    database,
+		Name:        name,
+		Engine:      info.Engine,
+		OrderBy:     info.SortingKey,
+		PartitionBy: info.PartitionKey,
+		Columns:     columns,
+	}
+	table.TTL, table.Settings = parseEngineFull(info.EngineFull)
+	return table, nil
+}
+
+func DropTable(client *aiven.Client, projectName, serviceName, database, name string) error {
+	query := dropTableStatement(database, name)
+
+	log.Println("[DEBUG] Clickhouse: drop table query: ", query)
+	_, err := client.ClickHouseQuery.Query(projectName, serviceName, database, query)
+	return err
+}
+
+func CreateMaterializedView(client *aiven.Client, projectName, serviceName string, view MaterializedView) error {
+	query := createMaterializedViewStatement(view)
+
+	log.Println("[DEBUG] Clickhouse: create materialized view query: ", query)
+	_, err := client.ClickHouseQuery.Query(projectName, serviceName, view.Database, query)
+	return err
+}
+
+func ReadMaterializedView(client *aiven.Client, projectName, serviceName, database, name string) (*MaterializedView, error) {
+	info, err := readTableInfo(client, projectName, serviceName, database, name)
+	if err != nil {
+		return nil, err
+	}
+	if info.Engine != "MaterializedView" {
+		return nil, fmt.Errorf("%s.%s is not a materialized view but a %s table", database, name, info.Engine)
+	}
+
+	return &MaterializedView{
+		Database: database,
+		Name:     name,
+		ToTable:  parseMaterializedViewTarget(info.CreateTableQuery),
+		Query:    info.AsSelect,
+	}, nil
+}
+
+func readTableInfo(client *aiven.Client, projectName, serviceName, database, name string) (*tableInfo, error) {
+	query := readTableStatement(database, name)
+
+	log.Println("[DEBUG] Clickhouse: read table query: ", query)
+	r, err := client.ClickHouseQuery.Query(projectName, serviceName, defaultDatabase, query)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := rowsFromAPIResponse(r, "engine", "engine_full", "sorting_key", "partition_key", "as_select", "create_table_query")
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, schemautil.NotFoundError("table", database+"."+name)
+	}
+
+	row := rows[0]
+	return &tableInfo{
+		Engine:           row.getString("engine"),
+		EngineFull:       row.getString("engine_full"),
+		SortingKey:       row.getString("sorting_key"),
+		PartitionKey:     row.getString("partition_key"),
+		AsSelect:         row.getString("as_select"),
+		CreateTableQuery: row.getString("create_table_query"),
+	}, row.err
+}
+
+func createTableStatement(table Table) string {
+	b := new(strings.Builder)
+
+	b.WriteString(fmt.Sprintf("CREATE TABLE %s.%s (", escape(table.Database), escape(table.Name)))
+	for i, c := range table.Columns {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(columnDefinition(c))
+	}
+	b.WriteString(fmt.Sprintf(") ENGINE = %s", table.Engine))
+
+	if table.PartitionBy != "" {
+		b.WriteString(fmt.Sprintf(" PARTITION BY %s", table.PartitionBy))
+	}
+	if table.OrderBy != "" {
+		b.WriteString(fmt.Sprintf(" ORDER BY %s", table.OrderBy))
+	}
+	if table.TTL != "" {
+		b.WriteString(fmt.Sprintf(" TTL %s", table.TTL))
+	}
+	if len(table.Settings) > 0 {
+		b.WriteString(" SETTINGS ")
+		b.WriteString(settingsList(table.Settings))
+	}
+
+	return b.String()
+}
+
+func columnDefinition(c Column) string {
+	b := new(strings.Builder)
+
+	b.WriteString(fmt.Sprintf("%s %s", escape(c.Name), c.Type))
+	if c.DefaultExpression != "" {
+		b.WriteString(fmt.Sprintf(" DEFAULT %s", c.DefaultExpression))
+	}
+	if c.Comment != "" {
+		b.WriteString(fmt.Sprintf(" COMMENT %s", escapeStringLiteral(c.Comment)))
+	}
+
+	return b.String()
+}
+
+func settingsList(settings map[string]string) string {
+	keys := make([]string, 0, len(settings))
+	for k := range settings {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	list := make([]string, len(keys))
+	for i, k := range keys {
+		list[i] = fmt.Sprintf("%s = %s", k, settingValue(settings[k]))
+	}
+	return strings.Join(list, ", ")
+}
+
+// settingValue quotes the value of a setting unless it is a number
+func settingValue(v string) string {
+	if _, err := strconv.ParseFloat(v, 64); err == nil {
+		return v
+	}
+	return escapeStringLiteral(v)
+}
+
+// alterTableStatements returns the statements to go from the old table to the new one, the columns, the TTL and
+// the settings are changed in place. The columns which are not in the new table are dropped, a renamed column
+// is dropped and added again. ClickHouse refuses some changes, e.g. of the types of the columns of the sorting key
+func alterTableStatements(old, new Table) []string {
+	table := fmt.Sprintf("%s.%s", escape(new.Database), escape(new.Name))
+
+	var statements []string
+
+	var removedDefaults, columnChanges []string
+	newColumns := make(map[string]bool, len(new.Columns))
+	for _, c := range new.Columns {
+		newColumns[c.Name] = true
+	}
+
+	// order is the order of the columns as the changes are applied one after the other
+	oldColumns := make(map[string]Column, len(old.Columns))
+	order := make([]string, 0, len(old.Columns))
+	for _, c := range old.Columns {
+		if !newColumns[c.Name] {
+			columnChanges = append(columnChanges, fmt.Sprintf("DROP COLUMN %s", escape(c.Name)))
+			continue
+		}
+		oldColumns[c.Name] = c
+		order = append(order, c.Name)
+	}
+
+	for i, c := range new.Columns {
+		position, previous := "FIRST", ""
+		if i > 0 {
+			previous = new.Columns[i-1].Name
+			position = "AFTER " + escape(previous)
+		}
+
+		o, ok := oldColumns[c.Name]
+		if !ok {
+			columnChanges = append(columnChanges, fmt.Sprintf("ADD COLUMN %s %s", columnDefinition(c), position))
+			order = moveColumn(order, c.Name, previous)
+			continue
+		}
+
+		j := indexOf(order, c.Name)
+		moved := (j == 0 && previous != "") || (j > 0 && order[j-1] != previous)
+		modified := normalizeExpression(o.Type) != normalizeExpression(c.Type) ||
+			normalizeExpression(o.DefaultExpression) != normalizeExpression(c.DefaultExpression)
+		if o.DefaultExpression != "" && c.DefaultExpression == "" {
+			removedDefaults = append(removedDefaults, fmt.Sprintf("MODIFY COLUMN %s REMOVE DEFAULT", escape(c.Name)))
+		}
+
+		switch {
+		case moved:
+			columnChanges = append(columnChanges, fmt.Sprintf("MODIFY COLUMN %s %s", columnDefinition(c), position))
+			order = moveColumn(order, c.Name, previous)
+		case modified:
+			columnChanges = append(columnChanges, fmt.Sprintf("MODIFY COLUMN %s", columnDefinition(c)))
+		}
+		// the comment is only kept by MODIFY COLUMN when it isn't removed
+		if o.Comment != c.Comment && (c.Comment == "" || !(moved || modified)) {
+			columnChanges = append(columnChanges, fmt.Sprintf("COMMENT COLUMN %s %s", escape(c.Name), escapeStringLiteral(c.Comment)))
+		}
+	}
+	if len(removedDefaults) > 0 {
+		statements = append(statements, fmt.Sprintf("ALTER TABLE %s %s", table, strings.Join(removedDefaults, ", ")))
+	}
+	if len(columnChanges) > 0 {
+		statements = append(statements, fmt.Sprintf("ALTER TABLE %s %s", table, strings.Join(columnChanges, ", ")))
+	}
+
+	if normalizeExpression(old.TTL) != normalizeExpression(new.TTL) {
+		if new.TTL == "" {
+			statements = append(statements, fmt.Sprintf("ALTER TABLE %s REMOVE TTL", table))
+		} else {
+			statements = append(statements, fmt.Sprintf("ALTER TABLE %s MODIFY TTL %s", table, new.TTL))
+		}
+	}
+
+	modified := make(map[string]string)
+	for k, v := range new.Settings {
+		if ov, ok := old.Settings[k]; !ok || ov != v {
+			modified[k] = v
+		}
+	}
+	if len(modified) > 0 {
+		statements = append(statements, fmt.Sprintf("ALTER TABLE %s MODIFY SETTING %s", table, settingsList(modified)))
+	}
+
+	var reset []string
+	for k := range old.Settings {
+		if _, ok := new.Settings[k]; !ok {
+			reset = append(reset, k)
+		}
+	}
+	if len(reset) > 0 {
+		sort.Strings(reset)
+		statements = append(statements, fmt.Sprintf("ALTER TABLE %s RESET SETTING %s", table, strings.Join(reset, ", ")))
+	}
+
+	return statements
+}
+
+// moveColumn puts the column after the previous one, first when there is no previous column
+func moveColumn(order []string, name, previous string) []string {
+	res := make([]string, 0, len(order)+1)
+	if previous == "" {
+		res = append(res, name)
+	}
+	for _, c := range order {
+		if c == name {
+			continue
+		}
+		res = append(res, c)
+		if c == previous {
+			res = append(res, name)
+		}
+	}
+	return res
+}
+
+func indexOf(names []string, name string) int {
+	for i, n := range names {
+		if n == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func dropTableStatement(database, name string) string {
+	return fmt.Sprintf("DROP TABLE IF EXISTS %s.%s", escape(database), escape(name))
+}
+
+func readTableStatement(database, name string) string {
+	return fmt.Sprintf(
+		"SELECT engine, engine_full, sorting_key, partition_key, as_select, create_table_query FROM system.tables WHERE database = %s AND name = %s",
+		escapeStringLiteral(database), escapeStringLiteral(name),
+	)
+}
+
+func readColumnsStatement(database, table string) string {
+	return fmt.Sprintf(
+		"SELECT name, type, default_kind, default_expression, comment FROM system.columns WHERE database = %s AND table = %s ORDER BY position",
+		escapeStringLiteral(database), escapeStringLiteral(table),
+	)
+}
+
+func createMaterializedViewStatement(view MaterializedView) string {
+	b := new(strings.Builder)
+
+	b.WriteString(fmt.Sprintf("CREATE MATERIALIZED VIEW %s.%s", escape(view.Database), escape(view.Name)))
+	if view.ToTable != "" {
+		b.WriteString(fmt.Sprintf(" TO %s.%s", escape(view.Database), escape(view.ToTable)))
+	} else {
+		b.WriteString(fmt.Sprintf(" ENGINE = %s", view.Engine))
+		if view.OrderBy != "" {
+			b.WriteString(fmt.Sprintf(" ORDER BY %s", view.OrderBy))
+		}
+		if view.Populate {
+			b.WriteString(" POPULATE")
+		}
+	}
+	b.WriteString(fmt.Sprintf(" AS %s", view.Query))
+
+	return b.String()
+}
+
+// parseMaterializedViewTarget returns the table a materialized view writes to from its CREATE query,
+// e.g. "CREATE MATERIALIZED VIEW db.view TO db.target (...) AS SELECT ..."
+func parseMaterializedViewTarget(createQuery string) string {
+	rest := strings.TrimPrefix(createQuery, "CREATE MATERIALIZED VIEW ")
+	if rest == createQuery {
+		return ""
+	}
+
+	// the view itself, then the target, both qualified with the database
+	_, rest = readQualifiedIdentifier(rest)
+	if !strings.HasPrefix(rest, " TO ") {
+		return ""
+	}
+	target, _ := readQualifiedIdentifier(strings.TrimPrefix(rest, " TO "))
+	return target
+}
+
+// readQualifiedIdentifier reads a "database.name" identifier and returns the name and the rest of the query
+func readQualifiedIdentifier(s string) (string, string) {
+	name, rest := readIdentifier(s)
+	if strings.HasPrefix(rest, ".") {
+		name, rest = readIdentifier(rest[1:])
+	}
+	return name, rest
+}
+
+// readIdentifier reads an identifier, quoted with backticks or not, and returns it unquoted with the rest
+func readIdentifier(s string) (string, string) {
+	if !strings.HasPrefix(s, "`") {
+		i := strings.IndexAny(s, " .(")
+		if i < 0 {
+			return s, ""
+		}
+		return s[:i], s[i:]
+	}
+
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '`':
+			return unescapeQuoted(s[1:i]), s[i+1:]
+		}
+	}
+	return unescapeQuoted(s[1:]), ""
+}
+
+// unescapeQuoted reverses the escaping of escapeQuoted
+func unescapeQuoted(s string) string {
+	unescapeMap := map[byte]byte{'0': 0, 'b': '\b', 'f': '\f', 'r': '\r', 'n': '\n', 't': '\t'}
+
+	b := new(strings.Builder)
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i == len(s)-1 {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		if c, ok := unescapeMap[s[i]]; ok {
+			b.WriteByte(c)
+		} else if s[i] == 'x' && i+2 < len(s) {
+			if v, err := strconv.ParseUint(s[i+1:i+3], 16, 8); err == nil {
+				b.WriteByte(byte(v))
+				i += 2
+			}
+		} else {
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
+
+// parseEngineFull returns the TTL and the settings from the engine_full column of system.tables,
+// e.g. "MergeTree ORDER BY id TTL ts + toIntervalDay(30) SETTINGS index_granularity = 8192"
+func parseEngineFull(engineFull string) (string, map[string]string) {
+	settings := make(map[string]string)
+
+	rest := engineFull
+	if i := strings.Index(rest, " SETTINGS "); i >= 0 {
+		for _, s := range splitOutsideQuotes(rest[i+len(" SETTINGS "):], ',') {
+			kv := strings.SplitN(s, "=", 2)
+			if len(kv) != 2 {
+				continue
+			}
+			v := strings.TrimSpace(kv[1])
+			if len(v) >= 2 && v[0] == '\'' && v[len(v)-1] == '\'' {
+				v = unescapeQuoted(v[1 : len(v)-1])
+			}
+			settings[strings.TrimSpace(kv[0])] = v
+		}
+		rest = rest[:i]
+	}
+
+	ttl := ""
+	if i := strings.Index(rest, " TTL "); i >= 0 {
+		ttl = strings.TrimSpace(rest[i+len(" TTL "):])
+	}
+
+	return ttl, settings
+}
+
+// splitOutsideQuotes splits a list on the separator, ignoring the separators inside of string literals
+func splitOutsideQuotes(s string, sep byte) []string {
+	var (
+		parts   []string
+		quoted  bool
+		escaped bool
+		start   int
+	)
+	for i := 0; i < len(s); i++ {
+		switch {
+		case escaped:
+			escaped = false
+		case s[i] == '\\':
+			escaped = true
+		case s[i] == '\'':
+			quoted = !quoted
+		case s[i] == sep && !quoted:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+var (
+	sqlWhitespaceRegexp  = regexp.MustCompile(`\s+`)
+	sqlPunctuationRegexp = regexp.MustCompile(`\s*([(),=+\-*/<>])\s*`)
+	sqlWordRegexp        = regexp.MustCompile(`[A-Za-z_][A-Za-z0-9_]*\(?`)
+	// sqlIntervalRegexp matches the INTERVAL literals ClickHouse rewrites to function calls, e.g. toIntervalDay(30)
+	sqlIntervalRegexp = regexp.MustCompile(`(?i)\bINTERVAL\s+(\d+)\s+(SECOND|MINUTE|HOUR|DAY|WEEK|MONTH|QUARTER|YEAR)S?\b`)
+)
+
+// sqlKeywords are the keywords of the expressions and queries ClickHouse returns, their case doesn't matter
+var sqlKeywords = map[string]bool{}
+
+func init() {
+	for _, k := range strings.Fields(`ALL AND ANY ARRAY AS ASC BETWEEN BY CASE CROSS DESC DISTINCT ELSE END FALSE FINAL
+		FROM FULL GLOBAL GROUP HAVING ILIKE IN INNER INTERVAL IS JOIN LEFT LIKE LIMIT NOT NULL OFFSET ON OR ORDER
+		OUTER PREWHERE RIGHT SAMPLE SELECT SETTINGS THEN TO TRUE UNION USING WHEN WHERE WITH`) {
+		sqlKeywords[k] = true
+	}
+}
+
+// normalizeExpression puts an SQL expression in a form that is stable across the reformatting ClickHouse does,
+// e.g. "(id, ts)" and "id,ts" are the same sorting key and "INTERVAL 30 DAY" is "toIntervalDay(30)". Only the case
+// of the keywords and the function names is ignored, the identifiers and the string literals are kept as they are
+func normalizeExpression(expression string) string {
+	parts := splitStringLiterals(expression)
+	for i := 0; i < len(parts); i += 2 {
+		e := strings.ReplaceAll(parts[i], "`", "")
+		e = sqlIntervalRegexp.ReplaceAllStringFunc(e, func(interval string) string {
+			m := sqlIntervalRegexp.FindStringSubmatch(interval)
+			return fmt.Sprintf("toInterval%s(%s)", m[2], m[1])
+		})
+		e = sqlWhitespaceRegexp.ReplaceAllString(e, " ")
+		e = sqlPunctuationRegexp.ReplaceAllString(e, "$1")
+		parts[i] = sqlWordRegexp.ReplaceAllStringFunc(e, func(w string) string {
+			if strings.HasSuffix(w, "(") || sqlKeywords[strings.ToUpper(w)] {
+				return strings.ToLower(w)
+			}
+			return w
+		})
+	}
+
+	e := strings.TrimSpace(strings.Join(parts, ""))
+	e = strings.TrimSpace(strings.TrimSuffix(e, ";"))
+	for strings.HasPrefix(e, "(") && strings.HasSuffix(e, ")") && balancedParentheses(e[1:len(e)-1]) {
+		e = e[1 : len(e)-1]
+	}
+	if e == "tuple()" {
+		e = ""
+	}
+	return e
+}
+
+// splitStringLiterals splits the expression into the text outside the string literals, at even indexes, and the
+// string literals with their quotes, at odd indexes
+func splitStringLiterals(s string) []string {
+	var parts []string
+	start, quoted, escaped := 0, false, false
+	for i := 0; i < len(s); i++ {
+		switch {
+		case escaped:
+			escaped = false
+		case s[i] == '\\' && quoted:
+			escaped = true
+		case s[i] == '\'' && !quoted:
+			parts = append(parts, s[start:i])
+			start, quoted = i, true
+		case s[i] == '\'' && quoted:
+			parts = append(parts, s[start:i+1])
+			start, quoted = i+1, false
+		}
+	}
+	if quoted {
+		// an unterminated literal is kept as it is
+		return append(parts, s[start:], "")
+	}
+	return append(parts, s[start:])
+}
+
+func balancedParentheses(s string) bool {
+	depth := 0
+	for _, r := range s {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth < 0 {
+				return false
+			}
+		}
+	}
+	return depth == 0
+}
+
+// engineName returns the name of a table engine without its parameters and without the Replicated prefix
+// Aiven adds to the MergeTree engines
+func engineName(engine string) string {
+	if i := strings.Index(engine, "("); i >= 0 {
+		engine = engine[:i]
+	}
+	return strings.TrimPrefix(strings.TrimSpace(engine), "Replicated")
+}
+
+func columnsFromAPIResponse(r *aiven.ClickhouseQueryResponse) ([]Column, error) {
+	rows, err := rowsFromAPIResponse(r, "name", "type", "default_kind", "default_expression", "comment")
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make([]Column, 0, len(rows))
+	for _, row := range rows {
+		c := Column{
+			Name:    row.getString("name"),
+			Type:    row.getString("type"),
+			Comment: row.getString("comment"),
+		}
+		// only the DEFAULT kind is managed, MATERIALIZED and ALIAS columns are read as plain columns
+		if row.getString("default_kind") == "DEFAULT" {
+			c.DefaultExpression = row.getString("default_expression")
+		}
+		if row.err != nil {
+			return nil, row.err
+		}
+		columns = append(columns, c)
+	}
+	return columns, nil
+}
@@ -0,0 +1,217 @@
+package clickhouse
+
+import (
+	"testing"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testTable() Table {
+	return Table{
+		Database:    "analytics",
+		Name:        "events",
+		Engine:      "MergeTree",
+		OrderBy:     "(id, timestamp)",
+		PartitionBy: "toYYYYMM(timestamp)",
+		TTL:         "timestamp + INTERVAL 30 DAY",
+		Settings:    map[string]string{"index_granularity": "8192", "storage_policy": "tiered"},
+		Columns: []Column{
+			{Name: "id", Type: "UInt64"},
+			{Name: "timestamp", Type: "DateTime", DefaultExpression: "now()"},
+			{Name: "user's name", Type: "String", Comment: "it's the name"},
+		},
+	}
+}
+
+func TestCreateTableStatement(t *testing.T) {
+	assert.Equal(t,
+		"CREATE TABLE `analytics`.`events` (`id` UInt64, `timestamp` DateTime DEFAULT now(), `user's name` String COMMENT 'it\\'s the name') "+
+			"ENGINE = MergeTree PARTITION BY toYYYYMM(timestamp) ORDER BY (id, timestamp) TTL timestamp + INTERVAL 30 DAY "+
+			"SETTINGS index_granularity = 8192, storage_policy = 'tiered'",
+		createTableStatement(testTable()),
+	)
+}
+
+func TestAlterTableStatements(t *testing.T) {
+	old := testTable()
+
+	new := testTable()
+	new.Columns = []Column{
+		{Name: "region", Type: "LowCardinality(String)"},
+		{Name: "id", Type: "UInt64"},
+		{Name: "timestamp", Type: "DateTime", DefaultExpression: "now()"},
+		{Name: "value", Type: "Float64", DefaultExpression: "0"},
+		{Name: "user's name", Type: "String", Comment: "display name"},
+	}
+	new.TTL = "timestamp + INTERVAL 90 DAY"
+	new.Settings = map[string]string{"index_granularity": "4096", "merge_with_ttl_timeout": "3600"}
+
+	assert.Equal(t, []string{
+		"ALTER TABLE `analytics`.`events` ADD COLUMN `region` LowCardinality(String) FIRST, " +
+			"ADD COLUMN `value` Float64 DEFAULT 0 AFTER `timestamp`, " +
+			"COMMENT COLUMN `user's name` 'display name'",
+		"ALTER TABLE `analytics`.`events` MODIFY TTL timestamp + INTERVAL 90 DAY",
+		"ALTER TABLE `analytics`.`events` MODIFY SETTING index_granularity = 4096, merge_with_ttl_timeout = 3600",
+		"ALTER TABLE `analytics`.`events` RESET SETTING storage_policy",
+	}, alterTableStatements(old, new))
+
+	new = testTable()
+	new.TTL = ""
+	assert.Equal(t, []string{"ALTER TABLE `analytics`.`events` REMOVE TTL"}, alterTableStatements(old, new))
+
+	// the TTL ClickHouse rewrote is the same one
+	new = testTable()
+	new.TTL = "timestamp + toIntervalDay(30)"
+	assert.Empty(t, alterTableStatements(old, new))
+
+	assert.Empty(t, alterTableStatements(old, testTable()))
+}
+
+func TestAlterTableStatementsColumns(t *testing.T) {
+	old := testTable()
+	columns := old.Columns
+
+	tests := []struct {
+		name    string
+		columns []Column
+		want    []string
+	}{
+		{"same", columns, nil},
+		{"reformatted", []Column{columns[0], {Name: "timestamp", Type: "DateTime", DefaultExpression: "now( )"}, columns[2]}, nil},
+		{"removed", columns[:2], []string{
+			"ALTER TABLE `analytics`.`events` DROP COLUMN `user's name`",
+		}},
+		{"type changed", []Column{{Name: "id", Type: "UInt32"}, columns[1], columns[2]}, []string{
+			"ALTER TABLE `analytics`.`events` MODIFY COLUMN `id` UInt32",
+		}},
+		{"default changed", []Column{columns[0], {Name: "timestamp", Type: "DateTime", DefaultExpression: "now() - 1"}, columns[2]}, []string{
+			"ALTER TABLE `analytics`.`events` MODIFY COLUMN `timestamp` DateTime DEFAULT now() - 1",
+		}},
+		{"default removed", []Column{columns[0], {Name: "timestamp", Type: "DateTime"}, columns[2]}, []string{
+			"ALTER TABLE `analytics`.`events` MODIFY COLUMN `timestamp` REMOVE DEFAULT",
+			"ALTER TABLE `analytics`.`events` MODIFY COLUMN `timestamp` DateTime",
+		}},
+		{"comment removed", []Column{columns[0], columns[1], {Name: "user's name", Type: "LowCardinality(String)"}}, []string{
+			"ALTER TABLE `analytics`.`events` MODIFY COLUMN `user's name` LowCardinality(String), COMMENT COLUMN `user's name` ''",
+		}},
+		{"reordered", []Column{columns[1], columns[0], columns[2]}, []string{
+			"ALTER TABLE `analytics`.`events` MODIFY COLUMN `timestamp` DateTime DEFAULT now() FIRST",
+		}},
+		{"renamed", []Column{{Name: "event_id", Type: "UInt64"}, columns[1], columns[2]}, []string{
+			"ALTER TABLE `analytics`.`events` DROP COLUMN `id`, ADD COLUMN `event_id` UInt64 FIRST",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			new := testTable()
+			new.Columns = tt.columns
+			assert.Equal(t, tt.want, alterTableStatements(old, new))
+		})
+	}
+}
+
+func TestParseEngineFull(t *testing.T) {
+	ttl, settings := parseEngineFull(
+		"ReplicatedMergeTree('/clickhouse/tables/{uuid}/{shard}', '{replica}') PARTITION BY toYYYYMM(timestamp) ORDER BY (id, timestamp) " +
+			"TTL timestamp + toIntervalDay(30) SETTINGS index_granularity = 8192, storage_policy = 'a, \\'b\\''",
+	)
+	assert.Equal(t, "timestamp + toIntervalDay(30)", ttl)
+	assert.Equal(t, map[string]string{"index_granularity": "8192", "storage_policy": "a, 'b'"}, settings)
+
+	ttl, settings = parseEngineFull("MergeTree ORDER BY id")
+	assert.Empty(t, ttl)
+	assert.Empty(t, settings)
+}
+
+func TestNormalizeExpression(t *testing.T) {
+	assert.Equal(t, normalizeExpression("id, timestamp"), normalizeExpression("(id,timestamp)"))
+	assert.Equal(t, normalizeExpression("`id`"), normalizeExpression("((id))"))
+	assert.Equal(t, normalizeExpression(""), normalizeExpression("tuple()"))
+	assert.Equal(t, normalizeExpression("Decimal(10, 2)"), normalizeExpression("Decimal(10,2)"))
+	assert.Equal(t,
+		normalizeExpression("SELECT id,\n  count() AS c\nFROM analytics.events\nGROUP BY id;"),
+		normalizeExpression("SELECT id, count() AS c FROM analytics.events GROUP BY id"),
+	)
+	assert.NotEqual(t, normalizeExpression("(a), (b)"), normalizeExpression("a), (b"))
+	assert.NotEqual(t, normalizeExpression("id"), normalizeExpression("timestamp"))
+	assert.Equal(t,
+		normalizeExpression("select COUNT() as c from events where region = 'EU'"),
+		normalizeExpression("SELECT count() AS c FROM events WHERE region='EU'"),
+	)
+	assert.NotEqual(t, normalizeExpression("region = 'EU'"), normalizeExpression("region = 'eu'"))
+	assert.NotEqual(t, normalizeExpression("region = 'a  b'"), normalizeExpression("region = 'a b'"))
+	assert.NotEqual(t, normalizeExpression("Region"), normalizeExpression("region"))
+	assert.Equal(t, normalizeExpression("concat(a, 'x (y)')"), normalizeExpression("concat(a,'x (y)')"))
+	assert.Equal(t, normalizeExpression("timestamp + INTERVAL 30 DAY"), normalizeExpression("timestamp + toIntervalDay(30)"))
+	assert.Equal(t, normalizeExpression("timestamp + interval 1 month"), normalizeExpression("timestamp + toIntervalMonth(1)"))
+	assert.NotEqual(t, normalizeExpression("timestamp + INTERVAL 30 DAY"), normalizeExpression("timestamp + toIntervalDay(90)"))
+	assert.NotEqual(t, normalizeExpression("timestamp + INTERVAL 30 DAY"), normalizeExpression("event_time + toIntervalDay(30)"))
+	assert.Equal(t, normalizeExpression("concat(a, 'INTERVAL 1 DAY')"), normalizeExpression("concat(a, 'INTERVAL 1 DAY')"))
+	assert.NotEqual(t, normalizeExpression("concat(a, 'INTERVAL 1 DAY')"), normalizeExpression("concat(a, 'toIntervalDay(1)')"))
+}
+
+func TestEngineName(t *testing.T) {
+	assert.Equal(t, "MergeTree", engineName("ReplicatedMergeTree"))
+	assert.Equal(t, "ReplacingMergeTree", engineName("ReplacingMergeTree(version)"))
+	assert.Equal(t, "Memory", engineName("Memory"))
+}
+
+func TestCreateMaterializedViewStatement(t *testing.T) {
+	assert.Equal(t,
+		"CREATE MATERIALIZED VIEW `analytics`.`events_mv` TO `analytics`.`events_daily` AS SELECT 1",
+		createMaterializedViewStatement(MaterializedView{Database: "analytics", Name: "events_mv", ToTable: "events_daily", Query: "SELECT 1"}),
+	)
+	assert.Equal(t,
+		"CREATE MATERIALIZED VIEW `analytics`.`events_mv` ENGINE = SummingMergeTree ORDER BY day POPULATE AS SELECT 1",
+		createMaterializedViewStatement(MaterializedView{
+			Database: "analytics", Name: "events_mv", Engine: "SummingMergeTree", OrderBy: "day", Populate: true, Query: "SELECT 1",
+		}),
+	)
+}
+
+func TestParseMaterializedViewTarget(t *testing.T) {
+	assert.Equal(t, "events_daily", parseMaterializedViewTarget(
+		"CREATE MATERIALIZED VIEW analytics.events_mv TO analytics.events_daily (`day` Date, `c` UInt64) AS SELECT 1",
+	))
+	assert.Equal(t, "daily events", parseMaterializedViewTarget(
+		"CREATE MATERIALIZED VIEW `analytics`.`events mv` TO `analytics`.`daily events` (`day` Date) AS SELECT 1",
+	))
+	assert.Equal(t, "we`ird", parseMaterializedViewTarget(
+		"CREATE MATERIALIZED VIEW analytics.mv TO analytics.`we\\`ird` AS SELECT 1",
+	))
+	assert.Empty(t, parseMaterializedViewTarget(
+		"CREATE MATERIALIZED VIEW analytics.events_mv (`day` Date) ENGINE = ReplicatedSummingMergeTree ORDER BY day AS SELECT 1",
+	))
+	assert.Empty(t, parseMaterializedViewTarget("CREATE TABLE analytics.events (`id` UInt64) ENGINE = MergeTree"))
+}
+
+func TestColumnsFromAPIResponse(t *testing.T) {
+	r := &aiven.ClickhouseQueryResponse{
+		Meta: []aiven.ClickhouseQueryColumnMeta{
+			{Name: "name", Type: "String"},
+			{Name: "type", Type: "String"},
+			{Name: "default_kind", Type: "String"},
+			{Name: "default_expression", Type: "String"},
+			{Name: "comment", Type: "String"},
+		},
+		Data: []interface{}{
+			[]interface{}{"id", "UInt64", "", "", ""},
+			[]interface{}{"timestamp", "DateTime", "DEFAULT", "now()", "event time"},
+			[]interface{}{"day", "Date", "MATERIALIZED", "toDate(timestamp)", ""},
+		},
+	}
+
+	columns, err := columnsFromAPIResponse(r)
+	require.NoError(t, err)
+	assert.Equal(t, []Column{
+		{Name: "id", Type: "UInt64"},
+		{Name: "timestamp", Type: "DateTime", DefaultExpression: "now()", Comment: "event time"},
+		{Name: "day", Type: "Date"},
+	}, columns)
+
+	r.Meta = r.Meta[:4]
+	_, err = columnsFromAPIResponse(r)
+	assert.EqualError(t, err, "query response metadata is missing the 'comment' column")
+}
@@ -0,0 +1,411 @@
+package flink
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+type (
+	// flinkApplication is an Aiven Flink application, a named container of versions and deployments
+	flinkApplication struct {
+		ID                     string                            `json:"id"`
+		Name                   string                            `json:"name"`
+		CreatedAt              string                            `json:"created_at"`
+		CreatedBy              string                            `json:"created_by"`
+		UpdatedAt              string                            `json:"updated_at"`
+		UpdatedBy              string                            `json:"updated_by"`
+		CurrentDeployment      *flinkApplicationDeployment       `json:"current_deployment,omitempty"`
+		ApplicationVersions    []flinkApplicationVersion         `json:"application_versions"`
+		ApplicationDeployments []flinkApplicationDeploymentShort `json:"application_deployments"`
+	}
+
+	flinkApplicationListResponse struct {
+		Applications []flinkApplication `json:"applications"`
+	}
+
+	flinkApplicationRequest struct {
+		Name string `json:"name"`
+	}
+
+	// flinkApplicationTable is a source or sink table of an application version
+	flinkApplicationTable struct {
+		CreateTable   string `json:"create_table"`
+		IntegrationID string `json:"integration_id,omitempty"`
+		TableID       string `json:"table_id,omitempty"`
+		TableName     string `json:"table_name,omitempty"`
+	}
+
+	// flinkApplicationVersion is an immutable snapshot of the SQL and tables of an application
+	flinkApplicationVersion struct {
+		ID        string                  `json:"id"`
+		Version   int                     `json:"version"`
+		Statement string                  `json:"statement"`
+		Sources   []flinkApplicationTable `json:"sources"`
+		Sinks     []flinkApplicationTable `json:"sinks"`
+		CreatedAt string                  `json:"created_at"`
+		CreatedBy string                  `json:"created_by"`
+	}
+
+	flinkApplicationVersionRequest struct {
+		Statement string                  `json:"statement"`
+		Sources   []flinkApplicationTable `json:"sources"`
+		Sinks     []flinkApplicationTable `json:"sinks"`
+	}
+
+	flinkApplicationVersionValidateResponse struct {
+		flinkApplicationVersionRequest
+		StatementError *struct {
+			Message string `json:"message"`
+		} `json:"statement_error,omitempty"`
+		SourceErrors []flinkApplicationTableError `json:"sources"`
+		SinkErrors   []flinkApplicationTableError `json:"sinks"`
+	}
+
+	flinkApplicationTableError struct {
+		CreateTable string `json:"create_table"`
+		Message     string `json:"message"`
+	}
+
+	flinkApplicationDeploymentShort struct {
+		ID     string `json:"id"`
+		Status string `json:"status"`
+	}
+
+	// flinkApplicationDeployment is a running (or stopped) job of an application version
+	flinkApplicationDeployment struct {
+		ID                string `json:"id"`
+		VersionID         string `json:"version_id"`
+		Status            string `json:"status"`
+		JobID             string `json:"job_id"`
+		Parallelism       int    `json:"parallelism"`
+		RestartEnabled    bool   `json:"restart_enabled"`
+		StartingSavepoint string `json:"starting_savepoint"`
+		LastSavepoint     string `json:"last_savepoint"`
+		ErrorMsg          string `json:"error_msg"`
+		CreatedAt         string `json:"created_at"`
+		CreatedBy         string `json:"created_by"`
+	}
+
+	flinkApplicationDeploymentRequest struct {
+		VersionID         string `json:"version_id"`
+		Parallelism       int    `json:"parallelism,omitempty"`
+		RestartEnabled    bool   `json:"restart_enabled"`
+		StartingSavepoint string `json:"starting_savepoint,omitempty"`
+	}
+)
+
+// Flink application deployment statuses
+const (
+	flinkDeploymentStatusRunning  = "RUNNING"
+	flinkDeploymentStatusFinished = "FINISHED"
+	flinkDeploymentStatusCanceled = "CANCELED"
+	flinkDeploymentStatusFailed   = "FAILED"
+)
+
+var (
+	// flinkDeploymentStartingStatuses are the statuses a deployment goes through before it is running
+	flinkDeploymentStartingStatuses = []string{
+		"INITIALIZING",
+		"CREATED",
+		"RESTARTING",
+		"RECONCILING",
+	}
+
+	// flinkDeploymentStoppingStatuses are the statuses a deployment goes through while it
+	// takes a savepoint and stops, or is canceled
+	flinkDeploymentStoppingStatuses = []string{
+		"RUNNING",
+		"SAVING",
+		"SAVING_AND_STOP_REQUESTED",
+		"SAVING_AND_STOP",
+		"CANCELLING_REQUESTED",
+		"CANCELLING",
+		"FAILING",
+		"RESTARTING",
+		"INITIALIZING",
+		"CREATED",
+		"RECONCILING",
+	}
+
+	// flinkDeploymentPollInterval is how often deployment status is polled while waiting for a transition
+	flinkDeploymentPollInterval = 5 * time.Second
+)
+
+func flinkApplicationPath(project, serviceName string, parts ...string) string {
+	return schemautil.BuildAPIPath(append([]string{"project", project, "service", serviceName, "flink", "application"}, parts...)...)
+}
+
+func createFlinkApplication(ctx context.Context, client *aiven.Client, project, serviceName string, req flinkApplicationRequest) (*flinkApplication, error) {
+	var r flinkApplication
+	err := schemautil.APIRequest(ctx, client, http.MethodPost, flinkApplicationPath(project, serviceName), req, &r)
+	return &r, err
+}
+
+func listFlinkApplications(ctx context.Context, client *aiven.Client, project, serviceName string) ([]flinkApplication, error) {
+	var r flinkApplicationListResponse
+	err := schemautil.APIRequest(ctx, client, http.MethodGet, flinkApplicationPath(project, serviceName), nil, &r)
+	return r.Applications, err
+}
+
+func getFlinkApplication(ctx context.Context, client *aiven.Client, project, serviceName, applicationID string) (*flinkApplication, error) {
+	var r flinkApplication
+	err := schemautil.APIRequest(ctx, client, http.MethodGet, flinkApplicationPath(project, serviceName, applicationID), nil, &r)
+	return &r, err
+}
+
+func updateFlinkApplication(ctx context.Context, client *aiven.Client, project, serviceName, applicationID string, req flinkApplicationRequest) (*flinkApplication, error) {
+	var r flinkApplication
+	err := schemautil.APIRequest(ctx, client, http.MethodPut, flinkApplicationPath(project, serviceName, applicationID), req, &r)
+	return &r, err
+}
+
+func deleteFlinkApplication(ctx context.Context, client *aiven.Client, project, serviceName, applicationID string) error {
+	return schemautil.APIRequest(ctx, client, http.MethodDelete, flinkApplicationPath(project, serviceName, applicationID), nil, nil)
+}
+
+func createFlinkApplicationVersion(ctx context.Context, client *aiven.Client, project, serviceName, applicationID string, req flinkApplicationVersionRequest) (*flinkApplicationVersion, error) {
+	var r flinkApplicationVersion
+	err := schemautil.APIRequest(ctx, client, http.MethodPost, flinkApplicationPath(project, serviceName, applicationID, "version"), req, &r)
+	return &r, err
+}
+
+func getFlinkApplicationVersion(ctx context.Context, client *aiven.Client, project, serviceName, applicationID, versionID string) (*flinkApplicationVersion, error) {
+	var r flinkApplicationVersion
+	err := schemautil.APIRequest(ctx, client, http.MethodGet, flinkApplicationPath(project, serviceName, applicationID, "version", versionID), nil, &r)
+	return &r, err
+}
+
+func deleteFlinkApplicationVersion(ctx context.Context, client *aiven.Client, project, serviceName, applicationID, versionID string) error {
+	return schemautil.APIRequest(ctx, client, http.MethodDelete, flinkApplicationPath(project, serviceName, applicationID, "version", versionID), nil, nil)
+}
+
+// validateFlinkApplicationVersion validates the statement and tables of a version, the returned
+// error lists every problem reported by the API
+func validateFlinkApplicationVersion(ctx context.Context, client *aiven.Client, project, serviceName, applicationID string, req flinkApplicationVersionRequest) error {
+	var r flinkApplicationVersionValidateResponse
+	path := flinkApplicationPath(project, serviceName, applicationID, "version", "validate")
+	if err := schemautil.APIRequest(ctx, client, http.MethodPost, path, req, &r); err != nil {
+		return err
+	}
+
+	var msgs []string
+	if r.StatementError != nil && r.StatementError.Message != "" {
+		msgs = append(msgs, fmt.Sprintf("statement: %s", r.StatementError.Message))
+	}
+	for i, e := range r.SourceErrors {
+		if e.Message != "" {
+			msgs = append(msgs, fmt.Sprintf("source.%d: %s", i, e.Message))
+		}
+	}
+	for i, e := range r.SinkErrors {
+		if e.Message != "" {
+			msgs = append(msgs, fmt.Sprintf("sink.%d: %s", i, e.Message))
+		}
+	}
+
+	if len(msgs) > 0 {
+		return fmt.Errorf("invalid Flink application version:\n%s", strings.Join(msgs, "\n"))
+	}
+
+	return nil
+}
+
+func createFlinkApplicationDeployment(ctx context.Context, client *aiven.Client, project, serviceName, applicationID string, req flinkApplicationDeploymentRequest) (*flinkApplicationDeployment, error) {
+	var r flinkApplicationDeployment
+	err := schemautil.APIRequest(ctx, client, http.MethodPost, flinkApplicationPath(project, serviceName, applicationID, "deployment"), req, &r)
+	return &r, err
+}
+
+func getFlinkApplicationDeployment(ctx context.Context, client *aiven.Client, project, serviceName, applicationID, deploymentID string) (*flinkApplicationDeployment, error) {
+	var r flinkApplicationDeployment
+	err := schemautil.APIRequest(ctx, client, http.MethodGet, flinkApplicationPath(project, serviceName, applicationID, "deployment", deploymentID), nil, &r)
+	return &r, err
+}
+
+func deleteFlinkApplicationDeployment(ctx context.Context, client *aiven.Client, project, serviceName, applicationID, deploymentID string) error {
+	return schemautil.APIRequest(ctx, client, http.MethodDelete, flinkApplicationPath(project, serviceName, applicationID, "deployment", deploymentID), nil, nil)
+}
+
+// stopFlinkApplicationDeployment requests a savepoint, after which the job is stopped
+func stopFlinkApplicationDeployment(ctx context.Context, client *aiven.Client, project, serviceName, applicationID, deploymentID string) error {
+	return schemautil.APIRequest(ctx, client, http.MethodPost, flinkApplicationPath(project, serviceName, applicationID, "deployment", deploymentID, "stop"), nil, nil)
+}
+
+// cancelFlinkApplicationDeployment stops the job without taking a savepoint
+func cancelFlinkApplicationDeployment(ctx context.Context, client *aiven.Client, project, serviceName, applicationID, deploymentID string) error {
+	return schemautil.APIRequest(ctx, client, http.MethodPost, flinkApplicationPath(project, serviceName, applicationID, "deployment", deploymentID, "cancel"), nil, nil)
+}
+
+// waitForFlinkApplicationDeployment polls a deployment until it reaches one of the target statuses
+func waitForFlinkApplicationDeployment(
+	ctx context.Context,
+	client *aiven.Client,
+	project, serviceName, applicationID, deploymentID string,
+	pending, target []string,
+	timeout time.Duration,
+) (*flinkApplicationDeployment, error) {
+	conf := &resource.StateChangeConf{
+		Pending: pending,
+		Target:  target,
+		Refresh: func() (interface{}, string, error) {
+			r, err := getFlinkApplicationDeployment(ctx, client, project, serviceName, applicationID, deploymentID)
+			if err != nil {
+				return nil, "", err
+			}
+			return r, r.Status, nil
+		},
+		Timeout:      timeout,
+		PollInterval: flinkDeploymentPollInterval,
+	}
+
+	r, err := conf.WaitForStateContext(ctx)
+	if err != nil {
+		if r, ok := r.(*flinkApplicationDeployment); ok && r.ErrorMsg != "" {
+			return nil, fmt.Errorf("%w: %s", err, r.ErrorMsg)
+		}
+		return nil, err
+	}
+
+	return r.(*flinkApplicationDeployment), nil
+}
+
+// deployFlinkApplication starts a deployment and waits for its job to be running. A deployment
+// which fails or doesn't start in time is canceled and deleted, nothing would track it otherwise
+func deployFlinkApplication(
+	ctx context.Context,
+	client *aiven.Client,
+	project, serviceName, applicationID string,
+	req flinkApplicationDeploymentRequest,
+	timeout time.Duration,
+) (*flinkApplicationDeployment, error) {
+	r, err := createFlinkApplicationDeployment(ctx, client, project, serviceName, applicationID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	running, err := waitForFlinkApplicationDeployment(
+		ctx, client, project, serviceName, applicationID, r.ID,
+		flinkDeploymentStartingStatuses, []string{flinkDeploymentStatusRunning}, timeout,
+	)
+	if err != nil {
+		if cerr := cancelAndDeleteFlinkApplicationDeployment(
+			ctx, client, project, serviceName, applicationID, r.ID, timeout,
+		); cerr != nil && !aiven.IsNotFound(cerr) {
+			return nil, fmt.Errorf("%w; error removing deployment %s: %s", err, r.ID, cerr)
+		}
+		return nil, err
+	}
+
+	return running, nil
+}
+
+// stopFlinkApplicationDeploymentWithSavepoint stops a deployment with a savepoint and returns
+// the stopped deployment. Stopping a deployment which is already finished is a no-op, so a
+// redeployment that failed halfway can be resumed from the savepoint taken earlier.
+func stopFlinkApplicationDeploymentWithSavepoint(
+	ctx context.Context,
+	client *aiven.Client,
+	project, serviceName, applicationID, deploymentID string,
+	timeout time.Duration,
+) (*flinkApplicationDeployment, error) {
+	r, err := getFlinkApplicationDeployment(ctx, client, project, serviceName, applicationID, deploymentID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch r.Status {
+	case flinkDeploymentStatusFinished, flinkDeploymentStatusCanceled, flinkDeploymentStatusFailed:
+		// a canceled or failed job has no new savepoint, the new deployment starts from
+		// the last recorded one if any
+		return r, nil
+	}
+
+	if err := stopFlinkApplicationDeployment(ctx, client, project, serviceName, applicationID, deploymentID); err != nil {
+		return nil, err
+	}
+
+	r, err = waitForFlinkApplicationDeployment(
+		ctx, client, project, serviceName, applicationID, deploymentID,
+		flinkDeploymentStoppingStatuses, []string{flinkDeploymentStatusFinished}, timeout,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error stopping Flink application deployment %s with a savepoint: %w", deploymentID, err)
+	}
+
+	return r, nil
+}
+
+// redeployFlinkApplication replaces a running deployment: the old job is stopped with a
+// savepoint, the new deployment is started from it and the old deployment is deleted. The new
+// deployment is returned along with the error when only the deletion fails
+func redeployFlinkApplication(
+	ctx context.Context,
+	client *aiven.Client,
+	project, serviceName, applicationID, deploymentID string,
+	req flinkApplicationDeploymentRequest,
+	timeout time.Duration,
+) (*flinkApplicationDeployment, error) {
+	stopped, err := stopFlinkApplicationDeploymentWithSavepoint(ctx, client, project, serviceName, applicationID, deploymentID, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	if stopped.LastSavepoint != "" {
+		req.StartingSavepoint = stopped.LastSavepoint
+	} else if req.StartingSavepoint == "" {
+		req.StartingSavepoint = stopped.StartingSavepoint
+	}
+
+	r, err := deployFlinkApplication(ctx, client, project, serviceName, applicationID, req, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	// the stopped deployment is only kept until the new one runs, so that a failed update can resume from it
+	if err := deleteFlinkApplicationDeployment(ctx, client, project, serviceName, applicationID, deploymentID); err != nil && !aiven.IsNotFound(err) {
+		return r, fmt.Errorf("error deleting the previous deployment %s: %w", deploymentID, err)
+	}
+
+	return r, nil
+}
+
+// cancelAndDeleteFlinkApplicationDeployment cancels a deployment, waits until it is canceled
+// and removes it
+func cancelAndDeleteFlinkApplicationDeployment(
+	ctx context.Context,
+	client *aiven.Client,
+	project, serviceName, applicationID, deploymentID string,
+	timeout time.Duration,
+) error {
+	r, err := getFlinkApplicationDeployment(ctx, client, project, serviceName, applicationID, deploymentID)
+	if err != nil {
+		return err
+	}
+
+	switch r.Status {
+	case flinkDeploymentStatusFinished, flinkDeploymentStatusCanceled, flinkDeploymentStatusFailed:
+	default:
+		if err := cancelFlinkApplicationDeployment(ctx, client, project, serviceName, applicationID, deploymentID); err != nil {
+			return err
+		}
+
+		_, err := waitForFlinkApplicationDeployment(
+			ctx, client, project, serviceName, applicationID, deploymentID,
+			flinkDeploymentStoppingStatuses,
+			[]string{flinkDeploymentStatusCanceled, flinkDeploymentStatusFinished, flinkDeploymentStatusFailed},
+			timeout,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return deleteFlinkApplicationDeployment(ctx, client, project, serviceName, applicationID, deploymentID)
+}
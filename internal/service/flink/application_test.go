@@ -0,0 +1,246 @@
+package flink
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubFlinkDeployment is a deployment of the stubbed API, every GET moves it to the next pending status
+type stubFlinkDeployment struct {
+	flinkApplicationDeployment
+	pending []string
+}
+
+// stubFlinkDeploymentAPI is an in-memory stand-in of the Flink application deployment endpoints
+type stubFlinkDeploymentAPI struct {
+	mu          sync.Mutex
+	deployments map[string]*stubFlinkDeployment
+	requests    []flinkApplicationDeploymentRequest
+	calls       []string
+	failStart   string
+	stuckStart  bool
+}
+
+func newStubFlinkDeploymentAPI(t *testing.T) (*stubFlinkDeploymentAPI, *aiven.Client) {
+	api := &stubFlinkDeploymentAPI{deployments: make(map[string]*stubFlinkDeployment)}
+
+	srv := httptest.NewServer(http.HandlerFunc(api.serveHTTP))
+	t.Cleanup(srv.Close)
+	t.Setenv("AIVEN_WEB_URL", srv.URL)
+
+	old := flinkDeploymentPollInterval
+	flinkDeploymentPollInterval = time.Millisecond
+	t.Cleanup(func() { flinkDeploymentPollInterval = old })
+
+	return api, &aiven.Client{APIKey: "token", Client: srv.Client()}
+}
+
+func (a *stubFlinkDeploymentAPI) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	const prefix = "/v1/project/p/service/s/flink/application/app/deployment"
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, prefix), "/"), "/")
+	a.calls = append(a.calls, strings.TrimSpace(r.Method+" "+strings.Join(parts, "/")))
+
+	if parts[0] == "" {
+		var req flinkApplicationDeploymentRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		a.requests = append(a.requests, req)
+
+		id := fmt.Sprintf("d%d", len(a.requests))
+		d := &stubFlinkDeployment{
+			flinkApplicationDeployment: flinkApplicationDeployment{
+				ID:                id,
+				VersionID:         req.VersionID,
+				Status:            "INITIALIZING",
+				Parallelism:       req.Parallelism,
+				RestartEnabled:    req.RestartEnabled,
+				StartingSavepoint: req.StartingSavepoint,
+			},
+			pending: []string{"CREATED", flinkDeploymentStatusRunning},
+		}
+		if a.failStart != "" {
+			d.pending = []string{"CREATED", flinkDeploymentStatusFailed}
+			d.ErrorMsg = a.failStart
+		}
+		if a.stuckStart {
+			d.pending = nil
+		}
+		a.deployments[id] = d
+		_ = json.NewEncoder(w).Encode(d.flinkApplicationDeployment)
+		return
+	}
+
+	d, ok := a.deployments[parts[0]]
+	if !ok {
+		http.Error(w, `{"message": "not found"}`, http.StatusNotFound)
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodGet:
+		if len(d.pending) > 0 {
+			d.Status, d.pending = d.pending[0], d.pending[1:]
+			if d.Status == flinkDeploymentStatusRunning {
+				d.JobID = "job-" + d.ID
+			}
+		}
+	case r.Method == http.MethodDelete:
+		delete(a.deployments, d.ID)
+	case len(parts) == 2 && parts[1] == "stop":
+		d.Status = "SAVING_AND_STOP_REQUESTED"
+		d.pending = []string{"SAVING_AND_STOP", flinkDeploymentStatusFinished}
+		d.LastSavepoint = "s3://savepoints/" + d.ID
+	case len(parts) == 2 && parts[1] == "cancel":
+		d.Status = "CANCELLING_REQUESTED"
+		d.pending = []string{"CANCELLING", flinkDeploymentStatusCanceled}
+	}
+
+	_ = json.NewEncoder(w).Encode(d.flinkApplicationDeployment)
+}
+
+func TestDeployFlinkApplication(t *testing.T) {
+	api, client := newStubFlinkDeploymentAPI(t)
+
+	r, err := deployFlinkApplication(context.Background(), client, "p", "s", "app", flinkApplicationDeploymentRequest{
+		VersionID:   "v1",
+		Parallelism: 2,
+	}, time.Minute)
+	require.NoError(t, err)
+
+	assert.Equal(t, flinkDeploymentStatusRunning, r.Status)
+	assert.Equal(t, "job-d1", r.JobID)
+	assert.Equal(t, 2, r.Parallelism)
+	assert.Len(t, api.requests, 1)
+}
+
+func TestDeployFlinkApplicationFailed(t *testing.T) {
+	api, client := newStubFlinkDeploymentAPI(t)
+	api.failStart = "table `orders` does not exist"
+
+	_, err := deployFlinkApplication(context.Background(), client, "p", "s", "app", flinkApplicationDeploymentRequest{
+		VersionID: "v1",
+	}, time.Minute)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "table `orders` does not exist")
+
+	// the failed deployment isn't left behind
+	assert.Contains(t, api.calls, "DELETE d1")
+	assert.Empty(t, api.deployments)
+}
+
+func TestDeployFlinkApplicationTimeout(t *testing.T) {
+	api, client := newStubFlinkDeploymentAPI(t)
+	api.stuckStart = true
+
+	_, err := deployFlinkApplication(context.Background(), client, "p", "s", "app", flinkApplicationDeploymentRequest{
+		VersionID: "v1",
+	}, 100*time.Millisecond)
+	require.Error(t, err)
+
+	// the deployment which didn't start is canceled and deleted
+	assert.Contains(t, api.calls, "POST d1/cancel")
+	assert.Contains(t, api.calls, "DELETE d1")
+	assert.Empty(t, api.deployments)
+}
+
+func TestRedeployFlinkApplication(t *testing.T) {
+	api, client := newStubFlinkDeploymentAPI(t)
+	ctx := context.Background()
+
+	d1, err := deployFlinkApplication(ctx, client, "p", "s", "app", flinkApplicationDeploymentRequest{
+		VersionID: "v1",
+	}, time.Minute)
+	require.NoError(t, err)
+
+	d2, err := redeployFlinkApplication(ctx, client, "p", "s", "app", d1.ID, flinkApplicationDeploymentRequest{
+		VersionID: "v2",
+	}, time.Minute)
+	require.NoError(t, err)
+
+	assert.Equal(t, "d2", d2.ID)
+	assert.Equal(t, flinkDeploymentStatusRunning, d2.Status)
+	assert.Equal(t, "v2", d2.VersionID)
+	assert.Equal(t, "s3://savepoints/d1", d2.StartingSavepoint)
+	assert.Contains(t, api.calls, "POST d1/stop")
+	assert.Contains(t, api.calls, "DELETE d1")
+	assert.NotContains(t, api.deployments, "d1")
+}
+
+func TestRedeployFlinkApplicationFailedKeepsStoppedDeployment(t *testing.T) {
+	api, client := newStubFlinkDeploymentAPI(t)
+	ctx := context.Background()
+
+	d1, err := deployFlinkApplication(ctx, client, "p", "s", "app", flinkApplicationDeploymentRequest{
+		VersionID: "v1",
+	}, time.Minute)
+	require.NoError(t, err)
+
+	api.failStart = "table `orders` does not exist"
+	_, err = redeployFlinkApplication(ctx, client, "p", "s", "app", d1.ID, flinkApplicationDeploymentRequest{
+		VersionID: "v2",
+	}, time.Minute)
+	require.Error(t, err)
+
+	// the next update resumes from the savepoint of the stopped deployment and the new one isn't left behind
+	assert.NotContains(t, api.calls, "DELETE d1")
+	assert.Equal(t, flinkDeploymentStatusFinished, api.deployments["d1"].Status)
+	assert.Contains(t, api.calls, "DELETE d2")
+	assert.NotContains(t, api.deployments, "d2")
+}
+
+func TestRedeployFlinkApplicationResume(t *testing.T) {
+	api, client := newStubFlinkDeploymentAPI(t)
+	ctx := context.Background()
+
+	// a previous update stopped the job with a savepoint but the new deployment failed
+	api.deployments["d0"] = &stubFlinkDeployment{
+		flinkApplicationDeployment: flinkApplicationDeployment{
+			ID:            "d0",
+			VersionID:     "v1",
+			Status:        flinkDeploymentStatusFinished,
+			LastSavepoint: "s3://savepoints/d0",
+		},
+	}
+
+	r, err := redeployFlinkApplication(ctx, client, "p", "s", "app", "d0", flinkApplicationDeploymentRequest{
+		VersionID: "v2",
+	}, time.Minute)
+	require.NoError(t, err)
+
+	assert.Equal(t, flinkDeploymentStatusRunning, r.Status)
+	assert.Equal(t, "s3://savepoints/d0", r.StartingSavepoint)
+	assert.NotContains(t, api.calls, "POST d0/stop")
+}
+
+func TestCancelAndDeleteFlinkApplicationDeployment(t *testing.T) {
+	api, client := newStubFlinkDeploymentAPI(t)
+	ctx := context.Background()
+
+	r, err := deployFlinkApplication(ctx, client, "p", "s", "app", flinkApplicationDeploymentRequest{
+		VersionID: "v1",
+	}, time.Minute)
+	require.NoError(t, err)
+
+	require.NoError(t, cancelAndDeleteFlinkApplicationDeployment(ctx, client, "p", "s", "app", r.ID, time.Minute))
+	assert.Empty(t, api.deployments)
+	assert.Contains(t, api.calls, "POST d1/cancel")
+
+	_, err = getFlinkApplicationDeployment(ctx, client, "p", "s", "app", r.ID)
+	assert.True(t, aiven.IsNotFound(err))
+}
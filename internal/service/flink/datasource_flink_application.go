@@ -0,0 +1,42 @@
+package flink
+
+import (
+	"context"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DatasourceFlinkApplication() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceFlinkApplicationRead,
+		Description: "The Flink Application data source provides information about the existing Aiven Flink Application.",
+		Schema: schemautil.ResourceSchemaAsDatasourceSchema(aivenFlinkApplicationSchema,
+			"project", "service_name", "name"),
+	}
+}
+
+func datasourceFlinkApplicationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+	name := d.Get("name").(string)
+
+	applications, err := listFlinkApplications(ctx, client, projectName, serviceName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	for _, a := range applications {
+		if a.Name == name {
+			d.SetId(schemautil.BuildResourceID(projectName, serviceName, a.ID))
+			return resourceFlinkApplicationRead(ctx, d, m)
+		}
+	}
+
+	return diag.Errorf("flink application %s not found", name)
+}
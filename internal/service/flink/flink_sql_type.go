@@ -0,0 +1,344 @@
+package flink
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// flinkSQLTypeParser is a recursive descent parser of Flink SQL data types, see
+// https://nightlies.apache.org/flink/flink-docs-stable/docs/dev/table/types/#list-of-data-types
+type flinkSQLTypeParser struct {
+	tokens []string
+	pos    int
+}
+
+// tokenizeFlinkSQLType splits a type into identifiers, numbers, quoted strings and punctuation
+func tokenizeFlinkSQLType(s string) ([]string, error) {
+	var tokens []string
+	for i := 0; i < len(s); {
+		c := rune(s[i])
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c == '\'' || c == '`':
+			end := i + 1
+			for ; end < len(s); end++ {
+				if rune(s[end]) == c {
+					if end+1 < len(s) && rune(s[end+1]) == c {
+						end++
+						continue
+					}
+					break
+				}
+			}
+			if end >= len(s) {
+				return nil, fmt.Errorf("unterminated quote at position %d", i)
+			}
+			tokens = append(tokens, s[i:end+1])
+			i = end + 1
+		case strings.ContainsRune("(),<>", c):
+			tokens = append(tokens, string(c))
+			i++
+		case c == '_' || unicode.IsLetter(c) || unicode.IsDigit(c):
+			end := i
+			for end < len(s) && (s[end] == '_' || s[end] == '$' || s[end] == '.' || unicode.IsLetter(rune(s[end])) || unicode.IsDigit(rune(s[end]))) {
+				end++
+			}
+			tokens = append(tokens, s[i:end])
+			i = end
+		default:
+			return nil, fmt.Errorf("unexpected character %q at position %d", c, i)
+		}
+	}
+	return tokens, nil
+}
+
+// validateFlinkSQLType checks that s is a valid Flink SQL data type
+func validateFlinkSQLType(s string) error {
+	tokens, err := tokenizeFlinkSQLType(s)
+	if err != nil {
+		return err
+	}
+
+	p := &flinkSQLTypeParser{tokens: tokens}
+	if err := p.parseType(); err != nil {
+		return err
+	}
+	if !p.done() {
+		return fmt.Errorf("unexpected %q after the type", p.peek())
+	}
+
+	return nil
+}
+
+func (p *flinkSQLTypeParser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *flinkSQLTypeParser) peek() string {
+	if p.done() {
+		return ""
+	}
+	return p.tokens[p.pos]
+}
+
+// accept consumes the next token when it is the keyword or punctuation kw
+func (p *flinkSQLTypeParser) accept(kw string) bool {
+	if strings.EqualFold(p.peek(), kw) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *flinkSQLTypeParser) expect(kw string) error {
+	if !p.accept(kw) {
+		if p.done() {
+			return fmt.Errorf("expected %q, got end of type", kw)
+		}
+		return fmt.Errorf("expected %q, got %q", kw, p.peek())
+	}
+	return nil
+}
+
+// parseInt parses an integer in the [min, max] range
+func (p *flinkSQLTypeParser) parseInt(what string, min, max int) (int, error) {
+	t := p.peek()
+	v, err := strconv.Atoi(t)
+	if err != nil {
+		return 0, fmt.Errorf("expected %s, got %q", what, t)
+	}
+	if v < min || v > max {
+		return 0, fmt.Errorf("%s must be between %d and %d, got %d", what, min, max, v)
+	}
+	p.pos++
+	return v, nil
+}
+
+// parseOptionalParam parses an optional "(n)" type parameter
+func (p *flinkSQLTypeParser) parseOptionalParam(what string, min, max int) error {
+	if !p.accept("(") {
+		return nil
+	}
+	if _, err := p.parseInt(what, min, max); err != nil {
+		return err
+	}
+	return p.expect(")")
+}
+
+// parseType parses a type with its array/multiset suffixes and nullability
+func (p *flinkSQLTypeParser) parseType() error {
+	if err := p.parseBaseType(); err != nil {
+		return err
+	}
+
+	for {
+		switch {
+		case p.accept("ARRAY"), p.accept("MULTISET"):
+		case p.accept("NOT"):
+			if err := p.expect("NULL"); err != nil {
+				return err
+			}
+		case p.accept("NULL"):
+		default:
+			return nil
+		}
+	}
+}
+
+func (p *flinkSQLTypeParser) parseBaseType() error {
+	const maxLength = 2147483647
+
+	t := strings.ToUpper(p.peek())
+	if t == "" {
+		return fmt.Errorf("expected a type, got end of type")
+	}
+	p.pos++
+
+	switch t {
+	case "STRING", "BYTES", "BOOLEAN", "TINYINT", "SMALLINT", "INT", "INTEGER", "BIGINT", "FLOAT", "DATE", "NULL":
+		return nil
+	case "DOUBLE":
+		p.accept("PRECISION")
+		return nil
+	case "CHAR", "VARCHAR", "BINARY", "VARBINARY":
+		return p.parseOptionalParam(strings.ToLower(t)+" length", 1, maxLength)
+	case "DECIMAL", "DEC", "NUMERIC":
+		if !p.accept("(") {
+			return nil
+		}
+		precision, err := p.parseInt("decimal precision", 1, 38)
+		if err != nil {
+			return err
+		}
+		if p.accept(",") {
+			if _, err := p.parseInt("decimal scale", 0, precision); err != nil {
+				return err
+			}
+		}
+		return p.expect(")")
+	case "TIME":
+		if err := p.parseOptionalParam("time precision", 0, 9); err != nil {
+			return err
+		}
+		return p.parseTimeZone(false)
+	case "TIMESTAMP":
+		if err := p.parseOptionalParam("timestamp precision", 0, 9); err != nil {
+			return err
+		}
+		return p.parseTimeZone(true)
+	case "TIMESTAMP_LTZ":
+		return p.parseOptionalParam("timestamp precision", 0, 9)
+	case "INTERVAL":
+		return p.parseInterval()
+	case "ARRAY", "MULTISET":
+		if err := p.expect("<"); err != nil {
+			return err
+		}
+		if err := p.parseType(); err != nil {
+			return err
+		}
+		return p.expect(">")
+	case "MAP":
+		if err := p.expect("<"); err != nil {
+			return err
+		}
+		if err := p.parseType(); err != nil {
+			return err
+		}
+		if err := p.expect(","); err != nil {
+			return err
+		}
+		if err := p.parseType(); err != nil {
+			return err
+		}
+		return p.expect(">")
+	case "ROW":
+		return p.parseRow()
+	case "RAW":
+		if err := p.expect("("); err != nil {
+			return err
+		}
+		if !strings.HasPrefix(p.peek(), "'") {
+			return fmt.Errorf("expected a quoted class name, got %q", p.peek())
+		}
+		p.pos++
+		if p.accept(",") {
+			if !strings.HasPrefix(p.peek(), "'") {
+				return fmt.Errorf("expected a quoted serializer snapshot, got %q", p.peek())
+			}
+			p.pos++
+		}
+		return p.expect(")")
+	}
+
+	return fmt.Errorf("unknown type %q", p.tokens[p.pos-1])
+}
+
+// parseTimeZone parses the optional "WITHOUT TIME ZONE" or "WITH LOCAL TIME ZONE" suffix
+func (p *flinkSQLTypeParser) parseTimeZone(local bool) error {
+	switch {
+	case p.accept("WITHOUT"):
+	case local && p.accept("WITH"):
+		if err := p.expect("LOCAL"); err != nil {
+			return err
+		}
+	default:
+		return nil
+	}
+	if err := p.expect("TIME"); err != nil {
+		return err
+	}
+	return p.expect("ZONE")
+}
+
+// parseInterval parses the year-month and day-time interval types
+func (p *flinkSQLTypeParser) parseInterval() error {
+	switch {
+	case p.accept("YEAR"):
+		if err := p.parseOptionalParam("year precision", 1, 4); err != nil {
+			return err
+		}
+		if p.accept("TO") {
+			return p.expect("MONTH")
+		}
+		return nil
+	case p.accept("MONTH"):
+		return nil
+	case p.accept("DAY"):
+		if err := p.parseOptionalParam("day precision", 1, 6); err != nil {
+			return err
+		}
+		if !p.accept("TO") {
+			return nil
+		}
+		if p.accept("HOUR") || p.accept("MINUTE") {
+			return nil
+		}
+		if err := p.expect("SECOND"); err != nil {
+			return err
+		}
+		return p.parseOptionalParam("fractional precision", 0, 9)
+	case p.accept("HOUR"):
+		if !p.accept("TO") {
+			return nil
+		}
+		if p.accept("MINUTE") {
+			return nil
+		}
+		if err := p.expect("SECOND"); err != nil {
+			return err
+		}
+		return p.parseOptionalParam("fractional precision", 0, 9)
+	case p.accept("MINUTE"):
+		if !p.accept("TO") {
+			return nil
+		}
+		if err := p.expect("SECOND"); err != nil {
+			return err
+		}
+		return p.parseOptionalParam("fractional precision", 0, 9)
+	case p.accept("SECOND"):
+		return p.parseOptionalParam("fractional precision", 0, 9)
+	}
+
+	return fmt.Errorf("expected an interval resolution, got %q", p.peek())
+}
+
+// parseRow parses both ROW<name type, ...> and ROW(name type, ...), fields may have a quoted description
+func (p *flinkSQLTypeParser) parseRow() error {
+	closing := ">"
+	if p.accept("(") {
+		closing = ")"
+	} else if err := p.expect("<"); err != nil {
+		return err
+	}
+
+	if p.accept(closing) {
+		return nil
+	}
+
+	for {
+		name := p.peek()
+		if name == "" || strings.ContainsAny(name, "(),<>'") {
+			return fmt.Errorf("expected a row field name, got %q", name)
+		}
+		p.pos++
+
+		if err := p.parseType(); err != nil {
+			return fmt.Errorf("row field %s: %w", name, err)
+		}
+		if strings.HasPrefix(p.peek(), "'") {
+			p.pos++
+		}
+
+		if p.accept(closing) {
+			return nil
+		}
+		if err := p.expect(","); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package flink
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateFlinkSQLType(t *testing.T) {
+	valid := []string{
+		"INT",
+		"integer not null",
+		"STRING",
+		"VARCHAR(255)",
+		"CHAR",
+		"DECIMAL(10, 2)",
+		"NUMERIC(38)",
+		"DOUBLE PRECISION",
+		"TIME(3) WITHOUT TIME ZONE",
+		"TIMESTAMP(3)",
+		"TIMESTAMP(6) WITH LOCAL TIME ZONE",
+		"TIMESTAMP_LTZ(3)",
+		"INTERVAL DAY(2) TO SECOND(3)",
+		"INTERVAL YEAR TO MONTH",
+		"INTERVAL MINUTE TO SECOND",
+		"ARRAY<INT NOT NULL>",
+		"INT ARRAY",
+		"MULTISET<STRING>",
+		"MAP<STRING, ARRAY<ROW<a INT, b STRING>>>",
+		"ROW<`user id` BIGINT 'the user', name STRING>",
+		"ROW(a INT, b MAP<STRING, DOUBLE>)",
+		"RAW('java.lang.Object', 'snapshot')",
+	}
+	for _, v := range valid {
+		assert.NoError(t, validateFlinkSQLType(v), v)
+	}
+
+	invalid := map[string]string{
+		"":                          "expected a type",
+		"INTEGR":                    `unknown type "INTEGR"`,
+		"VARCHAR(0)":                "varchar length must be between 1 and 2147483647",
+		"DECIMAL(39, 2)":            "decimal precision must be between 1 and 38",
+		"DECIMAL(10, 11)":           "decimal scale must be between 0 and 10",
+		"TIMESTAMP(12)":             "timestamp precision must be between 0 and 9",
+		"TIMESTAMP WITH TIME ZONE":  `expected "LOCAL"`,
+		"TIME WITH LOCAL TIME ZONE": `unexpected "WITH"`,
+		"ARRAY<INT":                 `expected ">"`,
+		"MAP<STRING>":               `expected ","`,
+		"ROW<a>":                    "row field a",
+		"INTERVAL WEEK":             "expected an interval resolution",
+		"INT NOT":                   `expected "NULL"`,
+		"VARCHAR(10) CHARACTER SET": `unexpected "CHARACTER"`,
+		"STRING;":                   "unexpected character",
+	}
+	for v, want := range invalid {
+		err := validateFlinkSQLType(v)
+		if assert.Error(t, err, v) {
+			assert.Contains(t, err.Error(), want, v)
+		}
+	}
+}
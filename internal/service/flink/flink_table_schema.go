@@ -0,0 +1,399 @@
+package flink
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// flinkTableColumnSchema is the structured alternative to schema_sql
+var flinkTableColumnSchema = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"name": {
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			Description: schemautil.Complex("Column name.").ForceNew().Build(),
+		},
+		"type": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			ValidateFunc: validateFlinkSQLTypeFunc,
+			Description:  schemautil.Complex("Flink SQL data type of a physical column, e.g. `INT`, `DECIMAL(10, 2)` or `ROW<a INT, b STRING>`. Exactly one of `type` and `computed` must be set.").ForceNew().Build(),
+		},
+		"nullable": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			ForceNew:    true,
+			Default:     true,
+			Description: schemautil.Complex("Whether the column accepts null values.").DefaultValue(true).ForceNew().Build(),
+		},
+		"computed": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			ForceNew:    true,
+			Description: schemautil.Complex("SQL expression of a computed column, e.g. `price * quantity`.").ForceNew().Build(),
+		},
+		"watermark": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			ForceNew:    true,
+			Description: schemautil.Complex("Watermark strategy expression of a time attribute column, e.g. `occurred_at - INTERVAL '5' SECOND`.").ForceNew().Build(),
+		},
+		"primary_key": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			ForceNew:    true,
+			Description: schemautil.Complex("Whether the column is part of the primary key of the table.").ForceNew().Build(),
+		},
+	},
+}
+
+// flinkTableColumn is a column of the table schema, either from a column block or parsed from schema_sql
+type flinkTableColumn struct {
+	Name       string
+	Type       string
+	Nullable   bool
+	Computed   string
+	Watermark  string
+	PrimaryKey bool
+	// Physical is false for computed and metadata columns, which can't be used as key fields
+	Physical bool
+}
+
+func validateFlinkSQLTypeFunc(i interface{}, k string) ([]string, []error) {
+	if err := validateFlinkSQLType(i.(string)); err != nil {
+		return nil, []error{fmt.Errorf("%s: invalid Flink SQL type %q: %w", k, i, err)}
+	}
+	return nil, nil
+}
+
+func flinkTableColumnsFromSchema(v []interface{}) []flinkTableColumn {
+	columns := make([]flinkTableColumn, 0, len(v))
+	for _, c := range v {
+		c := c.(map[string]interface{})
+		columns = append(columns, flinkTableColumn{
+			Name:       c["name"].(string),
+			Type:       c["type"].(string),
+			Nullable:   c["nullable"].(bool),
+			Computed:   c["computed"].(string),
+			Watermark:  c["watermark"].(string),
+			PrimaryKey: c["primary_key"].(bool),
+			Physical:   c["computed"].(string) == "",
+		})
+	}
+	return columns
+}
+
+func flinkTableColumnsToSchema(columns []flinkTableColumn) []map[string]interface{} {
+	res := make([]map[string]interface{}, 0, len(columns))
+	for _, c := range columns {
+		res = append(res, map[string]interface{}{
+			"name":        c.Name,
+			"type":        c.Type,
+			"nullable":    c.Nullable,
+			"computed":    c.Computed,
+			"watermark":   c.Watermark,
+			"primary_key": c.PrimaryKey,
+		})
+	}
+	return res
+}
+
+func quoteFlinkIdentifier(s string) string {
+	return "`" + strings.ReplaceAll(s, "`", "``") + "`"
+}
+
+// flinkTableSchemaSQL renders the columns as the column list of a CREATE TABLE statement
+func flinkTableSchemaSQL(columns []flinkTableColumn) string {
+	var lines, watermarks, primaryKey []string
+	for _, c := range columns {
+		name := quoteFlinkIdentifier(c.Name)
+		if c.Computed != "" {
+			lines = append(lines, name+" AS "+c.Computed)
+		} else {
+			line := name + " " + c.Type
+			if !c.Nullable {
+				line += " NOT NULL"
+			}
+			lines = append(lines, line)
+		}
+		if c.Watermark != "" {
+			watermarks = append(watermarks, "WATERMARK FOR "+name+" AS "+c.Watermark)
+		}
+		if c.PrimaryKey {
+			primaryKey = append(primaryKey, name)
+		}
+	}
+
+	lines = append(lines, watermarks...)
+	if len(primaryKey) > 0 {
+		lines = append(lines, "PRIMARY KEY ("+strings.Join(primaryKey, ", ")+") NOT ENFORCED")
+	}
+
+	return strings.Join(lines, ",\n")
+}
+
+// validateFlinkTableColumns checks the column blocks, the SQL types are checked by the schema
+func validateFlinkTableColumns(columns []flinkTableColumn) error {
+	seen := make(map[string]bool, len(columns))
+	for i, c := range columns {
+		if seen[c.Name] {
+			return fmt.Errorf("column.%d: duplicate column name %q", i, c.Name)
+		}
+		seen[c.Name] = true
+
+		if (c.Type == "") == (c.Computed == "") {
+			return fmt.Errorf("column.%d: exactly one of `type` and `computed` must be set for column %q", i, c.Name)
+		}
+		if c.PrimaryKey && c.Computed != "" {
+			return fmt.Errorf("column.%d: computed column %q can't be part of the primary key", i, c.Name)
+		}
+	}
+	return nil
+}
+
+// flinkSQLCollectionTypes are the types taking their parameters in angle brackets
+var flinkSQLCollectionTypes = map[string]bool{"ARRAY": true, "MULTISET": true, "MAP": true, "ROW": true}
+
+// splitFlinkTableSchemaSQL splits a schema_sql column list on the top level commas
+func splitFlinkTableSchemaSQL(sql string) []string {
+	var parts []string
+	var b strings.Builder
+	depth, angles := 0, 0
+
+	for i := 0; i < len(sql); i++ {
+		c := sql[i]
+		switch c {
+		case '\'', '`', '"':
+			end := i + 1
+			for end < len(sql) && sql[end] != c {
+				end++
+			}
+			if end >= len(sql) {
+				end = len(sql) - 1
+			}
+			b.WriteString(sql[i : end+1])
+			i = end
+			continue
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case '<':
+			// only the angle brackets of ARRAY<...>, MAP<...> etc. nest, others are comparisons
+			prev := strings.TrimRight(b.String(), " \t\r\n")
+			word := prev[strings.LastIndexFunc(prev, func(r rune) bool { return !unicode.IsLetter(r) })+1:]
+			if flinkSQLCollectionTypes[strings.ToUpper(word)] {
+				angles++
+				depth++
+			}
+		case '>':
+			if angles > 0 {
+				angles--
+				depth--
+			}
+		case ',':
+			if depth == 0 {
+				parts = append(parts, strings.TrimSpace(b.String()))
+				b.Reset()
+				continue
+			}
+		}
+		b.WriteByte(c)
+	}
+	if s := strings.TrimSpace(b.String()); s != "" {
+		parts = append(parts, s)
+	}
+
+	return parts
+}
+
+// flinkTableColumnsFromSQL extracts the columns of a schema_sql column list. Only the names and
+// whether a column is physical are recovered, which is what the key field checks need.
+func flinkTableColumnsFromSQL(sql string) []flinkTableColumn {
+	var columns []flinkTableColumn
+	for _, part := range splitFlinkTableSchemaSQL(sql) {
+		fields := strings.Fields(part)
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch strings.ToUpper(fields[0]) {
+		case "WATERMARK", "PRIMARY", "CONSTRAINT", "PERIOD":
+			continue
+		}
+
+		name := fields[0]
+		if strings.HasPrefix(name, "`") {
+			end := strings.Index(part[1:], "`")
+			if end < 0 {
+				continue
+			}
+			name = strings.ReplaceAll(part[1:end+1], "``", "`")
+			fields = strings.Fields(part[end+2:])
+		} else {
+			fields = fields[1:]
+		}
+
+		physical := true
+		if len(fields) > 0 && strings.EqualFold(fields[0], "AS") {
+			physical = false
+		}
+		for _, f := range fields {
+			if strings.EqualFold(f, "METADATA") {
+				physical = false
+			}
+		}
+
+		columns = append(columns, flinkTableColumn{Name: name, Physical: physical})
+	}
+
+	return columns
+}
+
+var (
+	flinkSQLNotNullSuffix    = regexp.MustCompile(`(?i)\s+NOT\s+NULL$`)
+	flinkSQLNullSuffix       = regexp.MustCompile(`(?i)\s+NULL$`)
+	flinkSQLPrimaryKeySuffix = regexp.MustCompile(`(?i)\s+PRIMARY\s+KEY\s+NOT\s+ENFORCED$`)
+	flinkSQLConstraintPrefix = regexp.MustCompile("(?i)^CONSTRAINT\\s+(`(?:[^`]|``)*`|\\S+)\\s+")
+	flinkSQLPrimaryKey       = regexp.MustCompile(`(?is)^PRIMARY\s+KEY\s*\((.*)\)\s*NOT\s+ENFORCED$`)
+	flinkSQLWatermark        = regexp.MustCompile("(?is)^WATERMARK\\s+FOR\\s+(`(?:[^`]|``)*`|\\S+)\\s+AS\\s+(.*)$")
+)
+
+// readFlinkIdentifier reads the identifier at the start of s, quoted with backticks or not, and returns
+// it with the rest of s
+func readFlinkIdentifier(s string) (string, string, error) {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "`") {
+		end := strings.IndexFunc(s, unicode.IsSpace)
+		if end < 0 {
+			end = len(s)
+		}
+		return s[:end], strings.TrimSpace(s[end:]), nil
+	}
+
+	for i := 1; i < len(s); i++ {
+		if s[i] != '`' {
+			continue
+		}
+		if i+1 < len(s) && s[i+1] == '`' {
+			i++
+			continue
+		}
+		return strings.ReplaceAll(s[1:i], "``", "`"), strings.TrimSpace(s[i+1:]), nil
+	}
+	return "", "", fmt.Errorf("unterminated identifier %s", s)
+}
+
+// parseFlinkTableSchemaSQL reads the column blocks back from a schema_sql column list, it is the reverse of
+// flinkTableSchemaSQL. The schemas the column blocks can't express, e.g. with metadata columns, are an error
+func parseFlinkTableSchemaSQL(sql string) ([]flinkTableColumn, error) {
+	var columns []flinkTableColumn
+	index := make(map[string]int)
+	var watermarks [][2]string
+	var primaryKey []string
+
+	for _, part := range splitFlinkTableSchemaSQL(sql) {
+		part = flinkSQLConstraintPrefix.ReplaceAllString(part, "")
+
+		if m := flinkSQLWatermark.FindStringSubmatch(part); m != nil {
+			name, _, err := readFlinkIdentifier(m[1])
+			if err != nil {
+				return nil, err
+			}
+			watermarks = append(watermarks, [2]string{name, strings.TrimSpace(m[2])})
+			continue
+		}
+		if m := flinkSQLPrimaryKey.FindStringSubmatch(part); m != nil {
+			for _, k := range splitFlinkTableSchemaSQL(m[1]) {
+				name, rest, err := readFlinkIdentifier(k)
+				if err != nil {
+					return nil, err
+				}
+				if rest != "" {
+					return nil, fmt.Errorf("unexpected %q in the primary key", rest)
+				}
+				primaryKey = append(primaryKey, name)
+			}
+			continue
+		}
+
+		name, rest, err := readFlinkIdentifier(part)
+		if err != nil {
+			return nil, err
+		}
+		c := flinkTableColumn{Name: name, Nullable: true}
+		if len(rest) > 3 && strings.EqualFold(rest[:3], "AS ") {
+			c.Computed = strings.TrimSpace(rest[3:])
+		} else {
+			if flinkSQLPrimaryKeySuffix.MatchString(rest) {
+				c.PrimaryKey = true
+				rest = flinkSQLPrimaryKeySuffix.ReplaceAllString(rest, "")
+			}
+			if flinkSQLNotNullSuffix.MatchString(rest) {
+				c.Nullable = false
+				rest = flinkSQLNotNullSuffix.ReplaceAllString(rest, "")
+			} else {
+				rest = flinkSQLNullSuffix.ReplaceAllString(rest, "")
+			}
+			if err := validateFlinkSQLType(rest); err != nil {
+				return nil, fmt.Errorf("column %q: %q is not a Flink SQL type: %w", name, rest, err)
+			}
+			c.Type = rest
+			c.Physical = true
+		}
+
+		index[name] = len(columns)
+		columns = append(columns, c)
+	}
+
+	for _, w := range watermarks {
+		i, ok := index[w[0]]
+		if !ok {
+			return nil, fmt.Errorf("watermark for unknown column %q", w[0])
+		}
+		columns[i].Watermark = w[1]
+	}
+	for _, k := range primaryKey {
+		i, ok := index[k]
+		if !ok {
+			return nil, fmt.Errorf("primary key of unknown column %q", k)
+		}
+		columns[i].PrimaryKey = true
+	}
+
+	return columns, nil
+}
+
+// validateFlinkTableKeyFields checks that the key fields refer to physical columns of the table
+func validateFlinkTableKeyFields(columns []flinkTableColumn, attribute string, keyFields []string) error {
+	physical := make(map[string]bool, len(columns))
+	for _, c := range columns {
+		physical[c.Name] = c.Physical
+	}
+
+	var errs []string
+	for _, f := range keyFields {
+		isPhysical, ok := physical[f]
+		switch {
+		case !ok:
+			errs = append(errs, fmt.Sprintf("%s: column %q does not exist in the table schema", attribute, f))
+		case !isPhysical:
+			errs = append(errs, fmt.Sprintf("%s: column %q is not a physical column", attribute, f))
+		}
+	}
+
+	if len(errs) > 0 {
+		sort.Strings(errs)
+		return fmt.Errorf("%s", strings.Join(errs, "\n"))
+	}
+
+	return nil
+}
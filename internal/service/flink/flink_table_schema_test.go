@@ -0,0 +1,119 @@
+package flink
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlinkTableSchemaSQL(t *testing.T) {
+	columns := []flinkTableColumn{
+		{Name: "id", Type: "BIGINT", PrimaryKey: true},
+		{Name: "price", Type: "DECIMAL(10, 2)", Nullable: true},
+		{Name: "quantity", Type: "INT", Nullable: true},
+		{Name: "total", Computed: "price * quantity"},
+		{Name: "occurred_at", Type: "TIMESTAMP(3)", Nullable: true, Watermark: "occurred_at - INTERVAL '5' SECOND"},
+	}
+
+	assert.NoError(t, validateFlinkTableColumns(columns))
+	assert.Equal(t, "`id` BIGINT NOT NULL,\n"+
+		"`price` DECIMAL(10, 2),\n"+
+		"`quantity` INT,\n"+
+		"`total` AS price * quantity,\n"+
+		"`occurred_at` TIMESTAMP(3),\n"+
+		"WATERMARK FOR `occurred_at` AS occurred_at - INTERVAL '5' SECOND,\n"+
+		"PRIMARY KEY (`id`) NOT ENFORCED",
+		flinkTableSchemaSQL(columns),
+	)
+}
+
+func TestParseFlinkTableSchemaSQL(t *testing.T) {
+	v := []interface{}{
+		map[string]interface{}{"name": "id", "type": "BIGINT", "nullable": false, "computed": "", "watermark": "", "primary_key": true},
+		map[string]interface{}{"name": "price", "type": "DECIMAL(10, 2)", "nullable": true, "computed": "", "watermark": "", "primary_key": false},
+		map[string]interface{}{"name": "user, id", "type": "MAP<STRING, ARRAY<INT>>", "nullable": true, "computed": "", "watermark": "", "primary_key": false},
+		map[string]interface{}{"name": "total", "type": "", "nullable": true, "computed": "price * 2", "watermark": "", "primary_key": false},
+		map[string]interface{}{"name": "occurred_at", "type": "TIMESTAMP(3)", "nullable": true, "computed": "", "watermark": "occurred_at - INTERVAL '5' SECOND", "primary_key": false},
+	}
+	columns := flinkTableColumnsFromSchema(v)
+
+	// the column blocks are read back from the SQL rendered from them
+	parsed, err := parseFlinkTableSchemaSQL(flinkTableSchemaSQL(columns))
+	require.NoError(t, err)
+	assert.Equal(t, columns, parsed)
+
+	parsed, err = parseFlinkTableSchemaSQL(`
+		id BIGINT NOT NULL PRIMARY KEY NOT ENFORCED,
+		name STRING NULL,
+		occurred_at TIMESTAMP(3),
+		watermark for occurred_at as occurred_at
+	`)
+	require.NoError(t, err)
+	assert.Equal(t, []flinkTableColumn{
+		{Name: "id", Type: "BIGINT", PrimaryKey: true, Physical: true},
+		{Name: "name", Type: "STRING", Nullable: true, Physical: true},
+		{Name: "occurred_at", Type: "TIMESTAMP(3)", Nullable: true, Watermark: "occurred_at", Physical: true},
+	}, parsed)
+
+	parsed, err = parseFlinkTableSchemaSQL("a INT, b INT, CONSTRAINT pk PRIMARY KEY (a, `b`) NOT ENFORCED")
+	require.NoError(t, err)
+	assert.True(t, parsed[0].PrimaryKey)
+	assert.True(t, parsed[1].PrimaryKey)
+
+	_, err = parseFlinkTableSchemaSQL("occurred_at TIMESTAMP(3) METADATA FROM 'timestamp'")
+	assert.Error(t, err)
+	_, err = parseFlinkTableSchemaSQL("a INT, WATERMARK FOR b AS b")
+	assert.EqualError(t, err, `watermark for unknown column "b"`)
+}
+
+func TestValidateFlinkTableColumns(t *testing.T) {
+	assert.EqualError(t,
+		validateFlinkTableColumns([]flinkTableColumn{{Name: "a", Type: "INT"}, {Name: "a", Type: "INT"}}),
+		`column.1: duplicate column name "a"`,
+	)
+	assert.EqualError(t,
+		validateFlinkTableColumns([]flinkTableColumn{{Name: "a"}}),
+		"column.0: exactly one of `type` and `computed` must be set for column \"a\"",
+	)
+	assert.EqualError(t,
+		validateFlinkTableColumns([]flinkTableColumn{{Name: "a", Type: "INT", Computed: "1"}}),
+		"column.0: exactly one of `type` and `computed` must be set for column \"a\"",
+	)
+	assert.EqualError(t,
+		validateFlinkTableColumns([]flinkTableColumn{{Name: "a", Computed: "1", PrimaryKey: true}}),
+		`column.0: computed column "a" can't be part of the primary key`,
+	)
+}
+
+func TestFlinkTableColumnsFromSQL(t *testing.T) {
+	sql := `
+		cpu INT,
+		node INT,
+		` + "`user, id`" + ` MAP<STRING, ARRAY<INT>>,
+		is_high AS cpu > 75,
+		occurred_at TIMESTAMP(3) METADATA FROM 'timestamp',
+		WATERMARK FOR occurred_at AS occurred_at - INTERVAL '5' SECOND,
+		PRIMARY KEY (node) NOT ENFORCED
+	`
+
+	assert.Equal(t, []flinkTableColumn{
+		{Name: "cpu", Physical: true},
+		{Name: "node", Physical: true},
+		{Name: "user, id", Physical: true},
+		{Name: "is_high"},
+		{Name: "occurred_at"},
+	}, flinkTableColumnsFromSQL(sql))
+}
+
+func TestValidateFlinkTableKeyFields(t *testing.T) {
+	columns := flinkTableColumnsFromSQL("cpu INT, node INT, total AS cpu * 2")
+
+	assert.NoError(t, validateFlinkTableKeyFields(columns, "kafka_key_fields", []string{"node", "cpu"}))
+	assert.NoError(t, validateFlinkTableKeyFields(columns, "kafka_key_fields", nil))
+	assert.EqualError(t,
+		validateFlinkTableKeyFields(columns, "kafka_key_fields", []string{"host", "total"}),
+		"kafka_key_fields: column \"host\" does not exist in the table schema\n"+
+			"kafka_key_fields: column \"total\" is not a physical column",
+	)
+}
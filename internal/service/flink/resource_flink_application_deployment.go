@@ -0,0 +1,248 @@
+package flink
+
+import (
+	"context"
+	"time"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var aivenFlinkApplicationDeploymentSchema = map[string]*schema.Schema{
+	"project":      schemautil.CommonSchemaProjectReference,
+	"service_name": schemautil.CommonSchemaServiceNameReference,
+
+	"application_id": {
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: schemautil.Complex("Application ID.").Referenced().ForceNew().Build(),
+	},
+	"version_id": {
+		Type:     schema.TypeString,
+		Required: true,
+		Description: schemautil.Complex("The application version to deploy. Changing it stops the running job " +
+			"with a savepoint and starts the new version from that savepoint.").Referenced().Build(),
+	},
+	"parallelism": {
+		Type:         schema.TypeInt,
+		Optional:     true,
+		Default:      1,
+		ValidateFunc: validation.IntBetween(1, 128),
+		Description:  schemautil.Complex("Flink job parallelism. Changing it restarts the job from a savepoint.").DefaultValue(1).Build(),
+	},
+	"restart_enabled": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     true,
+		Description: schemautil.Complex("Restart the Flink job when it fails.").DefaultValue(true).Build(),
+	},
+	"starting_savepoint": {
+		Type:        schema.TypeString,
+		Optional:    true,
+		ForceNew:    true,
+		Description: schemautil.Complex("Savepoint to start the first deployment from.").ForceNew().Build(),
+	},
+
+	// computed fields
+	"deployment_id": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Deployment ID, a new deployment is created every time the job is restarted.",
+	},
+	"status": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Deployment status.",
+	},
+	"job_id": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The ID of the Flink job run by the deployment.",
+	},
+	"last_savepoint": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The last savepoint taken of the Flink job.",
+	},
+	"created_at": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Deployment creation time.",
+	},
+	"created_by": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The user who created the deployment.",
+	},
+}
+
+func ResourceFlinkApplicationDeployment() *schema.Resource {
+	return &schema.Resource{
+		Description:   "The Flink Application Deployment resource runs a Flink Application Version as a Flink job.",
+		CreateContext: resourceFlinkApplicationDeploymentCreate,
+		ReadContext:   resourceFlinkApplicationDeploymentRead,
+		UpdateContext: resourceFlinkApplicationDeploymentUpdate,
+		DeleteContext: resourceFlinkApplicationDeploymentDelete,
+		CustomizeDiff: resourceFlinkApplicationDeploymentCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+
+		Schema: aivenFlinkApplicationDeploymentSchema,
+	}
+}
+
+// resourceFlinkApplicationDeploymentCustomizeDiff marks the fields of the deployment as unknown
+// when a change replaces it with a new deployment
+func resourceFlinkApplicationDeploymentCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" || !d.HasChanges("version_id", "parallelism", "restart_enabled") {
+		return nil
+	}
+
+	for _, k := range []string{"deployment_id", "job_id", "status", "last_savepoint", "created_at", "created_by"} {
+		if err := d.SetNewComputed(k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func flinkApplicationDeploymentRequestFromSchema(d *schema.ResourceData) flinkApplicationDeploymentRequest {
+	return flinkApplicationDeploymentRequest{
+		VersionID:         d.Get("version_id").(string),
+		Parallelism:       d.Get("parallelism").(int),
+		RestartEnabled:    d.Get("restart_enabled").(bool),
+		StartingSavepoint: d.Get("starting_savepoint").(string),
+	}
+}
+
+func resourceFlinkApplicationDeploymentCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	project := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+	applicationID := d.Get("application_id").(string)
+
+	r, err := deployFlinkApplication(
+		ctx, client, project, serviceName, applicationID,
+		flinkApplicationDeploymentRequestFromSchema(d),
+		d.Timeout(schema.TimeoutCreate),
+	)
+	if err != nil {
+		return diag.Errorf("error waiting for Flink application deployment to be running: %s", err)
+	}
+
+	d.SetId(schemautil.BuildResourceID(project, serviceName, applicationID, r.ID))
+
+	return resourceFlinkApplicationDeploymentRead(ctx, d, m)
+}
+
+func resourceFlinkApplicationDeploymentRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	project, serviceName, applicationID, deploymentID, err := schemautil.SplitResourceID4(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	r, err := getFlinkApplicationDeployment(ctx, client, project, serviceName, applicationID, deploymentID)
+	if err != nil {
+		return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
+	}
+
+	if err := d.Set("project", project); err != nil {
+		return diag.Errorf("error setting Flink Application Deployment `project` for resource %s: %s", d.Id(), err)
+	}
+	if err := d.Set("service_name", serviceName); err != nil {
+		return diag.Errorf("error setting Flink Application Deployment `service_name` for resource %s: %s", d.Id(), err)
+	}
+	if err := d.Set("application_id", applicationID); err != nil {
+		return diag.Errorf("error setting Flink Application Deployment `application_id` for resource %s: %s", d.Id(), err)
+	}
+	if err := d.Set("deployment_id", r.ID); err != nil {
+		return diag.Errorf("error setting Flink Application Deployment `deployment_id` for resource %s: %s", d.Id(), err)
+	}
+	if err := d.Set("version_id", r.VersionID); err != nil {
+		return diag.Errorf("error setting Flink Application Deployment `version_id` for resource %s: %s", d.Id(), err)
+	}
+	if r.Parallelism != 0 {
+		if err := d.Set("parallelism", r.Parallelism); err != nil {
+			return diag.Errorf("error setting Flink Application Deployment `parallelism` for resource %s: %s", d.Id(), err)
+		}
+	}
+	if err := d.Set("restart_enabled", r.RestartEnabled); err != nil {
+		return diag.Errorf("error setting Flink Application Deployment `restart_enabled` for resource %s: %s", d.Id(), err)
+	}
+	if err := d.Set("status", r.Status); err != nil {
+		return diag.Errorf("error setting Flink Application Deployment `status` for resource %s: %s", d.Id(), err)
+	}
+	if err := d.Set("job_id", r.JobID); err != nil {
+		return diag.Errorf("error setting Flink Application Deployment `job_id` for resource %s: %s", d.Id(), err)
+	}
+	if err := d.Set("last_savepoint", r.LastSavepoint); err != nil {
+		return diag.Errorf("error setting Flink Application Deployment `last_savepoint` for resource %s: %s", d.Id(), err)
+	}
+	if err := d.Set("created_at", r.CreatedAt); err != nil {
+		return diag.Errorf("error setting Flink Application Deployment `created_at` for resource %s: %s", d.Id(), err)
+	}
+	if err := d.Set("created_by", r.CreatedBy); err != nil {
+		return diag.Errorf("error setting Flink Application Deployment `created_by` for resource %s: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+// resourceFlinkApplicationDeploymentUpdate replaces the deployment: the running job is stopped
+// with a savepoint, a new deployment is started from it and the stopped one is deleted. When a
+// previous update failed after the job was stopped, the stopped deployment's savepoint is reused.
+func resourceFlinkApplicationDeploymentUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	project, serviceName, applicationID, deploymentID, err := schemautil.SplitResourceID4(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	r, err := redeployFlinkApplication(
+		ctx, client, project, serviceName, applicationID, deploymentID,
+		flinkApplicationDeploymentRequestFromSchema(d),
+		d.Timeout(schema.TimeoutUpdate),
+	)
+	if r != nil {
+		d.SetId(schemautil.BuildResourceID(project, serviceName, applicationID, r.ID))
+	}
+	if err != nil {
+		return diag.Errorf("error redeploying Flink application: %s", err)
+	}
+
+	return resourceFlinkApplicationDeploymentRead(ctx, d, m)
+}
+
+func resourceFlinkApplicationDeploymentDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	project, serviceName, applicationID, deploymentID, err := schemautil.SplitResourceID4(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = cancelAndDeleteFlinkApplicationDeployment(
+		ctx, client, project, serviceName, applicationID, deploymentID,
+		d.Timeout(schema.TimeoutDelete),
+	)
+	if err != nil && !aiven.IsNotFound(err) {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,186 @@
+package flink_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	acc "github.com/aiven/terraform-provider-aiven/internal/acctest"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func testAccFlinkApplicationManifest(projectName, flinkServiceName, kafkaServiceName, topicName, parallelism, where string) string {
+	return fmt.Sprintf(`
+variable "project_name" {
+  type    = string
+  default = "%s"
+}
+
+variable "service_name_flink" {
+  type    = string
+  default = "%s"
+}
+
+variable "service_name_kafka" {
+  type    = string
+  default = "%s"
+}
+
+variable "topic_name" {
+  type    = string
+  default = "%s"
+}
+
+resource "aiven_flink" "testing" {
+  project      = var.project_name
+  cloud_name   = "google-europe-west1"
+  plan         = "startup-4"
+  service_name = var.service_name_flink
+}
+
+resource "aiven_kafka" "testing" {
+  project      = var.project_name
+  cloud_name   = "google-europe-west1"
+  plan         = "startup-2"
+  service_name = var.service_name_kafka
+}
+
+resource "aiven_kafka_topic" "source" {
+  project      = aiven_kafka.testing.project
+  service_name = aiven_kafka.testing.service_name
+  topic_name   = "${var.topic_name}-source"
+  replication  = 2
+  partitions   = 2
+}
+
+resource "aiven_kafka_topic" "sink" {
+  project      = aiven_kafka.testing.project
+  service_name = aiven_kafka.testing.service_name
+  topic_name   = "${var.topic_name}-sink"
+  replication  = 2
+  partitions   = 2
+}
+
+resource "aiven_service_integration" "flinkkafka" {
+  project                  = aiven_flink.testing.project
+  integration_type         = "flink"
+  destination_service_name = aiven_flink.testing.service_name
+  source_service_name      = aiven_kafka.testing.service_name
+}
+
+resource "aiven_flink_application" "testing" {
+  project      = aiven_flink.testing.project
+  service_name = aiven_flink.testing.service_name
+  name         = "test-acc-application"
+}
+
+resource "aiven_flink_application_version" "testing" {
+  project        = aiven_flink_application.testing.project
+  service_name   = aiven_flink_application.testing.service_name
+  application_id = aiven_flink_application.testing.application_id
+
+  # the deployed version can only be removed once the new one runs
+  lifecycle {
+    create_before_destroy = true
+  }
+
+  statement = <<EOF
+    INSERT INTO cpu_high SELECT * FROM cpu_in WHERE cpu > %s
+  EOF
+
+  source {
+    integration_id = aiven_service_integration.flinkkafka.integration_id
+    create_table   = <<EOF
+      CREATE TABLE cpu_in (
+        cpu INT,
+        node INT
+      ) WITH (
+        'connector' = 'kafka',
+        'properties.bootstrap.servers' = '',
+        'scan.startup.mode' = 'earliest-offset',
+        'topic' = '${aiven_kafka_topic.source.topic_name}',
+        'value.format' = 'json'
+      )
+    EOF
+  }
+
+  sink {
+    integration_id = aiven_service_integration.flinkkafka.integration_id
+    create_table   = <<EOF
+      CREATE TABLE cpu_high (
+        cpu INT,
+        node INT
+      ) WITH (
+        'connector' = 'kafka',
+        'properties.bootstrap.servers' = '',
+        'topic' = '${aiven_kafka_topic.sink.topic_name}',
+        'value.format' = 'json'
+      )
+    EOF
+  }
+}
+
+resource "aiven_flink_application_deployment" "testing" {
+  project        = aiven_flink_application_version.testing.project
+  service_name   = aiven_flink_application_version.testing.service_name
+  application_id = aiven_flink_application_version.testing.application_id
+  version_id     = aiven_flink_application_version.testing.application_version_id
+  parallelism    = %s
+}
+
+data "aiven_flink_application" "testing" {
+  project      = aiven_flink_application.testing.project
+  service_name = aiven_flink_application.testing.service_name
+  name         = aiven_flink_application.testing.name
+}`, projectName, flinkServiceName, kafkaServiceName, topicName, where, parallelism)
+}
+
+func TestAccAiven_flink_application(t *testing.T) {
+	projectName := os.Getenv("AIVEN_PROJECT_NAME")
+	randString := func() string { return acctest.RandStringFromCharSet(10, acctest.CharSetAlpha) }
+	flinkServiceName := fmt.Sprintf("test-acc-flink-%s", randString())
+	kafkaServiceName := fmt.Sprintf("test-acc-flink-kafka-%s", randString())
+	topicName := fmt.Sprintf("test-acc-flink-topic-%s", randString())
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acc.TestAccPreCheck(t) },
+		ProviderFactories: acc.TestAccProviderFactories,
+		CheckDestroy:      acc.TestAccCheckAivenServiceResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFlinkApplicationManifest(projectName, flinkServiceName, kafkaServiceName, topicName, "1", "75"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aiven_flink_application.testing", "project", projectName),
+					resource.TestCheckResourceAttr("aiven_flink_application.testing", "service_name", flinkServiceName),
+					resource.TestCheckResourceAttrSet("aiven_flink_application.testing", "application_id"),
+					resource.TestCheckResourceAttrPair(
+						"data.aiven_flink_application.testing", "application_id",
+						"aiven_flink_application.testing", "application_id",
+					),
+					resource.TestCheckResourceAttr("aiven_flink_application_version.testing", "version", "1"),
+					resource.TestCheckResourceAttr("aiven_flink_application_deployment.testing", "status", "RUNNING"),
+					resource.TestCheckResourceAttrSet("aiven_flink_application_deployment.testing", "job_id"),
+				),
+			},
+			{
+				// a new version is deployed from the savepoint of the running job
+				Config: testAccFlinkApplicationManifest(projectName, flinkServiceName, kafkaServiceName, topicName, "2", "90"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aiven_flink_application_version.testing", "version", "2"),
+					resource.TestCheckResourceAttr("aiven_flink_application_deployment.testing", "status", "RUNNING"),
+					resource.TestCheckResourceAttr("aiven_flink_application_deployment.testing", "parallelism", "2"),
+				),
+			},
+			{
+				ResourceName:      "aiven_flink_application_deployment.testing",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"starting_savepoint",
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,238 @@
+package flink
+
+import (
+	"context"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func aivenFlinkApplicationVersionTableSchema(kind string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Required:    true,
+		ForceNew:    true,
+		MinItems:    1,
+		Description: schemautil.Complex("Application " + kind + " table definitions.").ForceNew().Build(),
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"create_table": {
+					Type:             schema.TypeString,
+					Required:         true,
+					ForceNew:         true,
+					DiffSuppressFunc: schemautil.TrimSpaceDiffSuppressFunc,
+					Description:      schemautil.Complex("The `CREATE TABLE` statement of the " + kind + " table.").ForceNew().Build(),
+				},
+				"integration_id": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					ForceNew:    true,
+					Description: schemautil.Complex("The id of the `flink` service integration the table connects through.").Referenced().ForceNew().Build(),
+				},
+			},
+		},
+	}
+}
+
+var aivenFlinkApplicationVersionSchema = map[string]*schema.Schema{
+	"project":      schemautil.CommonSchemaProjectReference,
+	"service_name": schemautil.CommonSchemaServiceNameReference,
+
+	"application_id": {
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: schemautil.Complex("Application ID.").Referenced().ForceNew().Build(),
+	},
+	"statement": {
+		Type:     schema.TypeString,
+		Required: true,
+		ForceNew: true,
+		Description: schemautil.Complex("The SQL of the job. Several `INSERT` statements separated " +
+			"by semicolons are run together as a single statement set.").ForceNew().Build(),
+		DiffSuppressFunc: schemautil.TrimSpaceDiffSuppressFunc,
+	},
+	"source": aivenFlinkApplicationVersionTableSchema("source"),
+	"sink":   aivenFlinkApplicationVersionTableSchema("sink"),
+
+	// computed fields
+	"application_version_id": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Application version ID.",
+	},
+	"version": {
+		Type:        schema.TypeInt,
+		Computed:    true,
+		Description: "Application version number.",
+	},
+	"created_at": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Application version creation time.",
+	},
+	"created_by": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The user who created the application version.",
+	},
+}
+
+func ResourceFlinkApplicationVersion() *schema.Resource {
+	return &schema.Resource{
+		Description: "The Flink Application Version resource allows the creation and management of Aiven Flink Application Versions. " +
+			"Versions are immutable, any change creates a new version.",
+		CreateContext: resourceFlinkApplicationVersionCreate,
+		ReadContext:   resourceFlinkApplicationVersionRead,
+		DeleteContext: resourceFlinkApplicationVersionDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema:        aivenFlinkApplicationVersionSchema,
+		CustomizeDiff: customdiff.If(schemautil.ResourceShouldNotExist, resourceFlinkApplicationVersionCustomizeDiff),
+	}
+}
+
+func flinkApplicationVersionTablesFromSchema(v []interface{}) []flinkApplicationTable {
+	tables := make([]flinkApplicationTable, 0, len(v))
+	for _, t := range v {
+		t := t.(map[string]interface{})
+		tables = append(tables, flinkApplicationTable{
+			CreateTable:   t["create_table"].(string),
+			IntegrationID: t["integration_id"].(string),
+		})
+	}
+	return tables
+}
+
+func flattenFlinkApplicationVersionTables(tables []flinkApplicationTable) []map[string]interface{} {
+	r := make([]map[string]interface{}, 0, len(tables))
+	for _, t := range tables {
+		r = append(r, map[string]interface{}{
+			"create_table":   t.CreateTable,
+			"integration_id": t.IntegrationID,
+		})
+	}
+	return r
+}
+
+func flinkApplicationVersionRequestFromSchema(d schemautil.ResourceStateOrResourceDiff) flinkApplicationVersionRequest {
+	return flinkApplicationVersionRequest{
+		Statement: flinkStatementSet(d.Get("statement").(string)),
+		Sources:   flinkApplicationVersionTablesFromSchema(d.Get("source").([]interface{})),
+		Sinks:     flinkApplicationVersionTablesFromSchema(d.Get("sink").([]interface{})),
+	}
+}
+
+func resourceFlinkApplicationVersionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	project := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+	applicationID := d.Get("application_id").(string)
+
+	r, err := createFlinkApplicationVersion(ctx, client, project, serviceName, applicationID, flinkApplicationVersionRequestFromSchema(d))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(schemautil.BuildResourceID(project, serviceName, applicationID, r.ID))
+
+	return resourceFlinkApplicationVersionRead(ctx, d, m)
+}
+
+func resourceFlinkApplicationVersionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	project, serviceName, applicationID, versionID, err := schemautil.SplitResourceID4(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	r, err := getFlinkApplicationVersion(ctx, client, project, serviceName, applicationID, versionID)
+	if err != nil {
+		return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
+	}
+
+	if err := d.Set("project", project); err != nil {
+		return diag.Errorf("error setting Flink Application Version `project` for resource %s: %s", d.Id(), err)
+	}
+	if err := d.Set("service_name", serviceName); err != nil {
+		return diag.Errorf("error setting Flink Application Version `service_name` for resource %s: %s", d.Id(), err)
+	}
+	if err := d.Set("application_id", applicationID); err != nil {
+		return diag.Errorf("error setting Flink Application Version `application_id` for resource %s: %s", d.Id(), err)
+	}
+	if err := d.Set("application_version_id", r.ID); err != nil {
+		return diag.Errorf("error setting Flink Application Version `application_version_id` for resource %s: %s", d.Id(), err)
+	}
+	if err := d.Set("version", r.Version); err != nil {
+		return diag.Errorf("error setting Flink Application Version `version` for resource %s: %s", d.Id(), err)
+	}
+	// the API stores several statements as a statement set, keep the script as it was written
+	if flinkStatementSet(d.Get("statement").(string)) != r.Statement {
+		if err := d.Set("statement", r.Statement); err != nil {
+			return diag.Errorf("error setting Flink Application Version `statement` for resource %s: %s", d.Id(), err)
+		}
+	}
+	if err := d.Set("source", flattenFlinkApplicationVersionTables(r.Sources)); err != nil {
+		return diag.Errorf("error setting Flink Application Version `source` for resource %s: %s", d.Id(), err)
+	}
+	if err := d.Set("sink", flattenFlinkApplicationVersionTables(r.Sinks)); err != nil {
+		return diag.Errorf("error setting Flink Application Version `sink` for resource %s: %s", d.Id(), err)
+	}
+	if err := d.Set("created_at", r.CreatedAt); err != nil {
+		return diag.Errorf("error setting Flink Application Version `created_at` for resource %s: %s", d.Id(), err)
+	}
+	if err := d.Set("created_by", r.CreatedBy); err != nil {
+		return diag.Errorf("error setting Flink Application Version `created_by` for resource %s: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceFlinkApplicationVersionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	project, serviceName, applicationID, versionID, err := schemautil.SplitResourceID4(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = deleteFlinkApplicationVersion(ctx, client, project, serviceName, applicationID, versionID)
+	if err != nil && !aiven.IsNotFound(err) {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceFlinkApplicationVersionCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	for _, k := range []string{"project", "service_name", "application_id", "statement", "source", "sink"} {
+		if !d.NewValueKnown(k) {
+			return nil
+		}
+	}
+
+	client := m.(*aiven.Client)
+
+	err := validateFlinkApplicationVersion(
+		ctx,
+		client,
+		d.Get("project").(string),
+		d.Get("service_name").(string),
+		d.Get("application_id").(string),
+		flinkApplicationVersionRequestFromSchema(d),
+	)
+	if aiven.IsNotFound(err) {
+		// the application does not exist yet
+		return nil
+	}
+
+	return err
+}
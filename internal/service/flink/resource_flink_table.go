@@ -2,6 +2,7 @@ package flink
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -25,10 +26,21 @@ var aivenFlinkTableSchema = map[string]*schema.Schema{
 		Description:  schemautil.Complex("Specifies the name of the table.").ForceNew().Build(),
 	},
 	"schema_sql": {
-		Type:        schema.TypeString,
-		Required:    true,
-		ForceNew:    true,
-		Description: schemautil.Complex("The SQL statement to create the table.").ForceNew().Build(),
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ForceNew:     true,
+		ExactlyOneOf: []string{"schema_sql", "column"},
+		Description:  schemautil.Complex("The SQL statement to create the table. Exactly one of `schema_sql` and `column` must be set.").ForceNew().Build(),
+	},
+	"column": {
+		Type:         schema.TypeList,
+		Optional:     true,
+		Computed:     true,
+		ForceNew:     true,
+		ExactlyOneOf: []string{"schema_sql", "column"},
+		Description:  schemautil.Complex("Structured definition of the table columns, an alternative to `schema_sql`. It is read back from the schema of the table, unless the schema has constructs it can't express, e.g. metadata columns.").ForceNew().Build(),
+		Elem:         flinkTableColumnSchema,
 	},
 	"integration_id": {
 		Type:        schema.TypeString,
@@ -197,8 +209,11 @@ func ResourceFlinkTable() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
-		Schema:        aivenFlinkTableSchema,
-		CustomizeDiff: customdiff.If(schemautil.ResourceShouldExist, resourceFlinkTableCustomizeDiff),
+		Schema: aivenFlinkTableSchema,
+		CustomizeDiff: customdiff.Sequence(
+			resourceFlinkTableColumnsCustomizeDiff,
+			customdiff.If(schemautil.ResourceShouldExist, resourceFlinkTableCustomizeDiff),
+		),
 	}
 }
 
@@ -233,6 +248,12 @@ func resourceFlinkTableRead(_ context.Context, d *schema.ResourceData, m interfa
 	if err := d.Set("schema_sql", r.SchemaSQL); err != nil {
 		return diag.Errorf("error setting Flink Tables `schema_sql` for resource %s: %s", d.Id(), err)
 	}
+	// a schema the column blocks can't express keeps the column blocks of the state
+	if columns, err := parseFlinkTableSchemaSQL(r.SchemaSQL); err == nil {
+		if err := d.Set("column", flinkTableColumnsToSchema(columns)); err != nil {
+			return diag.Errorf("error setting Flink Tables `column` for resource %s: %s", d.Id(), err)
+		}
+	}
 
 	return nil
 }
@@ -243,7 +264,7 @@ func resourceFlinkTableCreate(ctx context.Context, d *schema.ResourceData, m int
 	project := d.Get("project").(string)
 	serviceName := d.Get("service_name").(string)
 	tableName := d.Get("table_name").(string)
-	schemaSQL := d.Get("schema_sql").(string)
+	schemaSQL := flinkTableSchemaSQLFromSchema(d)
 	integrationId := d.Get("integration_id").(string)
 
 	// connector options
@@ -312,7 +333,7 @@ func resourceFlinkTableCustomizeDiff(_ context.Context, d *schema.ResourceDiff,
 
 	_, err := client.FlinkTables.Validate(projectName, serviceName, aiven.ValidateFlinkTableRequest{
 		Name:                    d.Get("table_name").(string),
-		SchemaSQL:               d.Get("schema_sql").(string),
+		SchemaSQL:               flinkTableSchemaSQLFromSchema(d),
 		IntegrationId:           d.Get("integration_id").(string),
 		JDBCTable:               d.Get("jdbc_table").(string),
 		KafkaConnectorType:      d.Get("kafka_connector_type").(string),
@@ -330,6 +351,56 @@ func resourceFlinkTableCustomizeDiff(_ context.Context, d *schema.ResourceDiff,
 	return err
 }
 
+// flinkTableSchemaSQLFromSchema returns schema_sql, or the SQL rendered from the column blocks
+func flinkTableSchemaSQLFromSchema(d schemautil.ResourceStateOrResourceDiff) string {
+	if columns := d.Get("column").([]interface{}); len(columns) > 0 {
+		return flinkTableSchemaSQL(flinkTableColumnsFromSchema(columns))
+	}
+	return d.Get("schema_sql").(string)
+}
+
+// resourceFlinkTableColumnsCustomizeDiff checks the column blocks and that the Kafka key fields
+// refer to physical columns of the table
+func resourceFlinkTableColumnsCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	for _, k := range []string{"column", "kafka_key_fields", "upsert_kafka"} {
+		if !d.NewValueKnown(k) {
+			return nil
+		}
+	}
+
+	var columns []flinkTableColumn
+	if v := d.Get("column").([]interface{}); len(v) > 0 {
+		for i := range v {
+			for _, k := range []string{"name", "type", "computed"} {
+				if !d.NewValueKnown(fmt.Sprintf("column.%d.%s", i, k)) {
+					return nil
+				}
+			}
+		}
+		columns = flinkTableColumnsFromSchema(v)
+		if err := validateFlinkTableColumns(columns); err != nil {
+			return err
+		}
+	} else {
+		if !d.NewValueKnown("schema_sql") {
+			return nil
+		}
+		columns = flinkTableColumnsFromSQL(d.Get("schema_sql").(string))
+	}
+
+	if err := validateFlinkTableKeyFields(
+		columns, "kafka_key_fields", schemautil.FlattenToString(d.Get("kafka_key_fields").([]interface{})),
+	); err != nil {
+		return err
+	}
+
+	if upsertKafka := readUpsertKafkaFromSchema(d); upsertKafka != nil {
+		return validateFlinkTableKeyFields(columns, "upsert_kafka.key_fields", upsertKafka.KeyFields)
+	}
+
+	return nil
+}
+
 func readUpsertKafkaFromSchema(d schemautil.ResourceStateOrResourceDiff) *aiven.FlinkTableUpsertKafka {
 	set := d.Get("upsert_kafka").(*schema.Set).List()
 	if len(set) == 0 {
@@ -1369,6 +1369,139 @@ resource "aiven_flink_job" "testing" {
 	})
 }
 
+func TestAccAiven_flink_tableColumns(t *testing.T) {
+	projectName := os.Getenv("AIVEN_PROJECT_NAME")
+	randString := func() string { return acctest.RandStringFromCharSet(10, acctest.CharSetAlpha) }
+	flinkServiceName := fmt.Sprintf("test-acc-flink-%s", randString())
+	kafkaServiceName := fmt.Sprintf("test-acc-flink-kafka-%s", randString())
+	topicName := fmt.Sprintf("test-acc-flink-kafka-topic-%s", randString())
+	tableName := fmt.Sprintf("test_acc_flink_kafka_table_%s", randString())
+
+	manifest := func(keyField string) string {
+		return fmt.Sprintf(`
+variable "project_name" {
+  type    = string
+  default = "%s"
+}
+
+variable "service_name_flink" {
+  type    = string
+  default = "%s"
+}
+
+variable "service_name_kafka" {
+  type    = string
+  default = "%s"
+}
+
+variable "topic_name" {
+  type    = string
+  default = "%s"
+}
+
+variable "table_name" {
+  type    = string
+  default = "%s"
+}
+
+resource "aiven_flink" "testing" {
+  project      = var.project_name
+  cloud_name   = "google-europe-west1"
+  plan         = "startup-4"
+  service_name = var.service_name_flink
+}
+
+resource "aiven_kafka" "testing" {
+  project      = var.project_name
+  cloud_name   = "google-europe-west1"
+  plan         = "startup-2"
+  service_name = var.service_name_kafka
+}
+
+resource "aiven_kafka_topic" "testing" {
+  project      = aiven_kafka.testing.project
+  service_name = aiven_kafka.testing.service_name
+  topic_name   = var.topic_name
+  replication  = 2
+  partitions   = 2
+}
+
+resource "aiven_service_integration" "testing" {
+  project                  = aiven_flink.testing.project
+  integration_type         = "flink"
+  destination_service_name = aiven_flink.testing.service_name
+  source_service_name      = aiven_kafka.testing.service_name
+}
+
+resource "aiven_flink_table" "testing" {
+  project              = aiven_flink.testing.project
+  service_name         = aiven_flink.testing.service_name
+  integration_id       = aiven_service_integration.testing.integration_id
+  table_name           = var.table_name
+  kafka_topic          = aiven_kafka_topic.testing.topic_name
+  kafka_connector_type = "kafka"
+  kafka_value_format   = "json"
+  kafka_key_format     = "json"
+  kafka_key_fields     = ["%s"]
+
+  column {
+    name     = "node"
+    type     = "INT"
+    nullable = false
+  }
+
+  column {
+    name = "cpu"
+    type = "DECIMAL(5, 2)"
+  }
+
+  column {
+    name     = "is_high"
+    computed = "cpu > 75"
+  }
+
+  column {
+    name      = "occurred_at"
+    type      = "TIMESTAMP(3)"
+    watermark = "occurred_at - INTERVAL '5' SECOND"
+  }
+}`, projectName, flinkServiceName, kafkaServiceName, topicName, tableName, keyField)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acc.TestAccPreCheck(t) },
+		ProviderFactories: acc.TestAccProviderFactories,
+		CheckDestroy:      testAccCheckAivenFlinkJobsAndTableResourcesDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:             manifest("is_high"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+				ExpectError:        regexp.MustCompile(`kafka_key_fields: column "is_high" is not a physical column`),
+			},
+			{
+				Config: manifest("node"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aiven_flink_table.testing", "project", projectName),
+					resource.TestCheckResourceAttr("aiven_flink_table.testing", "service_name", flinkServiceName),
+					resource.TestCheckResourceAttr("aiven_flink_table.testing", "column.#", "4"),
+					resource.TestCheckResourceAttrSet("aiven_flink_table.testing", "schema_sql"),
+					resource.TestCheckResourceAttrSet("aiven_flink_table.testing", "table_id"),
+				),
+			},
+			{
+				// the column blocks are read back from the schema of the table
+				ResourceName:      "aiven_flink_table.testing",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"kafka_topic", "kafka_connector_type", "kafka_value_format", "kafka_key_format", "kafka_key_fields",
+				},
+			},
+		},
+	})
+}
+
 func testAccCheckAivenFlinkJobsAndTableResourcesDestroy(s *terraform.State) error {
 	c := acc.TestAccProvider.Meta().(*aiven.Client)
 
@@ -0,0 +1,86 @@
+package flink
+
+import (
+	"regexp"
+	"strings"
+)
+
+// flinkStatementSetPrefix matches statements which already are a statement set
+var flinkStatementSetPrefix = regexp.MustCompile(`(?i)^(EXECUTE\s+)?(STATEMENT\s+SET|BEGIN\s+STATEMENT\s+SET)\b`)
+
+// splitFlinkStatements splits a SQL script on the top level semicolons, semicolons in string
+// literals, quoted identifiers and comments are left alone
+func splitFlinkStatements(sql string) []string {
+	var statements []string
+	var b strings.Builder
+
+	flush := func() {
+		if s := strings.TrimSpace(b.String()); s != "" {
+			statements = append(statements, s)
+		}
+		b.Reset()
+	}
+
+	for i := 0; i < len(sql); i++ {
+		c := sql[i]
+		switch {
+		case c == '\'' || c == '`' || c == '"':
+			end := i + 1
+			for end < len(sql) {
+				if sql[end] == c {
+					// a doubled quote is an escaped quote
+					if end+1 < len(sql) && sql[end+1] == c {
+						end += 2
+						continue
+					}
+					break
+				}
+				end++
+			}
+			if end >= len(sql) {
+				end = len(sql) - 1
+			}
+			b.WriteString(sql[i : end+1])
+			i = end
+		case c == '-' && strings.HasPrefix(sql[i:], "--"):
+			end := strings.IndexByte(sql[i:], '\n')
+			if end < 0 {
+				i = len(sql)
+				continue
+			}
+			i += end
+			b.WriteByte('\n')
+		case c == '/' && strings.HasPrefix(sql[i:], "/*"):
+			end := strings.Index(sql[i+2:], "*/")
+			if end < 0 {
+				i = len(sql)
+				continue
+			}
+			i += end + 3
+			b.WriteByte(' ')
+		case c == ';':
+			flush()
+		default:
+			b.WriteByte(c)
+		}
+	}
+	flush()
+
+	return statements
+}
+
+// flinkStatementSet turns a script of several INSERT statements into a single statement set,
+// which is what a Flink application version runs as one job
+func flinkStatementSet(sql string) string {
+	statements := splitFlinkStatements(sql)
+	switch {
+	case len(statements) == 0:
+		return ""
+	case len(statements) == 1:
+		return statements[0]
+	case flinkStatementSetPrefix.MatchString(statements[0]):
+		return strings.TrimSpace(sql)
+	}
+
+	return "EXECUTE STATEMENT SET\nBEGIN\n" + strings.Join(statements, ";\n") + ";\nEND"
+}
@@ -0,0 +1,57 @@
+package flink
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitFlinkStatements(t *testing.T) {
+	tests := []struct {
+		name string
+		sql  string
+		want []string
+	}{
+		{
+			name: "single",
+			sql:  "INSERT INTO a SELECT * FROM b;",
+			want: []string{"INSERT INTO a SELECT * FROM b"},
+		},
+		{
+			name: "several",
+			sql:  "INSERT INTO a SELECT * FROM b;\nINSERT INTO c SELECT * FROM d",
+			want: []string{"INSERT INTO a SELECT * FROM b", "INSERT INTO c SELECT * FROM d"},
+		},
+		{
+			name: "quoted semicolons",
+			sql:  "INSERT INTO a SELECT 'x;y', `c;d` FROM b; INSERT INTO c SELECT 'it''s;' FROM d",
+			want: []string{"INSERT INTO a SELECT 'x;y', `c;d` FROM b", "INSERT INTO c SELECT 'it''s;' FROM d"},
+		},
+		{
+			name: "comments",
+			sql:  "-- first; job\nINSERT INTO a SELECT * FROM b /* ; */;\n-- trailing",
+			want: []string{"INSERT INTO a SELECT * FROM b"},
+		},
+		{
+			name: "empty",
+			sql:  " ; ;\n",
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, splitFlinkStatements(tt.sql))
+		})
+	}
+}
+
+func TestFlinkStatementSet(t *testing.T) {
+	assert.Equal(t, "INSERT INTO a SELECT * FROM b", flinkStatementSet("INSERT INTO a SELECT * FROM b;\n"))
+	assert.Equal(t,
+		"EXECUTE STATEMENT SET\nBEGIN\nINSERT INTO a SELECT * FROM b;\nINSERT INTO c SELECT * FROM d;\nEND",
+		flinkStatementSet("INSERT INTO a SELECT * FROM b;\nINSERT INTO c SELECT * FROM d;"),
+	)
+
+	set := "EXECUTE STATEMENT SET\nBEGIN\nINSERT INTO a SELECT * FROM b;\nINSERT INTO c SELECT * FROM d;\nEND;"
+	assert.Equal(t, set, flinkStatementSet(set))
+}
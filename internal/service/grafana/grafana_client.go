@@ -0,0 +1,162 @@
+package grafana
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+)
+
+// grafanaClient calls the HTTP API of a Grafana service with the admin credentials of the service
+type grafanaClient struct {
+	*schemautil.ServiceHTTPClient
+}
+
+func newGrafanaClient(client *aiven.Client, project, serviceName string) (*grafanaClient, error) {
+	c, err := schemautil.NewServiceHTTPClient(client, project, serviceName)
+	if err != nil {
+		return nil, err
+	}
+	return grafanaClientFrom(c), nil
+}
+
+func grafanaClientFrom(c *schemautil.ServiceHTTPClient) *grafanaClient {
+	c.ErrorMessage = grafanaErrorMessage
+	return &grafanaClient{c}
+}
+
+// grafanaErrorMessage returns the message of an error response of the Grafana API
+func grafanaErrorMessage(b []byte) string {
+	var e struct {
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(b, &e); err != nil {
+		return ""
+	}
+	return e.Message
+}
+
+type grafanaFolder struct {
+	ID      int    `json:"id,omitempty"`
+	UID     string `json:"uid,omitempty"`
+	Title   string `json:"title"`
+	URL     string `json:"url,omitempty"`
+	Version int    `json:"version,omitempty"`
+}
+
+func (c *grafanaClient) getFolder(ctx context.Context, uid string) (*grafanaFolder, error) {
+	var r grafanaFolder
+	if err := c.JSONRequest(ctx, http.MethodGet, "/api/folders/"+url.PathEscape(uid), nil, &r); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+func (c *grafanaClient) createFolder(ctx context.Context, f *grafanaFolder) (*grafanaFolder, error) {
+	var r grafanaFolder
+	if err := c.JSONRequest(ctx, http.MethodPost, "/api/folders", f, &r); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+func (c *grafanaClient) updateFolder(ctx context.Context, f *grafanaFolder) error {
+	req := struct {
+		Title     string `json:"title"`
+		Overwrite bool   `json:"overwrite"`
+	}{f.Title, true}
+	return c.JSONRequest(ctx, http.MethodPut, "/api/folders/"+url.PathEscape(f.UID), req, nil)
+}
+
+func (c *grafanaClient) deleteFolder(ctx context.Context, uid string) error {
+	return c.JSONRequest(ctx, http.MethodDelete, "/api/folders/"+url.PathEscape(uid), nil, nil)
+}
+
+// grafanaDashboard is a dashboard with the JSON model and the metadata Grafana keeps next to it
+type grafanaDashboard struct {
+	Dashboard map[string]interface{} `json:"dashboard"`
+	Meta      struct {
+		FolderID  int    `json:"folderId"`
+		FolderUID string `json:"folderUid"`
+		URL       string `json:"url"`
+	} `json:"meta"`
+}
+
+type grafanaSaveDashboardResponse struct {
+	ID      int    `json:"id"`
+	UID     string `json:"uid"`
+	URL     string `json:"url"`
+	Version int    `json:"version"`
+}
+
+func (c *grafanaClient) getDashboard(ctx context.Context, uid string) (*grafanaDashboard, error) {
+	var r grafanaDashboard
+	if err := c.JSONRequest(ctx, http.MethodGet, "/api/dashboards/uid/"+url.PathEscape(uid), nil, &r); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// saveDashboard creates or overwrites the dashboard with the uid of the model
+func (c *grafanaClient) saveDashboard(ctx context.Context, model map[string]interface{}, folderUID string) (*grafanaSaveDashboardResponse, error) {
+	req := struct {
+		Dashboard map[string]interface{} `json:"dashboard"`
+		FolderUID string                 `json:"folderUid,omitempty"`
+		Overwrite bool                   `json:"overwrite"`
+	}{model, folderUID, true}
+
+	var r grafanaSaveDashboardResponse
+	if err := c.JSONRequest(ctx, http.MethodPost, "/api/dashboards/db", req, &r); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+func (c *grafanaClient) deleteDashboard(ctx context.Context, uid string) error {
+	return c.JSONRequest(ctx, http.MethodDelete, "/api/dashboards/uid/"+url.PathEscape(uid), nil, nil)
+}
+
+type grafanaDatasource struct {
+	ID             int                    `json:"id,omitempty"`
+	UID            string                 `json:"uid,omitempty"`
+	Name           string                 `json:"name"`
+	Type           string                 `json:"type"`
+	URL            string                 `json:"url"`
+	Access         string                 `json:"access"`
+	Database       string                 `json:"database"`
+	User           string                 `json:"user"`
+	BasicAuth      bool                   `json:"basicAuth"`
+	BasicAuthUser  string                 `json:"basicAuthUser"`
+	IsDefault      bool                   `json:"isDefault"`
+	JSONData       map[string]interface{} `json:"jsonData,omitempty"`
+	SecureJSONData map[string]string      `json:"secureJsonData,omitempty"`
+}
+
+func (c *grafanaClient) getDatasource(ctx context.Context, uid string) (*grafanaDatasource, error) {
+	var r grafanaDatasource
+	if err := c.JSONRequest(ctx, http.MethodGet, "/api/datasources/uid/"+url.PathEscape(uid), nil, &r); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+func (c *grafanaClient) createDatasource(ctx context.Context, ds *grafanaDatasource) (*grafanaDatasource, error) {
+	var r struct {
+		Datasource grafanaDatasource `json:"datasource"`
+	}
+	if err := c.JSONRequest(ctx, http.MethodPost, "/api/datasources", ds, &r); err != nil {
+		return nil, err
+	}
+	return &r.Datasource, nil
+}
+
+func (c *grafanaClient) updateDatasource(ctx context.Context, ds *grafanaDatasource) error {
+	return c.JSONRequest(ctx, http.MethodPut, "/api/datasources/uid/"+url.PathEscape(ds.UID), ds, nil)
+}
+
+func (c *grafanaClient) deleteDatasource(ctx context.Context, uid string) error {
+	return c.JSONRequest(ctx, http.MethodDelete, "/api/datasources/uid/"+url.PathEscape(uid), nil, nil)
+}
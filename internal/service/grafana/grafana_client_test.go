@@ -0,0 +1,351 @@
+package grafana
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newGrafanaStandIn serves the folder, dashboard and datasource APIs the way Grafana does,
+// including the fields it manages itself
+func newGrafanaStandIn(t *testing.T) (*httptest.Server, *grafanaClient) {
+	folders := make(map[string]*grafanaFolder)
+	dashboards := make(map[string]*grafanaDashboard)
+	datasources := make(map[string]*grafanaDatasource)
+	nextID := 0
+
+	notFound := func(w http.ResponseWriter, what string) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message": "` + what + ` not found"}`))
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, password, _ := r.BasicAuth(); user != "avnadmin" || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"message": "invalid username or password"}`))
+			return
+		}
+
+		path := r.URL.Path
+		uid := path[strings.LastIndex(path, "/")+1:]
+
+		switch {
+		case path == "/api/folders" && r.Method == http.MethodPost:
+			var f grafanaFolder
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&f))
+			nextID++
+			if f.UID == "" {
+				f.UID = fmt.Sprintf("generated%d", nextID)
+			}
+			f.ID, f.URL, f.Version = nextID, "/dashboards/f/"+f.UID, 1
+			folders[f.UID] = &f
+			_ = json.NewEncoder(w).Encode(f)
+		case strings.HasPrefix(path, "/api/folders/"):
+			f, ok := folders[uid]
+			if !ok {
+				notFound(w, "folder")
+				return
+			}
+			switch r.Method {
+			case http.MethodPut:
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&f))
+				f.Version++
+			case http.MethodDelete:
+				delete(folders, uid)
+				for k, d := range dashboards {
+					if d.Meta.FolderUID == uid {
+						delete(dashboards, k)
+					}
+				}
+			}
+			_ = json.NewEncoder(w).Encode(f)
+
+		case path == "/api/dashboards/db":
+			var in struct {
+				Dashboard map[string]interface{} `json:"dashboard"`
+				FolderUID string                 `json:"folderUid"`
+				Overwrite bool                   `json:"overwrite"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&in))
+			assert.True(t, in.Overwrite)
+
+			uid, _ := in.Dashboard["uid"].(string)
+			if uid == "" {
+				nextID++
+				uid = fmt.Sprintf("generated%d", nextID)
+			}
+			d := &grafanaDashboard{Dashboard: in.Dashboard}
+			if old, ok := dashboards[uid]; ok {
+				d.Dashboard["id"] = old.Dashboard["id"]
+				d.Dashboard["version"] = old.Dashboard["version"].(int) + 1
+			} else {
+				nextID++
+				d.Dashboard["id"] = nextID
+				d.Dashboard["version"] = 1
+			}
+			d.Dashboard["uid"] = uid
+			d.Meta.FolderUID = in.FolderUID
+			d.Meta.URL = "/d/" + uid
+			dashboards[uid] = d
+			_ = json.NewEncoder(w).Encode(grafanaSaveDashboardResponse{
+				ID: d.Dashboard["id"].(int), UID: uid, URL: d.Meta.URL, Version: d.Dashboard["version"].(int),
+			})
+		case strings.HasPrefix(path, "/api/dashboards/uid/"):
+			d, ok := dashboards[uid]
+			if !ok {
+				notFound(w, "dashboard")
+				return
+			}
+			if r.Method == http.MethodDelete {
+				delete(dashboards, uid)
+			}
+			_ = json.NewEncoder(w).Encode(d)
+
+		case path == "/api/datasources" && r.Method == http.MethodPost:
+			var ds grafanaDatasource
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&ds))
+			nextID++
+			ds.ID, ds.UID = nextID, fmt.Sprintf("generated%d", nextID)
+			if ds.JSONData == nil {
+				ds.JSONData = make(map[string]interface{})
+			}
+			ds.JSONData["tlsSkipVerify"] = false
+			datasources[ds.UID] = &ds
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"datasource": ds, "message": "Datasource added"})
+		case strings.HasPrefix(path, "/api/datasources/uid/"):
+			ds, ok := datasources[uid]
+			if !ok {
+				notFound(w, "data source")
+				return
+			}
+			switch r.Method {
+			case http.MethodPut:
+				var in grafanaDatasource
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&in))
+				in.ID, in.UID = ds.ID, ds.UID
+				datasources[uid] = &in
+			case http.MethodDelete:
+				delete(datasources, uid)
+			}
+			// the secure json data is never returned
+			out := *ds
+			out.SecureJSONData = nil
+			_ = json.NewEncoder(w).Encode(out)
+
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv, grafanaClientFrom(&schemautil.ServiceHTTPClient{URL: srv.URL, Username: "avnadmin", Password: "secret", Client: srv.Client()})
+}
+
+func TestGrafanaClientFolders(t *testing.T) {
+	ctx := context.Background()
+	_, c := newGrafanaStandIn(t)
+
+	f, err := c.createFolder(ctx, &grafanaFolder{Title: "Team"})
+	require.NoError(t, err)
+	assert.NotEmpty(t, f.UID)
+	assert.Equal(t, "/dashboards/f/"+f.UID, f.URL)
+
+	named, err := c.createFolder(ctx, &grafanaFolder{UID: "ops", Title: "Ops"})
+	require.NoError(t, err)
+	assert.Equal(t, "ops", named.UID)
+
+	require.NoError(t, c.updateFolder(ctx, &grafanaFolder{UID: "ops", Title: "Operations"}))
+	r, err := c.getFolder(ctx, "ops")
+	require.NoError(t, err)
+	assert.Equal(t, "Operations", r.Title)
+	assert.Equal(t, 2, r.Version)
+
+	require.NoError(t, c.deleteFolder(ctx, "ops"))
+	_, err = c.getFolder(ctx, "ops")
+	assert.True(t, aiven.IsNotFound(err))
+	assert.Contains(t, err.Error(), "folder not found")
+}
+
+func TestGrafanaClientUnauthorized(t *testing.T) {
+	_, c := newGrafanaStandIn(t)
+	c.Password = "wrong"
+
+	_, err := c.getFolder(context.Background(), "ops")
+	require.Error(t, err)
+	assert.Equal(t, http.StatusUnauthorized, err.(aiven.Error).Status)
+	assert.Contains(t, err.Error(), "invalid username or password")
+}
+
+func TestGrafanaClientDashboards(t *testing.T) {
+	ctx := context.Background()
+	_, c := newGrafanaStandIn(t)
+
+	config := `{"title": "Service metrics", "uid": "ignored", "id": 12, "version": 4, "panels": [{"type": "graph", "title": "CPU"}]}`
+
+	model, err := grafanaDashboardModel(config, "metrics")
+	require.NoError(t, err)
+	assert.Equal(t, "metrics", model["uid"])
+	assert.NotContains(t, model, "id")
+	assert.NotContains(t, model, "version")
+
+	r, err := c.saveDashboard(ctx, model, "ops")
+	require.NoError(t, err)
+	assert.Equal(t, "metrics", r.UID)
+	assert.Equal(t, 1, r.Version)
+
+	dashboard, err := c.getDashboard(ctx, "metrics")
+	require.NoError(t, err)
+	assert.Equal(t, "ops", dashboard.Meta.FolderUID)
+
+	// the managed fields of the remote model don't show up as a diff
+	state, err := grafanaDashboardStateJSON(config, dashboard.Dashboard)
+	require.NoError(t, err)
+	assert.Equal(t, config, state)
+	assert.True(t, grafanaDashboardDiffSuppressFunc("", state, `{"panels":[{"title":"CPU","type":"graph"}],"title":"Service metrics"}`, nil))
+
+	// a change made in Grafana is brought into the state
+	model, err = grafanaDashboardModel(`{"title": "Edited in Grafana"}`, "metrics")
+	require.NoError(t, err)
+	r, err = c.saveDashboard(ctx, model, "")
+	require.NoError(t, err)
+	assert.Equal(t, 2, r.Version)
+
+	dashboard, err = c.getDashboard(ctx, "metrics")
+	require.NoError(t, err)
+	state, err = grafanaDashboardStateJSON(config, dashboard.Dashboard)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"title": "Edited in Grafana"}`, state)
+	assert.False(t, grafanaDashboardDiffSuppressFunc("", state, config, nil))
+
+	require.NoError(t, c.deleteDashboard(ctx, "metrics"))
+	_, err = c.getDashboard(ctx, "metrics")
+	assert.True(t, aiven.IsNotFound(err))
+}
+
+func TestGrafanaClientDatasources(t *testing.T) {
+	ctx := context.Background()
+	_, c := newGrafanaStandIn(t)
+
+	ds, err := grafanaDatasourceFromService(&aiven.Service{
+		Name: "pg1",
+		Type: "pg",
+		URIParams: map[string]string{
+			"host": "pg1.aivencloud.com", "port": "12691", "dbname": "defaultdb", "user": "avnadmin", "password": "pgsecret",
+		},
+	}, "grafana", "secret")
+	require.NoError(t, err)
+	ds.Name, ds.Access = "pg1", "proxy"
+
+	created, err := c.createDatasource(ctx, ds)
+	require.NoError(t, err)
+	assert.NotEmpty(t, created.UID)
+	assert.Equal(t, "pg1.aivencloud.com:12691", created.URL)
+
+	r, err := c.getDatasource(ctx, created.UID)
+	require.NoError(t, err)
+	assert.Equal(t, "postgres", r.Type)
+	assert.Equal(t, "grafana", r.User)
+	assert.Nil(t, r.SecureJSONData)
+
+	// the configured json data stays in the state while Grafana only adds its own values
+	state, err := grafanaJSONDataStateJSON(`{"sslmode": "require"}`, r.JSONData)
+	require.NoError(t, err)
+	assert.Equal(t, `{"sslmode": "require"}`, state)
+
+	ds.UID = created.UID
+	ds.JSONData = map[string]interface{}{"sslmode": "verify-full"}
+	require.NoError(t, c.updateDatasource(ctx, ds))
+	r, err = c.getDatasource(ctx, created.UID)
+	require.NoError(t, err)
+	state, err = grafanaJSONDataStateJSON(`{"sslmode": "require"}`, r.JSONData)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"sslmode": "verify-full"}`, state)
+
+	require.NoError(t, c.deleteDatasource(ctx, created.UID))
+	_, err = c.getDatasource(ctx, created.UID)
+	assert.True(t, aiven.IsNotFound(err))
+}
+
+func TestGrafanaDatasourceFromService(t *testing.T) {
+	params := map[string]string{
+		"host": "example.aivencloud.com", "port": "443", "dbname": "defaultdb", "user": "grafana", "password": "admin-secret",
+	}
+
+	tests := []struct {
+		serviceType string
+		want        grafanaDatasource
+	}{
+		{"pg", grafanaDatasource{
+			Type: "postgres", URL: "example.aivencloud.com:443", Database: "defaultdb", User: "grafana",
+			SecureJSONData: map[string]string{"password": "secret"},
+			JSONData:       map[string]interface{}{"sslmode": "require"},
+		}},
+		{"mysql", grafanaDatasource{
+			Type: "mysql", URL: "example.aivencloud.com:443", Database: "defaultdb", User: "grafana",
+			SecureJSONData: map[string]string{"password": "secret"},
+		}},
+		{"influxdb", grafanaDatasource{
+			Type: "influxdb", URL: "https://example.aivencloud.com:443", Database: "defaultdb", User: "grafana",
+			SecureJSONData: map[string]string{"password": "secret"},
+		}},
+		{"opensearch", grafanaDatasource{
+			Type: "elasticsearch", URL: "https://example.aivencloud.com:443", BasicAuth: true, BasicAuthUser: "grafana",
+			SecureJSONData: map[string]string{"basicAuthPassword": "secret"},
+			JSONData:       map[string]interface{}{"timeField": "@timestamp"},
+		}},
+		{"m3db", grafanaDatasource{
+			Type: "prometheus", URL: "https://example.aivencloud.com:443", BasicAuth: true, BasicAuthUser: "grafana",
+			SecureJSONData: map[string]string{"basicAuthPassword": "secret"},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.serviceType, func(t *testing.T) {
+			got, err := grafanaDatasourceFromService(&aiven.Service{Name: "source", Type: tt.serviceType, URIParams: params}, "grafana", "secret")
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, *got)
+		})
+	}
+
+	_, err := grafanaDatasourceFromService(&aiven.Service{Name: "kafka1", Type: "kafka", URIParams: params}, "grafana", "secret")
+	assert.EqualError(t, err, "service kafka1 of type kafka can't be used as a Grafana datasource")
+}
+
+func TestGrafanaJSONDataContains(t *testing.T) {
+	assert.True(t, grafanaJSONDataContains(`{"sslmode": "require", "tlsSkipVerify": false}`, `{"sslmode": "require"}`))
+	assert.True(t, grafanaJSONDataContains(`{"sslmode": "require"}`, `{}`))
+	assert.False(t, grafanaJSONDataContains(`{"sslmode": "require"}`, `{"sslmode": "disable"}`))
+	assert.False(t, grafanaJSONDataContains(`{}`, `{"timeField": "@timestamp"}`))
+	assert.False(t, grafanaJSONDataContains(`{}`, `not json`))
+}
+
+func TestGrafanaSourceServiceUser(t *testing.T) {
+	s := &aiven.Service{Name: "pg1", Users: []*aiven.ServiceUser{
+		{Username: "avnadmin", Password: "admin-secret"},
+		{Username: "grafana", Password: "secret"},
+	}}
+
+	u, err := grafanaSourceServiceUser(s, "grafana")
+	require.NoError(t, err)
+	assert.Equal(t, "secret", u.Password)
+
+	_, err = grafanaSourceServiceUser(s, "reader")
+	assert.EqualError(t, err, "service user reader of source service pg1 not found")
+}
+
+func TestGrafanaSourceCredentialsFingerprint(t *testing.T) {
+	user := func(password string) *aiven.ServiceUser {
+		return &aiven.ServiceUser{Username: "grafana", Password: password}
+	}
+
+	assert.Equal(t, grafanaSourceCredentialsFingerprint(user("secret")), grafanaSourceCredentialsFingerprint(user("secret")))
+	assert.NotEqual(t, grafanaSourceCredentialsFingerprint(user("secret")), grafanaSourceCredentialsFingerprint(user("rotated")))
+}
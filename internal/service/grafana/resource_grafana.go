@@ -44,6 +44,14 @@ func ResourceGrafana() *schema.Resource {
 				schemautil.DiskSpaceShouldNotBeEmpty,
 				schemautil.CustomizeDiffCheckDiskSpace,
 			),
+			customdiff.IfValueChange("project_vpc_id",
+				schemautil.ProjectVPCShouldNotBeEmpty,
+				schemautil.CustomizeDiffCheckProjectVPCCapacity,
+			),
+			customdiff.IfValueChange("plan",
+				schemautil.PlanShouldNotBeEmpty,
+				schemautil.CustomizeDiffCheckProjectVPCCapacity,
+			),
 			customdiff.IfValueChange("service_integrations",
 				schemautil.ServiceIntegrationShouldNotBeEmpty,
 				schemautil.CustomizeDiffServiceIntegrationAfterCreation,
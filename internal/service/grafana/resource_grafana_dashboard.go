@@ -0,0 +1,257 @@
+package grafana
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+)
+
+var aivenGrafanaDashboardSchema = map[string]*schema.Schema{
+	"project":      schemautil.CommonSchemaProjectReference,
+	"service_name": schemautil.CommonSchemaServiceNameReference,
+	"config_json": {
+		Type:             schema.TypeString,
+		Required:         true,
+		ValidateFunc:     validation.StringIsJSON,
+		DiffSuppressFunc: grafanaDashboardDiffSuppressFunc,
+		Description:      "JSON model of the dashboard. The `id`, `uid` and `version` of the model are managed by Grafana and ignored in diffs.",
+	},
+	"folder_uid": {
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "Folder of the dashboard, e.g. the `uid` of `aiven_grafana_folder`. By default the dashboard is in the General folder.",
+	},
+	"uid": {
+		Type:        schema.TypeString,
+		Optional:    true,
+		Computed:    true,
+		ForceNew:    true,
+		Description: schemautil.Complex("Unique identifier of the dashboard. By default the `uid` of the model is used, or Grafana generates one.").ForceNew().Referenced().Build(),
+	},
+
+	// computed fields
+	"dashboard_id": {
+		Type:        schema.TypeInt,
+		Computed:    true,
+		Description: "Numeric id of the dashboard",
+	},
+	"version": {
+		Type:        schema.TypeInt,
+		Computed:    true,
+		Description: "Version of the dashboard, Grafana increments it on every save",
+	},
+	"url": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Path of the dashboard in Grafana",
+	},
+}
+
+func ResourceGrafanaDashboard() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+The Grafana Dashboard resource allows the creation and management of dashboards of an Aiven Grafana service from their
+JSON model. Changes made in the Grafana UI show up as a diff and are overwritten on apply.
+`,
+		CreateContext: resourceGrafanaDashboardCreate,
+		ReadContext:   resourceGrafanaDashboardRead,
+		UpdateContext: resourceGrafanaDashboardUpdate,
+		DeleteContext: resourceGrafanaDashboardDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: aivenGrafanaDashboardSchema,
+	}
+}
+
+func resourceGrafanaDashboardCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	project := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+
+	c, err := newGrafanaClient(client, project, serviceName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	model, err := grafanaDashboardModel(d.Get("config_json").(string), d.Get("uid").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	r, err := c.saveDashboard(ctx, model, d.Get("folder_uid").(string))
+	if err != nil {
+		return diag.Errorf("cannot create Grafana dashboard: %s", err)
+	}
+
+	d.SetId(schemautil.BuildResourceID(project, serviceName, r.UID))
+
+	return resourceGrafanaDashboardRead(ctx, d, m)
+}
+
+func resourceGrafanaDashboardRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	project, serviceName, uid, err := schemautil.SplitResourceID3(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	c, err := newGrafanaClient(client, project, serviceName)
+	if err != nil {
+		return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
+	}
+
+	dashboard, err := c.getDashboard(ctx, uid)
+	if err != nil {
+		return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
+	}
+
+	id, _ := dashboard.Dashboard["id"].(float64)
+	version, _ := dashboard.Dashboard["version"].(float64)
+
+	configJSON, err := grafanaDashboardStateJSON(d.Get("config_json").(string), dashboard.Dashboard)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("project", project); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("service_name", serviceName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("uid", uid); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("config_json", configJSON); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("folder_uid", dashboard.Meta.FolderUID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("dashboard_id", int(id)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("version", int(version)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("url", dashboard.Meta.URL); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceGrafanaDashboardUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	project, serviceName, uid, err := schemautil.SplitResourceID3(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	c, err := newGrafanaClient(client, project, serviceName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	model, err := grafanaDashboardModel(d.Get("config_json").(string), uid)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if _, err := c.saveDashboard(ctx, model, d.Get("folder_uid").(string)); err != nil {
+		return diag.Errorf("cannot update Grafana dashboard %s: %s", uid, err)
+	}
+
+	return resourceGrafanaDashboardRead(ctx, d, m)
+}
+
+func resourceGrafanaDashboardDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	project, serviceName, uid, err := schemautil.SplitResourceID3(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	c, err := newGrafanaClient(client, project, serviceName)
+	if err != nil {
+		if aiven.IsNotFound(err) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	if err := c.deleteDashboard(ctx, uid); err != nil && !aiven.IsNotFound(err) {
+		return diag.Errorf("cannot delete Grafana dashboard %s: %s", uid, err)
+	}
+
+	return nil
+}
+
+// grafanaDashboardModel decodes the configured model for saving, the uid overrides the one of the model
+// and the id is left out so that the dashboard is matched by uid
+func grafanaDashboardModel(configJSON, uid string) (map[string]interface{}, error) {
+	var model map[string]interface{}
+	if err := json.Unmarshal([]byte(configJSON), &model); err != nil {
+		return nil, err
+	}
+	delete(model, "id")
+	delete(model, "version")
+	if uid != "" {
+		model["uid"] = uid
+	}
+	return model, nil
+}
+
+// normalizeGrafanaDashboard removes the fields Grafana manages from a model
+func normalizeGrafanaDashboard(model map[string]interface{}) map[string]interface{} {
+	r := make(map[string]interface{}, len(model))
+	for k, v := range model {
+		if k != "id" && k != "uid" && k != "version" {
+			r[k] = v
+		}
+	}
+	return r
+}
+
+func grafanaDashboardDiffSuppressFunc(_, old, new string, _ *schema.ResourceData) bool {
+	var o, n map[string]interface{}
+	if err := json.Unmarshal([]byte(old), &o); err != nil {
+		return false
+	}
+	if err := json.Unmarshal([]byte(new), &n); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(normalizeGrafanaDashboard(o), normalizeGrafanaDashboard(n))
+}
+
+// grafanaDashboardStateJSON keeps the configured model in the state while it matches the remote one,
+// otherwise the remote model is returned so that the changes made in Grafana show up in the plan
+func grafanaDashboardStateJSON(configJSON string, remote map[string]interface{}) (string, error) {
+	normalized := normalizeGrafanaDashboard(remote)
+
+	var configured map[string]interface{}
+	if err := json.Unmarshal([]byte(configJSON), &configured); err == nil {
+		if reflect.DeepEqual(normalizeGrafanaDashboard(configured), normalized) {
+			return configJSON, nil
+		}
+	}
+
+	b, err := json.Marshal(normalized)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
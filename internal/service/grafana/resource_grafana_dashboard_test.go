@@ -0,0 +1,112 @@
+package grafana_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	acc "github.com/aiven/terraform-provider-aiven/internal/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAivenGrafanaDashboard_basic(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acc.TestAccPreCheck(t) },
+		ProviderFactories: acc.TestAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGrafanaDashboardResource(rName, "Service metrics"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aiven_grafana_folder.foo", "title", "Team"),
+					resource.TestCheckResourceAttrSet("aiven_grafana_folder.foo", "uid"),
+					resource.TestCheckResourceAttrPair("aiven_grafana_dashboard.foo", "folder_uid", "aiven_grafana_folder.foo", "uid"),
+					resource.TestCheckResourceAttr("aiven_grafana_dashboard.foo", "uid", "metrics-"+rName),
+					resource.TestCheckResourceAttr("aiven_grafana_dashboard.foo", "version", "1"),
+					resource.TestCheckResourceAttr("aiven_grafana_datasource.foo", "type", "postgres"),
+					resource.TestCheckResourceAttr("aiven_grafana_datasource.foo", "username", "grafana"),
+					resource.TestCheckResourceAttr("aiven_grafana_datasource.foo", "database", "defaultdb"),
+					resource.TestCheckResourceAttrSet("aiven_grafana_datasource.foo", "uid"),
+				),
+			},
+			{
+				Config: testAccGrafanaDashboardResource(rName, "Database metrics"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aiven_grafana_dashboard.foo", "version", "2"),
+				),
+			},
+			{
+				ResourceName:            "aiven_grafana_dashboard.foo",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"config_json"},
+			},
+			{
+				ResourceName:            "aiven_grafana_datasource.foo",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"source_service_name", "source_service_user", "source_credentials_fingerprint", "password", "json_data"},
+			},
+		},
+	})
+}
+
+func testAccGrafanaDashboardResource(name, title string) string {
+	return fmt.Sprintf(`
+data "aiven_project" "foo" {
+  project = "%s"
+}
+
+resource "aiven_grafana" "bar" {
+  project                 = data.aiven_project.foo.project
+  cloud_name              = "google-europe-west1"
+  plan                    = "startup-1"
+  service_name            = "test-acc-sr-grafana-%s"
+  maintenance_window_dow  = "monday"
+  maintenance_window_time = "10:00:00"
+}
+
+resource "aiven_pg" "bar" {
+  project                 = data.aiven_project.foo.project
+  cloud_name              = "google-europe-west1"
+  plan                    = "startup-4"
+  service_name            = "test-acc-sr-grafana-pg-%s"
+  maintenance_window_dow  = "monday"
+  maintenance_window_time = "10:00:00"
+}
+
+resource "aiven_grafana_folder" "foo" {
+  project      = aiven_grafana.bar.project
+  service_name = aiven_grafana.bar.service_name
+  title        = "Team"
+}
+
+resource "aiven_grafana_dashboard" "foo" {
+  project      = aiven_grafana.bar.project
+  service_name = aiven_grafana.bar.service_name
+  folder_uid   = aiven_grafana_folder.foo.uid
+  config_json = jsonencode({
+    title = "%s"
+    uid   = "metrics-%s"
+    panels = [
+      { type = "timeseries", title = "Connections", gridPos = { x = 0, y = 0, w = 12, h = 8 } }
+    ]
+  })
+}
+
+resource "aiven_pg_user" "grafana" {
+  project      = aiven_pg.bar.project
+  service_name = aiven_pg.bar.service_name
+  username     = "grafana"
+}
+
+resource "aiven_grafana_datasource" "foo" {
+  project             = aiven_grafana.bar.project
+  service_name        = aiven_grafana.bar.service_name
+  name                = "pg"
+  source_service_name = aiven_pg.bar.service_name
+  source_service_user = aiven_pg_user.grafana.username
+}`, os.Getenv("AIVEN_PROJECT_NAME"), name, name, title, name)
+}
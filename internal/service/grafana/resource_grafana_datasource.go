@@ -0,0 +1,524 @@
+package grafana
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+)
+
+var aivenGrafanaDatasourceSchema = map[string]*schema.Schema{
+	"project":      schemautil.CommonSchemaProjectReference,
+	"service_name": schemautil.CommonSchemaServiceNameReference,
+	"name": {
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: "Name of the datasource",
+	},
+	"source_service_name": {
+		Type:     schema.TypeString,
+		Optional: true,
+		Description: "Aiven service of the project the datasource connects to, its type, URL and database are used " +
+			"unless they are set. PostgreSQL, MySQL, InfluxDB, Opensearch and M3DB services are supported. Either " +
+			"`source_service_user` or `username` and `password` must be set with it.",
+	},
+	"source_service_user": {
+		Type:          schema.TypeString,
+		Optional:      true,
+		ConflictsWith: []string{"username", "password"},
+		RequiredWith:  []string{"source_service_name"},
+		Description: "Service user of `source_service_name` the datasource connects with, its password is read from " +
+			"the service. Use a user with read-only permissions, e.g. created with `aiven_pg_user`; the admin user " +
+			"`avnadmin` is only used when it is set here.",
+	},
+	"type": {
+		Type:        schema.TypeString,
+		Optional:    true,
+		Computed:    true,
+		Description: "Type of the datasource, e.g. `postgres` or `prometheus`. Required unless `source_service_name` is set.",
+	},
+	"url": {
+		Type:        schema.TypeString,
+		Optional:    true,
+		Computed:    true,
+		Description: "URL of the datasource",
+	},
+	"access": {
+		Type:         schema.TypeString,
+		Optional:     true,
+		Default:      "proxy",
+		ValidateFunc: validation.StringInSlice([]string{"proxy", "direct"}, false),
+		Description:  schemautil.Complex("Access mode of the datasource.").DefaultValue("proxy").PossibleValues("proxy", "direct").Build(),
+	},
+	"database": {
+		Type:        schema.TypeString,
+		Optional:    true,
+		Computed:    true,
+		Description: "Database of the datasource",
+	},
+	"username": {
+		Type:        schema.TypeString,
+		Optional:    true,
+		Computed:    true,
+		Description: "User of the datasource, the basic authentication user when `basic_auth` is set",
+	},
+	"password": {
+		Type:        schema.TypeString,
+		Optional:    true,
+		Sensitive:   true,
+		Description: "Password of the datasource, the basic authentication password when `basic_auth` is set. Grafana doesn't return it, changes made in Grafana are not detected.",
+	},
+	"basic_auth": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Computed:    true,
+		Description: "Use basic authentication",
+	},
+	"is_default": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Description: "Make the datasource the default one",
+	},
+	"json_data": {
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ValidateFunc: validation.StringIsJSON,
+		DiffSuppressFunc: func(_, old, new string, _ *schema.ResourceData) bool {
+			return grafanaJSONDataContains(old, new)
+		},
+		Description: "Type specific settings of the datasource as a JSON object, e.g. `{\"sslmode\": \"require\"}`",
+	},
+
+	// computed fields
+	"uid": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Unique identifier of the datasource",
+	},
+	"datasource_id": {
+		Type:        schema.TypeInt,
+		Computed:    true,
+		Description: "Numeric id of the datasource",
+	},
+	"source_credentials_fingerprint": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Sensitive:   true,
+		Description: "Fingerprint of the credentials of `source_service_user` the datasource was last updated with, the datasource is updated when they change",
+	},
+}
+
+func ResourceGrafanaDatasource() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+The Grafana Datasource resource allows the creation and management of datasources of an Aiven Grafana service. A
+datasource can be wired to another Aiven service of the project with ` + "`source_service_name`" + `, its connection
+parameters are then read from the service. It connects with the service user set in ` + "`source_service_user`" + `,
+whose password is read again on every plan and the datasource updated when it has been rotated, or with the
+configured ` + "`username`" + ` and ` + "`password`" + `. The admin credentials of the source service are never used
+unless its admin user is set in ` + "`source_service_user`" + `: give Grafana a user with read-only permissions.
+
+To have Aiven provision the datasource and its credentials instead, use ` + "`aiven_service_integration`" + ` with the
+` + "`dashboard`" + ` integration type; that datasource is owned by Aiven and can't be managed by this resource.
+`,
+		CreateContext: resourceGrafanaDatasourceCreate,
+		ReadContext:   resourceGrafanaDatasourceRead,
+		UpdateContext: resourceGrafanaDatasourceUpdate,
+		DeleteContext: resourceGrafanaDatasourceDelete,
+		CustomizeDiff: resourceGrafanaDatasourceCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: aivenGrafanaDatasourceSchema,
+	}
+}
+
+func resourceGrafanaDatasourceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	project := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+
+	c, err := newGrafanaClient(client, project, serviceName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	ds, fingerprint, err := grafanaDatasourceFromSchema(client, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	r, err := c.createDatasource(ctx, ds)
+	if err != nil {
+		return diag.Errorf("cannot create Grafana datasource %s: %s", ds.Name, err)
+	}
+
+	d.SetId(schemautil.BuildResourceID(project, serviceName, r.UID))
+	if err := d.Set("source_credentials_fingerprint", fingerprint); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceGrafanaDatasourceRead(ctx, d, m)
+}
+
+func resourceGrafanaDatasourceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	project, serviceName, uid, err := schemautil.SplitResourceID3(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	c, err := newGrafanaClient(client, project, serviceName)
+	if err != nil {
+		return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
+	}
+
+	ds, err := c.getDatasource(ctx, uid)
+	if err != nil {
+		return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
+	}
+
+	username := ds.User
+	if ds.BasicAuth {
+		username = ds.BasicAuthUser
+	}
+
+	jsonData, err := grafanaJSONDataStateJSON(d.Get("json_data").(string), ds.JSONData)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("project", project); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("service_name", serviceName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("uid", ds.UID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("datasource_id", ds.ID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("name", ds.Name); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("type", ds.Type); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("url", ds.URL); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("access", ds.Access); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("database", ds.Database); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("username", username); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("basic_auth", ds.BasicAuth); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("is_default", ds.IsDefault); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("json_data", jsonData); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceGrafanaDatasourceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	project, serviceName, uid, err := schemautil.SplitResourceID3(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	c, err := newGrafanaClient(client, project, serviceName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	ds, fingerprint, err := grafanaDatasourceFromSchema(client, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	ds.UID = uid
+
+	if err := c.updateDatasource(ctx, ds); err != nil {
+		return diag.Errorf("cannot update Grafana datasource %s: %s", uid, err)
+	}
+	if err := d.Set("source_credentials_fingerprint", fingerprint); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceGrafanaDatasourceRead(ctx, d, m)
+}
+
+func resourceGrafanaDatasourceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	project, serviceName, uid, err := schemautil.SplitResourceID3(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	c, err := newGrafanaClient(client, project, serviceName)
+	if err != nil {
+		if aiven.IsNotFound(err) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	if err := c.deleteDatasource(ctx, uid); err != nil && !aiven.IsNotFound(err) {
+		return diag.Errorf("cannot delete Grafana datasource %s: %s", uid, err)
+	}
+
+	return nil
+}
+
+// resourceGrafanaDatasourceCustomizeDiff plans an update of the datasource when the password of its
+// source service user has been rotated since it was last updated, Grafana doesn't return it to compare
+func resourceGrafanaDatasourceCustomizeDiff(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
+	source, user := d.Get("source_service_name").(string), d.Get("source_service_user").(string)
+	if d.Id() == "" || source == "" || user == "" || d.HasChange("source_service_name") || d.HasChange("source_service_user") {
+		return nil
+	}
+
+	s, err := m.(*aiven.Client).Services.Get(d.Get("project").(string), source)
+	if err != nil {
+		return fmt.Errorf("cannot get source service %s: %w", source, err)
+	}
+	u, err := grafanaSourceServiceUser(s, user)
+	if err != nil {
+		return err
+	}
+
+	if fingerprint := grafanaSourceCredentialsFingerprint(u); fingerprint != d.Get("source_credentials_fingerprint").(string) {
+		return d.SetNew("source_credentials_fingerprint", fingerprint)
+	}
+	return nil
+}
+
+// grafanaSourceServiceUser finds the user of the source service the datasource connects with
+func grafanaSourceServiceUser(s *aiven.Service, username string) (*aiven.ServiceUser, error) {
+	for _, u := range s.Users {
+		if u.Username == username {
+			return u, nil
+		}
+	}
+	return nil, fmt.Errorf("service user %s of source service %s not found", username, s.Name)
+}
+
+// grafanaSourceCredentialsFingerprint returns a hash of the credentials of the source service user, the
+// state keeps it instead of the credentials themselves
+func grafanaSourceCredentialsFingerprint(u *aiven.ServiceUser) string {
+	h := sha256.Sum256([]byte(u.Username + "\x00" + u.Password))
+	return hex.EncodeToString(h[:])
+}
+
+// grafanaDatasourceFromSchema builds the datasource from the source service, if any, and overrides
+// it with the values set in the configuration. The fingerprint of the credentials of the source service
+// user is returned with it, it is empty without a source service user
+func grafanaDatasourceFromSchema(client *aiven.Client, d *schema.ResourceData) (*grafanaDatasource, string, error) {
+	ds, fingerprint := &grafanaDatasource{}, ""
+	source := d.Get("source_service_name").(string)
+	if source != "" {
+		s, err := client.Services.Get(d.Get("project").(string), source)
+		if err != nil {
+			return nil, "", fmt.Errorf("cannot get source service %s: %w", source, err)
+		}
+
+		u := &aiven.ServiceUser{}
+		if user := d.Get("source_service_user").(string); user != "" {
+			if u, err = grafanaSourceServiceUser(s, user); err != nil {
+				return nil, "", err
+			}
+			fingerprint = grafanaSourceCredentialsFingerprint(u)
+		}
+		if ds, err = grafanaDatasourceFromService(s, u.Username, u.Password); err != nil {
+			return nil, "", err
+		}
+	}
+
+	ds.Name = d.Get("name").(string)
+	ds.Access = d.Get("access").(string)
+	ds.IsDefault = d.Get("is_default").(bool)
+
+	// the values of the state are not used, they may come from an earlier version of the source service
+	if v, ok := grafanaConfiguredValue(d, "type"); ok {
+		ds.Type = v.(string)
+	}
+	if v, ok := grafanaConfiguredValue(d, "url"); ok {
+		ds.URL = v.(string)
+	}
+	if v, ok := grafanaConfiguredValue(d, "database"); ok {
+		ds.Database = v.(string)
+	}
+	if v, ok := grafanaConfiguredValue(d, "basic_auth"); ok {
+		ds.BasicAuth = v.(bool)
+	}
+	if ds.Type == "" {
+		return nil, "", fmt.Errorf("type must be set unless source_service_name is set")
+	}
+
+	username, password := ds.User, ds.SecureJSONData["password"]
+	if ds.BasicAuthUser != "" {
+		username, password = ds.BasicAuthUser, ds.SecureJSONData["basicAuthPassword"]
+	}
+	if v, ok := grafanaConfiguredValue(d, "username"); ok {
+		username = v.(string)
+	}
+	if v, ok := d.GetOk("password"); ok {
+		password = v.(string)
+	}
+	if source != "" && username == "" {
+		return nil, "", fmt.Errorf("source_service_user or username and password must be set with source_service_name, " +
+			"the admin credentials of the source service are not used")
+	}
+	ds.User, ds.BasicAuthUser, ds.SecureJSONData = "", "", nil
+	if ds.BasicAuth {
+		ds.BasicAuthUser = username
+		if password != "" {
+			ds.SecureJSONData = map[string]string{"basicAuthPassword": password}
+		}
+	} else {
+		ds.User = username
+		if password != "" {
+			ds.SecureJSONData = map[string]string{"password": password}
+		}
+	}
+
+	if v, ok := grafanaConfiguredValue(d, "json_data"); ok {
+		var jsonData map[string]interface{}
+		if err := json.Unmarshal([]byte(v.(string)), &jsonData); err != nil {
+			return nil, "", fmt.Errorf("json_data: %w", err)
+		}
+		if ds.JSONData == nil {
+			ds.JSONData = make(map[string]interface{})
+		}
+		for k, v := range jsonData {
+			ds.JSONData[k] = v
+		}
+	}
+
+	return ds, fingerprint, nil
+}
+
+// grafanaConfiguredValue returns the value of a key when it is set in the configuration
+func grafanaConfiguredValue(d *schema.ResourceData, key string) (interface{}, bool) {
+	config := d.GetRawConfig()
+	if config.IsNull() || config.GetAttr(key).IsNull() {
+		return nil, false
+	}
+	return d.Get(key), true
+}
+
+// grafanaDatasourceFromService returns the datasource connecting to an Aiven service with the given credentials
+func grafanaDatasourceFromService(s *aiven.Service, username, password string) (*grafanaDatasource, error) {
+	params := s.URIParams
+	hostPort := fmt.Sprintf("%s:%s", params["host"], params["port"])
+
+	switch s.Type {
+	case "pg":
+		sslMode := params["sslmode"]
+		if sslMode == "" {
+			sslMode = "require"
+		}
+		return &grafanaDatasource{
+			Type:           "postgres",
+			URL:            hostPort,
+			Database:       params["dbname"],
+			User:           username,
+			SecureJSONData: map[string]string{"password": password},
+			JSONData:       map[string]interface{}{"sslmode": sslMode},
+		}, nil
+	case "mysql":
+		return &grafanaDatasource{
+			Type:           "mysql",
+			URL:            hostPort,
+			Database:       params["dbname"],
+			User:           username,
+			SecureJSONData: map[string]string{"password": password},
+		}, nil
+	case "influxdb":
+		return &grafanaDatasource{
+			Type:           "influxdb",
+			URL:            "https://" + hostPort,
+			Database:       params["dbname"],
+			User:           username,
+			SecureJSONData: map[string]string{"password": password},
+		}, nil
+	case "opensearch":
+		return &grafanaDatasource{
+			Type:           "elasticsearch",
+			URL:            "https://" + hostPort,
+			BasicAuth:      true,
+			BasicAuthUser:  username,
+			SecureJSONData: map[string]string{"basicAuthPassword": password},
+			JSONData:       map[string]interface{}{"timeField": "@timestamp"},
+		}, nil
+	case "m3db":
+		return &grafanaDatasource{
+			Type:           "prometheus",
+			URL:            "https://" + hostPort,
+			BasicAuth:      true,
+			BasicAuthUser:  username,
+			SecureJSONData: map[string]string{"basicAuthPassword": password},
+		}, nil
+	}
+
+	return nil, fmt.Errorf("service %s of type %s can't be used as a Grafana datasource", s.Name, s.Type)
+}
+
+// grafanaJSONDataContains tells whether the remote json data has all the values of the configured one,
+// Grafana and the source services add their own
+func grafanaJSONDataContains(remote, configured string) bool {
+	var r, c map[string]interface{}
+	if err := json.Unmarshal([]byte(remote), &r); err != nil {
+		return false
+	}
+	if err := json.Unmarshal([]byte(configured), &c); err != nil {
+		return false
+	}
+	for k, v := range c {
+		if !reflect.DeepEqual(r[k], v) {
+			return false
+		}
+	}
+	return true
+}
+
+// grafanaJSONDataStateJSON keeps the configured json data in the state while the remote one contains it
+func grafanaJSONDataStateJSON(configured string, remote map[string]interface{}) (string, error) {
+	if remote == nil {
+		remote = make(map[string]interface{})
+	}
+	b, err := json.Marshal(remote)
+	if err != nil {
+		return "", err
+	}
+	if configured != "" && grafanaJSONDataContains(string(b), configured) {
+		return configured, nil
+	}
+	return string(b), nil
+}
@@ -0,0 +1,160 @@
+package grafana
+
+import (
+	"context"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+)
+
+var aivenGrafanaFolderSchema = map[string]*schema.Schema{
+	"project":      schemautil.CommonSchemaProjectReference,
+	"service_name": schemautil.CommonSchemaServiceNameReference,
+	"title": {
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: "Title of the folder",
+	},
+	"uid": {
+		Type:        schema.TypeString,
+		Optional:    true,
+		Computed:    true,
+		ForceNew:    true,
+		Description: schemautil.Complex("Unique identifier of the folder, generated by Grafana when not set.").ForceNew().Referenced().Build(),
+	},
+
+	// computed fields
+	"folder_id": {
+		Type:        schema.TypeInt,
+		Computed:    true,
+		Description: "Numeric id of the folder",
+	},
+	"url": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Path of the folder in Grafana",
+	},
+}
+
+func ResourceGrafanaFolder() *schema.Resource {
+	return &schema.Resource{
+		Description:   "The Grafana Folder resource allows the creation and management of dashboard folders of an Aiven Grafana service.",
+		CreateContext: resourceGrafanaFolderCreate,
+		ReadContext:   resourceGrafanaFolderRead,
+		UpdateContext: resourceGrafanaFolderUpdate,
+		DeleteContext: resourceGrafanaFolderDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: aivenGrafanaFolderSchema,
+	}
+}
+
+func resourceGrafanaFolderCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	project := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+
+	c, err := newGrafanaClient(client, project, serviceName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	f, err := c.createFolder(ctx, &grafanaFolder{UID: d.Get("uid").(string), Title: d.Get("title").(string)})
+	if err != nil {
+		return diag.Errorf("cannot create Grafana folder: %s", err)
+	}
+
+	d.SetId(schemautil.BuildResourceID(project, serviceName, f.UID))
+
+	return resourceGrafanaFolderRead(ctx, d, m)
+}
+
+func resourceGrafanaFolderRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	project, serviceName, uid, err := schemautil.SplitResourceID3(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	c, err := newGrafanaClient(client, project, serviceName)
+	if err != nil {
+		return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
+	}
+
+	f, err := c.getFolder(ctx, uid)
+	if err != nil {
+		return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
+	}
+
+	if err := d.Set("project", project); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("service_name", serviceName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("uid", f.UID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("title", f.Title); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("folder_id", f.ID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("url", f.URL); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceGrafanaFolderUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	project, serviceName, uid, err := schemautil.SplitResourceID3(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	c, err := newGrafanaClient(client, project, serviceName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := c.updateFolder(ctx, &grafanaFolder{UID: uid, Title: d.Get("title").(string)}); err != nil {
+		return diag.Errorf("cannot update Grafana folder %s: %s", uid, err)
+	}
+
+	return resourceGrafanaFolderRead(ctx, d, m)
+}
+
+func resourceGrafanaFolderDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	project, serviceName, uid, err := schemautil.SplitResourceID3(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	c, err := newGrafanaClient(client, project, serviceName)
+	if err != nil {
+		if aiven.IsNotFound(err) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	// Grafana deletes the dashboards of the folder with it
+	if err := c.deleteFolder(ctx, uid); err != nil && !aiven.IsNotFound(err) {
+		return diag.Errorf("cannot delete Grafana folder %s: %s", uid, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,277 @@
+package influxdb
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+)
+
+type ContinuousQuery struct {
+	Database string
+	Name     string
+	// Query is the SELECT ... INTO ... GROUP BY time(...) statement the continuous query runs
+	Query string
+	// ResampleEvery and ResampleFor are the RESAMPLE options, 0 leaves them out
+	ResampleEvery time.Duration
+	ResampleFor   time.Duration
+}
+
+func (c *influxdbClient) createContinuousQuery(ctx context.Context, cq ContinuousQuery) error {
+	_, err := c.query(ctx, "", createContinuousQueryStatement(cq))
+	return err
+}
+
+func (c *influxdbClient) readContinuousQuery(ctx context.Context, database, name string) (*ContinuousQuery, error) {
+	series, err := c.query(ctx, "", "SHOW CONTINUOUS QUERIES")
+	if err != nil {
+		return nil, err
+	}
+	return continuousQueryFromSeries(series, database, name)
+}
+
+func (c *influxdbClient) dropContinuousQuery(ctx context.Context, database, name string) error {
+	_, err := c.query(ctx, "", fmt.Sprintf("DROP CONTINUOUS QUERY %s ON %s", quoteIdentifier(name), quoteIdentifier(database)))
+	return err
+}
+
+func createContinuousQueryStatement(cq ContinuousQuery) string {
+	b := new(strings.Builder)
+	b.WriteString(fmt.Sprintf("CREATE CONTINUOUS QUERY %s ON %s ", quoteIdentifier(cq.Name), quoteIdentifier(cq.Database)))
+	if cq.ResampleEvery != 0 || cq.ResampleFor != 0 {
+		b.WriteString("RESAMPLE ")
+		if cq.ResampleEvery != 0 {
+			b.WriteString(fmt.Sprintf("EVERY %s ", formatDuration(cq.ResampleEvery)))
+		}
+		if cq.ResampleFor != 0 {
+			b.WriteString(fmt.Sprintf("FOR %s ", formatDuration(cq.ResampleFor)))
+		}
+	}
+	b.WriteString(fmt.Sprintf("BEGIN %s END", strings.TrimSpace(cq.Query)))
+	return b.String()
+}
+
+// continuousQueryStatementRegexp matches the statement InfluxDB keeps for a continuous query, which it writes
+// again from the parsed statement
+var continuousQueryStatementRegexp = regexp.MustCompile(`(?is)^CREATE CONTINUOUS QUERY\s+.+?\s+ON\s+\S+\s+(?:RESAMPLE\s+(?:EVERY\s+(\S+)\s+)?(?:FOR\s+(\S+)\s+)?)?BEGIN\s+(.*?)\s+END$`)
+
+// continuousQueryFromSeries finds the continuous query in the result of SHOW CONTINUOUS QUERIES, which has a
+// series for each database with the names and the statements of its continuous queries
+func continuousQueryFromSeries(series []influxdbSeries, database, name string) (*ContinuousQuery, error) {
+	for _, s := range series {
+		if s.Name != database {
+			continue
+		}
+		for _, row := range seriesRows(s) {
+			if n, _ := row["name"].(string); n != name {
+				continue
+			}
+
+			statement, _ := row["query"].(string)
+			m := continuousQueryStatementRegexp.FindStringSubmatch(strings.TrimSpace(statement))
+			if m == nil {
+				return nil, fmt.Errorf("cannot read continuous query %s.%s: %q", database, name, statement)
+			}
+
+			cq := &ContinuousQuery{Database: database, Name: name, Query: m[3]}
+			var err error
+			if m[1] != "" {
+				if cq.ResampleEvery, err = parseDuration(m[1]); err != nil {
+					return nil, err
+				}
+			}
+			if m[2] != "" {
+				if cq.ResampleFor, err = parseDuration(m[2]); err != nil {
+					return nil, err
+				}
+			}
+			return cq, nil
+		}
+	}
+	return nil, schemautil.NotFoundError("continuous query", database+"."+name)
+}
+
+// influxqlKeywords are the keywords of InfluxQL, InfluxDB writes them in upper case
+var influxqlKeywords = map[string]bool{}
+
+func init() {
+	for _, k := range strings.Fields(`ALL ALTER AND ANY AS ASC BEGIN BY CONTINUOUS CREATE DATABASE DATABASES DEFAULT
+		DELETE DESC DISTINCT DROP DURATION END EVERY FALSE FIELD FOR FROM GROUP IN INF INTO KEY KEYS LIMIT MEASUREMENT
+		MEASUREMENTS NAME OFFSET ON OR ORDER POLICIES POLICY QUERY REPLICATION RESAMPLE RETENTION SELECT SERIES SET
+		SHARD SLIMIT SOFFSET TAG TO TRUE VALUES WHERE WITH`) {
+		influxqlKeywords[k] = true
+	}
+}
+
+// bareIdentifierRegexp matches the identifiers InfluxDB writes without quotes
+var bareIdentifierRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+type influxqlToken struct {
+	text       string
+	identifier bool
+}
+
+// normalizeQuery returns the query the way InfluxDB writes it again, so that it can be compared with the configured
+// one: the keywords are in upper case, the function names in lower case, the identifiers are only quoted when they
+// need to be and the whitespace is a single space. The database of the continuous query is left out in front of the
+// measurements after INTO and FROM and the default retention policy, when it is known, is added where there is none.
+// The case of the identifiers and the string literals and the other qualifiers are kept.
+func normalizeQuery(q, database, defaultRetentionPolicy string) string {
+	tokens := tokenizeQuery(q)
+	ownDatabase := influxqlIdentifier(database)
+	defaultRP := ""
+	if defaultRetentionPolicy != "" {
+		defaultRP = influxqlIdentifier(defaultRetentionPolicy)
+	}
+
+	result := make([]string, 0, len(tokens))
+	inFrom := false
+	for i := 0; i < len(tokens); i++ {
+		t := tokens[i]
+		result = append(result, t.text)
+		switch {
+		case t.text == "INTO" || t.text == "FROM":
+			inFrom = true
+		case !t.identifier && influxqlKeywords[t.text]:
+			inFrom = false
+		}
+		if !inFrom || (t.text != "INTO" && t.text != "FROM" && t.text != ",") {
+			continue
+		}
+
+		// db.rp.measurement, db..measurement, rp.measurement or measurement
+		next := tokens[i+1:]
+		db, rp, n := "", "", 0
+		switch {
+		case isMeasurementQualifier(next, 4):
+			db, rp, n = next[0].text, next[2].text, 4
+		case isMeasurementQualifier(next, 3):
+			db, n = next[0].text, 3
+		case isMeasurementQualifier(next, 2):
+			rp, n = next[0].text, 2
+		case len(next) > 0 && next[0].identifier:
+		default:
+			continue
+		}
+		if db == ownDatabase {
+			db = ""
+		}
+		if db == "" && rp == "" {
+			rp = defaultRP
+		}
+
+		measurement := next[n].text
+		switch {
+		case db != "":
+			measurement = db + "." + rp + "." + measurement
+		case rp != "":
+			measurement = rp + "." + measurement
+		}
+		result = append(result, measurement)
+		i += n + 1
+	}
+	return strings.Join(result, " ")
+}
+
+// influxqlIdentifier returns the identifier the way InfluxDB writes it, quoted only when it needs to be
+func influxqlIdentifier(name string) string {
+	if bareIdentifierRegexp.MatchString(name) && !influxqlKeywords[strings.ToUpper(name)] {
+		return name
+	}
+	return quoteIdentifier(name)
+}
+
+// isMeasurementQualifier tells whether the first n tokens are the database and retention policy in front of a
+// measurement, like db . rp . or db . . or rp .
+func isMeasurementQualifier(tokens []influxqlToken, n int) bool {
+	if len(tokens) <= n || !tokens[n].identifier {
+		return false
+	}
+	switch n {
+	case 4:
+		return tokens[0].identifier && tokens[1].text == "." && tokens[2].identifier && tokens[3].text == "."
+	case 3:
+		return tokens[0].identifier && tokens[1].text == "." && tokens[2].text == "."
+	default:
+		return tokens[0].identifier && tokens[1].text == "."
+	}
+}
+
+// tokenizeQuery splits the query into its keywords, identifiers, literals and operators
+func tokenizeQuery(q string) []influxqlToken {
+	var tokens []influxqlToken
+	previous := func() string {
+		if len(tokens) == 0 {
+			return ""
+		}
+		return tokens[len(tokens)-1].text
+	}
+
+	for i := 0; i < len(q); {
+		c := q[i]
+		switch {
+		case unicode.IsSpace(rune(c)):
+			i++
+		case c == '"' || c == '\'':
+			end := quotedEnd(q, i)
+			text := q[i:end]
+			if c == '"' {
+				name := strings.NewReplacer(`\"`, `"`, `\\`, `\`).Replace(strings.TrimSuffix(text[1:], `"`))
+				tokens = append(tokens, influxqlToken{text: influxqlIdentifier(name), identifier: true})
+			} else {
+				tokens = append(tokens, influxqlToken{text: text})
+			}
+			i = end
+		case c == '/' && (previous() == "FROM" || previous() == "," || previous() == "~" || previous() == "("):
+			// a regular expression, like FROM /^cpu/
+			end := quotedEnd(q, i)
+			tokens = append(tokens, influxqlToken{text: q[i:end]})
+			i = end
+		case c == '_' || unicode.IsLetter(rune(c)):
+			end := i + 1
+			for end < len(q) && (q[end] == '_' || unicode.IsLetter(rune(q[end])) || unicode.IsDigit(rune(q[end]))) {
+				end++
+			}
+			word := q[i:end]
+			next := strings.TrimLeftFunc(q[end:], unicode.IsSpace)
+			switch {
+			case strings.HasPrefix(next, "("):
+				tokens = append(tokens, influxqlToken{text: strings.ToLower(word)})
+			case influxqlKeywords[strings.ToUpper(word)]:
+				tokens = append(tokens, influxqlToken{text: strings.ToUpper(word)})
+			default:
+				tokens = append(tokens, influxqlToken{text: word, identifier: true})
+			}
+			i = end
+		case unicode.IsDigit(rune(c)):
+			end := i + 1
+			for end < len(q) && (q[end] == '.' || unicode.IsLetter(rune(q[end])) || unicode.IsDigit(rune(q[end]))) {
+				end++
+			}
+			tokens = append(tokens, influxqlToken{text: q[i:end]})
+			i = end
+		default:
+			tokens = append(tokens, influxqlToken{text: string(c)})
+			i++
+		}
+	}
+	return tokens
+}
+
+// quotedEnd returns the end of the quoted text starting at i, the quote can be escaped with a backslash
+func quotedEnd(q string, i int) int {
+	quote := q[i]
+	for end := i + 1; end < len(q); end++ {
+		switch q[end] {
+		case '\\':
+			end++
+		case quote:
+			return end + 1
+		}
+	}
+	return len(q)
+}
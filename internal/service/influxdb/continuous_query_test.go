@@ -0,0 +1,159 @@
+package influxdb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateContinuousQueryStatement(t *testing.T) {
+	query := `SELECT mean("value") INTO "one_year"."cpu_1h" FROM "cpu" GROUP BY time(1h), *`
+
+	assert.Equal(t,
+		`CREATE CONTINUOUS QUERY "cpu_1h" ON "metrics" BEGIN `+query+` END`,
+		createContinuousQueryStatement(ContinuousQuery{Database: "metrics", Name: "cpu_1h", Query: "\n" + query + "\n"}),
+	)
+	assert.Equal(t,
+		`CREATE CONTINUOUS QUERY "cpu_1h" ON "metrics" RESAMPLE EVERY 30m FOR 2h BEGIN `+query+` END`,
+		createContinuousQueryStatement(ContinuousQuery{
+			Database:      "metrics",
+			Name:          "cpu_1h",
+			Query:         query,
+			ResampleEvery: 30 * time.Minute,
+			ResampleFor:   2 * time.Hour,
+		}),
+	)
+	assert.Equal(t,
+		`CREATE CONTINUOUS QUERY "cpu_1h" ON "metrics" RESAMPLE FOR 2h BEGIN `+query+` END`,
+		createContinuousQueryStatement(ContinuousQuery{Database: "metrics", Name: "cpu_1h", Query: query, ResampleFor: 2 * time.Hour}),
+	)
+}
+
+func TestContinuousQueryFromSeries(t *testing.T) {
+	series := []influxdbSeries{
+		{
+			Name:    "_internal",
+			Columns: []string{"name", "query"},
+		},
+		{
+			Name:    "metrics",
+			Columns: []string{"name", "query"},
+			Values: [][]interface{}{
+				{"cpu_1h", `CREATE CONTINUOUS QUERY cpu_1h ON metrics BEGIN SELECT mean(value) INTO metrics.one_year.cpu_1h FROM metrics.autogen.cpu GROUP BY time(1h), * END`},
+				{"cpu_5m", `CREATE CONTINUOUS QUERY cpu_5m ON metrics RESAMPLE EVERY 1m FOR 10m BEGIN SELECT max(value) INTO metrics."default".cpu_5m FROM metrics."default".cpu GROUP BY time(5m) END`},
+			},
+		},
+	}
+
+	cq, err := continuousQueryFromSeries(series, "metrics", "cpu_1h")
+	require.NoError(t, err)
+	assert.Equal(t, &ContinuousQuery{
+		Database: "metrics",
+		Name:     "cpu_1h",
+		Query:    `SELECT mean(value) INTO metrics.one_year.cpu_1h FROM metrics.autogen.cpu GROUP BY time(1h), *`,
+	}, cq)
+
+	cq, err = continuousQueryFromSeries(series, "metrics", "cpu_5m")
+	require.NoError(t, err)
+	assert.Equal(t, time.Minute, cq.ResampleEvery)
+	assert.Equal(t, 10*time.Minute, cq.ResampleFor)
+	assert.Equal(t, `SELECT max(value) INTO metrics."default".cpu_5m FROM metrics."default".cpu GROUP BY time(5m)`, cq.Query)
+
+	_, err = continuousQueryFromSeries(series, "_internal", "cpu_1h")
+	assert.True(t, aiven.IsNotFound(err))
+}
+
+func TestNormalizeQuery(t *testing.T) {
+	assert.Equal(t,
+		normalizeQuery(`SELECT mean(value) INTO metrics.one_year.cpu_1h FROM metrics.autogen.cpu GROUP BY time(1h), *`, "metrics", "autogen"),
+		normalizeQuery(`select mean("value")
+  into "one_year"."cpu_1h"
+  from "cpu"
+  group by time(1h),*`, "metrics", "autogen"),
+	)
+	assert.Equal(t,
+		normalizeQuery(`SELECT max(value) INTO metrics."default".a FROM metrics."my-rp".b, metrics..c GROUP BY time(5m)`, "metrics", ""),
+		normalizeQuery(`SELECT max(value) INTO "default".a FROM "my-rp".b, c GROUP BY time(5m)`, "metrics", ""),
+	)
+	assert.Equal(t,
+		normalizeQuery(`SELECT percentile(value, 0.5) INTO a FROM b GROUP BY time(5m)`, "metrics", ""),
+		normalizeQuery(`SELECT percentile(value,0.5) INTO a FROM b GROUP BY time(5m)`, "metrics", ""),
+	)
+	assert.NotEqual(t,
+		normalizeQuery(`SELECT percentile(value, 0.5) INTO a FROM b GROUP BY time(5m)`, "metrics", ""),
+		normalizeQuery(`SELECT percentile(value, 1.5) INTO a FROM b GROUP BY time(5m)`, "metrics", ""),
+	)
+	assert.NotEqual(t,
+		normalizeQuery(`SELECT mean(value) INTO a FROM b GROUP BY time(5m)`, "metrics", ""),
+		normalizeQuery(`SELECT max(value) INTO a FROM b GROUP BY time(5m)`, "metrics", ""),
+	)
+	assert.Equal(t,
+		normalizeQuery(`SELECT mean(value) INTO a FROM b WHERE host =~ /^web/ AND region = 'EU' GROUP BY time(5m)`, "metrics", ""),
+		normalizeQuery(`select MEAN("value") into "a" from b where "host"=~/^web/ and region='EU' group by TIME(5m)`, "metrics", ""),
+	)
+	assert.Equal(t,
+		normalizeQuery(`SELECT mean(value) INTO a FROM "default".b GROUP BY time(5m)`, "metrics", "default"),
+		normalizeQuery(`SELECT mean(value) INTO a FROM b GROUP BY time(5m)`, "metrics", "default"),
+	)
+	assert.Equal(t,
+		normalizeQuery(`SELECT mean("my field") INTO a FROM b GROUP BY time(5m)`, "metrics", ""),
+		normalizeQuery(`SELECT mean( "my field" ) INTO a FROM b GROUP BY time(5m)`, "metrics", ""),
+	)
+	assert.Equal(t,
+		normalizeQuery(`SELECT mean(value) INTO "my-db".a FROM b GROUP BY time(5m)`, "my-db", ""),
+		normalizeQuery(`SELECT mean(value) INTO "my-db"."my-db".a FROM "my-db"..b GROUP BY time(5m)`, "my-db", ""),
+	)
+}
+
+func TestNormalizeQueryKeepsQualifiers(t *testing.T) {
+	assert.NotEqual(t,
+		normalizeQuery(`SELECT mean(value) INTO "one_year"."cpu_1h" FROM cpu GROUP BY time(1h)`, "metrics", ""),
+		normalizeQuery(`SELECT mean(value) INTO "forever"."cpu_1h" FROM cpu GROUP BY time(1h)`, "metrics", ""),
+	)
+	assert.NotEqual(t,
+		normalizeQuery(`SELECT mean(value) INTO metrics.one_year.cpu_1h FROM metrics.autogen.cpu GROUP BY time(1h)`, "metrics", "autogen"),
+		normalizeQuery(`SELECT mean(value) INTO forever.cpu_1h FROM cpu GROUP BY time(1h)`, "metrics", "autogen"),
+	)
+	assert.NotEqual(t,
+		normalizeQuery(`SELECT max(value) INTO a FROM "my-rp".b GROUP BY time(5m)`, "metrics", ""),
+		normalizeQuery(`SELECT max(value) INTO a FROM b GROUP BY time(5m)`, "metrics", ""),
+	)
+	assert.NotEqual(t,
+		normalizeQuery(`SELECT max(value) INTO a FROM "default".b GROUP BY time(5m)`, "metrics", "autogen"),
+		normalizeQuery(`SELECT max(value) INTO a FROM b GROUP BY time(5m)`, "metrics", "autogen"),
+	)
+	assert.NotEqual(t,
+		normalizeQuery(`SELECT max(value) INTO a FROM other.autogen.b GROUP BY time(5m)`, "metrics", "autogen"),
+		normalizeQuery(`SELECT max(value) INTO a FROM b GROUP BY time(5m)`, "metrics", "autogen"),
+	)
+}
+
+func TestNormalizeQueryKeepsCase(t *testing.T) {
+	assert.NotEqual(t,
+		normalizeQuery(`SELECT mean(value) INTO a FROM cpu GROUP BY time(5m)`, "metrics", ""),
+		normalizeQuery(`SELECT mean(value) INTO a FROM CPU GROUP BY time(5m)`, "metrics", ""),
+	)
+	assert.NotEqual(t,
+		normalizeQuery(`SELECT mean(value) INTO a FROM "cpu" GROUP BY time(5m)`, "metrics", ""),
+		normalizeQuery(`SELECT mean(value) INTO a FROM "Cpu" GROUP BY time(5m)`, "metrics", ""),
+	)
+	assert.NotEqual(t,
+		normalizeQuery(`SELECT mean(Value) INTO a FROM b GROUP BY time(5m)`, "metrics", ""),
+		normalizeQuery(`SELECT mean(value) INTO a FROM b GROUP BY time(5m)`, "metrics", ""),
+	)
+	assert.NotEqual(t,
+		normalizeQuery(`SELECT mean(value) INTO a FROM b WHERE region = 'EU' GROUP BY time(5m)`, "metrics", ""),
+		normalizeQuery(`SELECT mean(value) INTO a FROM b WHERE region = 'eu' GROUP BY time(5m)`, "metrics", ""),
+	)
+	assert.NotEqual(t,
+		normalizeQuery(`SELECT mean(value) INTO a FROM b WHERE region = 'my region' GROUP BY time(5m)`, "metrics", ""),
+		normalizeQuery(`SELECT mean(value) INTO a FROM b WHERE region = 'my  region' GROUP BY time(5m)`, "metrics", ""),
+	)
+	assert.NotEqual(t,
+		normalizeQuery(`SELECT mean(value) INTO a FROM b WHERE region = 'EU' GROUP BY time(5m)`, "metrics", ""),
+		normalizeQuery(`SELECT mean(value) INTO a FROM b WHERE region = "EU" GROUP BY time(5m)`, "metrics", ""),
+	)
+}
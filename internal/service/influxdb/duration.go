@@ -0,0 +1,113 @@
+package influxdb
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// infiniteDuration is how InfluxQL writes a retention duration that keeps the data forever, InfluxDB reports it as 0s
+const infiniteDuration = "INF"
+
+// durationUnits are the units of InfluxQL durations, from the largest to the smallest
+var durationUnits = []struct {
+	unit     string
+	duration time.Duration
+}{
+	{"w", 7 * 24 * time.Hour},
+	{"d", 24 * time.Hour},
+	{"h", time.Hour},
+	{"m", time.Minute},
+	{"s", time.Second},
+	{"ms", time.Millisecond},
+	{"u", time.Microsecond},
+	{"µ", time.Microsecond},
+	{"µs", time.Microsecond},
+	{"ns", time.Nanosecond},
+}
+
+// parseDuration parses InfluxQL durations like 30d, the way InfluxDB reports them like 720h0m0s, and INF, which
+// is 0. µs is only there for the way InfluxDB reports microseconds
+func parseDuration(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if strings.EqualFold(s, infiniteDuration) {
+		return 0, nil
+	}
+	if s == "" {
+		return 0, fmt.Errorf("empty duration")
+	}
+
+	var d time.Duration
+	for rest := s; rest != ""; {
+		i := strings.IndexFunc(rest, func(r rune) bool { return r < '0' || r > '9' })
+		if i <= 0 {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+		n, err := strconv.ParseInt(rest[:i], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q: %w", s, err)
+		}
+		rest = rest[i:]
+
+		// the units are matched from the longest, so that ms and ns aren't read as m and a leftover s
+		unit := ""
+		var unitDuration time.Duration
+		for _, u := range durationUnits {
+			if strings.HasPrefix(rest, u.unit) && len(u.unit) > len(unit) {
+				unit, unitDuration = u.unit, u.duration
+			}
+		}
+		if unit == "" {
+			return 0, fmt.Errorf("invalid unit in duration %q", s)
+		}
+		rest = rest[len(unit):]
+		d += time.Duration(n) * unitDuration
+	}
+	return d, nil
+}
+
+// formatDuration returns the duration as an InfluxQL duration in the largest unit that divides it, 0 is INF
+func formatDuration(d time.Duration) string {
+	if d == 0 {
+		return infiniteDuration
+	}
+	for _, u := range durationUnits {
+		if d%u.duration == 0 {
+			return fmt.Sprintf("%d%s", d/u.duration, u.unit)
+		}
+	}
+	return fmt.Sprintf("%dns", d)
+}
+
+// validateDuration is a ValidateFunc for InfluxQL durations
+func validateDuration(v interface{}, k string) (ws []string, es []error) {
+	if _, err := parseDuration(v.(string)); err != nil {
+		es = append(es, fmt.Errorf("%s: %w", k, err))
+	}
+	return
+}
+
+// diffSuppressDuration suppresses the difference between two ways to write the same duration, like 1d and 24h
+func diffSuppressDuration(_, old, new string, _ *schema.ResourceData) bool {
+	o, err := parseDuration(old)
+	if err != nil {
+		return false
+	}
+	n, err := parseDuration(new)
+	if err != nil {
+		return false
+	}
+	return o == n
+}
+
+// durationOrConfigured returns the configured duration when it is the same as the one InfluxDB reports, so that
+// it is shown the way it is written
+func durationOrConfigured(configured string, d time.Duration) string {
+	if c, err := parseDuration(configured); err == nil && c == d {
+		return configured
+	}
+	return formatDuration(d)
+}
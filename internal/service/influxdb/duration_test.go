@@ -0,0 +1,65 @@
+package influxdb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Duration
+	}{
+		{"30d", 30 * 24 * time.Hour},
+		{"2w", 14 * 24 * time.Hour},
+		{"1h30m", 90 * time.Minute},
+		{"720h0m0s", 720 * time.Hour},
+		{"0s", 0},
+		{"INF", 0},
+		{"inf", 0},
+		{"100ms", 100 * time.Millisecond},
+		{"5u", 5 * time.Microsecond},
+		{"5µ", 5 * time.Microsecond},
+		{"5µs", 5 * time.Microsecond},
+		{"10ns", 10 * time.Nanosecond},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			d, err := parseDuration(tt.in)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, d)
+		})
+	}
+
+	for _, in := range []string{"", "d", "30", "1.5h", "30x", "-1h", "1h 30m"} {
+		_, err := parseDuration(in)
+		assert.Error(t, err, in)
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	assert.Equal(t, "INF", formatDuration(0))
+	assert.Equal(t, "2w", formatDuration(14*24*time.Hour))
+	assert.Equal(t, "30d", formatDuration(30*24*time.Hour))
+	assert.Equal(t, "90m", formatDuration(90*time.Minute))
+	assert.Equal(t, "1500ms", formatDuration(1500*time.Millisecond))
+	assert.Equal(t, "7u", formatDuration(7*time.Microsecond))
+	assert.Equal(t, "3ns", formatDuration(3))
+}
+
+func TestDurationOrConfigured(t *testing.T) {
+	assert.Equal(t, "1d", durationOrConfigured("1d", 24*time.Hour))
+	assert.Equal(t, "INF", durationOrConfigured("INF", 0))
+	assert.Equal(t, "2d", durationOrConfigured("1d", 48*time.Hour))
+	assert.Equal(t, "1w", durationOrConfigured("", 7*24*time.Hour))
+}
+
+func TestDiffSuppressDuration(t *testing.T) {
+	assert.True(t, diffSuppressDuration("", "1d", "24h", nil))
+	assert.True(t, diffSuppressDuration("", "INF", "0s", nil))
+	assert.False(t, diffSuppressDuration("", "1d", "1w", nil))
+	assert.False(t, diffSuppressDuration("", "", "1d", nil))
+}
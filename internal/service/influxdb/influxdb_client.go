@@ -0,0 +1,119 @@
+package influxdb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+)
+
+// influxdbClient runs InfluxQL statements through the HTTP query API of an InfluxDB service with the admin
+// credentials of the service
+type influxdbClient struct {
+	*schemautil.ServiceHTTPClient
+}
+
+func newInfluxDBClient(client *aiven.Client, project, serviceName string) (*influxdbClient, error) {
+	c, err := schemautil.NewServiceHTTPClient(client, project, serviceName)
+	if err != nil {
+		return nil, err
+	}
+	return influxdbClientFrom(c), nil
+}
+
+func influxdbClientFrom(c *schemautil.ServiceHTTPClient) *influxdbClient {
+	c.ErrorMessage = influxdbErrorMessage
+	return &influxdbClient{c}
+}
+
+// influxdbErrorMessage returns the message of an error response of the query API
+func influxdbErrorMessage(b []byte) string {
+	var r influxdbResponse
+	if err := json.Unmarshal(b, &r); err != nil {
+		return ""
+	}
+	return r.Error
+}
+
+// influxdbSeries is a series of the result of a statement, SHOW statements return one row per value
+type influxdbSeries struct {
+	Name    string          `json:"name"`
+	Columns []string        `json:"columns"`
+	Values  [][]interface{} `json:"values"`
+}
+
+type influxdbResponse struct {
+	Results []struct {
+		Series []influxdbSeries `json:"series"`
+		Error  string           `json:"error"`
+	} `json:"results"`
+	Error string `json:"error"`
+}
+
+// query runs the statement on the database and returns the series of its result, the errors InfluxDB gives for
+// a database or a retention policy that doesn't exist are returned as 404s
+func (c *influxdbClient) query(ctx context.Context, database, statement string) ([]influxdbSeries, error) {
+	log.Println("[DEBUG] InfluxDB: query: ", statement)
+
+	form := url.Values{"q": {statement}}
+	if database != "" {
+		form.Set("db", database)
+	}
+	b, err := c.Request(ctx, http.MethodPost, "/query", "application/x-www-form-urlencoded", strings.NewReader(form.Encode()))
+	if err != nil {
+		var e aiven.Error
+		if errors.As(err, &e) {
+			return nil, queryError(e.Message, e.Status)
+		}
+		return nil, err
+	}
+
+	// statement errors come with a 200 status
+	var r influxdbResponse
+	if err := json.Unmarshal(b, &r); err != nil {
+		return nil, err
+	}
+	if r.Error != "" {
+		return nil, queryError(r.Error, http.StatusBadRequest)
+	}
+	if len(r.Results) == 0 {
+		return nil, nil
+	}
+	if r.Results[0].Error != "" {
+		return nil, queryError(r.Results[0].Error, http.StatusBadRequest)
+	}
+	return r.Results[0].Series, nil
+}
+
+func queryError(message string, status int) error {
+	if strings.HasPrefix(message, "database not found") || strings.HasPrefix(message, "retention policy not found") {
+		status = http.StatusNotFound
+	}
+	return aiven.Error{Message: message, Status: status}
+}
+
+// seriesRows returns the rows of the series as maps from the columns to the values
+func seriesRows(s influxdbSeries) []map[string]interface{} {
+	rows := make([]map[string]interface{}, 0, len(s.Values))
+	for _, v := range s.Values {
+		row := make(map[string]interface{}, len(s.Columns))
+		for i, c := range s.Columns {
+			if i < len(v) {
+				row[c] = v[i]
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// quoteIdentifier quotes an InfluxQL identifier, double quotes and backslashes are escaped with a backslash
+func quoteIdentifier(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
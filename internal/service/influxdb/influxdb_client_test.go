@@ -0,0 +1,73 @@
+package influxdb
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newInfluxDBStandIn answers the query API the way InfluxDB does, statement errors come with a 200 status
+func newInfluxDBStandIn(t *testing.T) (*httptest.Server, *influxdbClient) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, password, _ := r.BasicAuth(); user != "avnadmin" || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error": "authorization failed"}`))
+			return
+		}
+		require.Equal(t, "/query", r.URL.Path)
+		require.Equal(t, http.MethodPost, r.Method)
+
+		switch r.FormValue("q") {
+		case `SHOW RETENTION POLICIES ON "metrics"`:
+			_, _ = w.Write([]byte(`{"results": [{"statement_id": 0, "series": [{"columns": ["name", "duration", "shardGroupDuration", "replicaN", "default"], "values": [["autogen", "0s", "168h0m0s", 1, true]]}]}]}`))
+		case `SHOW RETENTION POLICIES ON "missing"`:
+			_, _ = w.Write([]byte(`{"results": [{"statement_id": 0, "error": "database not found: missing"}]}`))
+		case `CREATE RETENTION POLICY "bad" ON "metrics" DURATION 1s REPLICATION 1`:
+			_, _ = w.Write([]byte(`{"results": [{"statement_id": 0, "error": "retention policy duration must be at least 1h0m0s"}]}`))
+		case "nonsense":
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error": "error parsing query: found nonsense, expected SELECT"}`))
+		default:
+			_, _ = w.Write([]byte(`{"results": [{"statement_id": 0}]}`))
+		}
+	}))
+
+	return srv, influxdbClientFrom(&schemautil.ServiceHTTPClient{URL: srv.URL, Username: "avnadmin", Password: "secret", Client: srv.Client()})
+}
+
+func TestInfluxDBClientQuery(t *testing.T) {
+	srv, c := newInfluxDBStandIn(t)
+	defer srv.Close()
+	ctx := context.Background()
+
+	rp, err := c.readRetentionPolicy(ctx, "metrics", "autogen")
+	require.NoError(t, err)
+	assert.True(t, rp.Default)
+	assert.Equal(t, 1, rp.Replication)
+
+	_, err = c.readRetentionPolicy(ctx, "metrics", "missing")
+	assert.True(t, aiven.IsNotFound(err))
+	_, err = c.readRetentionPolicy(ctx, "missing", "autogen")
+	assert.True(t, aiven.IsNotFound(err))
+
+	err = c.createRetentionPolicy(ctx, RetentionPolicy{Database: "metrics", Name: "bad", Duration: 1e9, Replication: 1})
+	assert.EqualError(t, err, aiven.Error{Message: "retention policy duration must be at least 1h0m0s", Status: http.StatusBadRequest}.Error())
+
+	_, err = c.query(ctx, "", "nonsense")
+	var e aiven.Error
+	require.ErrorAs(t, err, &e)
+	assert.Equal(t, http.StatusBadRequest, e.Status)
+
+	assert.NoError(t, c.dropContinuousQuery(ctx, "metrics", "cpu_1h"))
+
+	c.Password = "wrong"
+	_, err = c.query(ctx, "", "SHOW DATABASES")
+	require.ErrorAs(t, err, &e)
+	assert.Equal(t, http.StatusUnauthorized, e.Status)
+}
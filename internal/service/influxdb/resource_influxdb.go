@@ -50,6 +50,14 @@ func ResourceInfluxDB() *schema.Resource {
 				schemautil.DiskSpaceShouldNotBeEmpty,
 				schemautil.CustomizeDiffCheckDiskSpace,
 			),
+			customdiff.IfValueChange("project_vpc_id",
+				schemautil.ProjectVPCShouldNotBeEmpty,
+				schemautil.CustomizeDiffCheckProjectVPCCapacity,
+			),
+			customdiff.IfValueChange("plan",
+				schemautil.PlanShouldNotBeEmpty,
+				schemautil.CustomizeDiffCheckProjectVPCCapacity,
+			),
 			customdiff.IfValueChange("service_integrations",
 				schemautil.ServiceIntegrationShouldNotBeEmpty,
 				schemautil.CustomizeDiffServiceIntegrationAfterCreation,
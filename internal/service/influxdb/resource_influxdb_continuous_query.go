@@ -0,0 +1,189 @@
+package influxdb
+
+import (
+	"context"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var aivenInfluxDBContinuousQuerySchema = map[string]*schema.Schema{
+	"project":      schemautil.CommonSchemaProjectReference,
+	"service_name": schemautil.CommonSchemaServiceNameReference,
+	"database_name": {
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: schemautil.Complex("The name of the database of the continuous query.").ForceNew().Referenced().Build(),
+	},
+	"name": {
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: schemautil.Complex("The name of the continuous query.").ForceNew().Build(),
+	},
+	"query": {
+		Type:     schema.TypeString,
+		Required: true,
+		ForceNew: true,
+		DiffSuppressFunc: func(_, old, new string, d *schema.ResourceData) bool {
+			database := d.Get("database_name").(string)
+			return normalizeQuery(old, database, "") == normalizeQuery(new, database, "")
+		},
+		Description: schemautil.Complex("The SELECT statement with INTO and GROUP BY time() clauses the continuous query runs.").ForceNew().Build(),
+	},
+	"resample_every": {
+		Type:             schema.TypeString,
+		Optional:         true,
+		ForceNew:         true,
+		ValidateFunc:     validateDuration,
+		DiffSuppressFunc: diffSuppressDuration,
+		Description:      schemautil.Complex("How often the continuous query runs, as an InfluxQL duration. It runs at the interval of GROUP BY time() when it is not set.").ForceNew().Build(),
+	},
+	"resample_for": {
+		Type:             schema.TypeString,
+		Optional:         true,
+		ForceNew:         true,
+		ValidateFunc:     validateDuration,
+		DiffSuppressFunc: diffSuppressDuration,
+		Description:      schemautil.Complex("The time range the continuous query covers each time it runs, as an InfluxQL duration. It covers the interval of GROUP BY time() when it is not set.").ForceNew().Build(),
+	},
+}
+
+func ResourceInfluxDBContinuousQuery() *schema.Resource {
+	return &schema.Resource{
+		Description: `The InfluxDB Continuous Query resource allows the creation and management of the continuous queries of the databases of Aiven InfluxDB services.
+
+Notes:
+* InfluxDB can't change a continuous query, any change recreates it.
+* InfluxDB keeps the query the way it writes it again, with the database and the retention policy in front of the measurements. The query is compared with the configured one without the database of the continuous query in front of the measurements, with the default retention policy of the database where the configured query has none, and without the whitespace, the case of the keywords and function names and the quotes InfluxDB doesn't need around identifiers. Changes of the retention policy, of another database and of the case of identifiers and string literals are changes of the query.
+`,
+		CreateContext: resourceInfluxDBContinuousQueryCreate,
+		ReadContext:   resourceInfluxDBContinuousQueryRead,
+		DeleteContext: resourceInfluxDBContinuousQueryDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: aivenInfluxDBContinuousQuerySchema,
+	}
+}
+
+func resourceInfluxDBContinuousQueryCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+	cq := ContinuousQuery{
+		Database: d.Get("database_name").(string),
+		Name:     d.Get("name").(string),
+		Query:    d.Get("query").(string),
+	}
+
+	var err error
+	if v := d.Get("resample_every").(string); v != "" {
+		if cq.ResampleEvery, err = parseDuration(v); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if v := d.Get("resample_for").(string); v != "" {
+		if cq.ResampleFor, err = parseDuration(v); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	c, err := newInfluxDBClient(client, projectName, serviceName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := c.createContinuousQuery(ctx, cq); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(schemautil.BuildResourceID(projectName, serviceName, cq.Database, cq.Name))
+
+	return resourceInfluxDBContinuousQueryRead(ctx, d, m)
+}
+
+func resourceInfluxDBContinuousQueryRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName, serviceName, databaseName, name, err := schemautil.SplitResourceID4(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	c, err := newInfluxDBClient(client, projectName, serviceName)
+	if err != nil {
+		return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
+	}
+	cq, err := c.readContinuousQuery(ctx, databaseName, name)
+	if err != nil {
+		return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
+	}
+
+	// the configured query is kept when it is the one InfluxDB has, which adds the default retention policy to the
+	// measurements that have none
+	defaultRetentionPolicy, err := c.readDefaultRetentionPolicy(ctx, databaseName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	query := cq.Query
+	configured := d.Get("query").(string)
+	if normalizeQuery(configured, databaseName, defaultRetentionPolicy) == normalizeQuery(query, databaseName, defaultRetentionPolicy) {
+		query = configured
+	}
+
+	resampleEvery, resampleFor := "", ""
+	if cq.ResampleEvery != 0 {
+		resampleEvery = durationOrConfigured(d.Get("resample_every").(string), cq.ResampleEvery)
+	}
+	if cq.ResampleFor != 0 {
+		resampleFor = durationOrConfigured(d.Get("resample_for").(string), cq.ResampleFor)
+	}
+
+	if err := d.Set("project", projectName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("service_name", serviceName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("database_name", databaseName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("name", name); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("query", query); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("resample_every", resampleEvery); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("resample_for", resampleFor); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceInfluxDBContinuousQueryDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName, serviceName, databaseName, name, err := schemautil.SplitResourceID4(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	c, err := newInfluxDBClient(client, projectName, serviceName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := c.dropContinuousQuery(ctx, databaseName, name); err != nil && !aiven.IsNotFound(err) {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,227 @@
+package influxdb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var aivenInfluxDBRetentionPolicySchema = map[string]*schema.Schema{
+	"project":      schemautil.CommonSchemaProjectReference,
+	"service_name": schemautil.CommonSchemaServiceNameReference,
+	"database_name": {
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: schemautil.Complex("The name of the database of the retention policy.").ForceNew().Referenced().Build(),
+	},
+	"name": {
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: schemautil.Complex("The name of the retention policy.").ForceNew().Build(),
+	},
+	"duration": {
+		Type:             schema.TypeString,
+		Required:         true,
+		ValidateFunc:     validateDuration,
+		DiffSuppressFunc: diffSuppressDuration,
+		Description:      "How long the data is kept, as an InfluxQL duration like `30d` or `INF` to keep it forever.",
+	},
+	"shard_group_duration": {
+		Type:             schema.TypeString,
+		Optional:         true,
+		Computed:         true,
+		ValidateFunc:     validateDuration,
+		DiffSuppressFunc: diffSuppressDuration,
+		Description:      "The time range of the shard groups, as an InfluxQL duration like `1d`. InfluxDB picks it from the duration when it is not set.",
+	},
+	"replication": {
+		Type:         schema.TypeInt,
+		Optional:     true,
+		Default:      1,
+		ValidateFunc: validation.IntAtLeast(1),
+		Description:  schemautil.Complex("The number of copies of the data.").DefaultValue(1).Build(),
+	},
+	"default": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: schemautil.Complex("Make it the default retention policy of the database. It can only be taken away by making another retention policy the default.").DefaultValue(false).Build(),
+	},
+	"termination_protection": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: schemautil.Complex(`It is a Terraform client-side deletion protection, which prevents the retention policy and its data from being dropped by Terraform.`).DefaultValue(false).Build(),
+	},
+}
+
+func ResourceInfluxDBRetentionPolicy() *schema.Resource {
+	return &schema.Resource{
+		Description:   "The InfluxDB Retention Policy resource allows the creation and management of the retention policies of the databases of Aiven InfluxDB services.",
+		CreateContext: resourceInfluxDBRetentionPolicyCreate,
+		ReadContext:   resourceInfluxDBRetentionPolicyRead,
+		UpdateContext: resourceInfluxDBRetentionPolicyUpdate,
+		DeleteContext: resourceInfluxDBRetentionPolicyDelete,
+		CustomizeDiff: resourceInfluxDBRetentionPolicyCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: aivenInfluxDBRetentionPolicySchema,
+	}
+}
+
+func resourceInfluxDBRetentionPolicyCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" || !d.HasChange("default") {
+		return nil
+	}
+	if o, _ := d.GetChange("default"); o.(bool) {
+		return fmt.Errorf("retention policy %s can't stop being the default, make another retention policy the default instead", d.Get("name").(string))
+	}
+	return nil
+}
+
+func resourceInfluxDBRetentionPolicyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+	rp, err := retentionPolicyFromSchema(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	c, err := newInfluxDBClient(client, projectName, serviceName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := c.createRetentionPolicy(ctx, rp); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(schemautil.BuildResourceID(projectName, serviceName, rp.Database, rp.Name))
+
+	return resourceInfluxDBRetentionPolicyRead(ctx, d, m)
+}
+
+func resourceInfluxDBRetentionPolicyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName, serviceName, databaseName, name, err := schemautil.SplitResourceID4(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	c, err := newInfluxDBClient(client, projectName, serviceName)
+	if err != nil {
+		return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
+	}
+	rp, err := c.readRetentionPolicy(ctx, databaseName, name)
+	if err != nil {
+		return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
+	}
+
+	if err := d.Set("project", projectName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("service_name", serviceName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("database_name", databaseName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("name", name); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("duration", durationOrConfigured(d.Get("duration").(string), rp.Duration)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("shard_group_duration", durationOrConfigured(d.Get("shard_group_duration").(string), rp.ShardGroupDuration)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("replication", rp.Replication); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("default", rp.Default); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceInfluxDBRetentionPolicyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName, serviceName, _, _, err := schemautil.SplitResourceID4(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.HasChanges("duration", "shard_group_duration", "replication", "default") {
+		rp, err := retentionPolicyFromSchema(d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		c, err := newInfluxDBClient(client, projectName, serviceName)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if err := c.alterRetentionPolicy(ctx, rp); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceInfluxDBRetentionPolicyRead(ctx, d, m)
+}
+
+func resourceInfluxDBRetentionPolicyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName, serviceName, databaseName, name, err := schemautil.SplitResourceID4(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.Get("termination_protection").(bool) {
+		return diag.Errorf("cannot drop retention policy %s of database %s, termination_protection is enabled", name, databaseName)
+	}
+
+	c, err := newInfluxDBClient(client, projectName, serviceName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := c.dropRetentionPolicy(ctx, databaseName, name); err != nil && !aiven.IsNotFound(err) {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func retentionPolicyFromSchema(d *schema.ResourceData) (RetentionPolicy, error) {
+	rp := RetentionPolicy{
+		Database:    d.Get("database_name").(string),
+		Name:        d.Get("name").(string),
+		Replication: d.Get("replication").(int),
+		Default:     d.Get("default").(bool),
+	}
+
+	var err error
+	if rp.Duration, err = parseDuration(d.Get("duration").(string)); err != nil {
+		return rp, err
+	}
+	// the shard group duration is computed, it is left to InfluxDB until it is set
+	if v := d.Get("shard_group_duration").(string); v != "" {
+		if rp.ShardGroupDuration, err = parseDuration(v); err != nil {
+			return rp, err
+		}
+	}
+	return rp, nil
+}
@@ -0,0 +1,115 @@
+package influxdb_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	acc "github.com/aiven/terraform-provider-aiven/internal/acctest"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAivenInfluxDBRetentionPolicyAndContinuousQuery(t *testing.T) {
+	serviceName := fmt.Sprintf("test-acc-sr-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	projectName := os.Getenv("AIVEN_PROJECT_NAME")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acc.TestAccPreCheck(t) },
+		ProviderFactories: acc.TestAccProviderFactories,
+		CheckDestroy:      acc.TestAccCheckAivenServiceResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccInfluxDBRetentionPolicyResource(projectName, serviceName, "30d"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aiven_influxdb_retention_policy.one_month", "duration", "30d"),
+					resource.TestCheckResourceAttr("aiven_influxdb_retention_policy.one_month", "shard_group_duration", "1d"),
+					resource.TestCheckResourceAttr("aiven_influxdb_retention_policy.one_month", "replication", "1"),
+					resource.TestCheckResourceAttr("aiven_influxdb_retention_policy.one_month", "default", "true"),
+					resource.TestCheckResourceAttr("aiven_influxdb_retention_policy.one_year", "duration", "52w"),
+					resource.TestCheckResourceAttrSet("aiven_influxdb_retention_policy.one_year", "shard_group_duration"),
+					resource.TestCheckResourceAttr("aiven_influxdb_continuous_query.cpu_1h", "resample_every", "30m"),
+				),
+			},
+			{
+				// the retention policy is changed in place
+				Config: testAccInfluxDBRetentionPolicyResource(projectName, serviceName, "60d"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aiven_influxdb_retention_policy.one_month", "duration", "60d"),
+				),
+			},
+			{
+				ResourceName:      "aiven_influxdb_retention_policy.one_month",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"termination_protection",
+					// InfluxDB has the durations in the largest unit that divides them
+					"duration",
+					"shard_group_duration",
+				},
+			},
+			{
+				ResourceName:      "aiven_influxdb_continuous_query.cpu_1h",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					// InfluxDB has the query with the database and the retention policy of the measurements
+					"query",
+				},
+			},
+		},
+	})
+}
+
+func testAccInfluxDBRetentionPolicyResource(projectName, serviceName, duration string) string {
+	return fmt.Sprintf(`
+resource "aiven_influxdb" "bar" {
+  project                 = "%s"
+  cloud_name              = "google-europe-west1"
+  plan                    = "startup-4"
+  service_name            = "%s"
+  maintenance_window_dow  = "monday"
+  maintenance_window_time = "10:00:00"
+}
+
+resource "aiven_influxdb_database" "metrics" {
+  project       = aiven_influxdb.bar.project
+  service_name  = aiven_influxdb.bar.service_name
+  database_name = "metrics"
+}
+
+resource "aiven_influxdb_retention_policy" "one_month" {
+  project              = aiven_influxdb_database.metrics.project
+  service_name         = aiven_influxdb_database.metrics.service_name
+  database_name        = aiven_influxdb_database.metrics.database_name
+  name                 = "one_month"
+  duration             = "%s"
+  shard_group_duration = "1d"
+  default              = true
+}
+
+resource "aiven_influxdb_retention_policy" "one_year" {
+  project       = aiven_influxdb_database.metrics.project
+  service_name  = aiven_influxdb_database.metrics.service_name
+  database_name = aiven_influxdb_database.metrics.database_name
+  name          = "one_year"
+  duration      = "52w"
+}
+
+resource "aiven_influxdb_continuous_query" "cpu_1h" {
+  project        = aiven_influxdb_database.metrics.project
+  service_name   = aiven_influxdb_database.metrics.service_name
+  database_name  = aiven_influxdb_database.metrics.database_name
+  name           = "cpu_1h"
+  resample_every = "30m"
+
+  query = <<EOT
+SELECT mean("usage_user") AS "usage_user"
+  INTO "${aiven_influxdb_retention_policy.one_year.name}"."cpu_1h"
+  FROM "${aiven_influxdb_retention_policy.one_month.name}"."cpu"
+  GROUP BY time(1h), *
+EOT
+}`, projectName, serviceName, duration)
+}
@@ -0,0 +1,128 @@
+package influxdb
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+)
+
+type RetentionPolicy struct {
+	Database string
+	Name     string
+	// Duration is how long the data is kept, 0 keeps it forever
+	Duration time.Duration
+	// ShardGroupDuration is the time range of a shard group, 0 lets InfluxDB pick it from the duration
+	ShardGroupDuration time.Duration
+	Replication        int
+	Default            bool
+}
+
+func (c *influxdbClient) createRetentionPolicy(ctx context.Context, rp RetentionPolicy) error {
+	_, err := c.query(ctx, "", createRetentionPolicyStatement(rp))
+	return err
+}
+
+func (c *influxdbClient) alterRetentionPolicy(ctx context.Context, rp RetentionPolicy) error {
+	_, err := c.query(ctx, "", alterRetentionPolicyStatement(rp))
+	return err
+}
+
+func (c *influxdbClient) readRetentionPolicy(ctx context.Context, database, name string) (*RetentionPolicy, error) {
+	series, err := c.query(ctx, "", fmt.Sprintf("SHOW RETENTION POLICIES ON %s", quoteIdentifier(database)))
+	if err != nil {
+		return nil, err
+	}
+	return retentionPolicyFromSeries(series, database, name)
+}
+
+// readDefaultRetentionPolicy returns the name of the default retention policy of the database, which is empty when
+// the database has none
+func (c *influxdbClient) readDefaultRetentionPolicy(ctx context.Context, database string) (string, error) {
+	series, err := c.query(ctx, "", fmt.Sprintf("SHOW RETENTION POLICIES ON %s", quoteIdentifier(database)))
+	if err != nil {
+		return "", err
+	}
+	return defaultRetentionPolicyFromSeries(series), nil
+}
+
+func (c *influxdbClient) dropRetentionPolicy(ctx context.Context, database, name string) error {
+	_, err := c.query(ctx, "", fmt.Sprintf("DROP RETENTION POLICY %s ON %s", quoteIdentifier(name), quoteIdentifier(database)))
+	return err
+}
+
+func createRetentionPolicyStatement(rp RetentionPolicy) string {
+	return fmt.Sprintf("CREATE RETENTION POLICY %s ON %s %s", quoteIdentifier(rp.Name), quoteIdentifier(rp.Database), retentionPolicyOptions(rp))
+}
+
+func alterRetentionPolicyStatement(rp RetentionPolicy) string {
+	return fmt.Sprintf("ALTER RETENTION POLICY %s ON %s %s", quoteIdentifier(rp.Name), quoteIdentifier(rp.Database), retentionPolicyOptions(rp))
+}
+
+// retentionPolicyOptions returns the options of the retention policy, InfluxQL can only make a retention policy
+// the default, another one has to be made the default to take it away
+func retentionPolicyOptions(rp RetentionPolicy) string {
+	options := []string{
+		fmt.Sprintf("DURATION %s", formatDuration(rp.Duration)),
+		fmt.Sprintf("REPLICATION %d", rp.Replication),
+	}
+	if rp.ShardGroupDuration != 0 {
+		options = append(options, fmt.Sprintf("SHARD DURATION %s", formatDuration(rp.ShardGroupDuration)))
+	}
+	if rp.Default {
+		options = append(options, "DEFAULT")
+	}
+	return strings.Join(options, " ")
+}
+
+// retentionPolicyFromSeries finds the retention policy in the result of SHOW RETENTION POLICIES, which has the
+// durations the way Go formats them and the numbers as JSON numbers
+func retentionPolicyFromSeries(series []influxdbSeries, database, name string) (*RetentionPolicy, error) {
+	for _, s := range series {
+		for _, row := range seriesRows(s) {
+			if n, _ := row["name"].(string); n != name {
+				continue
+			}
+
+			rp := &RetentionPolicy{Database: database, Name: name}
+			var err error
+			if rp.Duration, err = durationColumn(row, "duration"); err != nil {
+				return nil, err
+			}
+			if rp.ShardGroupDuration, err = durationColumn(row, "shardGroupDuration"); err != nil {
+				return nil, err
+			}
+			if replication, ok := row["replicaN"].(float64); ok {
+				rp.Replication = int(replication)
+			}
+			rp.Default, _ = row["default"].(bool)
+			return rp, nil
+		}
+	}
+	return nil, schemautil.NotFoundError("retention policy", database+"."+name)
+}
+
+// defaultRetentionPolicyFromSeries finds the name of the default retention policy in the result of SHOW RETENTION
+// POLICIES
+func defaultRetentionPolicyFromSeries(series []influxdbSeries) string {
+	for _, s := range series {
+		for _, row := range seriesRows(s) {
+			if isDefault, _ := row["default"].(bool); isDefault {
+				name, _ := row["name"].(string)
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+func durationColumn(row map[string]interface{}, column string) (time.Duration, error) {
+	v, _ := row[column].(string)
+	d, err := parseDuration(v)
+	if err != nil {
+		return 0, fmt.Errorf("cannot read %s of retention policy: %w", column, err)
+	}
+	return d, nil
+}
@@ -0,0 +1,58 @@
+package influxdb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetentionPolicyStatements(t *testing.T) {
+	assert.Equal(t,
+		`CREATE RETENTION POLICY "one_month" ON "metrics" DURATION 30d REPLICATION 1`,
+		createRetentionPolicyStatement(RetentionPolicy{Database: "metrics", Name: "one_month", Duration: 30 * 24 * time.Hour, Replication: 1}),
+	)
+	assert.Equal(t,
+		`ALTER RETENTION POLICY "for\"ever" ON "metrics" DURATION INF REPLICATION 2 SHARD DURATION 1w DEFAULT`,
+		alterRetentionPolicyStatement(RetentionPolicy{
+			Database:           "metrics",
+			Name:               `for"ever`,
+			ShardGroupDuration: 7 * 24 * time.Hour,
+			Replication:        2,
+			Default:            true,
+		}),
+	)
+}
+
+func TestRetentionPolicyFromSeries(t *testing.T) {
+	series := []influxdbSeries{{
+		Columns: []string{"name", "duration", "shardGroupDuration", "replicaN", "default"},
+		Values: [][]interface{}{
+			{"autogen", "0s", "168h0m0s", float64(1), false},
+			{"one_month", "720h0m0s", "24h0m0s", float64(1), true},
+		},
+	}}
+
+	rp, err := retentionPolicyFromSeries(series, "metrics", "one_month")
+	require.NoError(t, err)
+	assert.Equal(t, &RetentionPolicy{
+		Database:           "metrics",
+		Name:               "one_month",
+		Duration:           720 * time.Hour,
+		ShardGroupDuration: 24 * time.Hour,
+		Replication:        1,
+		Default:            true,
+	}, rp)
+
+	rp, err = retentionPolicyFromSeries(series, "metrics", "autogen")
+	require.NoError(t, err)
+	assert.Equal(t, time.Duration(0), rp.Duration)
+
+	_, err = retentionPolicyFromSeries(series, "metrics", "missing")
+	assert.True(t, aiven.IsNotFound(err))
+
+	assert.Equal(t, "one_month", defaultRetentionPolicyFromSeries(series))
+	assert.Equal(t, "", defaultRetentionPolicyFromSeries(nil))
+}
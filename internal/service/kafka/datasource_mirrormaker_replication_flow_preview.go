@@ -0,0 +1,160 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DatasourceMirrorMakerReplicationFlowPreview() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceMirrorMakerReplicationFlowPreviewRead,
+		Description: "The MirrorMaker 2 Replication Flow Preview data source evaluates replication flow topic patterns " +
+			"against the topics of the source Kafka service and returns the topics the flow would replicate.",
+		Schema: map[string]*schema.Schema{
+			"project":      schemautil.CommonSchemaProjectReference,
+			"service_name": schemautil.CommonSchemaServiceNameReference,
+			"source_cluster": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Source cluster alias, as configured in the `kafka_mirrormaker` service integration.",
+			},
+			"target_cluster": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "Target cluster alias. When set and `topics` is not, the topic patterns of " +
+					"the existing replication flow between both clusters are previewed.",
+			},
+			"topics": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				Description: "List of topics and/or regular expressions to replicate.",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateJavaRegex,
+				},
+			},
+			"topics_blacklist": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				Description: "List of topics and/or regular expressions to not replicate. Defaults to the " +
+					"MirrorMaker 2 `topics.exclude` default when empty.",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateJavaRegex,
+				},
+			},
+			"source_service_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of the Kafka service the source cluster alias refers to.",
+			},
+			"matched_topics": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Topics of the source cluster that the replication flow replicates.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"excluded_topics": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Topics of the source cluster that match `topics` but are excluded by `topics_blacklist`.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func datasourceMirrorMakerReplicationFlowPreviewRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+	sourceCluster := d.Get("source_cluster").(string)
+	targetCluster := d.Get("target_cluster").(string)
+
+	topics := schemautil.FlattenToString(d.Get("topics").([]interface{}))
+	blacklist := schemautil.FlattenToString(d.Get("topics_blacklist").([]interface{}))
+	if len(topics) == 0 && targetCluster != "" {
+		flow, err := client.KafkaMirrorMakerReplicationFlow.Get(projectName, serviceName, sourceCluster, targetCluster)
+		if err != nil {
+			return diag.Errorf("cannot get replication flow %s -> %s: %s", sourceCluster, targetCluster, err)
+		}
+		topics = flow.ReplicationFlow.Topics
+		blacklist = flow.ReplicationFlow.TopicsBlacklist
+	}
+	if len(blacklist) == 0 {
+		blacklist = mirrorMakerDefaultTopicsBlacklist
+	}
+
+	sourceService, err := mirrorMakerClusterAliasService(client, projectName, serviceName, sourceCluster)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	list, err := client.KafkaTopics.List(projectName, sourceService)
+	if err != nil {
+		return diag.Errorf("cannot list topics of Kafka service %s: %s", sourceService, err)
+	}
+
+	names := make([]string, len(list))
+	for i, t := range list {
+		names[i] = t.TopicName
+	}
+	sort.Strings(names)
+
+	matched, excluded, err := matchReplicationFlowTopics(names, topics, blacklist)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(schemautil.BuildResourceID(projectName, serviceName, sourceCluster))
+
+	if err := d.Set("topics", topics); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("topics_blacklist", blacklist); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("source_service_name", sourceService); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("matched_topics", matched); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("excluded_topics", excluded); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// mirrorMakerClusterAliasService finds the Kafka service a MirrorMaker 2 cluster alias points at
+func mirrorMakerClusterAliasService(client *aiven.Client, projectName, serviceName, alias string) (string, error) {
+	integrations, err := client.ServiceIntegrations.List(projectName, serviceName)
+	if err != nil {
+		return "", err
+	}
+
+	for _, i := range integrations {
+		if i.IntegrationType != "kafka_mirrormaker" || i.UserConfig["cluster_alias"] != alias {
+			continue
+		}
+
+		if i.SourceService == nil {
+			return "", fmt.Errorf("cluster alias %s does not refer to an Aiven Kafka service, topics cannot be listed", alias)
+		}
+
+		return *i.SourceService, nil
+	}
+
+	return "", fmt.Errorf("cluster alias %s not found in the integrations of service %s", alias, serviceName)
+}
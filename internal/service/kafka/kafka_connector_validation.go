@@ -0,0 +1,289 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type (
+	// kafkaConnectorConfigDefinition is a single configuration option of a Kafka Connect plugin
+	kafkaConnectorConfigDefinition struct {
+		Name         string      `json:"name"`
+		Type         string      `json:"type"`
+		Required     bool        `json:"required"`
+		DefaultValue interface{} `json:"default_value"`
+		DisplayName  string      `json:"display_name"`
+		Group        string      `json:"group"`
+	}
+
+	kafkaConnectorConfigDefinitionResponse struct {
+		ConfigurationSchema []kafkaConnectorConfigDefinition `json:"configuration_schema"`
+	}
+
+	kafkaConnectorValidationValue struct {
+		Name   string   `json:"name"`
+		Errors []string `json:"errors"`
+	}
+
+	kafkaConnectorValidationResponse struct {
+		ErrorCount int `json:"error_count"`
+		Configs    []struct {
+			Value kafkaConnectorValidationValue `json:"value"`
+		} `json:"configs"`
+	}
+)
+
+// kafkaConnectorCommonConfigKeys are the options every connector accepts on top of its plugin
+// specific ones, see org.apache.kafka.connect.runtime.ConnectorConfig
+var kafkaConnectorCommonConfigKeys = map[string]bool{
+	"name":                             true,
+	"connector.class":                  true,
+	"tasks.max":                        true,
+	"key.converter":                    true,
+	"value.converter":                  true,
+	"header.converter":                 true,
+	"config.action.reload":             true,
+	"transforms":                       true,
+	"predicates":                       true,
+	"topics":                           true,
+	"topics.regex":                     true,
+	"exactly.once.support":             true,
+	"transaction.boundary":             true,
+	"offsets.storage.topic":            true,
+	"transaction.boundary.interval.ms": true,
+}
+
+// kafkaConnectorCommonConfigPrefixes are the prefixes of options which are passed through
+// to converters, transformations and client overrides
+var kafkaConnectorCommonConfigPrefixes = []string{
+	"key.converter.",
+	"value.converter.",
+	"header.converter.",
+	"transforms.",
+	"predicates.",
+	"errors.",
+	"topic.creation.",
+	"consumer.override.",
+	"producer.override.",
+	"admin.override.",
+}
+
+// kafkaConnectorDefinitionCache caches plugin configuration definitions, they only change
+// when a service is upgraded, and are needed on every plan and refresh
+var kafkaConnectorDefinitionCache sync.Map
+
+// getKafkaConnectorConfigDefinitions returns the configuration definitions of a connector plugin class
+func getKafkaConnectorConfigDefinitions(
+	ctx context.Context,
+	client *aiven.Client,
+	project, serviceName, class string,
+) (map[string]kafkaConnectorConfigDefinition, error) {
+	key := schemautil.BuildResourceID(project, serviceName, class)
+	if v, ok := kafkaConnectorDefinitionCache.Load(key); ok {
+		return v.(map[string]kafkaConnectorConfigDefinition), nil
+	}
+
+	var rsp kafkaConnectorConfigDefinitionResponse
+	path := schemautil.BuildAPIPath("project", project, "service", serviceName, "connector-plugins", class, "configuration")
+	if err := schemautil.APIRequest(ctx, client, http.MethodGet, path, nil, &rsp); err != nil {
+		return nil, err
+	}
+
+	defs := make(map[string]kafkaConnectorConfigDefinition, len(rsp.ConfigurationSchema))
+	for _, def := range rsp.ConfigurationSchema {
+		defs[def.Name] = def
+	}
+
+	kafkaConnectorDefinitionCache.Store(key, defs)
+
+	return defs, nil
+}
+
+// validateKafkaConnectorConfigRemotely runs the config through the Kafka Connect validation endpoint
+// and returns the per key errors reported by the plugin
+func validateKafkaConnectorConfigRemotely(
+	ctx context.Context,
+	client *aiven.Client,
+	project, serviceName, class string,
+	config map[string]string,
+) ([]error, error) {
+	var rsp kafkaConnectorValidationResponse
+	path := schemautil.BuildAPIPath("project", project, "service", serviceName, "connector-plugins", class, "config", "validate")
+	if err := schemautil.APIRequest(ctx, client, http.MethodPut, path, config, &rsp); err != nil {
+		return nil, err
+	}
+
+	var errs []error
+	for _, c := range rsp.Configs {
+		for _, e := range c.Value.Errors {
+			errs = append(errs, fmt.Errorf("config.%s: %s", c.Value.Name, e))
+		}
+	}
+
+	return errs, nil
+}
+
+// isKafkaConnectorCommonConfigKey checks whether a key is accepted by every connector
+func isKafkaConnectorCommonConfigKey(k string) bool {
+	if kafkaConnectorCommonConfigKeys[k] {
+		return true
+	}
+
+	for _, p := range kafkaConnectorCommonConfigPrefixes {
+		if strings.HasPrefix(k, p) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// validateKafkaConnectorConfigValue checks that a value can be parsed as the given Kafka ConfigDef type
+func validateKafkaConnectorConfigValue(t, v string) error {
+	var err error
+	switch strings.ToUpper(t) {
+	case "BOOLEAN":
+		if !strings.EqualFold(v, "true") && !strings.EqualFold(v, "false") {
+			return fmt.Errorf("expected a boolean, got %q", v)
+		}
+	case "SHORT":
+		_, err = strconv.ParseInt(v, 10, 16)
+	case "INT":
+		_, err = strconv.ParseInt(v, 10, 32)
+	case "LONG":
+		_, err = strconv.ParseInt(v, 10, 64)
+	case "DOUBLE":
+		_, err = strconv.ParseFloat(v, 64)
+	}
+
+	if err != nil {
+		return fmt.Errorf("expected a value of type %s, got %q", strings.ToLower(t), v)
+	}
+
+	return nil
+}
+
+// validateKafkaConnectorConfig checks a connector config against the plugin's definitions;
+// unknown keys, values of a wrong type and missing required options are reported
+func validateKafkaConnectorConfig(config map[string]string, defs map[string]kafkaConnectorConfigDefinition) []error {
+	var errs []error
+
+	keys := make([]string, 0, len(config))
+	for k := range config {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		def, ok := defs[k]
+		if !ok {
+			if !isKafkaConnectorCommonConfigKey(k) {
+				errs = append(errs, fmt.Errorf("config.%s: unknown configuration option for this connector", k))
+			}
+			continue
+		}
+
+		if err := validateKafkaConnectorConfigValue(def.Type, config[k]); err != nil {
+			errs = append(errs, fmt.Errorf("config.%s: %w", k, err))
+		}
+	}
+
+	var required []string
+	for k, def := range defs {
+		if _, ok := config[k]; !ok && def.Required && def.DefaultValue == nil {
+			required = append(required, k)
+		}
+	}
+	sort.Strings(required)
+
+	for _, k := range required {
+		errs = append(errs, fmt.Errorf("config.%s: missing required configuration option", k))
+	}
+
+	return errs
+}
+
+// kafkaConnectorConfigWithoutDefaults drops the options the server has filled in with
+// the plugin defaults, unless they are already tracked in the state
+func kafkaConnectorConfigWithoutDefaults(
+	apiConfig aiven.KafkaConnectorConfig,
+	stateConfig map[string]interface{},
+	defs map[string]kafkaConnectorConfigDefinition,
+) map[string]string {
+	config := make(map[string]string, len(apiConfig))
+	for k, v := range apiConfig {
+		if _, ok := stateConfig[k]; !ok {
+			if def, ok := defs[k]; ok && def.DefaultValue != nil && schemautil.ToOptionalString(def.DefaultValue) == v {
+				continue
+			}
+		}
+		config[k] = v
+	}
+
+	return config
+}
+
+// customizeDiffKafkaConnectorConfig validates the connector config against the plugin
+// definition and the Kafka Connect validation endpoint at plan time
+func customizeDiffKafkaConnectorConfig() schema.CustomizeDiffFunc {
+	return func(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+		if !diff.NewValueKnown("config") || !diff.NewValueKnown("project") || !diff.NewValueKnown("service_name") {
+			return nil
+		}
+
+		config := make(map[string]string)
+		for k, v := range diff.Get("config").(map[string]interface{}) {
+			config[k] = v.(string)
+		}
+
+		class := config["connector.class"]
+		if class == "" {
+			return fmt.Errorf("config.connector.class: missing required configuration option")
+		}
+
+		client := m.(*aiven.Client)
+		project := diff.Get("project").(string)
+		serviceName := diff.Get("service_name").(string)
+
+		defs, err := getKafkaConnectorConfigDefinitions(ctx, client, project, serviceName, class)
+		if err != nil {
+			// the service may not exist yet, or run a version without the plugin
+			// definitions, leave it to the API to validate the config then
+			if aiven.IsNotFound(err) {
+				log.Printf("[DEBUG] skipping Kafka Connector config validation: %s", err)
+				return nil
+			}
+			return fmt.Errorf("unable to get Kafka Connector plugin definition for `%s`: %w", class, err)
+		}
+
+		errs := validateKafkaConnectorConfig(config, defs)
+		if len(errs) == 0 {
+			remoteErrs, err := validateKafkaConnectorConfigRemotely(ctx, client, project, serviceName, class, config)
+			if err != nil && !aiven.IsNotFound(err) {
+				return fmt.Errorf("unable to validate Kafka Connector config: %w", err)
+			}
+			errs = remoteErrs
+		}
+
+		if len(errs) == 0 {
+			return nil
+		}
+
+		msgs := make([]string, len(errs))
+		for i, e := range errs {
+			msgs[i] = e.Error()
+		}
+
+		return fmt.Errorf("invalid Kafka Connector configuration:\n%s", strings.Join(msgs, "\n"))
+	}
+}
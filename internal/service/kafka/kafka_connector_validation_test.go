@@ -0,0 +1,93 @@
+package kafka
+
+import (
+	"testing"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/stretchr/testify/assert"
+)
+
+var testKafkaConnectorConfigDefinitions = map[string]kafkaConnectorConfigDefinition{
+	"connection.url":    {Name: "connection.url", Type: "STRING", Required: true},
+	"batch.size":        {Name: "batch.size", Type: "INT", DefaultValue: "3000"},
+	"auto.create":       {Name: "auto.create", Type: "BOOLEAN", DefaultValue: "false"},
+	"retry.backoff.ms":  {Name: "retry.backoff.ms", Type: "LONG", DefaultValue: "3000"},
+	"connection.driver": {Name: "connection.driver", Type: "CLASS", Required: true, DefaultValue: "org.Driver"},
+}
+
+func TestValidateKafkaConnectorConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		config map[string]string
+		want   []string
+	}{
+		{
+			name: "valid",
+			config: map[string]string{
+				"name":                        "foo",
+				"connector.class":             "io.aiven.connect.jdbc.JdbcSinkConnector",
+				"connection.url":              "jdbc:postgresql://localhost/db",
+				"batch.size":                  "10",
+				"auto.create":                 "TRUE",
+				"transforms.unwrap.type":      "io.debezium.transforms.ExtractNewRecordState",
+				"consumer.override.client.id": "foo",
+			},
+		},
+		{
+			name: "unknown key",
+			config: map[string]string{
+				"connection.url": "jdbc:postgresql://localhost/db",
+				"conection.user": "avnadmin",
+			},
+			want: []string{"config.conection.user: unknown configuration option for this connector"},
+		},
+		{
+			name: "wrong types",
+			config: map[string]string{
+				"connection.url":   "jdbc:postgresql://localhost/db",
+				"batch.size":       "3000000000",
+				"auto.create":      "yes",
+				"retry.backoff.ms": "1s",
+			},
+			want: []string{
+				`config.auto.create: expected a boolean, got "yes"`,
+				`config.batch.size: expected a value of type int, got "3000000000"`,
+				`config.retry.backoff.ms: expected a value of type long, got "1s"`,
+			},
+		},
+		{
+			name:   "missing required",
+			config: map[string]string{"batch.size": "1"},
+			want:   []string{"config.connection.url: missing required configuration option"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, err := range validateKafkaConnectorConfig(tt.config, testKafkaConnectorConfigDefinitions) {
+				got = append(got, err.Error())
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestKafkaConnectorConfigWithoutDefaults(t *testing.T) {
+	apiConfig := aiven.KafkaConnectorConfig{
+		"connection.url":   "jdbc:postgresql://localhost/db",
+		"batch.size":       "3000",
+		"auto.create":      "false",
+		"retry.backoff.ms": "100",
+	}
+	stateConfig := map[string]interface{}{
+		"connection.url": "jdbc:postgresql://localhost/db",
+		"auto.create":    "false",
+	}
+
+	assert.Equal(t, map[string]string{
+		"connection.url":   "jdbc:postgresql://localhost/db",
+		"auto.create":      "false",
+		"retry.backoff.ms": "100",
+	}, kafkaConnectorConfigWithoutDefaults(apiConfig, stateConfig, testKafkaConnectorConfigDefinitions))
+}
@@ -0,0 +1,196 @@
+package kafka
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// errJavaRegexUnsupported is returned for valid Java regular expressions that use constructs
+// RE2 cannot evaluate (lookarounds, backreferences, possessive quantifiers, ...)
+var errJavaRegexUnsupported = errors.New("uses Java regular expression features that cannot be evaluated by the provider")
+
+// mirrorMakerDefaultTopicsBlacklist is the MirrorMaker 2 `topics.exclude` default
+var mirrorMakerDefaultTopicsBlacklist = []string{`.*[\-\.]internal`, `.*\.replica`, `__.*`}
+
+// javaRegexFlagGroup matches the flags of an embedded flag group, e.g. `(?i)` or `(?-s:`
+var javaRegexFlagGroup = regexp.MustCompile(`^[a-zA-Z-]*[:)]`)
+
+// javaRegexUnicodeEscape matches the code point of a `\uXXXX` escape
+var javaRegexUnicodeEscape = regexp.MustCompile(`^[0-9a-fA-F]{4}`)
+
+// javaRegexUnicodeClass matches Java only property classes, e.g. `\p{javaLowerCase}` or `\p{IsLatin}`
+var javaRegexUnicodeClass = regexp.MustCompile(`^\{(java|Is|In)`)
+
+// translateJavaRegex converts a java.util.regex.Pattern expression to an equivalent RE2 expression.
+// Syntax errors are returned as is, while constructs without an RE2 counterpart are reported
+// wrapping errJavaRegexUnsupported.
+func translateJavaRegex(expr string) (string, error) {
+	var b strings.Builder
+	inClass := 0
+
+	for i := 0; i < len(expr); i++ {
+		c := expr[i]
+		switch {
+		case c == '\\':
+			if i+1 >= len(expr) {
+				return "", fmt.Errorf("unexpected trailing backslash")
+			}
+			i++
+			n := expr[i]
+			switch {
+			case n >= '1' && n <= '9' && inClass == 0, n == 'k':
+				return "", fmt.Errorf("backreference `\\%c` %w", n, errJavaRegexUnsupported)
+			case strings.IndexByte("0GZRXhHvVe", n) >= 0:
+				return "", fmt.Errorf("escape `\\%c` %w", n, errJavaRegexUnsupported)
+			case n == 'c':
+				return "", fmt.Errorf("control character escape %w", errJavaRegexUnsupported)
+			case n == 'u':
+				// RE2 has no \uXXXX, the code point is written as \x{XXXX}
+				hex := javaRegexUnicodeEscape.FindString(expr[i+1:])
+				if hex == "" {
+					return "", fmt.Errorf("illegal unicode escape sequence `\\u%s`", expr[i+1:])
+				}
+				b.WriteString(`\x{` + hex + `}`)
+				i += len(hex)
+				continue
+			case (n == 'p' || n == 'P') && javaRegexUnicodeClass.MatchString(expr[i+1:]):
+				return "", fmt.Errorf("character property %w", errJavaRegexUnsupported)
+			case n == 'Q':
+				// \Q...\E quoting is supported by RE2 as well, copy it verbatim
+				end := strings.Index(expr[i:], `\E`)
+				if end < 0 {
+					b.WriteString(`\Q` + expr[i+1:])
+					i = len(expr)
+					continue
+				}
+				b.WriteString(`\Q` + expr[i+1:i+end] + `\E`)
+				i += end + 1
+				continue
+			}
+			b.WriteByte('\\')
+			b.WriteByte(n)
+		case c == '[' && inClass > 0:
+			return "", fmt.Errorf("nested character class %w", errJavaRegexUnsupported)
+		case c == '[':
+			inClass++
+			b.WriteByte(c)
+			// a leading ] or ^] is a literal in both dialects
+			if i+1 < len(expr) && expr[i+1] == '^' {
+				i++
+				b.WriteByte('^')
+			}
+			if i+1 < len(expr) && expr[i+1] == ']' {
+				i++
+				b.WriteString(`\]`)
+			}
+		case c == ']' && inClass > 0:
+			inClass--
+			b.WriteByte(c)
+		case c == '&' && inClass > 0 && strings.HasPrefix(expr[i:], "&&"):
+			return "", fmt.Errorf("character class intersection %w", errJavaRegexUnsupported)
+		case c == '(' && inClass == 0 && strings.HasPrefix(expr[i:], "(?"):
+			rest := expr[i+2:]
+			switch {
+			case strings.HasPrefix(rest, "="), strings.HasPrefix(rest, "!"),
+				strings.HasPrefix(rest, "<="), strings.HasPrefix(rest, "<!"):
+				return "", fmt.Errorf("lookaround %w", errJavaRegexUnsupported)
+			case strings.HasPrefix(rest, ">"):
+				return "", fmt.Errorf("atomic group %w", errJavaRegexUnsupported)
+			case strings.HasPrefix(rest, "<"):
+				b.WriteString("(?P<")
+				i += 2
+				continue
+			}
+			if m := javaRegexFlagGroup.FindString(rest); m != "" {
+				// U is UNICODE_CHARACTER_CLASS in Java but ungreedy in RE2
+				if f := strings.Trim(m, "-:)"); strings.Trim(f, "ims-") != "" {
+					return "", fmt.Errorf("flag group `(?%s` %w", m, errJavaRegexUnsupported)
+				}
+			}
+			b.WriteByte(c)
+		case c == '+' && inClass == 0 && i > 0 && strings.IndexByte("*+?}", expr[i-1]) >= 0 && !isEscaped(expr, i-1):
+			return "", fmt.Errorf("possessive quantifier %w", errJavaRegexUnsupported)
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	if _, err := regexp.Compile(b.String()); err != nil {
+		return "", err
+	}
+
+	return b.String(), nil
+}
+
+// isEscaped checks whether the byte at position i is preceded by an odd number of backslashes
+func isEscaped(expr string, i int) bool {
+	n := 0
+	for j := i - 1; j >= 0 && expr[j] == '\\'; j-- {
+		n++
+	}
+	return n%2 == 1
+}
+
+// validateJavaRegex is a schema.SchemaValidateFunc for MirrorMaker 2 topic patterns, a pattern
+// which is valid Java but cannot be evaluated by the provider only produces a warning
+func validateJavaRegex(v interface{}, k string) (ws []string, errs []error) {
+	if _, err := translateJavaRegex(v.(string)); err != nil {
+		if errors.Is(err, errJavaRegexUnsupported) {
+			return []string{fmt.Sprintf("%q: %s, it cannot be previewed", k, err)}, nil
+		}
+		errs = append(errs, fmt.Errorf("%q: invalid regular expression %q: %w", k, v, err))
+	}
+	return
+}
+
+// compileTopicPatterns builds a single full match expression out of a MirrorMaker 2 topic list,
+// the same way MirrorMaker joins `topics` and `topics.exclude` entries
+func compileTopicPatterns(patterns []string) (*regexp.Regexp, error) {
+	if len(patterns) == 0 {
+		return nil, nil
+	}
+
+	parts := make([]string, len(patterns))
+	for i, p := range patterns {
+		t, err := translateJavaRegex(strings.TrimSpace(p))
+		if err != nil {
+			return nil, fmt.Errorf("pattern %q %w", p, err)
+		}
+		parts[i] = "(?:" + t + ")"
+	}
+
+	return regexp.Compile("^(?:" + strings.Join(parts, "|") + ")$")
+}
+
+// matchReplicationFlowTopics splits topics into the ones a replication flow replicates and
+// the ones that match the allow list but are excluded by the deny list
+func matchReplicationFlowTopics(topics, allow, deny []string) (matched, excluded []string, err error) {
+	allowRe, err := compileTopicPatterns(allow)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	denyRe, err := compileTopicPatterns(deny)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	matched = make([]string, 0)
+	excluded = make([]string, 0)
+	for _, t := range topics {
+		if allowRe == nil || !allowRe.MatchString(t) {
+			continue
+		}
+
+		if denyRe != nil && denyRe.MatchString(t) {
+			excluded = append(excluded, t)
+			continue
+		}
+
+		matched = append(matched, t)
+	}
+
+	return matched, excluded, nil
+}
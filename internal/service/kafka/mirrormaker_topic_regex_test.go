@@ -0,0 +1,83 @@
+package kafka
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTranslateJavaRegex(t *testing.T) {
+	tests := []struct {
+		in          string
+		out         string
+		unsupported bool
+		invalid     bool
+	}{
+		{in: `.*`, out: `.*`},
+		{in: `.*[\-\.]internal`, out: `.*[\-\.]internal`},
+		{in: `topic-[]a]`, out: `topic-[\]a]`},
+		{in: `(?<env>prod|dev)\..*`, out: `(?P<env>prod|dev)\..*`},
+		{in: `\Qa.b\E.*`, out: `\Qa.b\E.*`},
+		{in: `(?i)orders`, out: `(?i)orders`},
+		{in: `orders(?!-dlq).*`, unsupported: true},
+		{in: `(?<=x)y`, unsupported: true},
+		{in: `(a)\1`, unsupported: true},
+		{in: `a++`, unsupported: true},
+		{in: `a\++`, out: `a\++`},
+		{in: `(?>a)`, unsupported: true},
+		{in: `[a-z&&[^b]]`, unsupported: true},
+		{in: `\p{javaLowerCase}+`, unsupported: true},
+		{in: `(?x)a b`, unsupported: true},
+		{in: `(?U)\w+`, unsupported: true},
+		{in: `(?iU:a)`, unsupported: true},
+		{in: `(?-s:a.b)`, out: `(?-s:a.b)`},
+		{in: `caf\u00e9-.*`, out: `caf\x{00e9}-.*`},
+		{in: `[\u0041-\u005A]+`, out: `[\x{0041}-\x{005A}]+`},
+		{in: `\u00e`, invalid: true},
+		{in: `topic-[a-`, invalid: true},
+		{in: `*.foo`, invalid: true},
+		{in: `foo\`, invalid: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := translateJavaRegex(tt.in)
+			switch {
+			case tt.unsupported:
+				assert.True(t, errors.Is(err, errJavaRegexUnsupported), "expected unsupported, got %v", err)
+			case tt.invalid:
+				assert.Error(t, err)
+				assert.False(t, errors.Is(err, errJavaRegexUnsupported))
+			default:
+				assert.NoError(t, err)
+				assert.Equal(t, tt.out, got)
+			}
+		})
+	}
+}
+
+func TestMatchReplicationFlowTopics(t *testing.T) {
+	topics := []string{
+		"__consumer_offsets",
+		"orders",
+		"orders-internal",
+		"orders.replica",
+		"orders_v2",
+		"payments",
+		"preorders",
+	}
+
+	matched, excluded, err := matchReplicationFlowTopics(topics, []string{"orders.*", "payments"}, mirrorMakerDefaultTopicsBlacklist)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"orders", "orders_v2", "payments"}, matched)
+	assert.Equal(t, []string{"orders-internal", "orders.replica"}, excluded)
+
+	matched, excluded, err = matchReplicationFlowTopics(topics, nil, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, matched)
+	assert.Empty(t, excluded)
+
+	_, _, err = matchReplicationFlowTopics(topics, []string{"(?=x)"}, nil)
+	assert.Error(t, err)
+}
@@ -31,7 +31,7 @@ var aivenKafkaConnectorSchema = map[string]*schema.Schema{
 		Elem: &schema.Schema{
 			Type: schema.TypeString,
 		},
-		Description: "The Kafka Connector configuration parameters.",
+		Description: "The Kafka Connector configuration parameters, validated at plan time against the connector plugin's configuration definition.",
 	},
 	"plugin_author": {
 		Type:        schema.TypeString,
@@ -101,7 +101,10 @@ func ResourceKafkaConnector() *schema.Resource {
 		Schema: aivenKafkaConnectorSchema,
 		CustomizeDiff: customdiff.IfValueChange("config",
 			kafkaConnectorConfigNameShouldNotBeEmpty(),
-			customizeDiffKafkaConnectorConfigName(),
+			customdiff.Sequence(
+				customizeDiffKafkaConnectorConfigName(),
+				customizeDiffKafkaConnectorConfig(),
+			),
 		),
 	}
 }
@@ -193,7 +196,13 @@ func resourceKafkaConnectorRead(ctx context.Context, d *schema.ResourceData, m i
 			if err := d.Set("connector_name", connectorName); err != nil {
 				return diag.Errorf("error setting Kafka Connector `connector_name` for resource %s: %s", d.Id(), err)
 			}
-			if err := d.Set("config", r.Config); err != nil {
+			defs, err := getKafkaConnectorConfigDefinitions(ctx, m.(*aiven.Client), project, serviceName, r.Plugin.Class)
+			if err != nil {
+				log.Printf("[DEBUG] cannot get Kafka Connector plugin definition, keeping server defaults: %s", err)
+			}
+
+			config := kafkaConnectorConfigWithoutDefaults(r.Config, d.Get("config").(map[string]interface{}), defs)
+			if err := d.Set("config", config); err != nil {
 				return diag.Errorf("error setting Kafka Connector `config` for resource %s: %s", d.Id(), err)
 			}
 			if err := d.Set("plugin_author", r.Plugin.Author); err != nil {
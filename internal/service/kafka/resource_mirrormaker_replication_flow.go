@@ -44,19 +44,21 @@ var aivenMirrorMakerReplicationFlowSchema = map[string]*schema.Schema{
 	"topics": {
 		Type:        schema.TypeList,
 		Optional:    true,
-		Description: "List of topics and/or regular expressions to replicate",
+		Description: "List of topics and/or regular expressions to replicate. Patterns use Java regular expression syntax and must match the whole topic name.",
 		Elem: &schema.Schema{
-			Type:     schema.TypeString,
-			MaxItems: 256,
+			Type:         schema.TypeString,
+			MaxItems:     256,
+			ValidateFunc: validateJavaRegex,
 		},
 	},
 	"topics_blacklist": {
 		Type:        schema.TypeList,
 		Optional:    true,
-		Description: "List of topics and/or regular expressions to not replicate.",
+		Description: "List of topics and/or regular expressions to not replicate. Patterns use Java regular expression syntax and must match the whole topic name.",
 		Elem: &schema.Schema{
-			Type:     schema.TypeString,
-			MaxItems: 256,
+			Type:         schema.TypeString,
+			MaxItems:     256,
+			ValidateFunc: validateJavaRegex,
 		},
 	},
 	"replication_policy_class": {
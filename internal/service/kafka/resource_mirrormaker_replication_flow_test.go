@@ -3,6 +3,7 @@ package kafka_test
 import (
 	"fmt"
 	"os"
+	"regexp"
 	"testing"
 
 	"github.com/aiven/aiven-go-client"
@@ -32,8 +33,16 @@ func TestAccAivenMirrorMakerReplicationFlow_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "source_cluster", "source"),
 					resource.TestCheckResourceAttr(resourceName, "target_cluster", "target"),
 					resource.TestCheckResourceAttr(resourceName, "enable", "true"),
+					resource.TestCheckResourceAttr("data.aiven_mirrormaker_replication_flow_preview.preview", "source_service_name", fmt.Sprintf("test-acc-sr-source-%s", rName)),
+					resource.TestCheckResourceAttr("data.aiven_mirrormaker_replication_flow_preview.preview", "matched_topics.#", "1"),
+					resource.TestCheckResourceAttr("data.aiven_mirrormaker_replication_flow_preview.preview", "matched_topics.0", fmt.Sprintf("test-acc-topic-a-%s", rName)),
 				),
 			},
+			{
+				Config:      testAccMirrorMakerReplicationFlowInvalidTopicsResource(rName),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("invalid regular expression"),
+			},
 		},
 	})
 }
@@ -200,9 +209,37 @@ data "aiven_mirrormaker_replication_flow" "flow" {
   target_cluster = aiven_mirrormaker_replication_flow.foo.target_cluster
 
   depends_on = [aiven_mirrormaker_replication_flow.foo]
+}
+
+data "aiven_mirrormaker_replication_flow_preview" "preview" {
+  project        = data.aiven_project.foo.project
+  service_name   = aiven_kafka_mirrormaker.mm.service_name
+  source_cluster = aiven_mirrormaker_replication_flow.foo.source_cluster
+  target_cluster = aiven_mirrormaker_replication_flow.foo.target_cluster
+
+  depends_on = [aiven_mirrormaker_replication_flow.foo, aiven_kafka_topic.source]
 }`, os.Getenv("AIVEN_PROJECT_NAME"), name, name, name, name, name)
 }
 
+func testAccMirrorMakerReplicationFlowInvalidTopicsResource(name string) string {
+	return fmt.Sprintf(`
+data "aiven_project" "foo" {
+  project = "%s"
+}
+
+resource "aiven_mirrormaker_replication_flow" "foo" {
+  project        = data.aiven_project.foo.project
+  service_name   = "test-acc-sr-mm-%s"
+  source_cluster = "source"
+  target_cluster = "target"
+  enable         = true
+
+  topics = [
+    "test-acc-topic-[a-",
+  ]
+}`, os.Getenv("AIVEN_PROJECT_NAME"), name)
+}
+
 func testAccCheckAivenMirrorMakerReplicationFlowAttributes(n string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		r := s.RootModule().Resources[n]
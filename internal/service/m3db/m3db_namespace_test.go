@@ -0,0 +1,222 @@
+package m3db
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testM3DBNamespaces() []interface{} {
+	return []interface{}{
+		map[string]interface{}{"name": "default", "type": "unaggregated"},
+		map[string]interface{}{
+			"name":       "hourly",
+			"type":       "aggregated",
+			"resolution": "1h",
+			"options": map[string]interface{}{
+				"retention_options": map[string]interface{}{"retention_period_duration": "720h"},
+				"snapshot_enabled":  false,
+			},
+		},
+	}
+}
+
+func Test_m3dbFindNamespace(t *testing.T) {
+	namespaces := testM3DBNamespaces()
+
+	i, ok := m3dbFindNamespace(namespaces, "hourly")
+	assert.True(t, ok)
+	assert.Equal(t, 1, i)
+
+	_, ok = m3dbFindNamespace(namespaces, "daily")
+	assert.False(t, ok)
+}
+
+func Test_m3dbNamespacesFromUserConfig(t *testing.T) {
+	userConfig := map[string]interface{}{"namespaces": testM3DBNamespaces()}
+
+	// the modifiers work on a copy, the user config of the service is left as it is
+	namespaces := m3dbNamespacesFromUserConfig(userConfig)
+	namespaces = append(namespaces[:0], namespaces[1:]...)
+	assert.Len(t, namespaces, 1)
+	assert.Len(t, userConfig["namespaces"], 2)
+	assert.Equal(t, "default", userConfig["namespaces"].([]interface{})[0].(map[string]interface{})["name"])
+
+	assert.Empty(t, m3dbNamespacesFromUserConfig(map[string]interface{}{}))
+}
+
+func Test_m3dbNamespaceFromSchema(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, aivenM3DBNamespaceSchema, map[string]interface{}{
+		"project":      "test-project",
+		"service_name": "test-m3db",
+		"name":         "daily",
+		"type":         "aggregated",
+		"resolution":   "24h",
+		"retention_options": []interface{}{map[string]interface{}{
+			"retention_period_duration": "8760h",
+			"blocksize_duration":        "48h",
+		}},
+	})
+
+	assert.Equal(t, map[string]interface{}{
+		"name":       "daily",
+		"type":       "aggregated",
+		"resolution": "24h",
+		"options": map[string]interface{}{
+			"retention_options": map[string]interface{}{
+				"retention_period_duration": "8760h",
+				"blocksize_duration":        "48h",
+			},
+		},
+	}, m3dbNamespaceFromSchema(d))
+}
+
+func Test_m3dbMergeNamespaceFlags(t *testing.T) {
+	current := testM3DBNamespaces()[1].(map[string]interface{})
+
+	// flags that are not configured keep their current value
+	namespace := m3dbMergeNamespaceFlags(map[string]interface{}{"name": "hourly", "type": "aggregated", "resolution": "1h"}, current)
+	assert.Equal(t, map[string]interface{}{"snapshot_enabled": false}, namespace["options"])
+
+	// configured flags win
+	namespace = m3dbMergeNamespaceFlags(map[string]interface{}{
+		"name":    "hourly",
+		"options": map[string]interface{}{"snapshot_enabled": true, "writes_to_commitlog": false},
+	}, current)
+	assert.Equal(t, map[string]interface{}{"snapshot_enabled": true, "writes_to_commitlog": false}, namespace["options"])
+
+	namespace = m3dbMergeNamespaceFlags(map[string]interface{}{"name": "default"}, testM3DBNamespaces()[0].(map[string]interface{}))
+	assert.NotContains(t, namespace, "options")
+}
+
+func Test_m3dbNamespaceRetentionOptionsToSchema(t *testing.T) {
+	options := testM3DBNamespaces()[1].(map[string]interface{})["options"].(map[string]interface{})
+	assert.Equal(t, []map[string]interface{}{{"retention_period_duration": "720h"}}, m3dbNamespaceRetentionOptionsToSchema(options))
+	assert.Nil(t, m3dbNamespaceRetentionOptionsToSchema(nil))
+}
+
+func Test_m3dbNamespacesUpdateRequest(t *testing.T) {
+	vpcID := "vpc-id"
+	s := &aiven.Service{
+		CloudName:             "google-europe-west1",
+		Plan:                  "business-8",
+		ProjectVPCID:          &vpcID,
+		Powered:               true,
+		TerminationProtection: true,
+		DiskSpaceMB:           122880,
+		MaintenanceWindow:     aiven.MaintenanceWindow{DayOfWeek: "monday", TimeOfDay: "10:00:00"},
+		UserConfig:            map[string]interface{}{"namespaces": testM3DBNamespaces(), "m3_version": "1.5"},
+	}
+
+	req := m3dbNamespacesUpdateRequest(s, testM3DBNamespaces()[:1])
+	assert.Equal(t, "google-europe-west1", req.Cloud)
+	assert.Equal(t, "business-8", req.Plan)
+	require.NotNil(t, req.ProjectVPCID)
+	assert.Equal(t, "vpc-id", *req.ProjectVPCID)
+	assert.True(t, req.Powered)
+	assert.True(t, req.TerminationProtection)
+	assert.Equal(t, 122880, req.DiskSpaceMB)
+	assert.Equal(t, "monday", req.MaintenanceWindow.DayOfWeek)
+	assert.Equal(t, map[string]interface{}{"namespaces": testM3DBNamespaces()[:1]}, req.UserConfig)
+}
+
+func Test_m3dbNamespaceApplied(t *testing.T) {
+	namespaces := testM3DBNamespaces()
+
+	assert.True(t, m3dbNamespaceApplied(namespaces, "hourly", map[string]interface{}{
+		"name": "hourly", "type": "aggregated", "resolution": "1h",
+		"options": map[string]interface{}{"snapshot_enabled": false},
+	}))
+	assert.False(t, m3dbNamespaceApplied(namespaces, "hourly", map[string]interface{}{
+		"name": "hourly", "type": "aggregated", "resolution": "2h",
+	}))
+	assert.False(t, m3dbNamespaceApplied(namespaces, "daily", map[string]interface{}{"name": "daily", "type": "aggregated"}))
+	assert.True(t, m3dbNamespaceApplied(namespaces, "daily", nil))
+	assert.False(t, m3dbNamespaceApplied(namespaces, "hourly", nil))
+}
+
+// m3dbServiceStandIn serves the service GET and PUT of the Aiven API, the namespaces of the first PUTs are
+// replaced by another writer before the next GET
+type m3dbServiceStandIn struct {
+	mu         sync.Mutex
+	namespaces []interface{}
+	puts       int
+	overwrites int
+}
+
+func newM3DBServiceStandIn(t *testing.T, overwrites int) (*m3dbServiceStandIn, *aiven.Client) {
+	a := &m3dbServiceStandIn{namespaces: testM3DBNamespaces(), overwrites: overwrites}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		a.mu.Lock()
+		defer a.mu.Unlock()
+
+		if r.Method == http.MethodPut {
+			var req struct {
+				UserConfig map[string]interface{} `json:"user_config"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			a.puts++
+			a.namespaces = m3dbNamespacesFromUserConfig(req.UserConfig)
+			if a.overwrites > 0 {
+				a.overwrites--
+				a.namespaces = append(testM3DBNamespaces(), map[string]interface{}{"name": "other", "type": "unaggregated"})
+			}
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"service": map[string]interface{}{
+			"service_name": "m3db1",
+			"user_config":  map[string]interface{}{"namespaces": a.namespaces},
+		}})
+	}))
+	t.Cleanup(srv.Close)
+
+	// the client keeps the URL of the API in a package variable, it is set back once the test is done
+	webURL := os.Getenv("AIVEN_WEB_URL")
+	if webURL == "" {
+		webURL = "https://api.aiven.io"
+	}
+	t.Setenv("AIVEN_WEB_URL", srv.URL)
+	t.Setenv("AIVEN_TOKEN", "token")
+	client, err := aiven.SetupEnvClient("")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = os.Setenv("AIVEN_WEB_URL", webURL)
+		_, _ = aiven.SetupEnvClient("")
+	})
+
+	return a, client
+}
+
+func Test_resourceM3DBNamespaceModifyUserConfig(t *testing.T) {
+	daily := map[string]interface{}{"name": "daily", "type": "aggregated", "resolution": "24h"}
+	add := func(namespaces []interface{}) ([]interface{}, error) {
+		return append(namespaces, daily), nil
+	}
+
+	a, client := newM3DBServiceStandIn(t, 1)
+	require.NoError(t, resourceM3DBNamespaceModifyUserConfig(client, "p", "m3db1", "daily", add))
+
+	// the change is merged again into the list of the other writer
+	assert.Equal(t, 2, a.puts)
+	_, ok := m3dbFindNamespace(a.namespaces, "other")
+	assert.True(t, ok)
+	_, ok = m3dbFindNamespace(a.namespaces, "daily")
+	assert.True(t, ok)
+
+	a, client = newM3DBServiceStandIn(t, m3dbNamespaceModifyAttempts)
+	err := resourceM3DBNamespaceModifyUserConfig(client, "p", "m3db1", "daily", add)
+	assert.EqualError(t, err, "the namespaces of service m3db1 were replaced by another change 3 times")
+	assert.Equal(t, m3dbNamespaceModifyAttempts, a.puts)
+}
@@ -0,0 +1,437 @@
+package m3db
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"reflect"
+	"regexp"
+	"sync"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+)
+
+var (
+	// this mutex is needed to serialize calls to modify the namespaces of the user config
+	// since the API only allows to replace the whole list, so that it is first GETed, modified and PUT again.
+	// It only covers the provider process, the writers outside of it are detected by reading the list back
+	resourceM3DBNamespaceModifierMutex sync.Mutex
+)
+
+// m3dbNamespaceModifyAttempts is how many times a change of a namespace is merged again into the list
+// when another writer replaced the list at the same time
+const m3dbNamespaceModifyAttempts = 3
+
+// m3dbNamespaceRetentionOptions are the retention options of a namespace, all of them are M3 durations
+var m3dbNamespaceRetentionOptions = map[string]string{
+	"retention_period_duration":  "Controls the duration of time that M3DB will retain data for the namespace",
+	"blocksize_duration":         "Controls how long to keep a block in memory before flushing to a fileset on disk",
+	"block_data_expiry_duration": "Controls how long we wait before expiring stale data",
+	"buffer_future_duration":     "Controls how far into the future writes to the namespace will be accepted",
+	"buffer_past_duration":       "Controls how far into the past writes to the namespace will be accepted",
+}
+
+func m3dbNamespaceRetentionOptionsSchema() map[string]*schema.Schema {
+	s := make(map[string]*schema.Schema, len(m3dbNamespaceRetentionOptions))
+	for k, description := range m3dbNamespaceRetentionOptions {
+		s[k] = &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: schemautil.ValidateM3DurationString,
+			Description:  description + ", e.g. `48h`.",
+		}
+	}
+	return s
+}
+
+var aivenM3DBNamespaceSchema = map[string]*schema.Schema{
+	"project":      schemautil.CommonSchemaProjectReference,
+	"service_name": schemautil.CommonSchemaServiceNameReference,
+	"name": {
+		Type:     schema.TypeString,
+		Required: true,
+		ForceNew: true,
+		ValidateFunc: validation.All(
+			validation.StringLenBetween(1, 256),
+			validation.StringMatch(regexp.MustCompile("^[a-zA-Z_0-9]+$"), "must only contain letters, digits and underscores"),
+		),
+		Description: schemautil.Complex("The name of the namespace.").ForceNew().Referenced().Build(),
+	},
+	"type": {
+		Type:         schema.TypeString,
+		Required:     true,
+		ForceNew:     true,
+		ValidateFunc: validation.StringInSlice([]string{"aggregated", "unaggregated"}, false),
+		Description:  schemautil.Complex("The type of aggregation.").PossibleValues("aggregated", "unaggregated").ForceNew().Build(),
+	},
+	"resolution": {
+		Type:         schema.TypeString,
+		Optional:     true,
+		ForceNew:     true,
+		ValidateFunc: schemautil.ValidateM3DurationString,
+		Description:  schemautil.Complex("The resolution of an aggregated namespace, e.g. `30s`. Required for aggregated namespaces.").ForceNew().Build(),
+	},
+	"retention_options": {
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "Retention options of the namespace",
+		Elem: &schema.Resource{
+			Schema: m3dbNamespaceRetentionOptionsSchema(),
+		},
+	},
+	"snapshot_enabled": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Computed:    true,
+		Description: "Controls whether M3DB will create snapshot files for this namespace",
+	},
+	"writes_to_commitlog": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Computed:    true,
+		Description: "Controls whether M3DB will include writes to this namespace in the commitlog",
+	},
+}
+
+func ResourceM3DBNamespace() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+The M3DB Namespace resource allows the creation and management of a single namespace of an Aiven M3DB service.
+Only the entry of the namespace in the ` + "`namespaces`" + ` of the service user config is changed, the other namespaces
+are left as they are. Don't set ` + "`namespaces`" + ` in ` + "`m3db_user_config`" + ` of the ` + "`aiven_m3db`" + ` resource
+when the namespaces are managed with this resource, and add ` + "`m3db_user_config[0].namespaces`" + ` to its
+` + "`ignore_changes`" + `.
+
+The namespaces are changed by replacing the whole list, the changes of the namespaces of a service are serialized
+within a Terraform run. The list is read back after it is written: when a concurrent apply or a change made outside
+of Terraform replaced it in the meantime, the change of the namespace is merged into the new list again, up to three
+times before failing. The entries of the other namespaces written by a concurrent change can still be lost in a race.
+`,
+		CreateContext: resourceM3DBNamespaceCreate,
+		ReadContext:   resourceM3DBNamespaceRead,
+		UpdateContext: resourceM3DBNamespaceUpdate,
+		DeleteContext: resourceM3DBNamespaceDelete,
+		CustomizeDiff: resourceM3DBNamespaceCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: aivenM3DBNamespaceSchema,
+	}
+}
+
+func resourceM3DBNamespaceCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	resolution := d.Get("resolution").(string)
+	switch d.Get("type").(string) {
+	case "aggregated":
+		if resolution == "" && d.NewValueKnown("resolution") {
+			return fmt.Errorf("resolution must be set for aggregated namespaces")
+		}
+	case "unaggregated":
+		if resolution != "" {
+			return fmt.Errorf("resolution can only be set for aggregated namespaces")
+		}
+	}
+	return nil
+}
+
+func resourceM3DBNamespaceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	project := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+	namespace := m3dbNamespaceFromSchema(d)
+
+	err := resourceM3DBNamespaceModifyUserConfig(client, project, serviceName, d.Get("name").(string), func(namespaces []interface{}) ([]interface{}, error) {
+		if _, ok := m3dbFindNamespace(namespaces, d.Get("name").(string)); ok {
+			return nil, fmt.Errorf("namespace %s already exists, it must be imported to be managed", d.Get("name"))
+		}
+		return append(namespaces, namespace), nil
+	})
+	if err != nil {
+		return diag.Errorf("cannot create M3DB namespace: %s", err)
+	}
+
+	d.SetId(schemautil.BuildResourceID(project, serviceName, d.Get("name").(string)))
+
+	return resourceM3DBNamespaceRead(ctx, d, m)
+}
+
+func resourceM3DBNamespaceRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	project, serviceName, name, err := schemautil.SplitResourceID3(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	s, err := client.Services.Get(project, serviceName)
+	if err != nil {
+		return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
+	}
+
+	namespaces := m3dbNamespacesFromUserConfig(s.UserConfig)
+	i, ok := m3dbFindNamespace(namespaces, name)
+	if !ok {
+		log.Printf("[WARN] M3DB namespace %s is gone from service %s, removing it from the state", name, serviceName)
+		d.SetId("")
+		return nil
+	}
+	namespace := namespaces[i].(map[string]interface{})
+	options, _ := namespace["options"].(map[string]interface{})
+
+	if err := d.Set("project", project); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("service_name", serviceName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("name", name); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("type", namespace["type"]); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("resolution", namespace["resolution"]); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("retention_options", m3dbNamespaceRetentionOptionsToSchema(options)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("snapshot_enabled", options["snapshot_enabled"]); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("writes_to_commitlog", options["writes_to_commitlog"]); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceM3DBNamespaceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	project, serviceName, name, err := schemautil.SplitResourceID3(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	namespace := m3dbNamespaceFromSchema(d)
+
+	err = resourceM3DBNamespaceModifyUserConfig(client, project, serviceName, name, func(namespaces []interface{}) ([]interface{}, error) {
+		i, ok := m3dbFindNamespace(namespaces, name)
+		if !ok {
+			return nil, schemautil.NotFoundError("namespace", name)
+		}
+		namespaces[i] = m3dbMergeNamespaceFlags(namespace, namespaces[i].(map[string]interface{}))
+		return namespaces, nil
+	})
+	if err != nil {
+		return diag.Errorf("cannot update M3DB namespace %s: %s", name, err)
+	}
+
+	return resourceM3DBNamespaceRead(ctx, d, m)
+}
+
+func resourceM3DBNamespaceDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	project, serviceName, name, err := schemautil.SplitResourceID3(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = resourceM3DBNamespaceModifyUserConfig(client, project, serviceName, name, func(namespaces []interface{}) ([]interface{}, error) {
+		i, ok := m3dbFindNamespace(namespaces, name)
+		if !ok {
+			return nil, schemautil.NotFoundError("namespace", name)
+		}
+		return append(namespaces[:i], namespaces[i+1:]...), nil
+	})
+	if err != nil && !aiven.IsNotFound(err) {
+		return diag.Errorf("cannot delete M3DB namespace %s: %s", name, err)
+	}
+
+	return nil
+}
+
+// resourceM3DBNamespaceModifyUserConfig GETs the namespaces of the service, applies the modifier and
+// sends the resulting list back, the rest of the user config and the service settings are unchanged.
+// The GET is done under the lock, so the modifier always merges into the latest list the provider wrote.
+// The list is read back to confirm the entry of the namespace, the change is merged again when another
+// writer replaced the list in the meantime
+func resourceM3DBNamespaceModifyUserConfig(client *aiven.Client, project, serviceName, name string, modify func([]interface{}) ([]interface{}, error)) error {
+	resourceM3DBNamespaceModifierMutex.Lock()
+	defer resourceM3DBNamespaceModifierMutex.Unlock()
+
+	for attempt := 1; ; attempt++ {
+		s, err := client.Services.Get(project, serviceName)
+		if err != nil {
+			return err
+		}
+
+		namespaces, err := modify(m3dbNamespacesFromUserConfig(s.UserConfig))
+		if err != nil {
+			return err
+		}
+
+		// the entry written for the namespace, nil when it is removed
+		var written map[string]interface{}
+		if i, ok := m3dbFindNamespace(namespaces, name); ok {
+			written = namespaces[i].(map[string]interface{})
+		}
+
+		if _, err := client.Services.Update(project, serviceName, m3dbNamespacesUpdateRequest(s, namespaces)); err != nil {
+			return err
+		}
+
+		s, err = client.Services.Get(project, serviceName)
+		if err != nil {
+			return err
+		}
+		if m3dbNamespaceApplied(m3dbNamespacesFromUserConfig(s.UserConfig), name, written) {
+			return nil
+		}
+		if attempt == m3dbNamespaceModifyAttempts {
+			return fmt.Errorf("the namespaces of service %s were replaced by another change %d times", serviceName, attempt)
+		}
+		log.Printf("[WARN] the namespaces of M3DB service %s were replaced by another change, merging namespace %s again", serviceName, name)
+	}
+}
+
+// m3dbNamespaceApplied tells whether the namespaces have the written entry of the namespace, the service adds
+// its defaults to it, or don't have the namespace when it was removed
+func m3dbNamespaceApplied(namespaces []interface{}, name string, written map[string]interface{}) bool {
+	i, ok := m3dbFindNamespace(namespaces, name)
+	if written == nil {
+		return !ok
+	}
+	return ok && m3dbContains(namespaces[i], written)
+}
+
+// m3dbContains tells whether the value has all the keys of the expected one with the same values
+func m3dbContains(value, expected interface{}) bool {
+	e, ok := expected.(map[string]interface{})
+	if !ok {
+		return reflect.DeepEqual(value, expected)
+	}
+	v, ok := value.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	for k, ev := range e {
+		if !m3dbContains(v[k], ev) {
+			return false
+		}
+	}
+	return true
+}
+
+// m3dbNamespacesUpdateRequest only changes the namespaces, the fields of the update request that
+// are always sent keep the current values of the service
+func m3dbNamespacesUpdateRequest(s *aiven.Service, namespaces []interface{}) aiven.UpdateServiceRequest {
+	maintenanceWindow := s.MaintenanceWindow
+	return aiven.UpdateServiceRequest{
+		Cloud:                 s.CloudName,
+		Plan:                  s.Plan,
+		MaintenanceWindow:     &maintenanceWindow,
+		ProjectVPCID:          s.ProjectVPCID,
+		Powered:               s.Powered,
+		TerminationProtection: s.TerminationProtection,
+		DiskSpaceMB:           s.DiskSpaceMB,
+		UserConfig:            map[string]interface{}{"namespaces": namespaces},
+	}
+}
+
+// m3dbNamespacesFromUserConfig returns a copy of the namespaces of the user config
+func m3dbNamespacesFromUserConfig(userConfig map[string]interface{}) []interface{} {
+	namespaces, _ := userConfig["namespaces"].([]interface{})
+	return append([]interface{}{}, namespaces...)
+}
+
+func m3dbFindNamespace(namespaces []interface{}, name string) (int, bool) {
+	for i, n := range namespaces {
+		if n, ok := n.(map[string]interface{}); ok && n["name"] == name {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+// m3dbNamespaceFromSchema builds the user config entry of the namespace
+func m3dbNamespaceFromSchema(d *schema.ResourceData) map[string]interface{} {
+	namespace := map[string]interface{}{
+		"name": d.Get("name").(string),
+		"type": d.Get("type").(string),
+	}
+	if v := d.Get("resolution").(string); v != "" {
+		namespace["resolution"] = v
+	}
+
+	options := make(map[string]interface{})
+	if v := d.Get("retention_options").([]interface{}); len(v) > 0 && v[0] != nil {
+		retentionOptions := make(map[string]interface{})
+		for k, o := range v[0].(map[string]interface{}) {
+			if o.(string) != "" {
+				retentionOptions[k] = o
+			}
+		}
+		if len(retentionOptions) > 0 {
+			options["retention_options"] = retentionOptions
+		}
+	}
+
+	// the flags are only sent when they are set, otherwise the defaults of M3 apply
+	config := d.GetRawConfig()
+	for _, k := range m3dbNamespaceFlags {
+		if !config.IsNull() && !config.GetAttr(k).IsNull() {
+			options[k] = d.Get(k).(bool)
+		}
+	}
+	if len(options) > 0 {
+		namespace["options"] = options
+	}
+
+	return namespace
+}
+
+// m3dbNamespaceFlags are the options that are only sent when they are set in the configuration
+var m3dbNamespaceFlags = []string{"snapshot_enabled", "writes_to_commitlog"}
+
+// m3dbMergeNamespaceFlags keeps the current value of the flags that are not set in the configuration
+func m3dbMergeNamespaceFlags(namespace, current map[string]interface{}) map[string]interface{} {
+	currentOptions, _ := current["options"].(map[string]interface{})
+	options, _ := namespace["options"].(map[string]interface{})
+	for _, k := range m3dbNamespaceFlags {
+		v, ok := currentOptions[k]
+		if _, set := options[k]; !ok || set {
+			continue
+		}
+		if options == nil {
+			options = make(map[string]interface{})
+			namespace["options"] = options
+		}
+		options[k] = v
+	}
+	return namespace
+}
+
+func m3dbNamespaceRetentionOptionsToSchema(options map[string]interface{}) []map[string]interface{} {
+	retentionOptions, _ := options["retention_options"].(map[string]interface{})
+	if len(retentionOptions) == 0 {
+		return nil
+	}
+
+	r := make(map[string]interface{})
+	for k := range m3dbNamespaceRetentionOptions {
+		if v, ok := retentionOptions[k].(string); ok {
+			r[k] = v
+		}
+	}
+	return []map[string]interface{}{r}
+}
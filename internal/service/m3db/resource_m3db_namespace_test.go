@@ -0,0 +1,123 @@
+package m3db_test
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	acc "github.com/aiven/terraform-provider-aiven/internal/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAivenM3DBNamespace_basic(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acc.TestAccPreCheck(t) },
+		ProviderFactories: acc.TestAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccM3DBNamespaceResource(rName, "720h"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aiven_m3db_namespace.hourly", "type", "aggregated"),
+					resource.TestCheckResourceAttr("aiven_m3db_namespace.hourly", "resolution", "1h"),
+					resource.TestCheckResourceAttr("aiven_m3db_namespace.hourly", "retention_options.0.retention_period_duration", "720h"),
+					resource.TestCheckResourceAttr("aiven_m3db_namespace.daily", "retention_options.0.blocksize_duration", "48h"),
+					resource.TestCheckResourceAttr("aiven_m3db_namespace.daily", "snapshot_enabled", "true"),
+				),
+			},
+			{
+				Config: testAccM3DBNamespaceResource(rName, "1440h"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aiven_m3db_namespace.hourly", "retention_options.0.retention_period_duration", "1440h"),
+					resource.TestCheckResourceAttr("aiven_m3db_namespace.daily", "retention_options.0.retention_period_duration", "8760h"),
+				),
+			},
+			{
+				ResourceName:      "aiven_m3db_namespace.daily",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccAivenM3DBNamespace_invalid(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acc.TestAccPreCheck(t) },
+		ProviderFactories: acc.TestAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "aiven_m3db_namespace" "foo" {
+  project      = "test-project"
+  service_name = "test-m3db"
+  name         = "hourly"
+  type         = "aggregated"
+  resolution   = "1h30m"
+}`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("invalid M3 duration"),
+			},
+			{
+				Config: `
+resource "aiven_m3db_namespace" "foo" {
+  project      = "test-project"
+  service_name = "test-m3db"
+  name         = "hourly"
+  type         = "aggregated"
+}`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("resolution must be set for aggregated namespaces"),
+			},
+		},
+	})
+}
+
+func testAccM3DBNamespaceResource(name, hourlyRetention string) string {
+	return fmt.Sprintf(`
+data "aiven_project" "foo" {
+  project = "%s"
+}
+
+resource "aiven_m3db" "bar" {
+  project                 = data.aiven_project.foo.project
+  cloud_name              = "google-europe-west1"
+  plan                    = "business-8"
+  service_name            = "test-acc-sr-m3ns-%s"
+  maintenance_window_dow  = "monday"
+  maintenance_window_time = "10:00:00"
+
+  lifecycle {
+    ignore_changes = [m3db_user_config[0].namespaces]
+  }
+}
+
+resource "aiven_m3db_namespace" "hourly" {
+  project      = aiven_m3db.bar.project
+  service_name = aiven_m3db.bar.service_name
+  name         = "hourly"
+  type         = "aggregated"
+  resolution   = "1h"
+
+  retention_options {
+    retention_period_duration = "%s"
+  }
+}
+
+resource "aiven_m3db_namespace" "daily" {
+  project          = aiven_m3db.bar.project
+  service_name     = aiven_m3db.bar.service_name
+  name             = "daily"
+  type             = "aggregated"
+  resolution       = "24h"
+  snapshot_enabled = true
+
+  retention_options {
+    retention_period_duration = "8760h"
+    blocksize_duration        = "48h"
+  }
+}`, os.Getenv("AIVEN_PROJECT_NAME"), name, hourlyRetention)
+}
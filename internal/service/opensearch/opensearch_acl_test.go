@@ -0,0 +1,89 @@
+package opensearch
+
+import (
+	"testing"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_diffOpensearchACLs(t *testing.T) {
+	current := []aiven.ElasticSearchACL{
+		{Username: "alice", Rules: []aiven.ElasticsearchACLRule{{Index: "logs-*", Permission: "read"}, {Index: "tmp", Permission: "admin"}}},
+		{Username: "bob", Rules: []aiven.ElasticsearchACLRule{{Index: "*", Permission: "readwrite"}}},
+	}
+	wanted := []aiven.ElasticSearchACL{
+		{Username: "alice", Rules: []aiven.ElasticsearchACLRule{{Index: "logs-*", Permission: "readwrite"}}},
+		{Username: "bob", Rules: []aiven.ElasticsearchACLRule{{Index: "*", Permission: "readwrite"}}},
+		{Username: "carol", Rules: []aiven.ElasticsearchACLRule{{Index: "metrics-*", Permission: "read"}}},
+	}
+
+	changes := diffOpensearchACLs(current, wanted)
+	assert.Equal(t, []opensearchACLRuleChange{
+		{username: "alice", index: "logs-*", oldPermission: "read", newPermission: "readwrite"},
+		{username: "alice", index: "tmp", oldPermission: "admin"},
+		{username: "carol", index: "metrics-*", newPermission: "read"},
+	}, changes)
+	assert.Equal(t, "change alice logs-*: read -> readwrite", changes[0].String())
+	assert.Equal(t, "remove alice tmp: admin", changes[1].String())
+	assert.Equal(t, "add carol metrics-*: read", changes[2].String())
+
+	assert.Empty(t, diffOpensearchACLs(wanted, wanted))
+	assert.Len(t, diffOpensearchACLs(current, nil), 3)
+}
+
+func Test_validateOpensearchACLs(t *testing.T) {
+	assert.NoError(t, validateOpensearchACLs([]aiven.ElasticSearchACL{
+		{Username: "alice", Rules: []aiven.ElasticsearchACLRule{{Index: "logs-*", Permission: "read"}, {Index: "tmp", Permission: "admin"}}},
+		{Username: "bob", Rules: []aiven.ElasticsearchACLRule{{Index: "logs-*", Permission: "read"}}},
+	}))
+
+	assert.EqualError(t, validateOpensearchACLs([]aiven.ElasticSearchACL{
+		{Username: "alice", Rules: []aiven.ElasticsearchACLRule{{Index: "logs-*", Permission: "read"}}},
+		{Username: "alice", Rules: []aiven.ElasticsearchACLRule{{Index: "tmp", Permission: "admin"}}},
+	}), "username alice has more than one acl block")
+
+	assert.EqualError(t, validateOpensearchACLs([]aiven.ElasticSearchACL{
+		{Username: "alice", Rules: []aiven.ElasticsearchACLRule{{Index: "logs-*", Permission: "read"}, {Index: "logs-*", Permission: "write"}}},
+	}), "username alice has more than one rule for index logs-*")
+}
+
+func Test_opensearchACLIndexPatternRegexp(t *testing.T) {
+	for _, index := range []string{"logs-*", "*", "_all", "logs-2022.10.?", ".kibana"} {
+		assert.True(t, opensearchACLIndexPatternRegexp.MatchString(index), index)
+	}
+	for _, index := range []string{"", "Logs", "-logs", "+logs", "logs,metrics", "logs metrics", "logs/1", "a#b"} {
+		assert.False(t, opensearchACLIndexPatternRegexp.MatchString(index), index)
+	}
+}
+
+func Test_opensearchACLsFromSchema(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, aivenOpensearchACLSchema, map[string]interface{}{
+		"project":      "foo",
+		"service_name": "os",
+		"acl": []interface{}{
+			map[string]interface{}{
+				"username": "bob",
+				"rule":     []interface{}{map[string]interface{}{"index": "*", "permission": "read"}},
+			},
+			map[string]interface{}{
+				"username": "alice",
+				"rule": []interface{}{
+					map[string]interface{}{"index": "tmp", "permission": "admin"},
+					map[string]interface{}{"index": "logs-*", "permission": "read"},
+				},
+			},
+		},
+	})
+
+	acls := opensearchACLsFromSchema(d.Get("acl"))
+	assert.Equal(t, []aiven.ElasticSearchACL{
+		{Username: "alice", Rules: []aiven.ElasticsearchACLRule{{Index: "logs-*", Permission: "read"}, {Index: "tmp", Permission: "admin"}}},
+		{Username: "bob", Rules: []aiven.ElasticsearchACLRule{{Index: "*", Permission: "read"}}},
+	}, acls)
+
+	require.NoError(t, d.Set("acl", opensearchACLsToSchema(append(acls, aiven.ElasticSearchACL{Username: "carol"}))))
+	assert.Equal(t, acls, opensearchACLsFromSchema(d.Get("acl")))
+}
@@ -0,0 +1,53 @@
+package opensearch
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+)
+
+// opensearchClient calls the REST API of an Opensearch service
+type opensearchClient struct {
+	*schemautil.ServiceHTTPClient
+}
+
+// newOpensearchClient connects to the service with the admin credentials of the service, as read into
+// service_username and service_password of aiven_opensearch
+func newOpensearchClient(client *aiven.Client, project, serviceName string) (*opensearchClient, error) {
+	c, err := schemautil.NewServiceHTTPClient(client, project, serviceName)
+	if err != nil {
+		return nil, err
+	}
+	return opensearchClientFrom(c), nil
+}
+
+func opensearchClientFrom(c *schemautil.ServiceHTTPClient) *opensearchClient {
+	c.ErrorMessage = opensearchErrorMessage
+	return &opensearchClient{c}
+}
+
+// opensearchErrorMessage returns the message of an error response, the Security plugin returns a message
+// and Opensearch an error object with a reason
+func opensearchErrorMessage(b []byte) string {
+	var e struct {
+		Message string          `json:"message"`
+		Error   json.RawMessage `json:"error"`
+	}
+	if err := json.Unmarshal(b, &e); err != nil {
+		return string(b)
+	}
+	if e.Message != "" {
+		return e.Message
+	}
+
+	var reason struct {
+		Type   string `json:"type"`
+		Reason string `json:"reason"`
+	}
+	if err := json.Unmarshal(e.Error, &reason); err == nil && reason.Reason != "" {
+		return fmt.Sprintf("%s: %s", reason.Type, reason.Reason)
+	}
+	return string(b)
+}
@@ -0,0 +1,233 @@
+package opensearch
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// opensearchReturnedSettings are the index template settings Opensearch returns for the settings the tests
+// put, keyed by the JSON the client sends: nested under "index" and with string values
+var opensearchReturnedSettings = map[string]string{
+	`{"index.refresh_interval":"5s","number_of_shards":1}`: `{"index": {"number_of_shards": "1", "refresh_interval": "5s"}}`,
+}
+
+// newOpensearchStandIn serves the index template, ISM policy and snapshot repository APIs the way
+// Opensearch does, including the defaults it adds to the documents
+func newOpensearchStandIn(t *testing.T) (*httptest.Server, *opensearchClient) {
+	documents := make(map[string]map[string]interface{})
+	seqNo := 0
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, password, _ := r.BasicAuth(); user != "avnadmin" || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		var in map[string]interface{}
+		if r.Method == http.MethodPut {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&in))
+		}
+
+		path := r.URL.Path
+		name := path[strings.LastIndex(path, "/")+1:]
+		doc, found := documents[path]
+		if r.Method != http.MethodPut && !found {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error": {"type": "resource_not_found_exception", "reason": "` + name + ` missing"}, "status": 404}`))
+			return
+		}
+
+		switch {
+		case r.Method == http.MethodDelete:
+			delete(documents, path)
+			_, _ = w.Write([]byte(`{"acknowledged": true}`))
+
+		case strings.HasPrefix(path, "/_index_template/") && r.Method == http.MethodPut:
+			if template, ok := in["template"].(map[string]interface{}); ok && template["settings"] != nil {
+				sent, err := json.Marshal(template["settings"])
+				require.NoError(t, err)
+				returned, ok := opensearchReturnedSettings[string(sent)]
+				require.True(t, ok, "no settings document for %s", sent)
+				var settings map[string]interface{}
+				require.NoError(t, json.Unmarshal([]byte(returned), &settings))
+				template["settings"] = settings
+			}
+			in["composed_of"] = []interface{}{}
+			documents[path] = in
+			_, _ = w.Write([]byte(`{"acknowledged": true}`))
+		case strings.HasPrefix(path, "/_index_template/"):
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"index_templates": []interface{}{map[string]interface{}{"name": name, "index_template": doc}},
+			})
+
+		case strings.HasPrefix(path, "/_plugins/_ism/policies/") && r.Method == http.MethodPut:
+			if found && r.URL.Query().Get("if_seq_no") != strconv.Itoa(doc["_seq_no"].(int)) {
+				w.WriteHeader(http.StatusConflict)
+				_, _ = w.Write([]byte(`{"error": {"type": "version_conflict_engine_exception", "reason": "version conflict"}, "status": 409}`))
+				return
+			}
+			policy := in["policy"].(map[string]interface{})
+			policy["policy_id"] = name
+			policy["last_updated_time"] = 1666000000000
+			policy["schema_version"] = 15
+			for _, s := range policy["states"].([]interface{}) {
+				for _, a := range s.(map[string]interface{})["actions"].([]interface{}) {
+					a.(map[string]interface{})["retry"] = map[string]interface{}{"count": 3, "backoff": "exponential", "delay": "1m"}
+				}
+			}
+			seqNo++
+			documents[path] = map[string]interface{}{"_seq_no": seqNo, "policy": policy}
+			_, _ = w.Write([]byte(`{}`))
+		case strings.HasPrefix(path, "/_plugins/_ism/policies/"):
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"_id": name, "_seq_no": doc["_seq_no"], "_primary_term": 1, "policy": doc["policy"],
+			})
+
+		case strings.HasPrefix(path, "/_snapshot/") && r.Method == http.MethodPut:
+			assert.Equal(t, "false", r.URL.Query().Get("verify"))
+			documents[path] = in
+			_, _ = w.Write([]byte(`{"acknowledged": true}`))
+		case strings.HasPrefix(path, "/_snapshot/"):
+			settings := make(map[string]interface{})
+			for k, v := range doc["settings"].(map[string]interface{}) {
+				settings[k] = v
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{name: map[string]interface{}{"type": doc["type"], "settings": settings}})
+		}
+	}))
+
+	return srv, opensearchClientFrom(&schemautil.ServiceHTTPClient{URL: srv.URL, Username: "avnadmin", Password: "secret", Client: srv.Client()})
+}
+
+func Test_opensearchIndexTemplate(t *testing.T) {
+	srv, c := newOpensearchStandIn(t)
+	defer srv.Close()
+	ctx := context.Background()
+
+	configured := `{
+		"template": {"settings": {"number_of_shards": 1, "index.refresh_interval": "5s"}},
+		"priority": 10,
+		"index_patterns": ["logs-*"]
+	}`
+	require.NoError(t, c.putIndexTemplate(ctx, "logs", configured))
+
+	template, err := c.getIndexTemplate(ctx, "logs")
+	require.NoError(t, err)
+
+	// the settings are returned nested and as strings, and composed_of is added
+	body, err := opensearchStateJSON(configured, opensearchNormalizeIndexTemplate(template), opensearchNormalizeIndexTemplate)
+	require.NoError(t, err)
+	assert.Equal(t, configured, body)
+
+	// a change outside of Terraform replaces the configuration in the state
+	require.NoError(t, c.putIndexTemplate(ctx, "logs", `{"index_patterns": ["logs-*"], "priority": 20}`))
+	template, err = c.getIndexTemplate(ctx, "logs")
+	require.NoError(t, err)
+	body, err = opensearchStateJSON(configured, opensearchNormalizeIndexTemplate(template), opensearchNormalizeIndexTemplate)
+	require.NoError(t, err)
+	assert.Equal(t, `{"composed_of":[],"index_patterns":["logs-*"],"priority":20}`, body)
+
+	// on import there is no configuration yet
+	body, err = opensearchStateJSON("", opensearchNormalizeIndexTemplate(template), opensearchNormalizeIndexTemplate)
+	require.NoError(t, err)
+	assert.Equal(t, `{"composed_of":[],"index_patterns":["logs-*"],"priority":20}`, body)
+
+	require.NoError(t, c.deleteIndexTemplate(ctx, "logs"))
+	_, err = c.getIndexTemplate(ctx, "logs")
+	assert.True(t, aiven.IsNotFound(err))
+	assert.Contains(t, err.Error(), "resource_not_found_exception: logs missing")
+}
+
+func Test_opensearchISMPolicy(t *testing.T) {
+	srv, c := newOpensearchStandIn(t)
+	defer srv.Close()
+	ctx := context.Background()
+
+	configured := `{
+		"description": "delete old logs",
+		"default_state": "hot",
+		"states": [
+			{"name": "hot", "actions": [{"rollover": {"min_index_age": "1d"}}], "transitions": [{"state_name": "delete", "conditions": {"min_index_age": "30d"}}]},
+			{"name": "delete", "actions": [{"delete": {}}], "transitions": []}
+		],
+		"ism_template": {"index_patterns": ["logs-*"]}
+	}`
+	require.NoError(t, c.putISMPolicy(ctx, "logs", configured, nil))
+
+	p, err := c.getISMPolicy(ctx, "logs")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), p.SeqNo)
+
+	// the retry defaults and the managed fields don't cause a diff
+	policy, err := opensearchStateJSON(configured, opensearchNormalizeISMPolicy(p.Policy), opensearchNormalizeISMPolicy)
+	require.NoError(t, err)
+	assert.Equal(t, configured, policy)
+
+	// updates must be based on the latest policy
+	assert.Error(t, c.putISMPolicy(ctx, "logs", configured, &opensearchISMPolicy{SeqNo: 0, PrimaryTerm: 1}))
+	require.NoError(t, c.putISMPolicy(ctx, "logs", strings.Replace(configured, "30d", "7d", 1), p))
+
+	p, err = c.getISMPolicy(ctx, "logs")
+	require.NoError(t, err)
+	policy, err = opensearchStateJSON(configured, opensearchNormalizeISMPolicy(p.Policy), opensearchNormalizeISMPolicy)
+	require.NoError(t, err)
+	assert.Contains(t, policy, `"min_index_age":"7d"`)
+	assert.NotContains(t, policy, "last_updated_time")
+
+	require.NoError(t, c.deleteISMPolicy(ctx, "logs"))
+	_, err = c.getISMPolicy(ctx, "logs")
+	assert.True(t, aiven.IsNotFound(err))
+}
+
+func Test_opensearchSnapshotRepository(t *testing.T) {
+	srv, c := newOpensearchStandIn(t)
+	defer srv.Close()
+	ctx := context.Background()
+
+	repository := &opensearchSnapshotRepository{Type: "s3", Settings: map[string]string{"bucket": "backups", "compress": "true"}}
+	require.NoError(t, c.putSnapshotRepository(ctx, "backups", repository, false))
+
+	r, err := c.getSnapshotRepository(ctx, "backups")
+	require.NoError(t, err)
+	assert.Equal(t, repository, r)
+
+	require.NoError(t, c.deleteSnapshotRepository(ctx, "backups"))
+	_, err = c.getSnapshotRepository(ctx, "backups")
+	assert.True(t, aiven.IsNotFound(err))
+}
+
+func Test_opensearchJSONDiffSuppressFunc(t *testing.T) {
+	suppress := opensearchJSONDiffSuppressFunc(opensearchNormalizeIndexTemplate)
+	assert.True(t, suppress("body", `{"a": 1, "b": [1, 2]}`, `{"b": [1, 2],  "a": 1}`, nil))
+	assert.False(t, suppress("body", `{"a": 1, "b": [1, 2]}`, `{"b": [2, 1], "a": 1}`, nil))
+	assert.True(t, suppress("body",
+		`{"template": {"settings": {"index": {"number_of_shards": "1"}}}}`,
+		`{"template": {"settings": {"number_of_shards": 1}}}`, nil))
+	assert.False(t, suppress("body", `{"a": 1}`, `not json`, nil))
+}
+
+func Test_opensearchFlattenSettings(t *testing.T) {
+	assert.Equal(t, map[string]interface{}{
+		"index": map[string]interface{}{
+			"number_of_shards": "1",
+			"refresh_interval": "5s",
+			"sort":             map[string]interface{}{"field": []interface{}{"date"}},
+			"blocks":           map[string]interface{}{"read_only": "true"},
+		},
+	}, opensearchFlattenSettings(map[string]interface{}{
+		"number_of_shards":       float64(1),
+		"index.refresh_interval": "5s",
+		"index":                  map[string]interface{}{"sort.field": []interface{}{"date"}},
+		"blocks":                 map[string]interface{}{"read_only": true},
+	}))
+}
@@ -0,0 +1,158 @@
+package opensearch
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// The index templates, ISM policies and snapshot repositories are configured as JSON documents. Opensearch adds
+// defaults and server managed fields to what it returns, so the documents read back are compared to the configuration
+// rather than copied to the state: the configuration is kept as long as every value it sets is still there.
+
+// opensearchJSONNormalizer rewrites a decoded document into the form Opensearch returns it in
+type opensearchJSONNormalizer func(interface{}) interface{}
+
+// opensearchDecodeJSON decodes a document and normalizes it with the normalizer, which can be nil
+func opensearchDecodeJSON(s string, normalize opensearchJSONNormalizer) (interface{}, error) {
+	var v interface{}
+	if err := json.Unmarshal([]byte(s), &v); err != nil {
+		return nil, err
+	}
+	if normalize != nil {
+		v = normalize(v)
+	}
+	return v, nil
+}
+
+// opensearchEncodeJSON encodes a document with sorted keys
+func opensearchEncodeJSON(v interface{}) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// opensearchJSONDiffSuppressFunc suppresses the diffs of documents which only differ in formatting, key order
+// or in what the normalizer rewrites
+func opensearchJSONDiffSuppressFunc(normalize opensearchJSONNormalizer) schema.SchemaDiffSuppressFunc {
+	return func(_, old, new string, _ *schema.ResourceData) bool {
+		o, err := opensearchDecodeJSON(old, normalize)
+		if err != nil {
+			return false
+		}
+		n, err := opensearchDecodeJSON(new, normalize)
+		if err != nil {
+			return false
+		}
+		return reflect.DeepEqual(o, n)
+	}
+}
+
+// opensearchJSONContains tells whether every value set in want is set to the same value in have, arrays
+// must have the same length
+func opensearchJSONContains(have, want interface{}) bool {
+	switch w := want.(type) {
+	case map[string]interface{}:
+		h, ok := have.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for k, v := range w {
+			hv, ok := h[k]
+			if !ok || !opensearchJSONContains(hv, v) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		h, ok := have.([]interface{})
+		if !ok || len(h) != len(w) {
+			return false
+		}
+		for i := range w {
+			if !opensearchJSONContains(h[i], w[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return reflect.DeepEqual(have, want)
+	}
+}
+
+// opensearchStateJSON returns the document to keep in the state: the configured one when the remote
+// document still contains it, otherwise the remote document so that the drift shows up in the plan
+func opensearchStateJSON(configured string, remote interface{}, normalize opensearchJSONNormalizer) (string, error) {
+	if configured != "" {
+		if c, err := opensearchDecodeJSON(configured, normalize); err == nil && opensearchJSONContains(remote, c) {
+			return configured, nil
+		}
+	}
+	return opensearchEncodeJSON(remote)
+}
+
+// opensearchFlattenSettings rewrites index settings the way Opensearch returns them: nested under "index"
+// and with string values, e.g. {"number_of_shards": 1} becomes {"index": {"number_of_shards": "1"}}
+func opensearchFlattenSettings(settings interface{}) interface{} {
+	m, ok := settings.(map[string]interface{})
+	if !ok {
+		return settings
+	}
+
+	flat := make(map[string]interface{})
+	var flatten func(prefix string, v interface{})
+	flatten = func(prefix string, v interface{}) {
+		switch t := v.(type) {
+		case map[string]interface{}:
+			for k, v := range t {
+				flatten(prefix+"."+k, v)
+			}
+		default:
+			flat[strings.TrimPrefix(prefix, ".")] = opensearchSettingValue(v)
+		}
+	}
+	flatten("", m)
+
+	r := make(map[string]interface{})
+	for k, v := range flat {
+		if !strings.HasPrefix(k, "index.") {
+			k = "index." + k
+		}
+		// nest the dotted keys again
+		parts := strings.Split(k, ".")
+		node := r
+		for _, p := range parts[:len(parts)-1] {
+			child, ok := node[p].(map[string]interface{})
+			if !ok {
+				child = make(map[string]interface{})
+				node[p] = child
+			}
+			node = child
+		}
+		node[parts[len(parts)-1]] = v
+	}
+	return r
+}
+
+func opensearchSettingValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case []interface{}:
+		r := make([]interface{}, len(t))
+		for i := range t {
+			r[i] = opensearchSettingValue(t[i])
+		}
+		return r
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64)
+	case bool, string:
+		return fmt.Sprint(t)
+	default:
+		return v
+	}
+}
@@ -0,0 +1,165 @@
+package opensearch
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+)
+
+// Aiven enables the management of the OpenSearch Security plugin and sets the password of its admin user,
+// the roles and role mappings are then managed with the Security plugin REST API of the service itself
+
+const (
+	// opensearchSecurityAdminUsername is the Security plugin admin user created by Aiven
+	opensearchSecurityAdminUsername = "os-sec-admin"
+
+	// opensearchSecurityAdminPasswordEnvVar is read when admin_password is not set, e.g. on import
+	opensearchSecurityAdminPasswordEnvVar = "AIVEN_OPENSEARCH_SECURITY_ADMIN_PASSWORD"
+
+	opensearchSecurityAPIPath = "/_plugins/_security/api"
+)
+
+// opensearchSecurityStatus is the Security plugin management status of a service in the Aiven API
+type opensearchSecurityStatus struct {
+	SecurityPluginAdminDefined bool `json:"security_plugin_admin_defined"`
+	SecurityPluginAvailable    bool `json:"security_plugin_available"`
+	SecurityPluginEnabled      bool `json:"security_plugin_enabled"`
+}
+
+type opensearchSecurityAdminRequest struct {
+	AdminPassword string `json:"admin_password"`
+	NewPassword   string `json:"new_password,omitempty"`
+}
+
+func opensearchSecurityPath(project, serviceName string, parts ...string) string {
+	return schemautil.BuildAPIPath(append([]string{"project", project, "service", serviceName, "opensearch", "security"}, parts...)...)
+}
+
+func getOpensearchSecurityStatus(ctx context.Context, client *aiven.Client, project, serviceName string) (*opensearchSecurityStatus, error) {
+	var r opensearchSecurityStatus
+	if err := schemautil.APIRequest(ctx, client, http.MethodGet, opensearchSecurityPath(project, serviceName), nil, &r); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// enableOpensearchSecurity hands the management of the Security plugin over to its admin user, it can't be undone
+func enableOpensearchSecurity(ctx context.Context, client *aiven.Client, project, serviceName, password string) error {
+	path := opensearchSecurityPath(project, serviceName, "admin")
+	return schemautil.APIRequest(ctx, client, http.MethodPost, path, opensearchSecurityAdminRequest{AdminPassword: password}, nil)
+}
+
+func updateOpensearchSecurityAdminPassword(ctx context.Context, client *aiven.Client, project, serviceName, password, newPassword string) error {
+	path := opensearchSecurityPath(project, serviceName, "admin")
+	req := opensearchSecurityAdminRequest{AdminPassword: password, NewPassword: newPassword}
+	return schemautil.APIRequest(ctx, client, http.MethodPut, path, req, nil)
+}
+
+// newOpensearchSecurityClient connects to the service as the Security plugin admin user, with the admin_password
+// of the resource or the password of the environment when it's not set
+func newOpensearchSecurityClient(d *schema.ResourceData, client *aiven.Client, project, serviceName string) (*opensearchClient, error) {
+	password := d.Get("admin_password").(string)
+	if password == "" {
+		password = os.Getenv(opensearchSecurityAdminPasswordEnvVar)
+	}
+	if password == "" {
+		return nil, fmt.Errorf("admin_password or %s must be set", opensearchSecurityAdminPasswordEnvVar)
+	}
+
+	c, err := newOpensearchClient(client, project, serviceName)
+	if err != nil {
+		return nil, err
+	}
+	c.Username = opensearchSecurityAdminUsername
+	c.Password = password
+	return c, nil
+}
+
+// opensearchSecurityIndexPermission is an index permission of a role, with document and field level security
+type opensearchSecurityIndexPermission struct {
+	IndexPatterns  []string `json:"index_patterns"`
+	DLS            string   `json:"dls,omitempty"`
+	FLS            []string `json:"fls"`
+	MaskedFields   []string `json:"masked_fields"`
+	AllowedActions []string `json:"allowed_actions"`
+}
+
+type opensearchSecurityTenantPermission struct {
+	TenantPatterns []string `json:"tenant_patterns"`
+	AllowedActions []string `json:"allowed_actions"`
+}
+
+type opensearchSecurityRole struct {
+	Reserved           bool                                 `json:"reserved,omitempty"`
+	Hidden             bool                                 `json:"hidden,omitempty"`
+	Description        string                               `json:"description,omitempty"`
+	ClusterPermissions []string                             `json:"cluster_permissions"`
+	IndexPermissions   []opensearchSecurityIndexPermission  `json:"index_permissions"`
+	TenantPermissions  []opensearchSecurityTenantPermission `json:"tenant_permissions"`
+}
+
+type opensearchSecurityRoleMapping struct {
+	Reserved     bool     `json:"reserved,omitempty"`
+	Hidden       bool     `json:"hidden,omitempty"`
+	Description  string   `json:"description,omitempty"`
+	BackendRoles []string `json:"backend_roles"`
+	Hosts        []string `json:"hosts"`
+	Users        []string `json:"users"`
+}
+
+func (c *opensearchClient) getRole(ctx context.Context, name string) (*opensearchSecurityRole, error) {
+	var r map[string]opensearchSecurityRole
+	if err := c.JSONRequest(ctx, http.MethodGet, opensearchSecurityAPIPath+"/roles/"+url.PathEscape(name), nil, &r); err != nil {
+		return nil, err
+	}
+	role, ok := r[name]
+	if !ok {
+		return nil, schemautil.NotFoundError("role", name)
+	}
+	return &role, nil
+}
+
+func (c *opensearchClient) putRole(ctx context.Context, name string, role *opensearchSecurityRole) error {
+	return c.JSONRequest(ctx, http.MethodPut, opensearchSecurityAPIPath+"/roles/"+url.PathEscape(name), role, nil)
+}
+
+func (c *opensearchClient) deleteRole(ctx context.Context, name string) error {
+	return c.JSONRequest(ctx, http.MethodDelete, opensearchSecurityAPIPath+"/roles/"+url.PathEscape(name), nil, nil)
+}
+
+func (c *opensearchClient) getRoleMapping(ctx context.Context, role string) (*opensearchSecurityRoleMapping, error) {
+	var r map[string]opensearchSecurityRoleMapping
+	if err := c.JSONRequest(ctx, http.MethodGet, opensearchSecurityAPIPath+"/rolesmapping/"+url.PathEscape(role), nil, &r); err != nil {
+		return nil, err
+	}
+	mapping, ok := r[role]
+	if !ok {
+		return nil, schemautil.NotFoundError("role mapping", role)
+	}
+	return &mapping, nil
+}
+
+func (c *opensearchClient) putRoleMapping(ctx context.Context, role string, mapping *opensearchSecurityRoleMapping) error {
+	return c.JSONRequest(ctx, http.MethodPut, opensearchSecurityAPIPath+"/rolesmapping/"+url.PathEscape(role), mapping, nil)
+}
+
+func (c *opensearchClient) deleteRoleMapping(ctx context.Context, role string) error {
+	return c.JSONRequest(ctx, http.MethodDelete, opensearchSecurityAPIPath+"/rolesmapping/"+url.PathEscape(role), nil, nil)
+}
+
+// opensearchSecurityAdminPasswordSchema is the admin_password of the resources managed with the Security plugin REST API
+var opensearchSecurityAdminPasswordSchema = &schema.Schema{
+	Type:      schema.TypeString,
+	Optional:  true,
+	Sensitive: true,
+	Description: "Password of the `" + opensearchSecurityAdminUsername + "` user, e.g. the `admin_password` of " +
+		"`aiven_opensearch_security_config`. The `" + opensearchSecurityAdminPasswordEnvVar + "` environment variable " +
+		"is used when it's not set, which is required to import the resource.",
+}
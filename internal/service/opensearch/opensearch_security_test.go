@@ -0,0 +1,159 @@
+package opensearch
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_opensearchSecurityStatus(t *testing.T) {
+	var adminRequest opensearchSecurityAdminRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /v1/project/foo/service/os/opensearch/security":
+			_, _ = w.Write([]byte(`{"security_plugin_admin_defined": false, "security_plugin_available": true, "security_plugin_enabled": true}`))
+		case "POST /v1/project/foo/service/os/opensearch/security/admin", "PUT /v1/project/foo/service/os/opensearch/security/admin":
+			_ = json.NewDecoder(r.Body).Decode(&adminRequest)
+			_, _ = w.Write([]byte(`{}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message": "not found"}`))
+		}
+	}))
+	defer srv.Close()
+	t.Setenv("AIVEN_WEB_URL", srv.URL)
+
+	ctx := context.Background()
+	client := &aiven.Client{APIKey: "token", Client: srv.Client()}
+
+	status, err := getOpensearchSecurityStatus(ctx, client, "foo", "os")
+	require.NoError(t, err)
+	assert.Equal(t, &opensearchSecurityStatus{SecurityPluginAvailable: true, SecurityPluginEnabled: true}, status)
+
+	require.NoError(t, enableOpensearchSecurity(ctx, client, "foo", "os", "secret"))
+	assert.Equal(t, opensearchSecurityAdminRequest{AdminPassword: "secret"}, adminRequest)
+
+	require.NoError(t, updateOpensearchSecurityAdminPassword(ctx, client, "foo", "os", "secret", "secret2"))
+	assert.Equal(t, opensearchSecurityAdminRequest{AdminPassword: "secret", NewPassword: "secret2"}, adminRequest)
+
+	_, err = getOpensearchSecurityStatus(ctx, client, "foo", "bar")
+	assert.True(t, aiven.IsNotFound(err))
+}
+
+func Test_opensearchSecurityClient(t *testing.T) {
+	roles := map[string]json.RawMessage{
+		"all_access": json.RawMessage(`{"reserved": true, "cluster_permissions": ["*"]}`),
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, password, _ := r.BasicAuth()
+		if user != opensearchSecurityAdminUsername || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`Unauthorized`))
+			return
+		}
+
+		name := r.URL.Path[len("/_plugins/_security/api/roles/"):]
+		switch r.Method {
+		case http.MethodGet:
+			role, ok := roles[name]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"status": "NOT_FOUND", "message": "Resource '` + name + `' not found."}`))
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]json.RawMessage{name: role})
+		case http.MethodPut:
+			b, _ := io.ReadAll(r.Body)
+			roles[name] = b
+			_, _ = w.Write([]byte(`{"status": "CREATED"}`))
+		case http.MethodDelete:
+			delete(roles, name)
+			_, _ = w.Write([]byte(`{"status": "OK"}`))
+		}
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	c := opensearchClientFrom(&schemautil.ServiceHTTPClient{URL: srv.URL, Username: opensearchSecurityAdminUsername, Password: "secret", Client: srv.Client()})
+
+	role, err := c.getRole(ctx, "all_access")
+	require.NoError(t, err)
+	assert.True(t, role.Reserved)
+
+	_, err = c.getRole(ctx, "readers")
+	assert.True(t, aiven.IsNotFound(err))
+	assert.Contains(t, err.Error(), "Resource 'readers' not found.")
+
+	readers := &opensearchSecurityRole{
+		ClusterPermissions: []string{"cluster_monitor"},
+		IndexPermissions: []opensearchSecurityIndexPermission{{
+			IndexPatterns:  []string{"logs-*"},
+			AllowedActions: []string{"read"},
+			DLS:            `{"term": {"team": "a"}}`,
+			FLS:            []string{"~secret"},
+			MaskedFields:   []string{"email"},
+		}},
+		TenantPermissions: []opensearchSecurityTenantPermission{},
+	}
+	require.NoError(t, c.putRole(ctx, "readers", readers))
+	assert.JSONEq(t, `{
+		"cluster_permissions": ["cluster_monitor"],
+		"index_permissions": [{
+			"index_patterns": ["logs-*"],
+			"allowed_actions": ["read"],
+			"dls": "{\"term\": {\"team\": \"a\"}}",
+			"fls": ["~secret"],
+			"masked_fields": ["email"]
+		}],
+		"tenant_permissions": []
+	}`, string(roles["readers"]))
+
+	role, err = c.getRole(ctx, "readers")
+	require.NoError(t, err)
+	assert.Equal(t, readers, role)
+
+	require.NoError(t, c.deleteRole(ctx, "readers"))
+	_, err = c.getRole(ctx, "readers")
+	assert.True(t, aiven.IsNotFound(err))
+
+	c.Password = "wrong"
+	_, err = c.getRole(ctx, "all_access")
+	assert.Equal(t, http.StatusUnauthorized, err.(aiven.Error).Status)
+}
+
+func Test_opensearchRoleFromSchema(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, aivenOpensearchRoleSchema, map[string]interface{}{
+		"project":             "foo",
+		"service_name":        "os",
+		"role_name":           "readers",
+		"cluster_permissions": []interface{}{"cluster_monitor", "cluster_composite_ops_ro"},
+		"index_permission": []interface{}{map[string]interface{}{
+			"index_patterns":  []interface{}{"logs-*"},
+			"allowed_actions": []interface{}{"read"},
+		}},
+	})
+
+	role := opensearchRoleFromSchema(d)
+	assert.Equal(t, &opensearchSecurityRole{
+		ClusterPermissions: []string{"cluster_composite_ops_ro", "cluster_monitor"},
+		IndexPermissions: []opensearchSecurityIndexPermission{{
+			IndexPatterns:  []string{"logs-*"},
+			AllowedActions: []string{"read"},
+			FLS:            []string{},
+			MaskedFields:   []string{},
+		}},
+		TenantPermissions: []opensearchSecurityTenantPermission{},
+	}, role)
+
+	require.NoError(t, d.Set("index_permission", opensearchIndexPermissionsToSchema(role.IndexPermissions)))
+	assert.Equal(t, role, opensearchRoleFromSchema(d))
+}
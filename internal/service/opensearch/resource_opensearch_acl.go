@@ -0,0 +1,328 @@
+package opensearch
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+)
+
+var opensearchACLPermissions = []string{"deny", "admin", "read", "readwrite", "write"}
+
+// opensearchACLIndexPatternRegexp follows the Opensearch index naming rules, with the glob characters '*' and '?'
+var opensearchACLIndexPatternRegexp = regexp.MustCompile(`^[^-+A-Z\\/"<>|,# ][^A-Z\\/"<>|,# ]{0,248}$`)
+
+var aivenOpensearchACLSchema = map[string]*schema.Schema{
+	"project":      schemautil.CommonSchemaProjectReference,
+	"service_name": schemautil.CommonSchemaServiceNameReference,
+	"acl": {
+		Type:        schema.TypeSet,
+		Optional:    true,
+		Description: "ACLs of the service users, users without an ACL have no access to the indices when ACLs are enabled",
+		Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+			"username": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: schemautil.GetACLUserValidateFunc(),
+				Description:  "Username or username pattern of the ACL",
+			},
+			"rule": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				MinItems:    1,
+				Description: "Rules of the ACL",
+				Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+					"index": {
+						Type:     schema.TypeString,
+						Required: true,
+						ValidateFunc: validation.StringMatch(opensearchACLIndexPatternRegexp,
+							"must be a lowercase index pattern of at most 249 characters, which doesn't start with '-' or '+' and doesn't contain spaces or the characters \\ / \" < > | , #"),
+						Description: schemautil.Complex("Index pattern of the rule, the glob characters '*' and '?' are supported.").MaxLen(249).Build(),
+					},
+					"permission": {
+						Type:         schema.TypeString,
+						Required:     true,
+						ValidateFunc: validation.StringInSlice(opensearchACLPermissions, false),
+						Description:  schemautil.Complex("Permission of the rule").PossibleValues(schemautil.StringSliceToInterfaceSlice(opensearchACLPermissions)...).Build(),
+					},
+				}},
+			},
+		}},
+	},
+}
+
+func ResourceOpensearchACL() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+The Opensearch ACL resource manages all the ACL rules of an Aiven Opensearch service authoritatively: rules created
+outside of Terraform are detected and removed, and the changes are applied at once. Whether ACLs are enabled is managed
+with ` + "`aiven_opensearch_acl_config`" + `.
+
+~> **Note** Don't use ` + "`aiven_opensearch_acl_rule`" + ` for a service whose ACLs are managed by this resource, the rules
+would overwrite each other.
+`,
+		CreateContext: resourceOpensearchACLCreate,
+		ReadContext:   resourceOpensearchACLRead,
+		UpdateContext: resourceOpensearchACLUpdate,
+		DeleteContext: resourceOpensearchACLDelete,
+		CustomizeDiff: resourceOpensearchACLCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: aivenOpensearchACLSchema,
+	}
+}
+
+func resourceOpensearchACLCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	project := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+
+	if err := resourceOpensearchACLReplace(client, project, serviceName, opensearchACLsFromSchema(d.Get("acl"))); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(schemautil.BuildResourceID(project, serviceName))
+
+	return resourceOpensearchACLRead(ctx, d, m)
+}
+
+func resourceOpensearchACLRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	project, serviceName, err := schemautil.SplitResourceID2(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	r, err := client.ElasticsearchACLs.Get(project, serviceName)
+	if err != nil {
+		return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
+	}
+
+	if err := d.Set("project", project); err != nil {
+		return diag.Errorf("error setting ACLs `project` for resource %s: %s", d.Id(), err)
+	}
+	if err := d.Set("service_name", serviceName); err != nil {
+		return diag.Errorf("error setting ACLs `service_name` for resource %s: %s", d.Id(), err)
+	}
+	if err := d.Set("acl", opensearchACLsToSchema(r.ElasticSearchACLConfig.ACLs)); err != nil {
+		return diag.Errorf("error setting ACLs `acl` for resource %s: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceOpensearchACLUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	project, serviceName, err := schemautil.SplitResourceID2(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := resourceOpensearchACLReplace(client, project, serviceName, opensearchACLsFromSchema(d.Get("acl"))); err != nil {
+		return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
+	}
+
+	return resourceOpensearchACLRead(ctx, d, m)
+}
+
+func resourceOpensearchACLDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	project, serviceName, err := schemautil.SplitResourceID2(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := resourceOpensearchACLReplace(client, project, serviceName, nil); err != nil && !aiven.IsNotFound(err) {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// resourceOpensearchACLReplace replaces all the ACLs of the service with a single PUT, the ACL config
+// fields are kept as they are
+func resourceOpensearchACLReplace(client *aiven.Client, project, serviceName string, acls []aiven.ElasticSearchACL) error {
+	resourceOpensearchACLModifierMutex.Lock()
+	defer resourceOpensearchACLModifierMutex.Unlock()
+
+	r, err := client.ElasticsearchACLs.Get(project, serviceName)
+	if err != nil {
+		return err
+	}
+
+	changes := diffOpensearchACLs(r.ElasticSearchACLConfig.ACLs, acls)
+	if len(changes) == 0 {
+		return nil
+	}
+	for _, c := range changes {
+		log.Printf("[DEBUG] Opensearch ACL change of service %s/%s: %s", project, serviceName, c)
+	}
+
+	config := r.ElasticSearchACLConfig
+	config.ACLs = acls
+	if config.ACLs == nil {
+		config.ACLs = []aiven.ElasticSearchACL{}
+	}
+	_, err = client.ElasticsearchACLs.Update(project, serviceName, aiven.ElasticsearchACLRequest{ElasticSearchACLConfig: config})
+	return err
+}
+
+// resourceOpensearchACLCustomizeDiff rejects the ACLs the API would merge or reject, the sets only
+// tell apart whole blocks
+func resourceOpensearchACLCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown("acl") {
+		return nil
+	}
+	return validateOpensearchACLs(opensearchACLsFromSchema(d.Get("acl")))
+}
+
+func validateOpensearchACLs(acls []aiven.ElasticSearchACL) error {
+	usernames := make(map[string]bool)
+	for _, acl := range acls {
+		// interpolated values are not known yet
+		if acl.Username == "" {
+			continue
+		}
+		if usernames[acl.Username] {
+			return fmt.Errorf("username %s has more than one acl block", acl.Username)
+		}
+		usernames[acl.Username] = true
+
+		indices := make(map[string]bool)
+		for _, rule := range acl.Rules {
+			if rule.Index == "" {
+				continue
+			}
+			if indices[rule.Index] {
+				return fmt.Errorf("username %s has more than one rule for index %s", acl.Username, rule.Index)
+			}
+			indices[rule.Index] = true
+		}
+	}
+	return nil
+}
+
+// opensearchACLRuleChange is a rule which is added, removed or whose permission changes
+type opensearchACLRuleChange struct {
+	username      string
+	index         string
+	oldPermission string
+	newPermission string
+}
+
+func (c opensearchACLRuleChange) String() string {
+	switch {
+	case c.oldPermission == "":
+		return fmt.Sprintf("add %s %s: %s", c.username, c.index, c.newPermission)
+	case c.newPermission == "":
+		return fmt.Sprintf("remove %s %s: %s", c.username, c.index, c.oldPermission)
+	default:
+		return fmt.Sprintf("change %s %s: %s -> %s", c.username, c.index, c.oldPermission, c.newPermission)
+	}
+}
+
+// diffOpensearchACLs returns the rule changes from current to wanted, sorted by username and index
+func diffOpensearchACLs(current, wanted []aiven.ElasticSearchACL) []opensearchACLRuleChange {
+	type ruleKey struct{ username, index string }
+	flatten := func(acls []aiven.ElasticSearchACL) map[ruleKey]string {
+		r := make(map[ruleKey]string)
+		for _, acl := range acls {
+			for _, rule := range acl.Rules {
+				r[ruleKey{acl.Username, rule.Index}] = rule.Permission
+			}
+		}
+		return r
+	}
+
+	currentRules := flatten(current)
+	wantedRules := flatten(wanted)
+
+	var changes []opensearchACLRuleChange
+	for k, permission := range wantedRules {
+		if currentRules[k] != permission {
+			changes = append(changes, opensearchACLRuleChange{k.username, k.index, currentRules[k], permission})
+		}
+	}
+	for k, permission := range currentRules {
+		if _, ok := wantedRules[k]; !ok {
+			changes = append(changes, opensearchACLRuleChange{k.username, k.index, permission, ""})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].username != changes[j].username {
+			return changes[i].username < changes[j].username
+		}
+		return changes[i].index < changes[j].index
+	})
+	return changes
+}
+
+// opensearchACLsFromSchema converts the acl set, the ACLs and their rules are sorted so that the
+// document doesn't change with the hashes of the set
+func opensearchACLsFromSchema(v interface{}) []aiven.ElasticSearchACL {
+	set, ok := v.(*schema.Set)
+	if !ok {
+		return nil
+	}
+
+	var acls []aiven.ElasticSearchACL
+	for _, a := range set.List() {
+		acl := a.(map[string]interface{})
+		r := aiven.ElasticSearchACL{Username: acl["username"].(string), Rules: []aiven.ElasticsearchACLRule{}}
+		if rules, ok := acl["rule"].(*schema.Set); ok {
+			for _, v := range rules.List() {
+				rule := v.(map[string]interface{})
+				r.Rules = append(r.Rules, aiven.ElasticsearchACLRule{
+					Index:      rule["index"].(string),
+					Permission: rule["permission"].(string),
+				})
+			}
+		}
+		sort.Slice(r.Rules, func(i, j int) bool {
+			return r.Rules[i].Index < r.Rules[j].Index
+		})
+		acls = append(acls, r)
+	}
+
+	sort.Slice(acls, func(i, j int) bool {
+		return acls[i].Username < acls[j].Username
+	})
+	return acls
+}
+
+func opensearchACLsToSchema(acls []aiven.ElasticSearchACL) []map[string]interface{} {
+	r := make([]map[string]interface{}, 0, len(acls))
+	for _, acl := range acls {
+		// the API keeps users whose last rule was removed
+		if len(acl.Rules) == 0 {
+			continue
+		}
+		var rules []map[string]interface{}
+		for _, rule := range acl.Rules {
+			rules = append(rules, map[string]interface{}{
+				"index":      rule.Index,
+				"permission": rule.Permission,
+			})
+		}
+		r = append(r, map[string]interface{}{
+			"username": acl.Username,
+			"rule":     rules,
+		})
+	}
+	return r
+}
@@ -0,0 +1,122 @@
+package opensearch_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/aiven/aiven-go-client"
+	acc "github.com/aiven/terraform-provider-aiven/internal/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAivenOpensearchACL_basic(t *testing.T) {
+	resourceName := "aiven_opensearch_acl.foo"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	serviceName := fmt.Sprintf("test-acc-sr-os-acl-%s", rName)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acc.TestAccPreCheck(t) },
+		ProviderFactories: acc.TestAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOpensearchACLResource(rName, "read"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "service_name", serviceName),
+					resource.TestCheckResourceAttr(resourceName, "acl.#", "2"),
+				),
+			},
+			{
+				// a rule created outside of Terraform is detected and removed
+				PreConfig: func() {
+					c := acc.TestAccProvider.Meta().(*aiven.Client)
+					project := os.Getenv("AIVEN_PROJECT_NAME")
+					r, err := c.ElasticsearchACLs.Get(project, serviceName)
+					if err != nil {
+						t.Fatal(err)
+					}
+					r.ElasticSearchACLConfig.Add(aiven.ElasticSearchACL{
+						Username: "user-" + rName,
+						Rules:    []aiven.ElasticsearchACLRule{{Index: "outside-*", Permission: "admin"}},
+					})
+					_, err = c.ElasticsearchACLs.Update(project, serviceName, aiven.ElasticsearchACLRequest{ElasticSearchACLConfig: r.ElasticSearchACLConfig})
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:             testAccOpensearchACLResource(rName, "read"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccOpensearchACLResource(rName, "readwrite"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "acl.#", "2"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccOpensearchACLResource(name, permission string) string {
+	return fmt.Sprintf(`
+data "aiven_project" "foo" {
+  project = "%s"
+}
+
+resource "aiven_opensearch" "bar" {
+  project                 = data.aiven_project.foo.project
+  cloud_name              = "google-europe-west1"
+  plan                    = "startup-4"
+  service_name            = "test-acc-sr-os-acl-%s"
+  maintenance_window_dow  = "monday"
+  maintenance_window_time = "10:00:00"
+}
+
+resource "aiven_opensearch_user" "foo" {
+  project      = data.aiven_project.foo.project
+  service_name = aiven_opensearch.bar.service_name
+  username     = "user-%s"
+}
+
+resource "aiven_opensearch_acl_config" "foo" {
+  project      = data.aiven_project.foo.project
+  service_name = aiven_opensearch.bar.service_name
+  enabled      = true
+  extended_acl = false
+}
+
+resource "aiven_opensearch_acl" "foo" {
+  project      = aiven_opensearch_acl_config.foo.project
+  service_name = aiven_opensearch_acl_config.foo.service_name
+
+  acl {
+    username = aiven_opensearch_user.foo.username
+
+    rule {
+      index      = "logs-*"
+      permission = "%s"
+    }
+
+    rule {
+      index      = "metrics-*"
+      permission = "read"
+    }
+  }
+
+  acl {
+    username = "admin-*"
+
+    rule {
+      index      = "*"
+      permission = "admin"
+    }
+  }
+}`, os.Getenv("AIVEN_PROJECT_NAME"), name, name, permission)
+}
@@ -0,0 +1,198 @@
+package opensearch
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+)
+
+var aivenOpensearchIndexTemplateSchema = map[string]*schema.Schema{
+	"project":      schemautil.CommonSchemaProjectReference,
+	"service_name": schemautil.CommonSchemaServiceNameReference,
+	"name": {
+		Type:         schema.TypeString,
+		Required:     true,
+		ForceNew:     true,
+		ValidateFunc: validation.StringLenBetween(1, 255),
+		Description:  schemautil.Complex("Name of the index template.").ForceNew().Build(),
+	},
+	"body": {
+		Type:             schema.TypeString,
+		Required:         true,
+		ValidateFunc:     validation.StringIsJSON,
+		DiffSuppressFunc: opensearchJSONDiffSuppressFunc(opensearchNormalizeIndexTemplate),
+		Description:      "Composable index template as JSON, with `index_patterns`, `template`, `composed_of`, `priority`, `version` and `_meta`",
+	},
+}
+
+func ResourceOpensearchIndexTemplate() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+The Opensearch Index Template resource allows the creation and management of composable index templates of an Aiven
+Opensearch service. The template is compared to the configuration on read, so the defaults Opensearch adds don't
+cause diffs.
+`,
+		CreateContext: resourceOpensearchIndexTemplateCreate,
+		ReadContext:   resourceOpensearchIndexTemplateRead,
+		UpdateContext: resourceOpensearchIndexTemplateUpdate,
+		DeleteContext: resourceOpensearchIndexTemplateDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: aivenOpensearchIndexTemplateSchema,
+	}
+}
+
+func resourceOpensearchIndexTemplateCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	project := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+	name := d.Get("name").(string)
+
+	c, err := newOpensearchClient(client, project, serviceName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := c.putIndexTemplate(ctx, name, d.Get("body").(string)); err != nil {
+		return diag.Errorf("cannot create Opensearch index template %s: %s", name, err)
+	}
+
+	d.SetId(schemautil.BuildResourceID(project, serviceName, name))
+
+	return resourceOpensearchIndexTemplateRead(ctx, d, m)
+}
+
+func resourceOpensearchIndexTemplateRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	project, serviceName, name, err := schemautil.SplitResourceID3(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	c, err := newOpensearchClient(client, project, serviceName)
+	if err != nil {
+		return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
+	}
+
+	template, err := c.getIndexTemplate(ctx, name)
+	if err != nil {
+		return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
+	}
+
+	body, err := opensearchStateJSON(d.Get("body").(string), opensearchNormalizeIndexTemplate(template), opensearchNormalizeIndexTemplate)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("project", project); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("service_name", serviceName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("name", name); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("body", body); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceOpensearchIndexTemplateUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	project, serviceName, name, err := schemautil.SplitResourceID3(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	c, err := newOpensearchClient(client, project, serviceName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := c.putIndexTemplate(ctx, name, d.Get("body").(string)); err != nil {
+		return diag.Errorf("cannot update Opensearch index template %s: %s", name, err)
+	}
+
+	return resourceOpensearchIndexTemplateRead(ctx, d, m)
+}
+
+func resourceOpensearchIndexTemplateDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	project, serviceName, name, err := schemautil.SplitResourceID3(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	c, err := newOpensearchClient(client, project, serviceName)
+	if err != nil {
+		if aiven.IsNotFound(err) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	if err := c.deleteIndexTemplate(ctx, name); err != nil && !aiven.IsNotFound(err) {
+		return diag.Errorf("cannot delete Opensearch index template %s: %s", name, err)
+	}
+
+	return nil
+}
+
+func (c *opensearchClient) getIndexTemplate(ctx context.Context, name string) (interface{}, error) {
+	var r struct {
+		IndexTemplates []struct {
+			Name          string      `json:"name"`
+			IndexTemplate interface{} `json:"index_template"`
+		} `json:"index_templates"`
+	}
+	if err := c.JSONRequest(ctx, http.MethodGet, "/_index_template/"+url.PathEscape(name), nil, &r); err != nil {
+		return nil, err
+	}
+	for _, t := range r.IndexTemplates {
+		if t.Name == name {
+			return t.IndexTemplate, nil
+		}
+	}
+	return nil, schemautil.NotFoundError("index template", name)
+}
+
+func (c *opensearchClient) putIndexTemplate(ctx context.Context, name, body string) error {
+	return c.JSONRequest(ctx, http.MethodPut, "/_index_template/"+url.PathEscape(name), json.RawMessage(body), nil)
+}
+
+func (c *opensearchClient) deleteIndexTemplate(ctx context.Context, name string) error {
+	return c.JSONRequest(ctx, http.MethodDelete, "/_index_template/"+url.PathEscape(name), nil, nil)
+}
+
+// opensearchNormalizeIndexTemplate rewrites the settings of the template the way Opensearch returns them
+func opensearchNormalizeIndexTemplate(v interface{}) interface{} {
+	t, ok := v.(map[string]interface{})
+	if !ok {
+		return v
+	}
+	template, ok := t["template"].(map[string]interface{})
+	if !ok {
+		return v
+	}
+	if settings, ok := template["settings"]; ok {
+		template["settings"] = opensearchFlattenSettings(settings)
+	}
+	return v
+}
@@ -0,0 +1,102 @@
+package opensearch_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	acc "github.com/aiven/terraform-provider-aiven/internal/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAivenOpensearchIndexTemplate_basic(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acc.TestAccPreCheck(t) },
+		ProviderFactories: acc.TestAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOpensearchIndexTemplateResource(rName, "30d"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aiven_opensearch_index_template.foo", "name", "logs"),
+					resource.TestCheckResourceAttr("aiven_opensearch_ism_policy.foo", "policy_id", "logs"),
+					resource.TestCheckResourceAttrSet("aiven_opensearch_ism_policy.foo", "seq_no"),
+				),
+			},
+			{
+				Config: testAccOpensearchIndexTemplateResource(rName, "7d"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aiven_opensearch_ism_policy.foo", "policy_id", "logs"),
+				),
+			},
+			{
+				ResourceName:            "aiven_opensearch_index_template.foo",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"body"},
+			},
+		},
+	})
+}
+
+func testAccOpensearchIndexTemplateResource(name, deleteAfter string) string {
+	return fmt.Sprintf(`
+data "aiven_project" "foo" {
+  project = "%s"
+}
+
+resource "aiven_opensearch" "bar" {
+  project                 = data.aiven_project.foo.project
+  cloud_name              = "google-europe-west1"
+  plan                    = "startup-4"
+  service_name            = "test-acc-sr-os-tmpl-%s"
+  maintenance_window_dow  = "monday"
+  maintenance_window_time = "10:00:00"
+}
+
+resource "aiven_opensearch_index_template" "foo" {
+  project      = aiven_opensearch.bar.project
+  service_name = aiven_opensearch.bar.service_name
+  name         = "logs"
+  body = jsonencode({
+    index_patterns = ["logs-*"]
+    priority       = 10
+    template = {
+      settings = {
+        number_of_shards   = 1
+        number_of_replicas = 1
+      }
+      mappings = {
+        properties = {
+          "@timestamp" = { type = "date" }
+        }
+      }
+    }
+  })
+}
+
+resource "aiven_opensearch_ism_policy" "foo" {
+  project      = aiven_opensearch.bar.project
+  service_name = aiven_opensearch.bar.service_name
+  policy_id    = "logs"
+  policy = jsonencode({
+    description   = "delete old logs"
+    default_state = "hot"
+    states = [
+      {
+        name        = "hot"
+        actions     = []
+        transitions = [{ state_name = "delete", conditions = { min_index_age = "%s" } }]
+      },
+      {
+        name        = "delete"
+        actions     = [{ delete = {} }]
+        transitions = []
+      }
+    ]
+    ism_template = [{ index_patterns = ["logs-*"] }]
+  })
+}`, os.Getenv("AIVEN_PROJECT_NAME"), name, deleteAfter)
+}
@@ -0,0 +1,230 @@
+package opensearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+)
+
+var aivenOpensearchISMPolicySchema = map[string]*schema.Schema{
+	"project":      schemautil.CommonSchemaProjectReference,
+	"service_name": schemautil.CommonSchemaServiceNameReference,
+	"policy_id": {
+		Type:         schema.TypeString,
+		Required:     true,
+		ForceNew:     true,
+		ValidateFunc: validation.StringLenBetween(1, 255),
+		Description:  schemautil.Complex("Id of the Index State Management policy.").ForceNew().Build(),
+	},
+	"policy": {
+		Type:             schema.TypeString,
+		Required:         true,
+		ValidateFunc:     validation.StringIsJSON,
+		DiffSuppressFunc: opensearchJSONDiffSuppressFunc(opensearchNormalizeISMPolicy),
+		Description:      "The policy as JSON, with `description`, `default_state`, `states` and `ism_template`, i.e. the value of the `policy` key of the Opensearch API",
+	},
+	"seq_no": {
+		Type:        schema.TypeInt,
+		Computed:    true,
+		Description: "Sequence number of the policy, Opensearch rejects concurrent updates of the policy",
+	},
+	"primary_term": {
+		Type:        schema.TypeInt,
+		Computed:    true,
+		Description: "Primary term of the policy, Opensearch rejects concurrent updates of the policy",
+	},
+}
+
+func ResourceOpensearchISMPolicy() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+The Opensearch ISM Policy resource allows the creation and management of Index State Management policies of an Aiven
+Opensearch service. The policy is compared to the configuration on read, so the defaults Opensearch adds, e.g. the
+retry settings of the actions, don't cause diffs.
+`,
+		CreateContext: resourceOpensearchISMPolicyCreate,
+		ReadContext:   resourceOpensearchISMPolicyRead,
+		UpdateContext: resourceOpensearchISMPolicyUpdate,
+		DeleteContext: resourceOpensearchISMPolicyDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: aivenOpensearchISMPolicySchema,
+	}
+}
+
+func resourceOpensearchISMPolicyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	project := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+	policyID := d.Get("policy_id").(string)
+
+	c, err := newOpensearchClient(client, project, serviceName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := c.putISMPolicy(ctx, policyID, d.Get("policy").(string), nil); err != nil {
+		return diag.Errorf("cannot create Opensearch ISM policy %s: %s", policyID, err)
+	}
+
+	d.SetId(schemautil.BuildResourceID(project, serviceName, policyID))
+
+	return resourceOpensearchISMPolicyRead(ctx, d, m)
+}
+
+func resourceOpensearchISMPolicyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	project, serviceName, policyID, err := schemautil.SplitResourceID3(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	c, err := newOpensearchClient(client, project, serviceName)
+	if err != nil {
+		return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
+	}
+
+	p, err := c.getISMPolicy(ctx, policyID)
+	if err != nil {
+		return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
+	}
+
+	policy, err := opensearchStateJSON(d.Get("policy").(string), opensearchNormalizeISMPolicy(p.Policy), opensearchNormalizeISMPolicy)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("project", project); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("service_name", serviceName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("policy_id", policyID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("policy", policy); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("seq_no", p.SeqNo); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("primary_term", p.PrimaryTerm); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceOpensearchISMPolicyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	project, serviceName, policyID, err := schemautil.SplitResourceID3(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	c, err := newOpensearchClient(client, project, serviceName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// the policy read last is updated, a concurrent update makes Opensearch reject the request
+	current := &opensearchISMPolicy{SeqNo: int64(d.Get("seq_no").(int)), PrimaryTerm: int64(d.Get("primary_term").(int))}
+	if err := c.putISMPolicy(ctx, policyID, d.Get("policy").(string), current); err != nil {
+		return diag.Errorf("cannot update Opensearch ISM policy %s: %s", policyID, err)
+	}
+
+	return resourceOpensearchISMPolicyRead(ctx, d, m)
+}
+
+func resourceOpensearchISMPolicyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	project, serviceName, policyID, err := schemautil.SplitResourceID3(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	c, err := newOpensearchClient(client, project, serviceName)
+	if err != nil {
+		if aiven.IsNotFound(err) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	if err := c.deleteISMPolicy(ctx, policyID); err != nil && !aiven.IsNotFound(err) {
+		return diag.Errorf("cannot delete Opensearch ISM policy %s: %s", policyID, err)
+	}
+
+	return nil
+}
+
+// opensearchISMPolicy is a policy as returned by the ISM plugin
+type opensearchISMPolicy struct {
+	ID          string      `json:"_id"`
+	SeqNo       int64       `json:"_seq_no"`
+	PrimaryTerm int64       `json:"_primary_term"`
+	Policy      interface{} `json:"policy"`
+}
+
+func (c *opensearchClient) getISMPolicy(ctx context.Context, policyID string) (*opensearchISMPolicy, error) {
+	var r opensearchISMPolicy
+	if err := c.JSONRequest(ctx, http.MethodGet, "/_plugins/_ism/policies/"+url.PathEscape(policyID), nil, &r); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// putISMPolicy creates the policy, or updates it when the sequence number and the primary term of the
+// current policy are given
+func (c *opensearchClient) putISMPolicy(ctx context.Context, policyID, policy string, current *opensearchISMPolicy) error {
+	path := "/_plugins/_ism/policies/" + url.PathEscape(policyID)
+	if current != nil {
+		path += fmt.Sprintf("?if_seq_no=%d&if_primary_term=%d", current.SeqNo, current.PrimaryTerm)
+	}
+	body := map[string]json.RawMessage{"policy": json.RawMessage(policy)}
+	return c.JSONRequest(ctx, http.MethodPut, path, body, nil)
+}
+
+func (c *opensearchClient) deleteISMPolicy(ctx context.Context, policyID string) error {
+	return c.JSONRequest(ctx, http.MethodDelete, "/_plugins/_ism/policies/"+url.PathEscape(policyID), nil, nil)
+}
+
+// opensearchNormalizeISMPolicy removes the fields the ISM plugin manages from a policy
+func opensearchNormalizeISMPolicy(v interface{}) interface{} {
+	p, ok := v.(map[string]interface{})
+	if !ok {
+		return v
+	}
+	delete(p, "policy_id")
+	delete(p, "last_updated_time")
+	delete(p, "schema_version")
+
+	// a single template is returned as a list
+	if t, ok := p["ism_template"].(map[string]interface{}); ok {
+		p["ism_template"] = []interface{}{t}
+	}
+	if templates, ok := p["ism_template"].([]interface{}); ok {
+		for _, t := range templates {
+			if t, ok := t.(map[string]interface{}); ok {
+				delete(t, "last_updated_time")
+			}
+		}
+	}
+	return v
+}
@@ -0,0 +1,299 @@
+package opensearch
+
+import (
+	"context"
+	"sort"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+)
+
+var aivenOpensearchRoleSchema = map[string]*schema.Schema{
+	"project":        schemautil.CommonSchemaProjectReference,
+	"service_name":   schemautil.CommonSchemaServiceNameReference,
+	"admin_password": opensearchSecurityAdminPasswordSchema,
+	"role_name": {
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: schemautil.Complex("Name of the Opensearch Security role.").ForceNew().Referenced().Build(),
+	},
+	"description": {
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "Description of the role",
+	},
+	"cluster_permissions": {
+		Type:        schema.TypeSet,
+		Optional:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Description: "Cluster permissions of the role, action groups like `cluster_monitor` or actions like `cluster:admin/ingest/pipeline/get`",
+	},
+	"index_permission": {
+		Type:        schema.TypeList,
+		Optional:    true,
+		Description: "Permissions of the role on indices",
+		Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+			"index_patterns": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Index patterns the permission applies to, e.g. `logs-*`",
+			},
+			"allowed_actions": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Action groups like `read` or actions like `indices:data/read/search` allowed on the indices",
+			},
+			"dls": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Document level security query, restricting the documents the role can read",
+			},
+			"fls": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Field level security, the fields the role can read, or can't read when prefixed with `~`",
+			},
+			"masked_fields": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Fields whose values are returned hashed",
+			},
+		}},
+	},
+	"tenant_permission": {
+		Type:        schema.TypeList,
+		Optional:    true,
+		Description: "Permissions of the role on Opensearch Dashboards tenants",
+		Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+			"tenant_patterns": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Tenant patterns the permission applies to",
+			},
+			"allowed_actions": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: schemautil.Complex("Actions allowed on the tenants.").PossibleValues("kibana_all_read", "kibana_all_write").Build(),
+			},
+		}},
+	},
+}
+
+func ResourceOpensearchRole() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+The Opensearch Role resource allows the creation and management of roles of the Opensearch Security plugin of an Aiven
+Opensearch service, with cluster, index and tenant permissions and document and field level security. The Security
+plugin must be managed with ` + "`aiven_opensearch_security_config`" + `. Reserved roles can't be managed.
+`,
+		CreateContext: resourceOpensearchRoleCreate,
+		ReadContext:   resourceOpensearchRoleRead,
+		UpdateContext: resourceOpensearchRoleUpdate,
+		DeleteContext: resourceOpensearchRoleDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: aivenOpensearchRoleSchema,
+	}
+}
+
+func resourceOpensearchRoleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	project := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+	roleName := d.Get("role_name").(string)
+
+	c, err := newOpensearchSecurityClient(d, client, project, serviceName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	existing, err := c.getRole(ctx, roleName)
+	if err != nil && !aiven.IsNotFound(err) {
+		return diag.Errorf("cannot get Opensearch role %s: %s", roleName, err)
+	}
+	if existing != nil {
+		if existing.Reserved {
+			return diag.Errorf("Opensearch role %s is reserved and can't be managed", roleName)
+		}
+		return diag.Errorf("Opensearch role %s already exists, import it instead", roleName)
+	}
+
+	if err := c.putRole(ctx, roleName, opensearchRoleFromSchema(d)); err != nil {
+		return diag.Errorf("cannot create Opensearch role %s: %s", roleName, err)
+	}
+
+	d.SetId(schemautil.BuildResourceID(project, serviceName, roleName))
+
+	return resourceOpensearchRoleRead(ctx, d, m)
+}
+
+func resourceOpensearchRoleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	project, serviceName, roleName, err := schemautil.SplitResourceID3(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	c, err := newOpensearchSecurityClient(d, client, project, serviceName)
+	if err != nil {
+		return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
+	}
+
+	role, err := c.getRole(ctx, roleName)
+	if err != nil {
+		return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
+	}
+	if role.Reserved {
+		return diag.Errorf("Opensearch role %s is reserved and can't be managed", roleName)
+	}
+
+	if err := d.Set("project", project); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("service_name", serviceName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("role_name", roleName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("description", role.Description); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("cluster_permissions", role.ClusterPermissions); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("index_permission", opensearchIndexPermissionsToSchema(role.IndexPermissions)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("tenant_permission", opensearchTenantPermissionsToSchema(role.TenantPermissions)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceOpensearchRoleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	project, serviceName, roleName, err := schemautil.SplitResourceID3(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	c, err := newOpensearchSecurityClient(d, client, project, serviceName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// PUT replaces the whole role
+	if err := c.putRole(ctx, roleName, opensearchRoleFromSchema(d)); err != nil {
+		return diag.Errorf("cannot update Opensearch role %s: %s", roleName, err)
+	}
+
+	return resourceOpensearchRoleRead(ctx, d, m)
+}
+
+func resourceOpensearchRoleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	project, serviceName, roleName, err := schemautil.SplitResourceID3(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	c, err := newOpensearchSecurityClient(d, client, project, serviceName)
+	if err != nil {
+		if aiven.IsNotFound(err) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	if err := c.deleteRole(ctx, roleName); err != nil && !aiven.IsNotFound(err) {
+		return diag.Errorf("cannot delete Opensearch role %s: %s", roleName, err)
+	}
+
+	return nil
+}
+
+func opensearchRoleFromSchema(d *schema.ResourceData) *opensearchSecurityRole {
+	role := &opensearchSecurityRole{
+		Description:        d.Get("description").(string),
+		ClusterPermissions: opensearchStringSetFromSchema(d.Get("cluster_permissions")),
+		IndexPermissions:   []opensearchSecurityIndexPermission{},
+		TenantPermissions:  []opensearchSecurityTenantPermission{},
+	}
+
+	for _, v := range d.Get("index_permission").([]interface{}) {
+		p := v.(map[string]interface{})
+		role.IndexPermissions = append(role.IndexPermissions, opensearchSecurityIndexPermission{
+			IndexPatterns:  schemautil.FlattenToString(p["index_patterns"].([]interface{})),
+			AllowedActions: opensearchStringSetFromSchema(p["allowed_actions"]),
+			DLS:            p["dls"].(string),
+			FLS:            schemautil.FlattenToString(p["fls"].([]interface{})),
+			MaskedFields:   schemautil.FlattenToString(p["masked_fields"].([]interface{})),
+		})
+	}
+
+	for _, v := range d.Get("tenant_permission").([]interface{}) {
+		p := v.(map[string]interface{})
+		role.TenantPermissions = append(role.TenantPermissions, opensearchSecurityTenantPermission{
+			TenantPatterns: schemautil.FlattenToString(p["tenant_patterns"].([]interface{})),
+			AllowedActions: opensearchStringSetFromSchema(p["allowed_actions"]),
+		})
+	}
+
+	return role
+}
+
+func opensearchIndexPermissionsToSchema(permissions []opensearchSecurityIndexPermission) []map[string]interface{} {
+	r := make([]map[string]interface{}, 0, len(permissions))
+	for _, p := range permissions {
+		r = append(r, map[string]interface{}{
+			"index_patterns":  p.IndexPatterns,
+			"allowed_actions": p.AllowedActions,
+			"dls":             p.DLS,
+			"fls":             p.FLS,
+			"masked_fields":   p.MaskedFields,
+		})
+	}
+	return r
+}
+
+func opensearchTenantPermissionsToSchema(permissions []opensearchSecurityTenantPermission) []map[string]interface{} {
+	r := make([]map[string]interface{}, 0, len(permissions))
+	for _, p := range permissions {
+		r = append(r, map[string]interface{}{
+			"tenant_patterns": p.TenantPatterns,
+			"allowed_actions": p.AllowedActions,
+		})
+	}
+	return r
+}
+
+// opensearchStringSetFromSchema converts a set of strings to a sorted slice, the Security plugin
+// wants empty lists rather than nulls
+func opensearchStringSetFromSchema(v interface{}) []string {
+	r := []string{}
+	if s, ok := v.(*schema.Set); ok {
+		r = append(r, schemautil.FlattenToString(s.List())...)
+	}
+	sort.Strings(r)
+	return r
+}
@@ -0,0 +1,195 @@
+package opensearch
+
+import (
+	"context"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+)
+
+var aivenOpensearchRoleMappingSchema = map[string]*schema.Schema{
+	"project":        schemautil.CommonSchemaProjectReference,
+	"service_name":   schemautil.CommonSchemaServiceNameReference,
+	"admin_password": opensearchSecurityAdminPasswordSchema,
+	"role_name": {
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: schemautil.Complex("Name of the Opensearch Security role the users, backend roles and hosts are mapped to.").ForceNew().Referenced().Build(),
+	},
+	"description": {
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "Description of the role mapping",
+	},
+	"users": {
+		Type:        schema.TypeSet,
+		Optional:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Description: "Users mapped to the role, e.g. `aiven_opensearch_user` usernames",
+	},
+	"backend_roles": {
+		Type:        schema.TypeSet,
+		Optional:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Description: "Backend roles mapped to the role, e.g. SAML or OpenID roles",
+	},
+	"hosts": {
+		Type:        schema.TypeSet,
+		Optional:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Description: "Hosts mapped to the role",
+	},
+}
+
+func ResourceOpensearchRoleMapping() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+The Opensearch Role Mapping resource maps users, backend roles and hosts to a role of the Opensearch Security plugin of an
+Aiven Opensearch service. The Security plugin must be managed with ` + "`aiven_opensearch_security_config`" + `. The role
+mapping is authoritative, mappings added outside of Terraform are removed.
+`,
+		CreateContext: resourceOpensearchRoleMappingCreate,
+		ReadContext:   resourceOpensearchRoleMappingRead,
+		UpdateContext: resourceOpensearchRoleMappingUpdate,
+		DeleteContext: resourceOpensearchRoleMappingDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: aivenOpensearchRoleMappingSchema,
+	}
+}
+
+func resourceOpensearchRoleMappingCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	project := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+	roleName := d.Get("role_name").(string)
+
+	c, err := newOpensearchSecurityClient(d, client, project, serviceName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	existing, err := c.getRoleMapping(ctx, roleName)
+	if err != nil && !aiven.IsNotFound(err) {
+		return diag.Errorf("cannot get Opensearch role mapping %s: %s", roleName, err)
+	}
+	if existing != nil {
+		if existing.Reserved {
+			return diag.Errorf("Opensearch role mapping %s is reserved and can't be managed", roleName)
+		}
+		return diag.Errorf("Opensearch role mapping %s already exists, import it instead", roleName)
+	}
+
+	if err := c.putRoleMapping(ctx, roleName, opensearchRoleMappingFromSchema(d)); err != nil {
+		return diag.Errorf("cannot create Opensearch role mapping %s: %s", roleName, err)
+	}
+
+	d.SetId(schemautil.BuildResourceID(project, serviceName, roleName))
+
+	return resourceOpensearchRoleMappingRead(ctx, d, m)
+}
+
+func resourceOpensearchRoleMappingRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	project, serviceName, roleName, err := schemautil.SplitResourceID3(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	c, err := newOpensearchSecurityClient(d, client, project, serviceName)
+	if err != nil {
+		return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
+	}
+
+	mapping, err := c.getRoleMapping(ctx, roleName)
+	if err != nil {
+		return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
+	}
+	if mapping.Reserved {
+		return diag.Errorf("Opensearch role mapping %s is reserved and can't be managed", roleName)
+	}
+
+	if err := d.Set("project", project); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("service_name", serviceName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("role_name", roleName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("description", mapping.Description); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("users", mapping.Users); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("backend_roles", mapping.BackendRoles); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("hosts", mapping.Hosts); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceOpensearchRoleMappingUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	project, serviceName, roleName, err := schemautil.SplitResourceID3(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	c, err := newOpensearchSecurityClient(d, client, project, serviceName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := c.putRoleMapping(ctx, roleName, opensearchRoleMappingFromSchema(d)); err != nil {
+		return diag.Errorf("cannot update Opensearch role mapping %s: %s", roleName, err)
+	}
+
+	return resourceOpensearchRoleMappingRead(ctx, d, m)
+}
+
+func resourceOpensearchRoleMappingDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	project, serviceName, roleName, err := schemautil.SplitResourceID3(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	c, err := newOpensearchSecurityClient(d, client, project, serviceName)
+	if err != nil {
+		if aiven.IsNotFound(err) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	if err := c.deleteRoleMapping(ctx, roleName); err != nil && !aiven.IsNotFound(err) {
+		return diag.Errorf("cannot delete Opensearch role mapping %s: %s", roleName, err)
+	}
+
+	return nil
+}
+
+func opensearchRoleMappingFromSchema(d *schema.ResourceData) *opensearchSecurityRoleMapping {
+	return &opensearchSecurityRoleMapping{
+		Description:  d.Get("description").(string),
+		Users:        opensearchStringSetFromSchema(d.Get("users")),
+		BackendRoles: opensearchStringSetFromSchema(d.Get("backend_roles")),
+		Hosts:        opensearchStringSetFromSchema(d.Get("hosts")),
+	}
+}
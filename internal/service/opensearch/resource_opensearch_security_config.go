@@ -0,0 +1,159 @@
+package opensearch
+
+import (
+	"context"
+	"os"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+)
+
+var aivenOpensearchSecurityConfigSchema = map[string]*schema.Schema{
+	"project":      schemautil.CommonSchemaProjectReference,
+	"service_name": schemautil.CommonSchemaServiceNameReference,
+	"admin_password": {
+		Type:        schema.TypeString,
+		Required:    true,
+		Sensitive:   true,
+		Description: "Password of the `" + opensearchSecurityAdminUsername + "` user that manages the Security plugin. Changing it updates the password of the user.",
+	},
+
+	// computed fields
+	"security_plugin_available": {
+		Type:        schema.TypeBool,
+		Computed:    true,
+		Description: "Whether the Security plugin is available for the service",
+	},
+	"security_plugin_enabled": {
+		Type:        schema.TypeBool,
+		Computed:    true,
+		Description: "Whether the Security plugin is enabled for the service",
+	},
+	"security_plugin_admin_defined": {
+		Type:        schema.TypeBool,
+		Computed:    true,
+		Description: "Whether the `" + opensearchSecurityAdminUsername + "` user is defined, i.e. the Security plugin is managed with its REST API",
+	},
+}
+
+func ResourceOpensearchSecurityConfig() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+The Opensearch Security Config resource hands the management of the Opensearch Security plugin of an Aiven Opensearch
+service over to the ` + "`" + opensearchSecurityAdminUsername + "`" + ` admin user, so that roles and role mappings can be
+managed with ` + "`aiven_opensearch_role` and `aiven_opensearch_role_mapping`" + `.
+
+~> **Note** Enabling the Security plugin management can't be undone. Once enabled, the ACLs of the service are no longer
+used and deleting the resource only removes it from the Terraform state.
+`,
+		CreateContext: resourceOpensearchSecurityConfigCreate,
+		ReadContext:   resourceOpensearchSecurityConfigRead,
+		UpdateContext: resourceOpensearchSecurityConfigUpdate,
+		DeleteContext: resourceOpensearchSecurityConfigDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: aivenOpensearchSecurityConfigSchema,
+	}
+}
+
+func resourceOpensearchSecurityConfigCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	project := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+
+	status, err := getOpensearchSecurityStatus(ctx, client, project, serviceName)
+	if err != nil {
+		return diag.Errorf("cannot get Opensearch security status: %s", err)
+	}
+	if !status.SecurityPluginAvailable {
+		return diag.Errorf("the Security plugin is not available for service %s", serviceName)
+	}
+	if status.SecurityPluginAdminDefined {
+		return diag.Errorf("the Security plugin of service %s is already managed by the %s user, import the resource instead", serviceName, opensearchSecurityAdminUsername)
+	}
+
+	if err := enableOpensearchSecurity(ctx, client, project, serviceName, d.Get("admin_password").(string)); err != nil {
+		return diag.Errorf("cannot enable Opensearch security: %s", err)
+	}
+
+	d.SetId(schemautil.BuildResourceID(project, serviceName))
+
+	return resourceOpensearchSecurityConfigRead(ctx, d, m)
+}
+
+func resourceOpensearchSecurityConfigRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	project, serviceName, err := schemautil.SplitResourceID2(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	status, err := getOpensearchSecurityStatus(ctx, client, project, serviceName)
+	if err != nil {
+		return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
+	}
+	// the service was recreated, the Security plugin is no longer managed
+	if !status.SecurityPluginAdminDefined {
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set("project", project); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("service_name", serviceName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("security_plugin_available", status.SecurityPluginAvailable); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("security_plugin_enabled", status.SecurityPluginEnabled); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("security_plugin_admin_defined", status.SecurityPluginAdminDefined); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceOpensearchSecurityConfigUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	project, serviceName, err := schemautil.SplitResourceID2(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.HasChange("admin_password") {
+		oldPassword, newPassword := d.GetChange("admin_password")
+		password := oldPassword.(string)
+		// the password is not known after an import
+		if password == "" {
+			password = os.Getenv(opensearchSecurityAdminPasswordEnvVar)
+		}
+		if password != newPassword.(string) {
+			err := updateOpensearchSecurityAdminPassword(ctx, client, project, serviceName, password, newPassword.(string))
+			if err != nil {
+				return diag.Errorf("cannot update Opensearch security admin password: %s", err)
+			}
+		}
+	}
+
+	return resourceOpensearchSecurityConfigRead(ctx, d, m)
+}
+
+func resourceOpensearchSecurityConfigDelete(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  "Opensearch Security plugin management can't be disabled",
+		Detail:   "The resource was removed from the state, the Security plugin of service " + d.Get("service_name").(string) + " is still managed by the " + opensearchSecurityAdminUsername + " user.",
+	}}
+}
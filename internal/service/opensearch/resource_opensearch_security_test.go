@@ -0,0 +1,92 @@
+package opensearch_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	acc "github.com/aiven/terraform-provider-aiven/internal/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAivenOpensearchSecurity_basic(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acc.TestAccPreCheck(t) },
+		ProviderFactories: acc.TestAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOpensearchSecurityResource(rName, "logs-*"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aiven_opensearch_security_config.foo", "security_plugin_admin_defined", "true"),
+					resource.TestCheckResourceAttr("aiven_opensearch_security_config.foo", "security_plugin_enabled", "true"),
+					resource.TestCheckResourceAttr("aiven_opensearch_role.foo", "role_name", "readers"),
+					resource.TestCheckResourceAttr("aiven_opensearch_role.foo", "cluster_permissions.#", "1"),
+					resource.TestCheckResourceAttr("aiven_opensearch_role.foo", "index_permission.0.index_patterns.0", "logs-*"),
+					resource.TestCheckResourceAttr("aiven_opensearch_role.foo", "index_permission.0.fls.0", "~secret"),
+					resource.TestCheckResourceAttr("aiven_opensearch_role_mapping.foo", "role_name", "readers"),
+					resource.TestCheckResourceAttr("aiven_opensearch_role_mapping.foo", "users.#", "1"),
+				),
+			},
+			{
+				Config: testAccOpensearchSecurityResource(rName, "metrics-*"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aiven_opensearch_role.foo", "index_permission.0.index_patterns.0", "metrics-*"),
+				),
+			},
+		},
+	})
+}
+
+func testAccOpensearchSecurityResource(name, indexPattern string) string {
+	return fmt.Sprintf(`
+data "aiven_project" "foo" {
+  project = "%s"
+}
+
+resource "aiven_opensearch" "bar" {
+  project                 = data.aiven_project.foo.project
+  cloud_name              = "google-europe-west1"
+  plan                    = "startup-4"
+  service_name            = "test-acc-sr-os-sec-%s"
+  maintenance_window_dow  = "monday"
+  maintenance_window_time = "10:00:00"
+}
+
+resource "aiven_opensearch_user" "foo" {
+  project      = data.aiven_project.foo.project
+  service_name = aiven_opensearch.bar.service_name
+  username     = "user-%s"
+}
+
+resource "aiven_opensearch_security_config" "foo" {
+  project        = data.aiven_project.foo.project
+  service_name   = aiven_opensearch.bar.service_name
+  admin_password = "Test-%s-admin"
+}
+
+resource "aiven_opensearch_role" "foo" {
+  project             = aiven_opensearch_security_config.foo.project
+  service_name        = aiven_opensearch_security_config.foo.service_name
+  admin_password      = aiven_opensearch_security_config.foo.admin_password
+  role_name           = "readers"
+  cluster_permissions = ["cluster_monitor"]
+
+  index_permission {
+    index_patterns  = ["%s"]
+    allowed_actions = ["read"]
+    dls             = "{\"term\": {\"team\": \"a\"}}"
+    fls             = ["~secret"]
+  }
+}
+
+resource "aiven_opensearch_role_mapping" "foo" {
+  project        = aiven_opensearch_role.foo.project
+  service_name   = aiven_opensearch_role.foo.service_name
+  admin_password = aiven_opensearch_security_config.foo.admin_password
+  role_name      = aiven_opensearch_role.foo.role_name
+  users          = [aiven_opensearch_user.foo.username]
+}`, os.Getenv("AIVEN_PROJECT_NAME"), name, name, name, indexPattern)
+}
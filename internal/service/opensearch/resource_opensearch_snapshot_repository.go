@@ -0,0 +1,215 @@
+package opensearch
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+)
+
+var aivenOpensearchSnapshotRepositorySchema = map[string]*schema.Schema{
+	"project":      schemautil.CommonSchemaProjectReference,
+	"service_name": schemautil.CommonSchemaServiceNameReference,
+	"name": {
+		Type:         schema.TypeString,
+		Required:     true,
+		ForceNew:     true,
+		ValidateFunc: validation.StringLenBetween(1, 255),
+		Description:  schemautil.Complex("Name of the snapshot repository.").ForceNew().Build(),
+	},
+	"type": {
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: "Type of the snapshot repository, e.g. `s3`, `gcs` or `azure`",
+	},
+	"settings": {
+		Type:        schema.TypeMap,
+		Optional:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Description: "Settings of the snapshot repository, e.g. `bucket` and `base_path`. Opensearch returns all the values as strings.",
+	},
+	"verify": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     true,
+		Description: schemautil.Complex("Verify that the nodes of the service can access the repository when it's created or updated.").DefaultValue(true).Build(),
+	},
+}
+
+func ResourceOpensearchSnapshotRepository() *schema.Resource {
+	return &schema.Resource{
+		Description:   "The Opensearch Snapshot Repository resource allows the creation and management of snapshot repositories of an Aiven Opensearch service.",
+		CreateContext: resourceOpensearchSnapshotRepositoryCreate,
+		ReadContext:   resourceOpensearchSnapshotRepositoryRead,
+		UpdateContext: resourceOpensearchSnapshotRepositoryUpdate,
+		DeleteContext: resourceOpensearchSnapshotRepositoryDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceOpensearchSnapshotRepositoryImport,
+		},
+
+		Schema: aivenOpensearchSnapshotRepositorySchema,
+	}
+}
+
+func resourceOpensearchSnapshotRepositoryCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	project := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+	name := d.Get("name").(string)
+
+	c, err := newOpensearchClient(client, project, serviceName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := c.putSnapshotRepository(ctx, name, opensearchSnapshotRepositoryFromSchema(d), d.Get("verify").(bool)); err != nil {
+		return diag.Errorf("cannot create Opensearch snapshot repository %s: %s", name, err)
+	}
+
+	d.SetId(schemautil.BuildResourceID(project, serviceName, name))
+
+	return resourceOpensearchSnapshotRepositoryRead(ctx, d, m)
+}
+
+func resourceOpensearchSnapshotRepositoryRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	project, serviceName, name, err := schemautil.SplitResourceID3(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	c, err := newOpensearchClient(client, project, serviceName)
+	if err != nil {
+		return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
+	}
+
+	repository, err := c.getSnapshotRepository(ctx, name)
+	if err != nil {
+		return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
+	}
+
+	if err := d.Set("project", project); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("service_name", serviceName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("name", name); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("type", repository.Type); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("settings", repository.Settings); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceOpensearchSnapshotRepositoryUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	project, serviceName, name, err := schemautil.SplitResourceID3(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// verify only affects the requests
+	if d.HasChanges("type", "settings") {
+		c, err := newOpensearchClient(client, project, serviceName)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if err := c.putSnapshotRepository(ctx, name, opensearchSnapshotRepositoryFromSchema(d), d.Get("verify").(bool)); err != nil {
+			return diag.Errorf("cannot update Opensearch snapshot repository %s: %s", name, err)
+		}
+	}
+
+	return resourceOpensearchSnapshotRepositoryRead(ctx, d, m)
+}
+
+func resourceOpensearchSnapshotRepositoryDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	project, serviceName, name, err := schemautil.SplitResourceID3(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	c, err := newOpensearchClient(client, project, serviceName)
+	if err != nil {
+		if aiven.IsNotFound(err) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	// the snapshots are kept in the repository
+	if err := c.deleteSnapshotRepository(ctx, name); err != nil && !aiven.IsNotFound(err) {
+		return diag.Errorf("cannot delete Opensearch snapshot repository %s: %s", name, err)
+	}
+
+	return nil
+}
+
+func resourceOpensearchSnapshotRepositoryImport(_ context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	if err := d.Set("verify", true); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// opensearchSnapshotRepository is a snapshot repository, the settings are read as strings
+type opensearchSnapshotRepository struct {
+	Type     string            `json:"type"`
+	Settings map[string]string `json:"settings"`
+}
+
+func opensearchSnapshotRepositoryFromSchema(d *schema.ResourceData) *opensearchSnapshotRepository {
+	r := &opensearchSnapshotRepository{Type: d.Get("type").(string), Settings: make(map[string]string)}
+	for k, v := range d.Get("settings").(map[string]interface{}) {
+		r.Settings[k] = v.(string)
+	}
+	return r
+}
+
+func (c *opensearchClient) getSnapshotRepository(ctx context.Context, name string) (*opensearchSnapshotRepository, error) {
+	var r map[string]struct {
+		Type     string                 `json:"type"`
+		Settings map[string]interface{} `json:"settings"`
+	}
+	if err := c.JSONRequest(ctx, http.MethodGet, "/_snapshot/"+url.PathEscape(name), nil, &r); err != nil {
+		return nil, err
+	}
+	repository, ok := r[name]
+	if !ok {
+		return nil, schemautil.NotFoundError("snapshot repository", name)
+	}
+
+	settings := make(map[string]string)
+	for k, v := range repository.Settings {
+		settings[k] = fmt.Sprint(opensearchSettingValue(v))
+	}
+	return &opensearchSnapshotRepository{Type: repository.Type, Settings: settings}, nil
+}
+
+func (c *opensearchClient) putSnapshotRepository(ctx context.Context, name string, repository *opensearchSnapshotRepository, verify bool) error {
+	path := "/_snapshot/" + url.PathEscape(name) + "?verify=" + strconv.FormatBool(verify)
+	return c.JSONRequest(ctx, http.MethodPut, path, repository, nil)
+}
+
+func (c *opensearchClient) deleteSnapshotRepository(ctx context.Context, name string) error {
+	return c.JSONRequest(ctx, http.MethodDelete, "/_snapshot/"+url.PathEscape(name), nil, nil)
+}
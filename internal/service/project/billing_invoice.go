@@ -0,0 +1,269 @@
+package project
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sort"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+)
+
+// aiven-go-client has no invoice and credit support yet, the endpoints are called with schemautil.APIRequest
+
+// billingInvoiceStateEstimate is the state of the invoice of the ongoing billing period
+const billingInvoiceStateEstimate = "estimate"
+
+// billingInvoice is an invoice of a billing group, amounts are decimal strings
+type billingInvoice struct {
+	InvoiceNumber string `json:"invoice_number"`
+	Currency      string `json:"currency"`
+	PeriodBegin   string `json:"period_begin"`
+	PeriodEnd     string `json:"period_end"`
+	State         string `json:"state"`
+	TotalIncVAT   string `json:"total_inc_vat"`
+	TotalVATZero  string `json:"total_vat_zero"`
+	GeneratedAt   string `json:"generated_at"`
+}
+
+// billingInvoiceLine is a line item of an invoice, amounts are decimal strings
+type billingInvoiceLine struct {
+	LineType       string `json:"line_type"`
+	Description    string `json:"description"`
+	LineTotalLocal string `json:"line_total_local"`
+	LocalCurrency  string `json:"local_currency"`
+	LineTotalUSD   string `json:"line_total_usd"`
+	ProjectName    string `json:"project_name"`
+	ServiceName    string `json:"service_name"`
+	ServicePlan    string `json:"service_plan"`
+	ServiceType    string `json:"service_type"`
+	CloudName      string `json:"cloud_name"`
+	TimestampBegin string `json:"timestamp_begin"`
+	TimestampEnd   string `json:"timestamp_end"`
+}
+
+// billingCredit is a credit of a billing group, values are decimal strings
+type billingCredit struct {
+	Code           string `json:"code"`
+	Type           string `json:"type"`
+	Value          string `json:"value"`
+	RemainingValue string `json:"remaining_value"`
+	StartTime      string `json:"start_time"`
+	ExpireTime     string `json:"expire_time"`
+}
+
+func listBillingGroupInvoices(ctx context.Context, client *aiven.Client, billingGroupID string) ([]billingInvoice, error) {
+	var rsp struct {
+		Invoices []billingInvoice `json:"invoices"`
+	}
+	err := schemautil.APIRequest(ctx, client, http.MethodGet, schemautil.BuildAPIPath("billing-group", billingGroupID, "invoice"), nil, &rsp)
+	if err != nil {
+		return nil, err
+	}
+	return rsp.Invoices, nil
+}
+
+func listBillingGroupInvoiceLines(ctx context.Context, client *aiven.Client, billingGroupID, invoiceNumber string) ([]billingInvoiceLine, error) {
+	var rsp struct {
+		Lines []billingInvoiceLine `json:"lines"`
+	}
+	path := schemautil.BuildAPIPath("billing-group", billingGroupID, "invoice", invoiceNumber, "lines")
+	if err := schemautil.APIRequest(ctx, client, http.MethodGet, path, nil, &rsp); err != nil {
+		return nil, err
+	}
+	return rsp.Lines, nil
+}
+
+func listBillingGroupCredits(ctx context.Context, client *aiven.Client, billingGroupID string) ([]billingCredit, error) {
+	var rsp struct {
+		Credits []billingCredit `json:"credits"`
+	}
+	err := schemautil.APIRequest(ctx, client, http.MethodGet, schemautil.BuildAPIPath("billing-group", billingGroupID, "credits"), nil, &rsp)
+	if err != nil {
+		return nil, err
+	}
+	return rsp.Credits, nil
+}
+
+// findBillingInvoice returns the invoice with the given number, or the estimate of the ongoing period
+// when the number is empty
+func findBillingInvoice(invoices []billingInvoice, invoiceNumber string) (*billingInvoice, error) {
+	for i := range invoices {
+		match := invoices[i].InvoiceNumber == invoiceNumber
+		if invoiceNumber == "" {
+			match = invoices[i].State == billingInvoiceStateEstimate
+		}
+		if match {
+			return &invoices[i], nil
+		}
+	}
+
+	if invoiceNumber == "" {
+		return nil, fmt.Errorf("there is no estimate of the ongoing billing period")
+	}
+	return nil, fmt.Errorf("invoice %s not found", invoiceNumber)
+}
+
+// sumAmounts adds up decimal amounts exactly, an empty amount counts as zero
+func sumAmounts(amounts ...string) (string, error) {
+	total := new(big.Rat)
+	for _, a := range amounts {
+		if a == "" {
+			continue
+		}
+		r, ok := new(big.Rat).SetString(a)
+		if !ok {
+			return "", fmt.Errorf("invalid amount %q", a)
+		}
+		total.Add(total, r)
+	}
+	return total.FloatString(2), nil
+}
+
+// billingServiceCost is the cost of a service in a billing period
+type billingServiceCost struct {
+	serviceName string
+	serviceType string
+	servicePlan string
+	cloudName   string
+	amount      string
+}
+
+// billingProjectCost is the cost of a project in a billing period, computed from the invoice lines
+type billingProjectCost struct {
+	amount   string
+	services []billingServiceCost
+	lines    []billingInvoiceLine
+}
+
+// projectCost adds up the invoice lines of a project, in total and per service
+func projectCost(lines []billingInvoiceLine, project string) (*billingProjectCost, error) {
+	r := &billingProjectCost{}
+	var amounts []string
+	serviceAmounts := make(map[string][]string)
+	services := make(map[string]billingServiceCost)
+	for _, l := range lines {
+		if l.ProjectName != project {
+			continue
+		}
+		r.lines = append(r.lines, l)
+		amounts = append(amounts, l.LineTotalLocal)
+
+		if l.ServiceName == "" {
+			continue
+		}
+		serviceAmounts[l.ServiceName] = append(serviceAmounts[l.ServiceName], l.LineTotalLocal)
+		// the plan or the cloud of a service may change during the period, the latest line wins
+		services[l.ServiceName] = billingServiceCost{
+			serviceName: l.ServiceName,
+			serviceType: l.ServiceType,
+			servicePlan: l.ServicePlan,
+			cloudName:   l.CloudName,
+		}
+	}
+
+	var err error
+	if r.amount, err = sumAmounts(amounts...); err != nil {
+		return nil, err
+	}
+
+	for name, s := range services {
+		if s.amount, err = sumAmounts(serviceAmounts[name]...); err != nil {
+			return nil, err
+		}
+		r.services = append(r.services, s)
+	}
+	sort.Slice(r.services, func(i, j int) bool {
+		return r.services[i].serviceName < r.services[j].serviceName
+	})
+
+	return r, nil
+}
+
+var billingInvoiceLineSchema = &schema.Resource{Schema: map[string]*schema.Schema{
+	"line_type": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Type of the line item, e.g. `service_charge` or `credit_consumption`",
+	},
+	"description": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Human readable description of the line item",
+	},
+	"line_total_local": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Amount in the currency of the invoice",
+	},
+	"local_currency": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Currency of the invoice",
+	},
+	"line_total_usd": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Amount in USD",
+	},
+	"project_name": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Project the line item is charged for",
+	},
+	"service_name": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Service the line item is charged for, empty for project level charges",
+	},
+	"service_type": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Service type",
+	},
+	"service_plan": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Service plan",
+	},
+	"cloud_name": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Cloud of the service",
+	},
+	"timestamp_begin": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Beginning of the charged usage",
+	},
+	"timestamp_end": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "End of the charged usage",
+	},
+}}
+
+func billingInvoiceLinesToSchema(lines []billingInvoiceLine) []map[string]interface{} {
+	r := make([]map[string]interface{}, 0, len(lines))
+	for _, l := range lines {
+		r = append(r, map[string]interface{}{
+			"line_type":        l.LineType,
+			"description":      l.Description,
+			"line_total_local": l.LineTotalLocal,
+			"local_currency":   l.LocalCurrency,
+			"line_total_usd":   l.LineTotalUSD,
+			"project_name":     l.ProjectName,
+			"service_name":     l.ServiceName,
+			"service_type":     l.ServiceType,
+			"service_plan":     l.ServicePlan,
+			"cloud_name":       l.CloudName,
+			"timestamp_begin":  l.TimestampBegin,
+			"timestamp_end":    l.TimestampEnd,
+		})
+	}
+	return r
+}
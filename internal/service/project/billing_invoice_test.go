@@ -0,0 +1,91 @@
+package project
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_listBillingGroupInvoices(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /v1/billing-group/bg1/invoice":
+			_, _ = w.Write([]byte(`{"invoices": [
+				{"invoice_number": "bg1-2", "state": "estimate", "currency": "EUR", "total_vat_zero": "10.50"},
+				{"invoice_number": "bg1-1", "state": "paid", "currency": "EUR", "total_vat_zero": "100.00"}
+			]}`))
+		case "GET /v1/billing-group/bg1/invoice/bg1-2/lines":
+			_, _ = w.Write([]byte(`{"lines": [
+				{"line_type": "service_charge", "line_total_local": "10.50", "project_name": "foo", "service_name": "pg"}
+			]}`))
+		case "GET /v1/billing-group/bg1/credits":
+			_, _ = w.Write([]byte(`{"credits": [{"code": "WELCOME", "type": "trial", "value": "300.00", "remaining_value": "250.00"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message": "not found"}`))
+		}
+	}))
+	defer srv.Close()
+	t.Setenv("AIVEN_WEB_URL", srv.URL)
+
+	ctx := context.Background()
+	client := &aiven.Client{APIKey: "token", Client: srv.Client()}
+
+	invoices, err := listBillingGroupInvoices(ctx, client, "bg1")
+	require.NoError(t, err)
+	require.Len(t, invoices, 2)
+
+	invoice, err := findBillingInvoice(invoices, "")
+	require.NoError(t, err)
+	assert.Equal(t, "bg1-2", invoice.InvoiceNumber)
+
+	invoice, err = findBillingInvoice(invoices, "bg1-1")
+	require.NoError(t, err)
+	assert.Equal(t, "100.00", invoice.TotalVATZero)
+
+	_, err = findBillingInvoice(invoices, "bg1-3")
+	assert.EqualError(t, err, "invoice bg1-3 not found")
+
+	lines, err := listBillingGroupInvoiceLines(ctx, client, "bg1", "bg1-2")
+	require.NoError(t, err)
+	assert.Equal(t, []billingInvoiceLine{{LineType: "service_charge", LineTotalLocal: "10.50", ProjectName: "foo", ServiceName: "pg"}}, lines)
+
+	credits, err := listBillingGroupCredits(ctx, client, "bg1")
+	require.NoError(t, err)
+	assert.Equal(t, "250.00", credits[0].RemainingValue)
+
+	_, err = listBillingGroupInvoices(ctx, client, "bg2")
+	assert.True(t, aiven.IsNotFound(err))
+}
+
+func Test_projectCost(t *testing.T) {
+	lines := []billingInvoiceLine{
+		{ProjectName: "foo", ServiceName: "pg", ServiceType: "pg", ServicePlan: "startup-4", LineTotalLocal: "10.10"},
+		{ProjectName: "foo", ServiceName: "pg", ServiceType: "pg", ServicePlan: "business-4", LineTotalLocal: "20.20"},
+		{ProjectName: "foo", ServiceName: "kafka", ServiceType: "kafka", ServicePlan: "business-4", LineTotalLocal: "0.1"},
+		{ProjectName: "foo", LineType: "support_charge", LineTotalLocal: "5"},
+		{ProjectName: "bar", ServiceName: "pg", LineTotalLocal: "1000"},
+	}
+
+	cost, err := projectCost(lines, "foo")
+	require.NoError(t, err)
+	assert.Equal(t, "35.40", cost.amount)
+	assert.Len(t, cost.lines, 4)
+	assert.Equal(t, []billingServiceCost{
+		{serviceName: "kafka", serviceType: "kafka", servicePlan: "business-4", amount: "0.10"},
+		{serviceName: "pg", serviceType: "pg", servicePlan: "business-4", amount: "30.30"},
+	}, cost.services)
+
+	cost, err = projectCost(lines, "baz")
+	require.NoError(t, err)
+	assert.Equal(t, "0.00", cost.amount)
+	assert.Empty(t, cost.services)
+
+	_, err = projectCost([]billingInvoiceLine{{ProjectName: "foo", LineTotalLocal: "n/a"}}, "foo")
+	assert.EqualError(t, err, `invalid amount "n/a"`)
+}
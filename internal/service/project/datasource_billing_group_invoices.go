@@ -0,0 +1,177 @@
+package project
+
+import (
+	"context"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DatasourceBillingGroupInvoices() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceBillingGroupInvoicesRead,
+		Description: "The Billing Group Invoices data source lists the invoices and the credits of an existing Aiven Billing Group, including the estimate of the ongoing billing period.",
+		Schema: map[string]*schema.Schema{
+			"billing_group_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Billing group id",
+			},
+			"include_lines": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Read the line items of every invoice, which takes a request per invoice",
+			},
+			"invoices": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Invoices of the billing group",
+				Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+					"invoice_number": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Invoice number",
+					},
+					"state": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Invoice state, `estimate` for the ongoing billing period",
+					},
+					"currency": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Currency of the invoice",
+					},
+					"period_begin": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Beginning of the billing period",
+					},
+					"period_end": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "End of the billing period",
+					},
+					"total_inc_vat": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Total amount including VAT",
+					},
+					"total_vat_zero": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Total amount excluding VAT",
+					},
+					"generated_at": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Time the invoice was generated",
+					},
+					"lines": {
+						Type:        schema.TypeList,
+						Computed:    true,
+						Description: "Line items of the invoice, only read when `include_lines` is set",
+						Elem:        billingInvoiceLineSchema,
+					},
+				}},
+			},
+			"credits": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Credits of the billing group",
+				Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+					"code": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Credit code",
+					},
+					"type": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Credit type",
+					},
+					"value": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Original value of the credit",
+					},
+					"remaining_value": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Value left of the credit",
+					},
+					"start_time": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Time the credit is valid from",
+					},
+					"expire_time": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Time the credit expires",
+					},
+				}},
+			},
+		},
+	}
+}
+
+func datasourceBillingGroupInvoicesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+	billingGroupID := d.Get("billing_group_id").(string)
+
+	invoices, err := listBillingGroupInvoices(ctx, client, billingGroupID)
+	if err != nil {
+		return diag.Errorf("cannot get billing group %s invoices: %s", billingGroupID, err)
+	}
+
+	credits, err := listBillingGroupCredits(ctx, client, billingGroupID)
+	if err != nil {
+		return diag.Errorf("cannot get billing group %s credits: %s", billingGroupID, err)
+	}
+
+	var invoiceList []map[string]interface{}
+	for _, i := range invoices {
+		var lines []billingInvoiceLine
+		if d.Get("include_lines").(bool) {
+			if lines, err = listBillingGroupInvoiceLines(ctx, client, billingGroupID, i.InvoiceNumber); err != nil {
+				return diag.Errorf("cannot get invoice %s lines: %s", i.InvoiceNumber, err)
+			}
+		}
+
+		invoiceList = append(invoiceList, map[string]interface{}{
+			"invoice_number": i.InvoiceNumber,
+			"state":          i.State,
+			"currency":       i.Currency,
+			"period_begin":   i.PeriodBegin,
+			"period_end":     i.PeriodEnd,
+			"total_inc_vat":  i.TotalIncVAT,
+			"total_vat_zero": i.TotalVATZero,
+			"generated_at":   i.GeneratedAt,
+			"lines":          billingInvoiceLinesToSchema(lines),
+		})
+	}
+
+	var creditList []map[string]interface{}
+	for _, c := range credits {
+		creditList = append(creditList, map[string]interface{}{
+			"code":            c.Code,
+			"type":            c.Type,
+			"value":           c.Value,
+			"remaining_value": c.RemainingValue,
+			"start_time":      c.StartTime,
+			"expire_time":     c.ExpireTime,
+		})
+	}
+
+	d.SetId(billingGroupID)
+	if err := d.Set("invoices", invoiceList); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("credits", creditList); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package project_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	acc "github.com/aiven/terraform-provider-aiven/internal/acctest"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAivenBillingGroupInvoicesDataSource_basic(t *testing.T) {
+	datasourceName := "data.aiven_billing_group_invoices.invoices"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acc.TestAccPreCheck(t) },
+		ProviderFactories: acc.TestAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBillingGroupResource(rName) + `
+data "aiven_billing_group_invoices" "invoices" {
+  billing_group_id = aiven_billing_group.foo.id
+  include_lines    = true
+
+  depends_on = [aiven_project.pr1]
+}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(datasourceName, "billing_group_id", "aiven_billing_group.foo", "id"),
+					resource.TestCheckResourceAttrSet(datasourceName, "invoices.#"),
+					resource.TestCheckResourceAttrSet(datasourceName, "credits.#"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAivenProjectCostDataSource_basic(t *testing.T) {
+	datasourceName := "data.aiven_project_cost.cost"
+	projectName := os.Getenv("AIVEN_PROJECT_NAME")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acc.TestAccPreCheck(t) },
+		ProviderFactories: acc.TestAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+data "aiven_project_cost" "cost" {
+  project = "%s"
+}`, projectName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(datasourceName, "project", projectName),
+					resource.TestCheckResourceAttr(datasourceName, "state", "estimate"),
+					resource.TestCheckResourceAttrSet(datasourceName, "billing_group_id"),
+					resource.TestCheckResourceAttrSet(datasourceName, "invoice_number"),
+					resource.TestCheckResourceAttrSet(datasourceName, "amount"),
+					resource.TestCheckResourceAttrSet(datasourceName, "currency"),
+				),
+			},
+		},
+	})
+}
@@ -14,10 +14,18 @@ func DatasourceProject() *schema.Resource {
 	return &schema.Resource{
 		ReadContext: datasourceProjectRead,
 		Description: "The Project data source provides information about the existing Aiven Project.",
-		Schema:      schemautil.ResourceSchemaAsDatasourceSchema(aivenProjectSchema, "project"),
+		Schema:      datasourceProjectSchema(),
 	}
 }
 
+// datasourceProjectSchema is the schema of the resource without the safeguards, they only apply to the resource
+func datasourceProjectSchema() map[string]*schema.Schema {
+	s := schemautil.ResourceSchemaAsDatasourceSchema(aivenProjectSchema, "project")
+	delete(s, "termination_protection")
+	delete(s, "force_destroy")
+	return s
+}
+
 func datasourceProjectRead(c context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*aiven.Client)
 
@@ -31,7 +39,12 @@ func datasourceProjectRead(c context.Context, d *schema.ResourceData, m interfac
 	for _, project := range projects {
 		if project.Name == projectName {
 			d.SetId(projectName)
-			return resourceProjectRead(c, d, m)
+
+			p, err := client.Projects.Get(projectName)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			return setProjectTerraformProperties(d, client, p)
 		}
 	}
 
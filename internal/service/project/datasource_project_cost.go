@@ -0,0 +1,185 @@
+package project
+
+import (
+	"context"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+)
+
+func DatasourceProjectCost() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceProjectCostRead,
+		Description: `
+The Project Cost data source computes the cost of an existing Aiven Project in a billing period from the invoice lines
+of its billing group, in total and per service. By default the estimate of the ongoing billing period is used.
+`,
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Project name",
+			},
+			"invoice_number": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Invoice of the billing period. By default the estimate of the ongoing billing period is used.",
+			},
+			"billing_group_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Billing group of the project",
+			},
+			"state": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Invoice state, `estimate` for the ongoing billing period",
+			},
+			"period_begin": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Beginning of the billing period",
+			},
+			"period_end": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "End of the billing period",
+			},
+			"currency": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Currency of the amounts",
+			},
+			"amount": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Cost of the project in the billing period, excluding VAT",
+			},
+			"estimated_balance": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The current accumulated bill of the project, as in `aiven_project`",
+			},
+			"services": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Cost of every service of the project in the billing period, sorted by service name",
+				Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+					"service_name": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Service name",
+					},
+					"service_type": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Service type",
+					},
+					"service_plan": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Latest service plan of the billing period",
+					},
+					"cloud_name": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Latest cloud of the service in the billing period",
+					},
+					"amount": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Cost of the service in the billing period",
+					},
+				}},
+			},
+			"lines": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Invoice line items of the project",
+				Elem:        billingInvoiceLineSchema,
+			},
+		},
+	}
+}
+
+func datasourceProjectCostRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+	projectName := d.Get("project").(string)
+
+	p, err := client.Projects.Get(projectName)
+	if err != nil {
+		return diag.Errorf("cannot get project %s: %s", projectName, err)
+	}
+	if p.BillingGroupId == "" {
+		return diag.Errorf("project %s has no billing group", projectName)
+	}
+
+	invoices, err := listBillingGroupInvoices(ctx, client, p.BillingGroupId)
+	if err != nil {
+		return diag.Errorf("cannot get billing group %s invoices: %s", p.BillingGroupId, err)
+	}
+
+	invoice, err := findBillingInvoice(invoices, d.Get("invoice_number").(string))
+	if err != nil {
+		return diag.Errorf("billing group %s: %s", p.BillingGroupId, err)
+	}
+
+	lines, err := listBillingGroupInvoiceLines(ctx, client, p.BillingGroupId, invoice.InvoiceNumber)
+	if err != nil {
+		return diag.Errorf("cannot get invoice %s lines: %s", invoice.InvoiceNumber, err)
+	}
+
+	cost, err := projectCost(lines, projectName)
+	if err != nil {
+		return diag.Errorf("invoice %s: %s", invoice.InvoiceNumber, err)
+	}
+
+	var services []map[string]interface{}
+	for _, s := range cost.services {
+		services = append(services, map[string]interface{}{
+			"service_name": s.serviceName,
+			"service_type": s.serviceType,
+			"service_plan": s.servicePlan,
+			"cloud_name":   s.cloudName,
+			"amount":       s.amount,
+		})
+	}
+
+	d.SetId(schemautil.BuildResourceID(projectName, invoice.InvoiceNumber))
+	if err := d.Set("invoice_number", invoice.InvoiceNumber); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("billing_group_id", p.BillingGroupId); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("state", invoice.State); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("period_begin", invoice.PeriodBegin); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("period_end", invoice.PeriodEnd); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("currency", invoice.Currency); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("amount", cost.amount); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("estimated_balance", p.EstimatedBalance); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("services", services); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("lines", billingInvoiceLinesToSchema(cost.lines)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,199 @@
+package project
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+)
+
+// projectInventory is what is left in a project when it's about to be deleted. Terraform destroys the
+// resources depending on the project first, so these are not managed by the configuration of the project.
+type projectInventory struct {
+	services  []*aiven.Service
+	vpcs      []*aiven.VPC
+	endpoints []*aiven.ServiceIntegrationEndpoint
+	staticIPs []aiven.StaticIP
+}
+
+func getProjectInventory(client *aiven.Client, project string) (*projectInventory, error) {
+	services, err := client.Services.List(project)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list services: %w", err)
+	}
+
+	vpcs, err := client.VPCs.List(project)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list VPCs: %w", err)
+	}
+
+	endpoints, err := client.ServiceIntegrationEndpoints.List(project)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list service integration endpoints: %w", err)
+	}
+
+	staticIPs, err := client.StaticIPs.List(project)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list static IPs: %w", err)
+	}
+
+	inv := &projectInventory{services: services, endpoints: endpoints}
+	for _, v := range vpcs {
+		if v.State != "DELETED" {
+			inv.vpcs = append(inv.vpcs, v)
+		}
+	}
+	for _, ip := range staticIPs.StaticIPs {
+		// the static IPs destroyed just before the project may still be listed for a while
+		if ip.State != "deleting" && ip.State != "deleted" {
+			inv.staticIPs = append(inv.staticIPs, ip)
+		}
+	}
+	return inv, nil
+}
+
+func (i *projectInventory) empty() bool {
+	return len(i.services)+len(i.vpcs)+len(i.endpoints)+len(i.staticIPs) == 0
+}
+
+// protectedServices returns the services with termination protection, force_destroy doesn't override it
+func (i *projectInventory) protectedServices() []string {
+	var r []string
+	for _, s := range i.services {
+		if s.TerminationProtection {
+			r = append(r, s.Name)
+		}
+	}
+	sort.Strings(r)
+	return r
+}
+
+// String lists the inventory in the order it's torn down
+func (i *projectInventory) String() string {
+	var services, vpcs, endpoints, staticIPs []string
+	for _, s := range i.services {
+		services = append(services, fmt.Sprintf("%s (%s)", s.Name, s.Type))
+	}
+	for _, v := range i.vpcs {
+		vpcs = append(vpcs, fmt.Sprintf("%s (%s %s, %d peering connections)", v.ProjectVPCID, v.CloudName, v.NetworkCIDR, len(v.PeeringConnections)))
+	}
+	for _, e := range i.endpoints {
+		endpoints = append(endpoints, fmt.Sprintf("%s (%s)", e.EndpointName, e.EndpointType))
+	}
+	for _, ip := range i.staticIPs {
+		staticIPs = append(staticIPs, fmt.Sprintf("%s (%s)", ip.StaticIPAddressID, ip.IPAddress))
+	}
+
+	var r []string
+	for _, kind := range []struct {
+		name  string
+		items []string
+	}{
+		{"services", services},
+		{"service integration endpoints", endpoints},
+		{"static IPs", staticIPs},
+		{"VPCs", vpcs},
+	} {
+		if len(kind.items) == 0 {
+			continue
+		}
+		sort.Strings(kind.items)
+		r = append(r, fmt.Sprintf("%d %s: %s", len(kind.items), kind.name, strings.Join(kind.items, ", ")))
+	}
+	return strings.Join(r, "; ")
+}
+
+// destroyProjectInventory tears down the inventory in dependency order: the services with their integrations,
+// then the integration endpoints and the static IPs they used, and finally the VPCs with their peering connections
+func destroyProjectInventory(ctx context.Context, client *aiven.Client, project string, inv *projectInventory, timeout time.Duration) error {
+	if protected := inv.protectedServices(); len(protected) > 0 {
+		return fmt.Errorf("services with termination protection can't be force destroyed: %s", strings.Join(protected, ", "))
+	}
+
+	for _, s := range inv.services {
+		log.Printf("[DEBUG] Force destroying service %s of project %s", s.Name, project)
+		if err := client.Services.Delete(project, s.Name); err != nil && !aiven.IsNotFound(err) {
+			return fmt.Errorf("cannot delete service %s: %w", s.Name, err)
+		}
+	}
+
+	if len(inv.services) > 0 {
+		if err := waitForProjectEmpty(ctx, "services", timeout, func() (int, error) {
+			services, err := client.Services.List(project)
+			return len(services), err
+		}); err != nil {
+			return err
+		}
+	}
+
+	for _, e := range inv.endpoints {
+		log.Printf("[DEBUG] Force destroying service integration endpoint %s of project %s", e.EndpointName, project)
+		if err := client.ServiceIntegrationEndpoints.Delete(project, e.EndpointID); err != nil && !aiven.IsNotFound(err) {
+			return fmt.Errorf("cannot delete service integration endpoint %s: %w", e.EndpointName, err)
+		}
+	}
+
+	for _, ip := range inv.staticIPs {
+		log.Printf("[DEBUG] Force destroying static IP %s of project %s", ip.StaticIPAddressID, project)
+		if ip.State == schemautil.StaticIpAvailable {
+			if err := client.StaticIPs.Dissociate(project, ip.StaticIPAddressID); err != nil && !aiven.IsNotFound(err) {
+				return fmt.Errorf("cannot dissociate static IP %s: %w", ip.StaticIPAddressID, err)
+			}
+		}
+		err := client.StaticIPs.Delete(project, aiven.DeleteStaticIPRequest{StaticIPAddressID: ip.StaticIPAddressID})
+		if err != nil && !aiven.IsNotFound(err) {
+			return fmt.Errorf("cannot delete static IP %s: %w", ip.StaticIPAddressID, err)
+		}
+	}
+
+	for _, v := range inv.vpcs {
+		log.Printf("[DEBUG] Force destroying VPC %s of project %s", v.ProjectVPCID, project)
+		if err := client.VPCs.Delete(project, v.ProjectVPCID); err != nil && !aiven.IsNotFound(err) {
+			return fmt.Errorf("cannot delete VPC %s: %w", v.ProjectVPCID, err)
+		}
+	}
+
+	if len(inv.vpcs) > 0 {
+		return waitForProjectEmpty(ctx, "VPCs", timeout, func() (int, error) {
+			inv, err := getProjectInventory(client, project)
+			if err != nil {
+				return 0, err
+			}
+			return len(inv.vpcs), nil
+		})
+	}
+
+	return nil
+}
+
+// waitForProjectEmpty waits until count returns zero
+func waitForProjectEmpty(ctx context.Context, kind string, timeout time.Duration, count func() (int, error)) error {
+	conf := &resource.StateChangeConf{
+		Pending: []string{"deleting"},
+		Target:  []string{"deleted"},
+		Refresh: func() (interface{}, string, error) {
+			n, err := count()
+			if err != nil {
+				return nil, "", err
+			}
+			if n > 0 {
+				return n, "deleting", nil
+			}
+			return n, "deleted", nil
+		},
+		Delay:      5 * time.Second,
+		Timeout:    timeout,
+		MinTimeout: 5 * time.Second,
+	}
+	if _, err := conf.WaitForStateContext(ctx); err != nil {
+		return fmt.Errorf("error waiting for the %s to be deleted: %w", kind, err)
+	}
+	return nil
+}
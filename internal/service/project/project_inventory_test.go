@@ -0,0 +1,35 @@
+package project
+
+import (
+	"testing"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_projectInventory(t *testing.T) {
+	inv := &projectInventory{}
+	assert.True(t, inv.empty())
+	assert.Equal(t, "", inv.String())
+
+	inv = &projectInventory{
+		services: []*aiven.Service{
+			{Name: "pg1", Type: "pg", TerminationProtection: true},
+			{Name: "kafka1", Type: "kafka"},
+		},
+		vpcs: []*aiven.VPC{
+			{ProjectVPCID: "vpc1", CloudName: "google-europe-west1", NetworkCIDR: "10.0.0.0/24", PeeringConnections: []*aiven.VPCPeeringConnection{{}}},
+		},
+		endpoints: []*aiven.ServiceIntegrationEndpoint{{EndpointName: "datadog", EndpointType: "datadog"}},
+		staticIPs: []aiven.StaticIP{{StaticIPAddressID: "ip1", IPAddress: "1.2.3.4"}},
+	}
+	assert.False(t, inv.empty())
+	assert.Equal(t, []string{"pg1"}, inv.protectedServices())
+	assert.Equal(t,
+		"2 services: kafka1 (kafka), pg1 (pg); "+
+			"1 service integration endpoints: datadog (datadog); "+
+			"1 static IPs: ip1 (1.2.3.4); "+
+			"1 VPCs: vpc1 (google-europe-west1 10.0.0.0/24, 1 peering connections)",
+		inv.String(),
+	)
+}
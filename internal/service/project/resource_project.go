@@ -2,9 +2,11 @@ package project
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"os"
 	"regexp"
+	"time"
 
 	"github.com/aiven/aiven-go-client"
 	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
@@ -88,6 +90,20 @@ var aivenProjectSchema = map[string]*schema.Schema{
 		},
 	},
 
+	"termination_protection": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: schemautil.Complex("Prevents the project from being deleted by Terraform. It's enforced by the provider, the project can still be deleted outside of Terraform.").DefaultValue(false).Build(),
+	},
+	"force_destroy": {
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+		Description: schemautil.Complex("The project is only deleted when it has no services, VPCs, service integration endpoints and static IPs left " +
+			"that are not managed by Terraform. Set to `true` to delete them with the project, services with termination protection are never deleted.").DefaultValue(false).Build(),
+	},
+
 	// computed fields
 	"payment_method": {
 		Type:        schema.TypeString,
@@ -117,6 +133,9 @@ func ResourceProject() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
 
 		Schema: aivenProjectSchema,
 		CustomizeDiff: customdiff.IfValueChange("tag",
@@ -228,6 +247,16 @@ func resourceProjectRead(_ context.Context, d *schema.ResourceData, m interface{
 		return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
 	}
 
+	// the safeguards are not stored in the API, the defaults are set when they are not in the state, e.g. after an
+	// import or an upgrade from a provider version without them
+	for _, k := range []string{"termination_protection", "force_destroy"} {
+		if _, ok := d.GetOk(k); !ok {
+			if err := d.Set(k, false); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+
 	return setProjectTerraformProperties(d, client, project)
 }
 
@@ -262,29 +291,55 @@ func resourceProjectUpdate(_ context.Context, d *schema.ResourceData, m interfac
 	return nil
 }
 
-func resourceProjectDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+func resourceProjectDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*aiven.Client)
 
-	err := client.Projects.Delete(d.Id())
+	if d.Get("termination_protection").(bool) {
+		return diag.Errorf("project %s has termination_protection enabled, disable it before destroying the project", d.Id())
+	}
+
+	inv, err := getProjectInventory(client, d.Id())
+	if err != nil && !aiven.IsNotFound(err) {
+		return diag.Errorf("cannot get project %s inventory: %s", d.Id(), err)
+	}
+
+	var diags diag.Diagnostics
+	if inv != nil && !inv.empty() {
+		if !d.Get("force_destroy").(bool) {
+			return diag.Errorf("project %s still has resources which are not managed by this configuration, "+
+				"delete them or set force_destroy to delete them with the project: %s", d.Id(), inv)
+		}
+
+		if err := destroyProjectInventory(ctx, client, d.Id(), inv, d.Timeout(schema.TimeoutDelete)); err != nil {
+			return diag.Errorf("cannot force destroy project %s resources: %s", d.Id(), err)
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Unmanaged project resources destroyed",
+			Detail:   fmt.Sprintf("force_destroy deleted the resources of project %s: %s", d.Id(), inv),
+		})
+	}
+
+	err = client.Projects.Delete(d.Id())
 
 	// Silence "Project with open balance cannot be deleted" error
 	// to make long acceptance tests pass which generate some balance
 	re := regexp.MustCompile("Project with open balance cannot be deleted")
 	if err != nil && os.Getenv("TF_ACC") != "" {
 		if re.MatchString(err.Error()) && err.(aiven.Error).Status == 403 {
-			return nil
+			return diags
 		}
 	}
 
 	if err != nil {
 		if aiven.IsNotFound(err) {
-			return nil
+			return diags
 		}
 
-		return diag.FromErr(err)
+		return append(diags, diag.FromErr(err)...)
 	}
 
-	return nil
+	return diags
 }
 
 func resourceProjectGetCACert(project string, client *aiven.Client, d *schema.ResourceData) diag.Diagnostics {
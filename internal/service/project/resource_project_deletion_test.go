@@ -0,0 +1,78 @@
+package project_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/aiven/aiven-go-client"
+	acc "github.com/aiven/terraform-provider-aiven/internal/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAivenProject_terminationProtection(t *testing.T) {
+	resourceName := "aiven_project.foo"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acc.TestAccPreCheck(t) },
+		ProviderFactories: acc.TestAccProviderFactories,
+		CheckDestroy:      testAccCheckAivenProjectResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProjectDeletionResource(rName, true, false),
+				Check:  resource.TestCheckResourceAttr(resourceName, "termination_protection", "true"),
+			},
+			{
+				Config:      testAccProjectDeletionResource(rName, true, false),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile("has termination_protection enabled"),
+			},
+			{
+				Config: testAccProjectDeletionResource(rName, false, false),
+				Check:  resource.TestCheckResourceAttr(resourceName, "termination_protection", "false"),
+			},
+		},
+	})
+}
+
+func TestAccAivenProject_forceDestroy(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	projectName := fmt.Sprintf("test-acc-pr-%s", rName)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acc.TestAccPreCheck(t) },
+		ProviderFactories: acc.TestAccProviderFactories,
+		CheckDestroy:      testAccCheckAivenProjectResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProjectDeletionResource(rName, false, false),
+			},
+			{
+				// a static IP which is not in the configuration
+				PreConfig: func() {
+					c := acc.TestAccProvider.Meta().(*aiven.Client)
+					if _, err := c.StaticIPs.Create(projectName, aiven.CreateStaticIPRequest{CloudName: "google-europe-west1"}); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:      testAccProjectDeletionResource(rName, false, false),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(`1 static IPs: `),
+			},
+			{
+				Config: testAccProjectDeletionResource(rName, false, true),
+			},
+		},
+	})
+}
+
+func testAccProjectDeletionResource(name string, terminationProtection, forceDestroy bool) string {
+	return fmt.Sprintf(`
+resource "aiven_project" "foo" {
+  project                = "test-acc-pr-%s"
+  termination_protection = %t
+  force_destroy          = %t
+}`, name, terminationProtection, forceDestroy)
+}
@@ -0,0 +1,14 @@
+package service
+
+import (
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DatasourceService() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: schemautil.DatasourceGenericServiceRead,
+		Description: "The Service data source provides information about an existing Aiven service of any service type.",
+		Schema:      schemautil.ResourceSchemaAsDatasourceSchema(aivenServiceSchema(), "project", "service_name"),
+	}
+}
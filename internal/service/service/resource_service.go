@@ -0,0 +1,85 @@
+package service
+
+import (
+	"time"
+
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func aivenServiceSchema() map[string]*schema.Schema {
+	s := schemautil.ServiceCommonSchema()
+	s["service_type"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Required:     true,
+		ForceNew:     true,
+		ValidateFunc: validation.StringIsNotEmpty,
+		Description:  schemautil.Complex("Aiven internal service type code, e.g. `pg` or `kafka`. Service types without a dedicated resource can be used as well.").ForceNew().Build(),
+	}
+	s["user_config"] = &schema.Schema{
+		Type:             schema.TypeString,
+		Optional:         true,
+		ValidateFunc:     validation.StringIsJSON,
+		DiffSuppressFunc: schemautil.GenericServiceUserConfigDiffSuppressFunc,
+		Description: "JSON encoded user configurable settings of the service, the same options as the " +
+			"`<service_type>_user_config` block of the dedicated resource. It is validated against the user config " +
+			"schema of the service type when the provider knows it. Only the options set here are tracked.",
+	}
+
+	return s
+}
+
+func ResourceService() *schema.Resource {
+	return &schema.Resource{
+		Description: "The Service resource allows the creation and management of Aiven services of any service type. " +
+			"Its state is not the one of the dedicated service resources, moving a service between `aiven_service` " +
+			"and e.g. `aiven_pg` needs a `terraform state rm` and an import, see the importing resources guide.",
+		CreateContext: schemautil.ResourceGenericServiceCreate,
+		ReadContext:   schemautil.ResourceGenericServiceRead,
+		UpdateContext: schemautil.ResourceGenericServiceUpdate,
+		DeleteContext: schemautil.ResourceServiceDelete,
+		CustomizeDiff: customdiff.Sequence(
+			schemautil.CustomizeDiffGenericServiceUserConfig,
+			customdiff.IfValueChange("tag",
+				schemautil.TagsShouldNotBeEmpty,
+				schemautil.CustomizeDiffCheckUniqueTag,
+			),
+			customdiff.IfValueChange("disk_space",
+				schemautil.DiskSpaceShouldNotBeEmpty,
+				schemautil.CustomizeDiffCheckDiskSpace,
+			),
+			customdiff.IfValueChange("additional_disk_space",
+				schemautil.DiskSpaceShouldNotBeEmpty,
+				schemautil.CustomizeDiffCheckDiskSpace,
+			),
+			customdiff.IfValueChange("project_vpc_id",
+				schemautil.ProjectVPCShouldNotBeEmpty,
+				schemautil.CustomizeDiffCheckProjectVPCCapacity,
+			),
+			customdiff.IfValueChange("plan",
+				schemautil.PlanShouldNotBeEmpty,
+				schemautil.CustomizeDiffCheckProjectVPCCapacity,
+			),
+			customdiff.IfValueChange("service_integrations",
+				schemautil.ServiceIntegrationShouldNotBeEmpty,
+				schemautil.CustomizeDiffServiceIntegrationAfterCreation,
+			),
+			customdiff.Sequence(
+				schemautil.CustomizeDiffCheckStaticIpDisassociation,
+				schemautil.CustomizeDiffCheckPlanAndStaticIpsCannotBeModifiedTogether,
+			),
+		),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+
+		Schema: aivenServiceSchema(),
+	}
+}
@@ -0,0 +1,130 @@
+package service_test
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	acc "github.com/aiven/terraform-provider-aiven/internal/acctest"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccAiven_service(t *testing.T) {
+	resourceName := "aiven_service.bar"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acc.TestAccPreCheck(t) },
+		ProviderFactories: acc.TestAccProviderFactories,
+		CheckDestroy:      acc.TestAccCheckAivenServiceResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:             testAccServiceResource(rName, `{ redis_maxmemory_policy = "most-recent" }`),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+				ExpectError:        regexp.MustCompile(`user_config.redis_maxmemory_policy: expected one of`),
+			},
+			{
+				Config:             testAccServiceResource(rName, `{ redis_foo = 1 }`),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+				ExpectError:        regexp.MustCompile(`user_config.redis_foo: unknown user config option`),
+			},
+			{
+				Config: testAccServiceResource(rName, `{
+    redis_maxmemory_policy = "allkeys-random"
+    public_access          = { redis = true }
+  }`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "service_name", fmt.Sprintf("test-acc-sr-%s", rName)),
+					resource.TestCheckResourceAttr(resourceName, "project", os.Getenv("AIVEN_PROJECT_NAME")),
+					resource.TestCheckResourceAttr(resourceName, "service_type", "redis"),
+					resource.TestCheckResourceAttr(resourceName, "state", "RUNNING"),
+					resource.TestCheckResourceAttr(resourceName, "user_config", `{"public_access":{"redis":true},"redis_maxmemory_policy":"allkeys-random"}`),
+					resource.TestCheckResourceAttrSet(resourceName, "service_host"),
+					resource.TestCheckResourceAttrSet(resourceName, "service_port"),
+					resource.TestCheckResourceAttrSet("data.aiven_service.common", "user_config"),
+				),
+			},
+			{
+				Config: testAccServiceResource(rName, `{
+    redis_maxmemory_policy = "volatile-lru"
+    public_access          = { redis = true }
+  }`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "user_config", `{"public_access":{"redis":true},"redis_maxmemory_policy":"volatile-lru"}`),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"user_config"},
+			},
+			{
+				// the service is moved to the dedicated resource by importing it, see the importing resources guide
+				Config: testAccServiceResource(rName, `{
+    redis_maxmemory_policy = "volatile-lru"
+    public_access          = { redis = true }
+  }`) + testAccServiceMovedResource(rName),
+				ResourceName:  "aiven_redis.moved",
+				ImportState:   true,
+				ImportStateId: fmt.Sprintf("%s/test-acc-sr-%s", os.Getenv("AIVEN_PROJECT_NAME"), rName),
+				ImportStateCheck: func(s []*terraform.InstanceState) error {
+					if len(s) != 1 {
+						return fmt.Errorf("expected one imported service, got %d", len(s))
+					}
+					attributes := s[0].Attributes
+					if attributes["service_type"] != "redis" {
+						return fmt.Errorf("expected service_type redis, got %q", attributes["service_type"])
+					}
+					if attributes["redis_user_config.0.redis_maxmemory_policy"] != "volatile-lru" {
+						return fmt.Errorf("expected the user config in redis_user_config, got %q", attributes["redis_user_config.0.redis_maxmemory_policy"])
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func testAccServiceMovedResource(name string) string {
+	return fmt.Sprintf(`
+
+resource "aiven_redis" "moved" {
+  project      = data.aiven_project.foo.project
+  cloud_name   = "google-europe-west1"
+  plan         = "startup-4"
+  service_name = "test-acc-sr-%s"
+}`, name)
+}
+
+func testAccServiceResource(name, userConfig string) string {
+	return fmt.Sprintf(`
+data "aiven_project" "foo" {
+  project = "%s"
+}
+
+resource "aiven_service" "bar" {
+  project                 = data.aiven_project.foo.project
+  service_type            = "redis"
+  cloud_name              = "google-europe-west1"
+  plan                    = "startup-4"
+  service_name            = "test-acc-sr-%s"
+  maintenance_window_dow  = "monday"
+  maintenance_window_time = "10:00:00"
+
+  user_config = jsonencode(%s)
+}
+
+data "aiven_service" "common" {
+  service_name = aiven_service.bar.service_name
+  project      = aiven_service.bar.project
+
+  depends_on = [aiven_service.bar]
+}`, os.Getenv("AIVEN_PROJECT_NAME"), name, userConfig)
+}
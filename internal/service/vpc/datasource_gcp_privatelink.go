@@ -0,0 +1,33 @@
+package vpc
+
+import (
+	"context"
+
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DatasourceGCPPrivatelink() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceGCPPrivatelinkRead,
+		Description: "The GCP Privatelink data source provides information about the existing Aiven GCP Private Service Connect service attachment of a service.",
+		Schema:      schemautil.ResourceSchemaAsDatasourceSchema(aivenGCPPrivatelinkSchema, "project", "service_name"),
+	}
+}
+
+func datasourceGCPPrivatelinkRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	projectName := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+	d.SetId(schemautil.BuildResourceID(projectName, serviceName))
+
+	if diags := resourceGCPPrivatelinkRead(ctx, d, m); diags.HasError() {
+		return diags
+	}
+	if d.Id() == "" {
+		return diag.Errorf("GCP privatelink of service %s/%s not found", projectName, serviceName)
+	}
+
+	return nil
+}
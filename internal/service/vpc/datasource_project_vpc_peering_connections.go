@@ -0,0 +1,150 @@
+package vpc
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DatasourceProjectVPCPeeringConnections() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceProjectVPCPeeringConnectionsRead,
+		Description: "The Project VPC Peering Connections data source lists the peering connections of a project VPC, with the resource type and ID to import each of them with.",
+		Schema: map[string]*schema.Schema{
+			"vpc_id": {
+				Required:     true,
+				Type:         schema.TypeString,
+				Description:  "The VPC the peering connections belong to.",
+				ValidateFunc: validateVPCID,
+			},
+			"peering_connections": {
+				Computed:    true,
+				Type:        schema.TypeList,
+				Description: "Peering connections of the project VPC",
+				Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+					"peer_cloud_account": {
+						Computed:    true,
+						Type:        schema.TypeString,
+						Description: "AWS account ID, GCP project ID or Azure subscription ID of the peered VPC",
+					},
+					"peer_vpc": {
+						Computed:    true,
+						Type:        schema.TypeString,
+						Description: "AWS VPC ID, GCP VPC network name or Azure VNet name of the peered VPC",
+					},
+					"peer_region": {
+						Computed:    true,
+						Type:        schema.TypeString,
+						Description: "Region of the peered VPC if not in the same region as the project VPC",
+					},
+					"peer_resource_group": {
+						Computed:    true,
+						Type:        schema.TypeString,
+						Description: "Azure resource group name of the peered VPC",
+					},
+					"user_peer_network_cidrs": {
+						Computed:    true,
+						Type:        schema.TypeList,
+						Elem:        &schema.Schema{Type: schema.TypeString},
+						Description: "Private IPv4 ranges routed through a transit gateway attachment",
+					},
+					"state": {
+						Computed:    true,
+						Type:        schema.TypeString,
+						Description: "State of the peering connection",
+					},
+					"state_info": {
+						Computed:    true,
+						Type:        schema.TypeMap,
+						Elem:        &schema.Schema{Type: schema.TypeString},
+						Description: "State-specific help or error information",
+					},
+					"resource_type": {
+						Computed:    true,
+						Type:        schema.TypeString,
+						Description: "Resource type to manage the peering connection with",
+					},
+					"import_id": {
+						Computed:    true,
+						Type:        schema.TypeString,
+						Description: "ID to import the peering connection into `resource_type` with",
+					},
+				}},
+			},
+		},
+	}
+}
+
+func datasourceProjectVPCPeeringConnectionsRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName, vpcID, err := schemautil.SplitResourceID2(d.Get("vpc_id").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	vpc, err := client.VPCs.Get(projectName, vpcID)
+	if err != nil {
+		return diag.Errorf("Error getting project VPC %s: %s", d.Get("vpc_id"), err)
+	}
+
+	peeringConnections := make([]map[string]interface{}, 0, len(vpc.PeeringConnections))
+	for _, pc := range vpc.PeeringConnections {
+		var peerRegion string
+		if pc.PeerRegion != nil {
+			peerRegion = *pc.PeerRegion
+		}
+
+		resourceType, importID := vpcPeeringConnectionResourceType(projectName, vpc, pc)
+		peeringConnections = append(peeringConnections, map[string]interface{}{
+			"peer_cloud_account":      pc.PeerCloudAccount,
+			"peer_vpc":                pc.PeerVPC,
+			"peer_region":             peerRegion,
+			"peer_resource_group":     pc.PeerResourceGroup,
+			"user_peer_network_cidrs": pc.UserPeerNetworkCIDRs,
+			"state":                   pc.State,
+			"state_info":              ConvertStateInfoToMap(pc.StateInfo),
+			"resource_type":           resourceType,
+			"import_id":               importID,
+		})
+	}
+
+	d.SetId(schemautil.BuildResourceID(projectName, vpcID))
+	if err := d.Set("peering_connections", peeringConnections); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// vpcPeeringConnectionResourceType returns the cloud specific resource type to manage a peering
+// connection with and its import ID, the type is guessed from the cloud of the project VPC
+func vpcPeeringConnectionResourceType(projectName string, vpc *aiven.VPC, pc *aiven.VPCPeeringConnection) (string, string) {
+	p := &peeringVPCID{
+		projectName:      projectName,
+		vpcID:            vpc.ProjectVPCID,
+		peerCloudAccount: pc.PeerCloudAccount,
+		peerVPC:          pc.PeerVPC,
+	}
+
+	switch {
+	case len(pc.UserPeerNetworkCIDRs) > 0:
+		return "aiven_transit_gateway_vpc_attachment", p.resourceID()
+	case pc.PeerResourceGroup != "" || strings.HasPrefix(vpc.CloudName, "azure-"):
+		return "aiven_azure_vpc_peering_connection", p.resourceID()
+	case strings.HasPrefix(vpc.CloudName, "google-"):
+		return "aiven_gcp_vpc_peering_connection", p.resourceID()
+	case strings.HasPrefix(vpc.CloudName, "aws-"):
+		if pc.PeerRegion != nil && *pc.PeerRegion != "" {
+			p.peerRegion = pc.PeerRegion
+		}
+		return "aiven_aws_vpc_peering_connection", p.resourceID()
+	}
+
+	return "aiven_vpc_peering_connection", p.resourceID()
+}
@@ -0,0 +1,79 @@
+package vpc
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/aiven/aiven-go-client"
+
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+)
+
+// aiven-go-client has no Google Cloud Private Service Connect support yet, the endpoints are
+// called with schemautil.APIRequest
+
+// gcpPrivatelink is the Private Service Connect service attachment of a service
+type gcpPrivatelink struct {
+	GoogleServiceAttachment string `json:"google_service_attachment"`
+	Message                 string `json:"message"`
+	State                   string `json:"state"`
+}
+
+// gcpPrivatelinkConnection is a Private Service Connect endpoint connected to a service attachment
+type gcpPrivatelinkConnection struct {
+	PrivatelinkConnectionID string `json:"privatelink_connection_id"`
+	PSCConnectionID         string `json:"psc_connection_id"`
+	State                   string `json:"state"`
+	UserIPAddress           string `json:"user_ip_address"`
+}
+
+type gcpPrivatelinkConnectionApproveRequest struct {
+	UserIPAddress string `json:"user_ip_address"`
+}
+
+func gcpPrivatelinkPath(project, serviceName string, parts ...string) string {
+	return schemautil.BuildAPIPath(append([]string{"project", project, "service", serviceName, "privatelink", "google"}, parts...)...)
+}
+
+func createGCPPrivatelink(ctx context.Context, client *aiven.Client, project, serviceName string) (*gcpPrivatelink, error) {
+	var pl gcpPrivatelink
+	err := schemautil.APIRequest(ctx, client, http.MethodPost, gcpPrivatelinkPath(project, serviceName), struct{}{}, &pl)
+	if err != nil {
+		return nil, err
+	}
+	return &pl, nil
+}
+
+func getGCPPrivatelink(ctx context.Context, client *aiven.Client, project, serviceName string) (*gcpPrivatelink, error) {
+	var pl gcpPrivatelink
+	err := schemautil.APIRequest(ctx, client, http.MethodGet, gcpPrivatelinkPath(project, serviceName), nil, &pl)
+	if err != nil {
+		return nil, err
+	}
+	return &pl, nil
+}
+
+func deleteGCPPrivatelink(ctx context.Context, client *aiven.Client, project, serviceName string) error {
+	return schemautil.APIRequest(ctx, client, http.MethodDelete, gcpPrivatelinkPath(project, serviceName), nil, nil)
+}
+
+// refreshGCPPrivatelinkConnections makes the API look for new Private Service Connect endpoints
+func refreshGCPPrivatelinkConnections(ctx context.Context, client *aiven.Client, project, serviceName string) error {
+	return schemautil.APIRequest(ctx, client, http.MethodPost, gcpPrivatelinkPath(project, serviceName, "connections", "refresh"), struct{}{}, nil)
+}
+
+func listGCPPrivatelinkConnections(ctx context.Context, client *aiven.Client, project, serviceName string) ([]gcpPrivatelinkConnection, error) {
+	var rsp struct {
+		Connections []gcpPrivatelinkConnection `json:"connections"`
+	}
+	err := schemautil.APIRequest(ctx, client, http.MethodGet, gcpPrivatelinkPath(project, serviceName, "connections"), nil, &rsp)
+	if err != nil {
+		return nil, err
+	}
+	return rsp.Connections, nil
+}
+
+func approveGCPPrivatelinkConnection(ctx context.Context, client *aiven.Client, project, serviceName, connectionID, userIPAddress string) error {
+	path := gcpPrivatelinkPath(project, serviceName, "connections", connectionID, "approve")
+	return schemautil.APIRequest(ctx, client, http.MethodPost, path, gcpPrivatelinkConnectionApproveRequest{UserIPAddress: userIPAddress}, nil)
+}
@@ -0,0 +1,60 @@
+package vpc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_gcpPrivatelinkConnections(t *testing.T) {
+	var approved map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /v1/project/foo/service/bar/privatelink/google/connections":
+			_, _ = w.Write([]byte(`{"connections": [{"privatelink_connection_id": "plc1", ` +
+				`"psc_connection_id": "1234", "state": "pending-user-approval", "user_ip_address": ""}]}`))
+		case "POST /v1/project/foo/service/bar/privatelink/google/connections/plc1/approve":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&approved))
+			_, _ = w.Write([]byte(`{"message": "approved"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message": "not found"}`))
+		}
+	}))
+	defer srv.Close()
+	t.Setenv("AIVEN_WEB_URL", srv.URL)
+
+	ctx := context.Background()
+	client := &aiven.Client{APIKey: "token", Client: srv.Client()}
+
+	connections, err := listGCPPrivatelinkConnections(ctx, client, "foo", "bar")
+	require.NoError(t, err)
+	assert.Equal(t, []gcpPrivatelinkConnection{{
+		PrivatelinkConnectionID: "plc1",
+		PSCConnectionID:         "1234",
+		State:                   "pending-user-approval",
+	}}, connections)
+
+	require.NoError(t, approveGCPPrivatelinkConnection(ctx, client, "foo", "bar", "plc1", "10.0.0.5"))
+	assert.Equal(t, map[string]interface{}{"user_ip_address": "10.0.0.5"}, approved)
+
+	_, err = getGCPPrivatelink(ctx, client, "foo", "bar")
+	assert.True(t, aiven.IsNotFound(err))
+}
+
+func Test_findGCPPrivatelinkConnection(t *testing.T) {
+	one := []gcpPrivatelinkConnection{{PrivatelinkConnectionID: "plc1"}}
+	two := []gcpPrivatelinkConnection{{PrivatelinkConnectionID: "plc1"}, {PrivatelinkConnectionID: "plc2"}}
+
+	assert.Equal(t, "plc2", findGCPPrivatelinkConnection(two, "plc2").PrivatelinkConnectionID)
+	assert.Nil(t, findGCPPrivatelinkConnection(two, "plc3"))
+	assert.Equal(t, "plc1", findGCPPrivatelinkConnection(one, "").PrivatelinkConnectionID)
+	assert.Nil(t, findGCPPrivatelinkConnection(two, ""))
+	assert.Nil(t, findGCPPrivatelinkConnection(nil, ""))
+}
@@ -0,0 +1,39 @@
+package vpc
+
+import (
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// privatelinkFirstConnection refreshes the connections of the privatelink of a service and returns the first one
+// with its state, found is false while there are no connections yet
+type privatelinkFirstConnection func() (connection interface{}, state string, found bool, err error)
+
+// waitForPrivatelinkConnectionState waits for the first connection of the privatelink of a service to reach one of
+// the target states, the cloud is only used in the logs
+func waitForPrivatelinkConnectionState(cloud string, firstConnection privatelinkFirstConnection, t time.Duration, pending []string, target []string) *resource.StateChangeConf {
+	return &resource.StateChangeConf{
+		Pending: pending,
+		Target:  target,
+		Refresh: func() (interface{}, string, error) {
+			plConnection, state, found, err := firstConnection()
+			if err != nil {
+				return nil, "", err
+			}
+
+			if !found {
+				log.Printf("[DEBUG] No %s privatelink connections yet, will refresh again", cloud)
+				return nil, "", nil
+			}
+
+			log.Printf("[DEBUG] Got %s state while waiting for %s privatelink connection state.", state, cloud)
+
+			return plConnection, state, nil
+		},
+		Delay:      10 * time.Second,
+		Timeout:    t,
+		MinTimeout: 2 * time.Second,
+	}
+}
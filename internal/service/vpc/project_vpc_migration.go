@@ -0,0 +1,338 @@
+package vpc
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+)
+
+// projectVPCMigration moves the services and peering connections of a project VPC to a new VPC
+// with a different network range or cloud. Every step checks what has already been done, so a
+// migration which failed half-way is resumed by applying the same change again.
+type projectVPCMigration struct {
+	client      *aiven.Client
+	project     string
+	oldVPCID    string
+	cloudName   string
+	networkCIDR string
+	timeout     time.Duration
+}
+
+// run performs the migration and returns the ID of the new VPC
+func (mg *projectVPCMigration) run(ctx context.Context) (string, diag.Diagnostics) {
+	newVPC, err := mg.createTargetVPC()
+	if err != nil {
+		return "", diag.Errorf("error creating the project VPC to migrate to: %s", err)
+	}
+
+	log.Printf("[INFO] Migrating project VPC %s to %s, waiting for %s to be ACTIVE", mg.oldVPCID, newVPC.ProjectVPCID, newVPC.ProjectVPCID)
+	activeWaiter := ProjectVPCActiveWaiter{
+		Client:  mg.client,
+		Project: mg.project,
+		VPCID:   newVPC.ProjectVPCID,
+	}
+	if _, err := activeWaiter.Conf(mg.timeout).WaitForStateContext(ctx); err != nil {
+		return "", diag.Errorf("error waiting for Aiven project VPC to be ACTIVE: %s", err)
+	}
+
+	if err := mg.moveServices(ctx, newVPC.ProjectVPCID); err != nil {
+		return "", diag.Errorf("error moving services to project VPC %s: %s", newVPC.ProjectVPCID, err)
+	}
+
+	diags := mg.recreatePeeringConnections(ctx, newVPC.ProjectVPCID)
+	if diags.HasError() {
+		return "", diags
+	}
+
+	log.Printf("[INFO] Migrating project VPC %s to %s, deleting %s", mg.oldVPCID, newVPC.ProjectVPCID, mg.oldVPCID)
+	deleteWaiter := ProjectVPCDeleteWaiter{
+		Client:  mg.client,
+		Project: mg.project,
+		VPCID:   mg.oldVPCID,
+	}
+	if _, err := deleteWaiter.Conf(mg.timeout).WaitForStateContext(ctx); err != nil {
+		return "", append(diags, diag.Errorf("error waiting for Aiven project VPC to be DELETED: %s", err)...)
+	}
+
+	return newVPC.ProjectVPCID, diags
+}
+
+// createTargetVPC creates the VPC to migrate to, unless a previous run already did
+func (mg *projectVPCMigration) createTargetVPC() (*aiven.VPC, error) {
+	vpcs, err := mg.client.VPCs.List(mg.project)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, vpc := range vpcs {
+		if vpc.ProjectVPCID == mg.oldVPCID || vpc.State == "DELETING" || vpc.State == "DELETED" {
+			continue
+		}
+		if vpc.CloudName == mg.cloudName && vpc.NetworkCIDR == mg.networkCIDR {
+			log.Printf("[INFO] Migrating project VPC %s, resuming with existing VPC %s", mg.oldVPCID, vpc.ProjectVPCID)
+			return vpc, nil
+		}
+	}
+
+	return mg.client.VPCs.Create(mg.project, aiven.CreateVPCRequest{
+		CloudName:   mg.cloudName,
+		NetworkCIDR: mg.networkCIDR,
+	})
+}
+
+// moveServices moves the services still in the old VPC, and waits for the ones a previous run has
+// moved already, so that the old VPC can be deleted
+func (mg *projectVPCMigration) moveServices(ctx context.Context, newVPCID string) error {
+	services, err := mg.client.Services.List(mg.project)
+	if err != nil {
+		return err
+	}
+
+	var moving []string
+	for _, s := range services {
+		if s.ProjectVPCID == nil {
+			continue
+		}
+
+		switch *s.ProjectVPCID {
+		case newVPCID:
+			moving = append(moving, s.Name)
+		case mg.oldVPCID:
+			log.Printf("[INFO] Migrating project VPC %s, moving service %s to %s", mg.oldVPCID, s.Name, newVPCID)
+
+			maintenanceWindow := s.MaintenanceWindow
+			if _, err := mg.client.Services.Update(mg.project, s.Name, aiven.UpdateServiceRequest{
+				Cloud:                 mg.cloudName,
+				Plan:                  s.Plan,
+				MaintenanceWindow:     &maintenanceWindow,
+				ProjectVPCID:          &newVPCID,
+				Powered:               s.Powered,
+				TerminationProtection: s.TerminationProtection,
+			}); err != nil {
+				return fmt.Errorf("error moving service %s: %w", s.Name, err)
+			}
+			moving = append(moving, s.Name)
+		}
+	}
+
+	for _, serviceName := range moving {
+		w := projectVPCServiceMigrationWaiter{
+			Client:      mg.client,
+			Project:     mg.project,
+			ServiceName: serviceName,
+			CloudName:   mg.cloudName,
+			VPCID:       newVPCID,
+		}
+		if _, err := w.Conf(mg.timeout).WaitForStateContext(ctx); err != nil {
+			return fmt.Errorf("error waiting for service %s to be RUNNING in the new VPC: %w", serviceName, err)
+		}
+	}
+
+	return nil
+}
+
+// recreatePeeringConnections creates the peering connections of the old VPC on the new one. The
+// peer has to accept new peering connections again, which is reported as a warning.
+func (mg *projectVPCMigration) recreatePeeringConnections(ctx context.Context, newVPCID string) diag.Diagnostics {
+	oldVPC, err := mg.client.VPCs.Get(mg.project, mg.oldVPCID)
+	if err != nil {
+		// a previous run got as far as deleting the old VPC
+		if aiven.IsNotFound(err) {
+			return nil
+		}
+		return diag.Errorf("error getting project VPC %s: %s", mg.oldVPCID, err)
+	}
+
+	newVPC, err := mg.client.VPCs.Get(mg.project, newVPCID)
+	if err != nil {
+		return diag.Errorf("error getting project VPC %s: %s", newVPCID, err)
+	}
+
+	var diags diag.Diagnostics
+	for _, pc := range oldVPC.PeeringConnections {
+		if vpcPeeringConnectionIsGone(pc) || pc.State == "REJECTED_BY_PEER" || pc.State == "INVALID_SPECIFICATION" {
+			continue
+		}
+		if findVPCPeeringConnection(newVPC, pc) != nil {
+			continue
+		}
+
+		log.Printf("[INFO] Migrating project VPC %s, recreating peering connection to %s/%s", mg.oldVPCID, pc.PeerCloudAccount, pc.PeerVPC)
+		if _, err := mg.client.VPCPeeringConnections.Create(mg.project, newVPCID, aiven.CreateVPCPeeringConnectionRequest{
+			PeerCloudAccount:     pc.PeerCloudAccount,
+			PeerVPC:              pc.PeerVPC,
+			PeerRegion:           pc.PeerRegion,
+			PeerAzureAppId:       pc.PeerAzureAppId,
+			PeerAzureTenantId:    pc.PeerAzureTenantId,
+			PeerResourceGroup:    pc.PeerResourceGroup,
+			UserPeerNetworkCIDRs: pc.UserPeerNetworkCIDRs,
+		}); err != nil {
+			return append(diags, diag.Errorf("error recreating peering connection to %s/%s: %s", pc.PeerCloudAccount, pc.PeerVPC, err)...)
+		}
+
+		created, err := mg.waitForPeeringConnection(ctx, newVPCID, pc)
+		if err != nil {
+			return append(diags, diag.Errorf("error waiting for peering connection to %s/%s: %s", pc.PeerCloudAccount, pc.PeerVPC, err)...)
+		}
+		diags = append(diags, getDiagnosticsFromState(created)...)
+	}
+
+	return diags
+}
+
+// waitForPeeringConnection waits for a recreated peering connection to leave the APPROVED state
+func (mg *projectVPCMigration) waitForPeeringConnection(
+	ctx context.Context,
+	vpcID string,
+	pc *aiven.VPCPeeringConnection,
+) (*aiven.VPCPeeringConnection, error) {
+	stateChangeConf := &resource.StateChangeConf{
+		Pending: []string{"APPROVED"},
+		Target: []string{
+			"ACTIVE",
+			"REJECTED_BY_PEER",
+			"PENDING_PEER",
+			"INVALID_SPECIFICATION",
+			"DELETING",
+			"DELETED",
+			"DELETED_BY_PEER",
+		},
+		Refresh: func() (interface{}, string, error) {
+			vpc, err := mg.client.VPCs.Get(mg.project, vpcID)
+			if err != nil {
+				return nil, "", err
+			}
+			created := findVPCPeeringConnection(vpc, pc)
+			if created == nil {
+				return nil, "", fmt.Errorf("peering connection not found in project VPC %s", vpcID)
+			}
+			return created, created.State, nil
+		},
+		Delay:      10 * time.Second,
+		Timeout:    mg.timeout,
+		MinTimeout: 2 * time.Second,
+	}
+
+	res, err := stateChangeConf.WaitForStateContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return res.(*aiven.VPCPeeringConnection), nil
+}
+
+// findVPCPeeringConnection returns the peering connection of the VPC to the same peer as pc
+func findVPCPeeringConnection(vpc *aiven.VPC, pc *aiven.VPCPeeringConnection) *aiven.VPCPeeringConnection {
+	for _, c := range vpc.PeeringConnections {
+		if sameVPCPeer(c, pc) && c.PeerResourceGroup == pc.PeerResourceGroup && !vpcPeeringConnectionIsGone(c) {
+			return c
+		}
+	}
+	return nil
+}
+
+func sameVPCPeer(a, b *aiven.VPCPeeringConnection) bool {
+	if a.PeerCloudAccount != b.PeerCloudAccount || a.PeerVPC != b.PeerVPC {
+		return false
+	}
+	if a.PeerRegion == nil || b.PeerRegion == nil {
+		return a.PeerRegion == b.PeerRegion
+	}
+	return *a.PeerRegion == *b.PeerRegion
+}
+
+func vpcPeeringConnectionIsGone(pc *aiven.VPCPeeringConnection) bool {
+	return pc.State == "DELETING" || pc.State == "DELETED" || pc.State == "DELETED_BY_PEER"
+}
+
+// findMigratedVPCPeering looks for a peering connection whose project VPC is gone in the other VPCs
+// of the project, a project VPC migration recreates the peering connections of the old VPC on the
+// new one. When found, p is updated to point at the new VPC, otherwise notFound is returned.
+func findMigratedVPCPeering(client *aiven.Client, p *peeringVPCID, notFound error) (*aiven.VPCPeeringConnection, error) {
+	if _, err := client.VPCs.Get(p.projectName, p.vpcID); !aiven.IsNotFound(err) {
+		return nil, notFound
+	}
+
+	vpcs, err := client.VPCs.List(p.projectName)
+	if err != nil {
+		return nil, err
+	}
+
+	peer := &aiven.VPCPeeringConnection{
+		PeerCloudAccount: p.peerCloudAccount,
+		PeerVPC:          p.peerVPC,
+		PeerRegion:       p.peerRegion,
+	}
+	for _, vpc := range vpcs {
+		if vpc.State == "DELETING" || vpc.State == "DELETED" {
+			continue
+		}
+
+		for _, pc := range vpc.PeeringConnections {
+			if sameVPCPeer(pc, peer) && !vpcPeeringConnectionIsGone(pc) {
+				log.Printf("[INFO] Peering connection to %s/%s moved from project VPC %s to %s", p.peerCloudAccount, p.peerVPC, p.vpcID, vpc.ProjectVPCID)
+				p.vpcID = vpc.ProjectVPCID
+				return pc, nil
+			}
+		}
+	}
+
+	return nil, notFound
+}
+
+// resourceID builds the resource ID of the peering connection
+func (p *peeringVPCID) resourceID() string {
+	if p.peerRegion != nil {
+		return schemautil.BuildResourceID(p.projectName, p.vpcID, p.peerCloudAccount, p.peerVPC, *p.peerRegion)
+	}
+	return schemautil.BuildResourceID(p.projectName, p.vpcID, p.peerCloudAccount, p.peerVPC)
+}
+
+// projectVPCServiceMigrationWaiter is used to wait for a service to run in its new project VPC
+type projectVPCServiceMigrationWaiter struct {
+	Client      *aiven.Client
+	Project     string
+	ServiceName string
+	CloudName   string
+	VPCID       string
+}
+
+// RefreshFunc will call the Aiven client and refresh it's state.
+func (w *projectVPCServiceMigrationWaiter) RefreshFunc() resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		s, err := w.Client.Services.Get(w.Project, w.ServiceName)
+		if err != nil {
+			return nil, "", err
+		}
+
+		state := s.State
+		if s.CloudName != w.CloudName || s.ProjectVPCID == nil || *s.ProjectVPCID != w.VPCID {
+			state = "MIGRATING"
+		}
+
+		log.Printf("[DEBUG] Got %s state while waiting for service %s to be RUNNING in VPC %s.", state, w.ServiceName, w.VPCID)
+
+		return s, state, nil
+	}
+}
+
+// Conf sets up the configuration to refresh.
+func (w *projectVPCServiceMigrationWaiter) Conf(timeout time.Duration) *resource.StateChangeConf {
+	log.Printf("[DEBUG] Service migration waiter timeout %.0f minutes", timeout.Minutes())
+
+	return &resource.StateChangeConf{
+		Pending:                   []string{"MIGRATING", "REBUILDING", "REBALANCING"},
+		Target:                    []string{"RUNNING", "POWEROFF"},
+		Refresh:                   w.RefreshFunc(),
+		Delay:                     10 * time.Second,
+		Timeout:                   timeout,
+		MinTimeout:                2 * time.Second,
+		ContinuousTargetOccurence: 3,
+	}
+}
@@ -54,7 +54,7 @@ var aivenAWSVPCPeeringConnectionSchema = map[string]*schema.Schema{
 	"aws_vpc_peering_connection_id": {
 		Computed:    true,
 		Type:        schema.TypeString,
-		Description: "AWS VPC peering connection ID",
+		Description: "AWS VPC peering connection ID to accept in the peer AWS account",
 	},
 }
 
@@ -63,16 +63,18 @@ func ResourceAWSVPCPeeringConnection() *schema.Resource {
 		Description:   "The AWS VPC Peering Connection resource allows the creation and management of Aiven AWS VPC Peering Connections.",
 		CreateContext: resourceAWSVPCPeeringConnectionCreate,
 		ReadContext:   resourceAWSVPCPeeringConnectionRead,
+		UpdateContext: resourceAWSVPCPeeringConnectionUpdate,
 		DeleteContext: resourceAWSVPCPeeringConnectionDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importVPCPeeringConnection(vpcPeeringConnectionImportOptions{withRegion: true}),
 		},
 		Timeouts: &schema.ResourceTimeout{
-			Create: schema.DefaultTimeout(2 * time.Minute),
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
 			Delete: schema.DefaultTimeout(2 * time.Minute),
 		},
 
-		Schema: aivenAWSVPCPeeringConnectionSchema,
+		Schema: withVPCPeeringConnectionWaitForActive(aivenAWSVPCPeeringConnectionSchema),
 	}
 }
 
@@ -118,17 +120,10 @@ func resourceAWSVPCPeeringConnectionCreate(ctx context.Context, d *schema.Resour
 		return diag.Errorf("Error waiting for AWS VPC peering connection creation: %s", err)
 	}
 
+	pending, target := vpcPeeringConnectionCreateStates(d)
 	stateChangeConf := &resource.StateChangeConf{
-		Pending: []string{"APPROVED"},
-		Target: []string{
-			"ACTIVE",
-			"REJECTED_BY_PEER",
-			"PENDING_PEER",
-			"INVALID_SPECIFICATION",
-			"DELETING",
-			"DELETED",
-			"DELETED_BY_PEER",
-		},
+		Pending: pending,
+		Target:  target,
 		Refresh: func() (interface{}, string, error) {
 			pc, err := client.VPCPeeringConnections.GetVPCPeering(
 				projectName,
@@ -175,6 +170,11 @@ func resourceAWSVPCPeeringConnectionRead(_ context.Context, d *schema.ResourceDa
 
 	pc, err := client.VPCPeeringConnections.GetVPCPeering(
 		p.projectName, p.vpcID, p.peerCloudAccount, p.peerVPC, p.peerRegion)
+	if aiven.IsNotFound(err) {
+		if pc, err = findMigratedVPCPeering(client, p, err); err == nil {
+			d.SetId(p.resourceID())
+		}
+	}
 	if err != nil {
 		return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
 	}
@@ -182,6 +182,24 @@ func resourceAWSVPCPeeringConnectionRead(_ context.Context, d *schema.ResourceDa
 	return copyAWSVPCPeeringConnectionPropertiesFromAPIResponseToTerraform(d, pc, p.projectName, p.vpcID)
 }
 
+func resourceAWSVPCPeeringConnectionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	p, err := parsePeerVPCIDWithRegion(d.Id())
+	if err != nil {
+		return diag.Errorf("error parsing AWS peering VPC ID: %s", err)
+	}
+
+	diags := updateVPCPeeringConnectionWaitForActive(ctx, d, func() (*aiven.VPCPeeringConnection, error) {
+		return client.VPCPeeringConnections.GetVPCPeering(p.projectName, p.vpcID, p.peerCloudAccount, p.peerVPC, p.peerRegion)
+	})
+	if diags.HasError() {
+		return diags
+	}
+
+	return append(diags, resourceAWSVPCPeeringConnectionRead(ctx, d, m)...)
+}
+
 func resourceAWSVPCPeeringConnectionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*aiven.Client)
 
@@ -257,12 +275,9 @@ func copyAWSVPCPeeringConnectionPropertiesFromAPIResponseToTerraform(
 		return diag.FromErr(err)
 	}
 
-	if peeringConnection.StateInfo != nil {
-		peeringID, ok := (*peeringConnection.StateInfo)["aws_vpc_peering_connection_id"]
-		if ok {
-			if err := d.Set("aws_vpc_peering_connection_id", peeringID); err != nil {
-				return diag.FromErr(err)
-			}
+	if peeringID := stateInfoValue(peeringConnection, "aws_vpc_peering_connection_id"); peeringID != "" {
+		if err := d.Set("aws_vpc_peering_connection_id", peeringID); err != nil {
+			return diag.FromErr(err)
 		}
 	}
 
@@ -2,7 +2,6 @@ package vpc
 
 import (
 	"context"
-	"log"
 	"time"
 
 	"github.com/aiven/aiven-go-client"
@@ -55,34 +54,17 @@ func ResourceAzurePrivatelinkConnectionApproval() *schema.Resource {
 }
 
 func waitForConnectionState(_ context.Context, client *aiven.Client, project string, service string, t time.Duration, pending []string, target []string) *resource.StateChangeConf {
-	return &resource.StateChangeConf{
-		Pending: pending,
-		Target:  target,
-		Refresh: func() (interface{}, string, error) {
-			err := client.AzurePrivatelink.Refresh(project, service)
-			if err != nil {
-				return nil, "", err
-			}
-
-			plConnections, err := client.AzurePrivatelink.ConnectionsList(project, service)
-			if err != nil {
-				return nil, "", err
-			}
-
-			if len(plConnections.Connections) == 0 {
-				log.Printf("[DEBUG] No azure privatelink connections yet, will refresh again")
-				return nil, "", nil
-			}
-
-			plConnection := plConnections.Connections[0]
-			log.Printf("[DEBUG] Got %s state while waiting for Azure privatelink connection state.", plConnection.State)
+	return waitForPrivatelinkConnectionState("Azure", func() (interface{}, string, bool, error) {
+		if err := client.AzurePrivatelink.Refresh(project, service); err != nil {
+			return nil, "", false, err
+		}
 
-			return plConnection, plConnection.State, nil
-		},
-		Delay:      10 * time.Second,
-		Timeout:    t,
-		MinTimeout: 2 * time.Second,
-	}
+		plConnections, err := client.AzurePrivatelink.ConnectionsList(project, service)
+		if err != nil || len(plConnections.Connections) == 0 {
+			return nil, "", false, err
+		}
+		return plConnections.Connections[0], plConnections.Connections[0].State, true, nil
+	}, t, pending, target)
 }
 
 func resourcePrivatelinkConnectionApprovalUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
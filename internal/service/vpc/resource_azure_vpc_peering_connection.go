@@ -65,6 +65,38 @@ var aivenAzureVPCPeeringConnectionSchema = map[string]*schema.Schema{
 		Type:        schema.TypeString,
 		Description: schemautil.Complex("Azure tenant id in UUID4 form.").ForceNew().Build(),
 	},
+	"to_tenant_id": {
+		Computed:    true,
+		Type:        schema.TypeString,
+		Description: "Azure tenant of the Aiven VNet to sign in to with `peer_azure_app_id` to create the peering back to the Aiven VNet. It's known once the peering connection is pending on the peer",
+	},
+	"to_network_id": {
+		Computed:    true,
+		Type:        schema.TypeString,
+		Description: "Azure resource ID of the Aiven VNet to create the peering back to, e.g. as the `remote_virtual_network_id` of an `azurerm_virtual_network_peering`. It's known once the peering connection is pending on the peer",
+	},
+	"required_role_assignments": {
+		Computed:    true,
+		Type:        schema.TypeList,
+		Description: "Azure role assignments needed in the peer subscription to create the peering back to the Aiven VNet",
+		Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+			"app_id": {
+				Computed:    true,
+				Type:        schema.TypeString,
+				Description: "App registration the role is assigned to",
+			},
+			"role_definition_name": {
+				Computed:    true,
+				Type:        schema.TypeString,
+				Description: "Name of the built-in role to assign",
+			},
+			"scope": {
+				Computed:    true,
+				Type:        schema.TypeString,
+				Description: "Azure resource ID the role is assigned on",
+			},
+		}},
+	},
 }
 
 func ResourceAzureVPCPeeringConnection() *schema.Resource {
@@ -72,16 +104,18 @@ func ResourceAzureVPCPeeringConnection() *schema.Resource {
 		Description:   "The Azure VPC Peering Connection resource allows the creation and management of Aiven VPC Peering Connections.",
 		CreateContext: resourceAzureVPCPeeringConnectionCreate,
 		ReadContext:   resourceAzureVPCPeeringConnectionRead,
+		UpdateContext: resourceAzureVPCPeeringConnectionUpdate,
 		DeleteContext: resourceAzureVPCPeeringConnectionDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importVPCPeeringConnection(vpcPeeringConnectionImportOptions{withResourceGroup: true}),
 		},
 		Timeouts: &schema.ResourceTimeout{
-			Create: schema.DefaultTimeout(2 * time.Minute),
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
 			Delete: schema.DefaultTimeout(2 * time.Minute),
 		},
 
-		Schema: aivenAzureVPCPeeringConnectionSchema,
+		Schema: withVPCPeeringConnectionWaitForActive(aivenAzureVPCPeeringConnectionSchema),
 	}
 }
 
@@ -133,17 +167,10 @@ func resourceAzureVPCPeeringConnectionCreate(ctx context.Context, d *schema.Reso
 		return diag.Errorf("Error waiting for VPC peering connection creation: %s", err)
 	}
 
+	pending, target := vpcPeeringConnectionCreateStates(d)
 	stateChangeConf := &resource.StateChangeConf{
-		Pending: []string{"APPROVED"},
-		Target: []string{
-			"ACTIVE",
-			"REJECTED_BY_PEER",
-			"PENDING_PEER",
-			"INVALID_SPECIFICATION",
-			"DELETING",
-			"DELETED",
-			"DELETED_BY_PEER",
-		},
+		Pending: pending,
+		Target:  target,
 		Refresh: func() (interface{}, string, error) {
 			pc, err := client.VPCPeeringConnections.GetVPCPeering(
 				projectName,
@@ -191,6 +218,11 @@ func resourceAzureVPCPeeringConnectionRead(_ context.Context, d *schema.Resource
 	peerResourceGroup := d.Get("peer_resource_group")
 	pc, err := client.VPCPeeringConnections.GetVPCPeeringWithResourceGroup(
 		p.projectName, p.vpcID, p.peerCloudAccount, p.peerVPC, p.peerRegion, peerResourceGroup.(string))
+	if aiven.IsNotFound(err) {
+		if pc, err = findMigratedVPCPeering(client, p, err); err == nil {
+			d.SetId(p.resourceID())
+		}
+	}
 	if err != nil {
 		return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
 	}
@@ -198,6 +230,25 @@ func resourceAzureVPCPeeringConnectionRead(_ context.Context, d *schema.Resource
 	return copyAzureVPCPeeringConnectionPropertiesFromAPIResponseToTerraform(d, pc, p.projectName, p.vpcID)
 }
 
+func resourceAzureVPCPeeringConnectionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	p, err := parsePeerVPCID(d.Id())
+	if err != nil {
+		return diag.Errorf("error parsing Azure peering VPC ID: %s", err)
+	}
+
+	diags := updateVPCPeeringConnectionWaitForActive(ctx, d, func() (*aiven.VPCPeeringConnection, error) {
+		return client.VPCPeeringConnections.GetVPCPeeringWithResourceGroup(
+			p.projectName, p.vpcID, p.peerCloudAccount, p.peerVPC, p.peerRegion, d.Get("peer_resource_group").(string))
+	})
+	if diags.HasError() {
+		return diags
+	}
+
+	return append(diags, resourceAzureVPCPeeringConnectionRead(ctx, d, m)...)
+}
+
 func resourceAzureVPCPeeringConnectionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*aiven.Client)
 
@@ -289,5 +340,26 @@ func copyAzureVPCPeeringConnectionPropertiesFromAPIResponseToTerraform(
 		return diag.FromErr(err)
 	}
 
+	// the state info is cleared once the peering is active, keep the last known values
+	if tenantID := stateInfoValue(peeringConnection, "to-tenant-id", "to_tenant_id"); tenantID != "" {
+		if err := d.Set("to_tenant_id", tenantID); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if networkID := stateInfoValue(peeringConnection, "to-network-id", "to_network_id"); networkID != "" {
+		if err := d.Set("to_network_id", networkID); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if err := d.Set("required_role_assignments", []map[string]interface{}{{
+		"app_id":               peeringConnection.PeerAzureAppId,
+		"role_definition_name": azurePeeringRoleDefinitionName,
+		"scope": azureVNetID(peeringConnection.PeerCloudAccount,
+			peeringConnection.PeerResourceGroup, peeringConnection.PeerVPC),
+	}}); err != nil {
+		return diag.FromErr(err)
+	}
+
 	return nil
 }
@@ -0,0 +1,167 @@
+package vpc
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var aivenGCPPrivatelinkSchema = map[string]*schema.Schema{
+	"project":      schemautil.CommonSchemaProjectReference,
+	"service_name": schemautil.CommonSchemaServiceNameReference,
+
+	"google_service_attachment": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Google Private Service Connect service attachment to create the consumer endpoint for",
+	},
+	"message": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Printable result of the GCP Privatelink request",
+	},
+	"state": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Privatelink resource state",
+	},
+}
+
+func ResourceGCPPrivatelink() *schema.Resource {
+	return &schema.Resource{
+		Description:   "The GCP Privatelink resource allows the creation and management of Aiven GCP Private Service Connect service attachments for a service.",
+		CreateContext: resourceGCPPrivatelinkCreate,
+		ReadContext:   resourceGCPPrivatelinkRead,
+		DeleteContext: resourceGCPPrivatelinkDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+
+		Schema: aivenGCPPrivatelinkSchema,
+	}
+}
+
+func resourceGCPPrivatelinkCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	var project = d.Get("project").(string)
+	var serviceName = d.Get("service_name").(string)
+
+	_, err := createGCPPrivatelink(ctx, client, project, serviceName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, err = waitForGCPPrivatelinkToBeActive(ctx, client, project, serviceName,
+		d.Timeout(schema.TimeoutCreate)).WaitForStateContext(ctx)
+	if err != nil {
+		return diag.Errorf("Error waiting for GCP privatelink: %s", err)
+	}
+
+	d.SetId(schemautil.BuildResourceID(project, serviceName))
+
+	return resourceGCPPrivatelinkRead(ctx, d, m)
+}
+
+func resourceGCPPrivatelinkRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+	project, serviceName, err := schemautil.SplitResourceID2(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	pl, err := getGCPPrivatelink(ctx, client, project, serviceName)
+	if err != nil {
+		return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
+	}
+
+	if err := d.Set("project", project); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("service_name", serviceName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("google_service_attachment", pl.GoogleServiceAttachment); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("message", pl.Message); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("state", pl.State); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// waitForGCPPrivatelinkToBeActive waits until the GCP privatelink is active
+func waitForGCPPrivatelinkToBeActive(ctx context.Context, client *aiven.Client, project string, serviceName string, t time.Duration) *resource.StateChangeConf {
+	return &resource.StateChangeConf{
+		Pending: []string{"creating"},
+		Target:  []string{"active"},
+		Refresh: func() (interface{}, string, error) {
+			pl, err := getGCPPrivatelink(ctx, client, project, serviceName)
+			if err != nil {
+				return nil, "", err
+			}
+
+			log.Printf("[DEBUG] Got %s state while waiting for GCP privatelink to be active.", pl.State)
+
+			return pl, pl.State, nil
+		},
+		Delay:      10 * time.Second,
+		Timeout:    t,
+		MinTimeout: 2 * time.Second,
+	}
+}
+
+func resourceGCPPrivatelinkDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+	project, serviceName, err := schemautil.SplitResourceID2(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = deleteGCPPrivatelink(ctx, client, project, serviceName)
+	if err != nil && !aiven.IsNotFound(err) {
+		return diag.FromErr(err)
+	}
+
+	stateChangeConf := &resource.StateChangeConf{
+		Pending: []string{"active", "deleting"},
+		Target:  []string{"deleted"},
+		Refresh: func() (interface{}, string, error) {
+			pl, err := getGCPPrivatelink(ctx, client, project, serviceName)
+			if err != nil {
+				if aiven.IsNotFound(err) {
+					return struct{}{}, "deleted", nil
+				}
+				return nil, "", err
+			}
+
+			log.Printf("[DEBUG] Got %s state while waiting for GCP privatelink to be deleted.", pl.State)
+
+			return pl, pl.State, nil
+		},
+		Delay:      10 * time.Second,
+		Timeout:    d.Timeout(schema.TimeoutDelete),
+		MinTimeout: 2 * time.Second,
+	}
+	_, err = stateChangeConf.WaitForStateContext(ctx)
+	if err != nil {
+		return diag.Errorf("Error waiting for GCP privatelink to be deleted: %s", err)
+	}
+
+	return nil
+}
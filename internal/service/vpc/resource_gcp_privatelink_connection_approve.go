@@ -0,0 +1,194 @@
+package vpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var aivenGCPPrivatelinkConnectionApprovalSchema = map[string]*schema.Schema{
+	"project":      schemautil.CommonSchemaProjectReference,
+	"service_name": schemautil.CommonSchemaServiceNameReference,
+	"user_ip_address": {
+		Type:         schema.TypeString,
+		Required:     true,
+		ForceNew:     true,
+		ValidateFunc: validation.IsIPv4Address,
+		Description:  schemautil.Complex("IP address of the Private Service Connect endpoint in the consumer VPC").ForceNew().Build(),
+	},
+	"state": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Privatelink connection state",
+	},
+	"privatelink_connection_id": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Aiven internal ID of the privatelink connection",
+	},
+	"psc_connection_id": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Google Private Service Connect connection ID",
+	},
+}
+
+func ResourceGCPPrivatelinkConnectionApproval() *schema.Resource {
+	return &schema.Resource{
+		Description:   "The GCP privatelink approve resource waits for an aiven privatelink connection on a service and approves it with associated endpoint IP",
+		CreateContext: resourceGCPPrivatelinkConnectionApprovalCreate,
+		ReadContext:   resourceGCPPrivatelinkConnectionApprovalRead,
+		DeleteContext: resourceGCPPrivatelinkConnectionApprovalDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+		},
+
+		Schema: aivenGCPPrivatelinkConnectionApprovalSchema,
+	}
+}
+
+func waitForGCPConnectionState(ctx context.Context, client *aiven.Client, project string, service string, t time.Duration, pending []string, target []string) *resource.StateChangeConf {
+	return waitForPrivatelinkConnectionState("GCP", func() (interface{}, string, bool, error) {
+		if err := refreshGCPPrivatelinkConnections(ctx, client, project, service); err != nil {
+			return nil, "", false, err
+		}
+
+		plConnections, err := listGCPPrivatelinkConnections(ctx, client, project, service)
+		if err != nil || len(plConnections) == 0 {
+			return nil, "", false, err
+		}
+		return plConnections[0], plConnections[0].State, true, nil
+	}, t, pending, target)
+}
+
+func resourceGCPPrivatelinkConnectionApprovalCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	var project = d.Get("project").(string)
+	var serviceName = d.Get("service_name").(string)
+	var userIPAddress = d.Get("user_ip_address").(string)
+
+	err := refreshGCPPrivatelinkConnections(ctx, client, project, serviceName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	pending := []string{""}
+	target := []string{"pending-user-approval", "user-approved", "connected", "active"}
+
+	_, err = waitForGCPConnectionState(ctx, client, project, serviceName, d.Timeout(schema.TimeoutCreate), pending, target).WaitForStateContext(ctx)
+	if err != nil {
+		return diag.Errorf("Error waiting for privatelink connection after refresh: %s", err)
+	}
+
+	plConnections, err := listGCPPrivatelinkConnections(ctx, client, project, serviceName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if len(plConnections) != 1 {
+		return diag.Errorf("number of privatelink connections != 1 (%d)", len(plConnections))
+	}
+
+	plConnection := plConnections[0]
+	plConnectionID := plConnection.PrivatelinkConnectionID
+
+	// unlike on Azure the endpoint IP address is given with the approval
+	if plConnection.State == "pending-user-approval" {
+		err = approveGCPPrivatelinkConnection(ctx, client, project, serviceName, plConnectionID, userIPAddress)
+		if err != nil {
+			return diag.Errorf("Error approving privatelink connection %s/%s/%s: %s", project, serviceName, plConnectionID, err)
+		}
+	}
+
+	pending = []string{"pending-user-approval", "user-approved", "connected"}
+	target = []string{"active"}
+	_, err = waitForGCPConnectionState(ctx, client, project, serviceName, d.Timeout(schema.TimeoutCreate), pending, target).WaitForStateContext(ctx)
+	if err != nil {
+		return diag.Errorf("Error waiting for privatelink connection after approval: %s", err)
+	}
+
+	if err := d.Set("privatelink_connection_id", plConnectionID); err != nil {
+		return diag.Errorf("Error updating privatelink connection: %s", err)
+	}
+
+	d.SetId(schemautil.BuildResourceID(project, serviceName))
+
+	return resourceGCPPrivatelinkConnectionApprovalRead(ctx, d, m)
+}
+
+func resourceGCPPrivatelinkConnectionApprovalRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+	project, service, err := schemautil.SplitResourceID2(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	plConnections, err := listGCPPrivatelinkConnections(ctx, client, project, service)
+	if err != nil {
+		return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
+	}
+
+	plConnection := findGCPPrivatelinkConnection(plConnections, d.Get("privatelink_connection_id").(string))
+	if plConnection == nil {
+		if d.IsNewResource() {
+			return diag.Errorf("GCP privatelink connection %s not found", d.Get("privatelink_connection_id"))
+		}
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set("project", project); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("service_name", service); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("privatelink_connection_id", plConnection.PrivatelinkConnectionID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("psc_connection_id", plConnection.PSCConnectionID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("state", plConnection.State); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("user_ip_address", plConnection.UserIPAddress); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// findGCPPrivatelinkConnection returns the connection with the given ID, an empty ID, e.g. on
+// import, matches a service with a single connection
+func findGCPPrivatelinkConnection(connections []gcpPrivatelinkConnection, id string) *gcpPrivatelinkConnection {
+	if id == "" {
+		if len(connections) == 1 {
+			return &connections[0]
+		}
+		return nil
+	}
+
+	for i := range connections {
+		if connections[i].PrivatelinkConnectionID == id {
+			return &connections[i]
+		}
+	}
+	return nil
+}
+
+func resourceGCPPrivatelinkConnectionApprovalDelete(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	/// API only supports approve/list. approved connection is deleted with the associated gcp_privatelink resource
+	return nil
+}
@@ -0,0 +1,110 @@
+package vpc_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/aiven/aiven-go-client"
+	acc "github.com/aiven/terraform-provider-aiven/internal/acctest"
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccAivenGCPPrivatelink_basic(t *testing.T) {
+	if os.Getenv("AIVEN_GCP_PRIVATELINK_VPCID") == "" {
+		t.Skip("AIVEN_GCP_PRIVATELINK_VPCID env variable is required to run this test")
+	}
+
+	resourceName := "aiven_gcp_privatelink.foo"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acc.TestAccPreCheck(t) },
+		ProviderFactories: acc.TestAccProviderFactories,
+		CheckDestroy:      testAccCheckAivenGCPPrivatelinkResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGCPPrivatelinkResource(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "google_service_attachment",
+						"data.aiven_gcp_privatelink.pr", "google_service_attachment"),
+					resource.TestCheckResourceAttrSet(resourceName, "google_service_attachment"),
+					resource.TestCheckResourceAttr(resourceName, "state", "active"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckAivenGCPPrivatelinkResourceDestroy(s *terraform.State) error {
+	c := acc.TestAccProvider.Meta().(*aiven.Client)
+
+	// loop through the resources in state, verifying each GCP privatelink is destroyed
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aiven_gcp_privatelink" {
+			continue
+		}
+
+		project, serviceName, err := schemautil.SplitResourceID2(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		path := schemautil.BuildAPIPath("project", project, "service", serviceName, "privatelink", "google")
+		err = schemautil.APIRequest(context.Background(), c, http.MethodGet, path, nil, nil)
+		if err == nil {
+			return fmt.Errorf("gcp privatelink (%s) still exists", rs.Primary.ID)
+		}
+		if !aiven.IsNotFound(err) {
+			return fmt.Errorf("error getting a GCP Privatelink: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func testAccGCPPrivatelinkResource(name string) string {
+	return fmt.Sprintf(`
+data "aiven_project" "foo" {
+  project = "%s"
+}
+
+resource "aiven_pg" "bar" {
+  project                 = data.aiven_project.foo.project
+  cloud_name              = "google-europe-west1"
+  plan                    = "startup-4"
+  service_name            = "test-acc-sr-%s"
+  maintenance_window_dow  = "monday"
+  maintenance_window_time = "10:00:00"
+  project_vpc_id          = "%s"
+
+  pg_user_config {
+    privatelink_access {
+      pg        = true
+      pgbouncer = true
+    }
+  }
+}
+
+resource "aiven_gcp_privatelink" "foo" {
+  project      = data.aiven_project.foo.project
+  service_name = aiven_pg.bar.service_name
+}
+
+data "aiven_gcp_privatelink" "pr" {
+  project      = data.aiven_project.foo.project
+  service_name = aiven_pg.bar.service_name
+
+  depends_on = [aiven_gcp_privatelink.foo]
+}`, os.Getenv("AIVEN_PROJECT_NAME"), name, os.Getenv("AIVEN_GCP_PRIVATELINK_VPCID"))
+}
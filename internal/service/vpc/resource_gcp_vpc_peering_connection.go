@@ -42,6 +42,11 @@ var aivenGCPVPCPeeringConnectionSchema = map[string]*schema.Schema{
 		Type:        schema.TypeMap,
 		Description: "State-specific help or error information",
 	},
+	"self_link": {
+		Computed:    true,
+		Type:        schema.TypeString,
+		Description: "Aiven VPC network to peer the GCP VPC network back to, e.g. as the `peer_network` of a `google_compute_network_peering`. It's known once the peering connection is pending on the peer",
+	},
 }
 
 func ResourceGCPVPCPeeringConnection() *schema.Resource {
@@ -49,16 +54,18 @@ func ResourceGCPVPCPeeringConnection() *schema.Resource {
 		Description:   "The GCP VPC Peering Connection resource allows the creation and management of Aiven GCP VPC Peering Connections.",
 		CreateContext: resourceGCPVPCPeeringConnectionCreate,
 		ReadContext:   resourceGCPVPCPeeringConnectionRead,
+		UpdateContext: resourceGCPVPCPeeringConnectionUpdate,
 		DeleteContext: resourceGCPVPCPeeringConnectionDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importVPCPeeringConnection(vpcPeeringConnectionImportOptions{}),
 		},
 		Timeouts: &schema.ResourceTimeout{
-			Create: schema.DefaultTimeout(2 * time.Minute),
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
 			Delete: schema.DefaultTimeout(2 * time.Minute),
 		},
 
-		Schema: aivenGCPVPCPeeringConnectionSchema,
+		Schema: withVPCPeeringConnectionWaitForActive(aivenGCPVPCPeeringConnectionSchema),
 	}
 }
 
@@ -98,17 +105,10 @@ func resourceGCPVPCPeeringConnectionCreate(ctx context.Context, d *schema.Resour
 		return diag.Errorf("Error waiting for VPC peering connection creation: %s", err)
 	}
 
+	pending, target := vpcPeeringConnectionCreateStates(d)
 	stateChangeConf := &resource.StateChangeConf{
-		Pending: []string{"APPROVED"},
-		Target: []string{
-			"ACTIVE",
-			"REJECTED_BY_PEER",
-			"PENDING_PEER",
-			"INVALID_SPECIFICATION",
-			"DELETING",
-			"DELETED",
-			"DELETED_BY_PEER",
-		},
+		Pending: pending,
+		Target:  target,
 		Refresh: func() (interface{}, string, error) {
 			pc, err := client.VPCPeeringConnections.GetVPCPeering(
 				projectName,
@@ -156,6 +156,11 @@ func resourceGCPVPCPeeringConnectionRead(_ context.Context, d *schema.ResourceDa
 	var pc *aiven.VPCPeeringConnection
 	pc, err = client.VPCPeeringConnections.GetVPCPeering(
 		p.projectName, p.vpcID, p.peerCloudAccount, p.peerVPC, p.peerRegion)
+	if aiven.IsNotFound(err) {
+		if pc, err = findMigratedVPCPeering(client, p, err); err == nil {
+			d.SetId(p.resourceID())
+		}
+	}
 	if err != nil {
 		return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
 	}
@@ -163,6 +168,24 @@ func resourceGCPVPCPeeringConnectionRead(_ context.Context, d *schema.ResourceDa
 	return copyGCPVPCPeeringConnectionPropertiesFromAPIResponseToTerraform(d, pc, p.projectName, p.vpcID)
 }
 
+func resourceGCPVPCPeeringConnectionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	p, err := parsePeerVPCID(d.Id())
+	if err != nil {
+		return diag.Errorf("error parsing GCP peering VPC ID: %s", err)
+	}
+
+	diags := updateVPCPeeringConnectionWaitForActive(ctx, d, func() (*aiven.VPCPeeringConnection, error) {
+		return client.VPCPeeringConnections.GetVPCPeering(p.projectName, p.vpcID, p.peerCloudAccount, p.peerVPC, p.peerRegion)
+	})
+	if diags.HasError() {
+		return diags
+	}
+
+	return append(diags, resourceGCPVPCPeeringConnectionRead(ctx, d, m)...)
+}
+
 func resourceGCPVPCPeeringConnectionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*aiven.Client)
 	p, err := parsePeerVPCID(d.Id())
@@ -241,5 +264,12 @@ func copyGCPVPCPeeringConnectionPropertiesFromAPIResponseToTerraform(
 		return diag.FromErr(err)
 	}
 
+	// the state info is cleared once the peering is active, keep the last known network
+	if selfLink := gcpPeerNetworkSelfLink(peeringConnection); selfLink != "" {
+		if err := d.Set("self_link", selfLink); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	return nil
 }
@@ -2,13 +2,16 @@ package vpc
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"net"
 	"time"
 
 	"github.com/aiven/aiven-go-client"
 	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -17,16 +20,21 @@ var aivenProjectVPCSchema = map[string]*schema.Schema{
 	"project": schemautil.CommonSchemaProjectReference,
 
 	"cloud_name": {
-		ForceNew:    true,
 		Required:    true,
 		Type:        schema.TypeString,
-		Description: schemautil.Complex("Defines where the cloud provider and region where the service is hosted in. See the Service resource for additional information.").ForceNew().Build(),
+		Description: "Defines where the cloud provider and region where the service is hosted in. See the Service resource for additional information. Changing this property forces recreation of the resource unless `migrate_services` is enabled.",
 	},
 	"network_cidr": {
-		ForceNew:    true,
-		Required:    true,
-		Type:        schema.TypeString,
-		Description: "Network address range used by the VPC like 192.168.0.0/24",
+		Required:     true,
+		Type:         schema.TypeString,
+		ValidateFunc: schemautil.ValidateIPv4CIDR,
+		Description:  "Network address range used by the VPC like 192.168.0.0/24. Changing this property forces recreation of the resource unless `migrate_services` is enabled.",
+	},
+	"migrate_services": {
+		Optional:    true,
+		Type:        schema.TypeBool,
+		Default:     false,
+		Description: schemautil.Complex("Migrate the VPC instead of recreating it when `cloud_name` or `network_cidr` change: a new VPC is created, the services in the VPC are moved to it, its peering connections are recreated and then the old VPC is deleted. The peers have to accept the recreated peering connections. A failed migration is resumed by applying again.").DefaultValue(false).Build(),
 	},
 	"state": {
 		Computed:    true,
@@ -40,12 +48,21 @@ func ResourceProjectVPC() *schema.Resource {
 		Description:   "The Project VPC resource allows the creation and management of Aiven Project VPCs.",
 		CreateContext: resourceProjectVPCCreate,
 		ReadContext:   resourceProjectVPCRead,
+		UpdateContext: resourceProjectVPCUpdate,
 		DeleteContext: resourceProjectVPCDelete,
+		CustomizeDiff: customdiff.Sequence(
+			resourceProjectVPCForceNewUnlessMigrating,
+			customdiff.IfValueChange("network_cidr",
+				func(_ context.Context, _, new, _ interface{}) bool { return new.(string) != "" },
+				resourceProjectVPCCustomizeDiff,
+			),
+		),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(4 * time.Minute),
+			Update: schema.DefaultTimeout(60 * time.Minute),
 			Delete: schema.DefaultTimeout(4 * time.Minute),
 		},
 
@@ -85,6 +102,111 @@ func resourceProjectVPCCreate(ctx context.Context, d *schema.ResourceData, m int
 	return resourceProjectVPCRead(ctx, d, m)
 }
 
+// resourceProjectVPCForceNewUnlessMigrating recreates the VPC when its cloud or network range
+// changes, unless the services are migrated to a new VPC by the update
+func resourceProjectVPCForceNewUnlessMigrating(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" || d.Get("migrate_services").(bool) {
+		return nil
+	}
+
+	for _, k := range []string{"cloud_name", "network_cidr"} {
+		if d.HasChange(k) {
+			if err := d.ForceNew(k); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// resourceProjectVPCCustomizeDiff checks that the network range can hold service nodes and does
+// not overlap with the other VPCs of the project, which the API only rejects after a while
+func resourceProjectVPCCustomizeDiff(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("network_cidr") || !d.NewValueKnown("project") || !d.NewValueKnown("cloud_name") {
+		return nil
+	}
+
+	networkCIDR := d.Get("network_cidr").(string)
+	network, err := schemautil.ParseIPv4CIDR(networkCIDR)
+	if err != nil {
+		return fmt.Errorf("network_cidr: %w", err)
+	}
+	if schemautil.VPCCIDRCapacity(network) <= 0 {
+		return fmt.Errorf("network_cidr: %s is too small to hold any service node", networkCIDR)
+	}
+
+	client := m.(*aiven.Client)
+	projectName := d.Get("project").(string)
+
+	vpcs, err := client.VPCs.List(projectName)
+	if err != nil {
+		if aiven.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("unable to list VPCs of project %s: %w", projectName, err)
+	}
+
+	var currentVPCID string
+	if d.Id() != "" {
+		_, currentVPCID, _ = schemautil.SplitResourceID2(d.Id())
+	}
+
+	// the VPC being replaced is deleted before the new one is created
+	if currentVPCID == "" || !d.Get("migrate_services").(bool) {
+		return checkProjectVPCCIDROverlap(network, currentVPCID, vpcs)
+	}
+
+	// while migrating both VPCs exist, and a VPC left behind by a failed migration is reused
+	var others []*aiven.VPC
+	for _, vpc := range vpcs {
+		if vpc.CloudName != d.Get("cloud_name").(string) || vpc.NetworkCIDR != networkCIDR || vpc.ProjectVPCID == currentVPCID {
+			others = append(others, vpc)
+		}
+	}
+	if err := checkProjectVPCCIDROverlap(network, "", others); err != nil {
+		return err
+	}
+
+	services, err := client.Services.List(projectName)
+	if err != nil {
+		return fmt.Errorf("unable to list services of project %s: %w", projectName, err)
+	}
+
+	nodes := 0
+	for _, s := range services {
+		if s.ProjectVPCID != nil && *s.ProjectVPCID == currentVPCID {
+			nodes += s.NodeCount
+		}
+	}
+	if capacity := schemautil.VPCCIDRCapacity(network); nodes > capacity {
+		return fmt.Errorf("network_cidr: %s can hold %d service nodes, the services to migrate have %d", networkCIDR, capacity, nodes)
+	}
+
+	return nil
+}
+
+// checkProjectVPCCIDROverlap checks a network range against the ranges of the other project VPCs
+func checkProjectVPCCIDROverlap(network *net.IPNet, currentVPCID string, vpcs []*aiven.VPC) error {
+	for _, vpc := range vpcs {
+		if vpc.ProjectVPCID == currentVPCID || vpc.State == "DELETING" || vpc.State == "DELETED" {
+			continue
+		}
+
+		other, err := schemautil.ParseIPv4CIDR(vpc.NetworkCIDR)
+		if err != nil {
+			continue
+		}
+
+		if schemautil.CIDRsOverlap(network, other) {
+			return fmt.Errorf("network_cidr: %s overlaps with %s of project VPC %s in %s",
+				network, vpc.NetworkCIDR, vpc.ProjectVPCID, vpc.CloudName)
+		}
+	}
+
+	return nil
+}
+
 func resourceProjectVPCRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*aiven.Client)
 
@@ -103,9 +225,47 @@ func resourceProjectVPCRead(_ context.Context, d *schema.ResourceData, m interfa
 		return diag.FromErr(err)
 	}
 
+	// not returned by the API, set on import
+	if err := d.Set("migrate_services", d.Get("migrate_services").(bool)); err != nil {
+		return diag.FromErr(err)
+	}
+
 	return nil
 }
 
+func resourceProjectVPCUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if !d.HasChanges("cloud_name", "network_cidr") {
+		return resourceProjectVPCRead(ctx, d, m)
+	}
+
+	projectName, vpcID, err := schemautil.SplitResourceID2(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// the state keeps the old VPC until the migration is done, so that it is resumed by the next apply
+	d.Partial(true)
+
+	migration := projectVPCMigration{
+		client:      m.(*aiven.Client),
+		project:     projectName,
+		oldVPCID:    vpcID,
+		cloudName:   d.Get("cloud_name").(string),
+		networkCIDR: d.Get("network_cidr").(string),
+		timeout:     d.Timeout(schema.TimeoutUpdate),
+	}
+
+	newVPCID, diags := migration.run(ctx)
+	if diags.HasError() {
+		return diags
+	}
+
+	d.Partial(false)
+	d.SetId(schemautil.BuildResourceID(projectName, newVPCID))
+
+	return append(diags, resourceProjectVPCRead(ctx, d, m)...)
+}
+
 func resourceProjectVPCDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*aiven.Client)
 
@@ -43,10 +43,18 @@ func TestAccAivenProjectVPC_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "state", "ACTIVE"),
 				),
 			},
+			{
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+				Config:             testAccProjectVPCResourceOverlap(rName),
+				ExpectError:        regexp.MustCompile("network_cidr: 192.168.0.128/25 overlaps with 192.168.0.0/24"),
+			},
 			{
 				Config: testAccProjectVPCResourceGetById(rName),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckAivenProjectVPCAttributes("data.aiven_project_vpc.vpc2"),
+					resource.TestCheckResourceAttrPair("data.aiven_project_vpc_peering_connections.peerings", "vpc_id", resourceName, "id"),
+					resource.TestCheckResourceAttr("data.aiven_project_vpc_peering_connections.peerings", "peering_connections.#", "0"),
 					resource.TestCheckResourceAttr(resourceName, "project", fmt.Sprintf("test-acc-pr-%s", rName)),
 					resource.TestCheckResourceAttr(resourceName, "cloud_name", "azure-westeurope"),
 					resource.TestCheckResourceAttr(resourceName, "network_cidr", "192.168.1.0/24"),
@@ -57,6 +65,62 @@ func TestAccAivenProjectVPC_basic(t *testing.T) {
 	})
 }
 
+func TestAccAivenProjectVPC_migrateServices(t *testing.T) {
+	resourceName := "aiven_project_vpc.bar"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acc.TestAccPreCheck(t) },
+		ProviderFactories: acc.TestAccProviderFactories,
+		CheckDestroy:      testAccCheckAivenProjectVPCResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProjectVPCMigrateServicesResource(rName, "10.0.0.0/24"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "network_cidr", "10.0.0.0/24"),
+					resource.TestCheckResourceAttr(resourceName, "migrate_services", "true"),
+					resource.TestCheckResourceAttrPair("aiven_pg.bar", "project_vpc_id", resourceName, "id"),
+				),
+			},
+			{
+				Config: testAccProjectVPCMigrateServicesResource(rName, "10.1.0.0/24"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "network_cidr", "10.1.0.0/24"),
+					resource.TestCheckResourceAttr(resourceName, "state", "ACTIVE"),
+					resource.TestCheckResourceAttr("aiven_pg.bar", "state", "RUNNING"),
+				),
+			},
+			{
+				// the service follows the VPC, so there is nothing left to change
+				Config:   testAccProjectVPCMigrateServicesResource(rName, "10.1.0.0/24"),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func testAccProjectVPCMigrateServicesResource(name, networkCIDR string) string {
+	return fmt.Sprintf(`
+resource "aiven_project" "foo" {
+  project = "test-acc-pr-%s"
+}
+
+resource "aiven_project_vpc" "bar" {
+  project          = aiven_project.foo.project
+  cloud_name       = "google-europe-west1"
+  network_cidr     = "%s"
+  migrate_services = true
+}
+
+resource "aiven_pg" "bar" {
+  project        = aiven_project.foo.project
+  cloud_name     = "google-europe-west1"
+  plan           = "startup-4"
+  service_name   = "test-acc-sr-%s"
+  project_vpc_id = aiven_project_vpc.bar.id
+}`, name, networkCIDR, name)
+}
+
 func testAccProjectVPCResource(name string) string {
 	return fmt.Sprintf(`
 resource "aiven_project" "foo" {
@@ -167,6 +231,15 @@ data "aiven_project_vpc" "vpc" {
 }`, name)
 }
 
+func testAccProjectVPCResourceOverlap(name string) string {
+	return testAccProjectVPCResource(name) + `
+resource "aiven_project_vpc" "overlap" {
+  project      = aiven_project.foo.project
+  cloud_name   = "google-europe-north1"
+  network_cidr = "192.168.0.128/25"
+}`
+}
+
 func testAccProjectVPCResourceGetById(name string) string {
 	return fmt.Sprintf(`
 resource "aiven_project" "foo" {
@@ -181,5 +254,9 @@ resource "aiven_project_vpc" "bar" {
 
 data "aiven_project_vpc" "vpc2" {
   vpc_id = aiven_project_vpc.bar.id
+}
+
+data "aiven_project_vpc_peering_connections" "peerings" {
+  vpc_id = aiven_project_vpc.bar.id
 }`, name)
 }
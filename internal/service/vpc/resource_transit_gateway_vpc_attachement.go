@@ -2,10 +2,14 @@ package vpc
 
 import (
 	"context"
+	"fmt"
+	"net"
+	"strings"
 	"time"
 
 	"github.com/aiven/aiven-go-client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
@@ -35,9 +39,10 @@ var aivenTransitGatewayVPCAttachmentSchema = map[string]*schema.Schema{
 		Type:        schema.TypeList,
 		Description: "List of private IPv4 ranges to route through the peering connection",
 		Elem: &schema.Schema{
-			Type:     schema.TypeString,
-			MaxItems: 128,
-			MinItems: 1,
+			Type:         schema.TypeString,
+			MaxItems:     128,
+			MinItems:     1,
+			ValidateFunc: schemautil.ValidateIPv4CIDR,
 		},
 	},
 	"peer_region": {
@@ -69,14 +74,19 @@ func ResourceTransitGatewayVPCAttachment() *schema.Resource {
 		ReadContext:   resourceVPCPeeringConnectionRead,
 		UpdateContext: resourceTransitGatewayVPCAttachmentUpdate,
 		DeleteContext: resourceVPCPeeringConnectionDelete,
+		CustomizeDiff: customdiff.IfValueChange("user_peer_network_cidrs",
+			func(_ context.Context, _, new, _ interface{}) bool { return len(new.([]interface{})) != 0 },
+			resourceTransitGatewayVPCAttachmentCustomizeDiff,
+		),
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importVPCPeeringConnection(vpcPeeringConnectionImportOptions{}),
 		},
 		Timeouts: &schema.ResourceTimeout{
-			Create: schema.DefaultTimeout(2 * time.Minute),
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
 		},
 
-		Schema: aivenTransitGatewayVPCAttachmentSchema,
+		Schema: withVPCPeeringConnectionWaitForActive(aivenTransitGatewayVPCAttachmentSchema),
 	}
 }
 
@@ -136,17 +146,101 @@ func resourceTransitGatewayVPCAttachmentUpdate(ctx context.Context, d *schema.Re
 		}
 	}
 
-	if len(add) == 0 && len(deleteCIDRs) == 0 {
-		return resourceVPCPeeringConnectionRead(ctx, d, m)
+	if len(add) != 0 || len(deleteCIDRs) != 0 {
+		_, err = client.TransitGatewayVPCAttachment.Update(p.projectName, p.vpcID, aiven.TransitGatewayVPCAttachmentRequest{
+			Add:    add,
+			Delete: deleteCIDRs,
+		})
+		if err != nil {
+			return diag.Errorf("cannot update transit gateway vpc attachment %s", err)
+		}
 	}
 
-	_, err = client.TransitGatewayVPCAttachment.Update(p.projectName, p.vpcID, aiven.TransitGatewayVPCAttachmentRequest{
-		Add:    add,
-		Delete: deleteCIDRs,
+	diags := updateVPCPeeringConnectionWaitForActive(ctx, d, func() (*aiven.VPCPeeringConnection, error) {
+		return client.VPCPeeringConnections.Get(p.projectName, p.vpcID, p.peerCloudAccount, p.peerVPC)
 	})
+	if diags.HasError() {
+		return diags
+	}
+
+	return append(diags, resourceVPCPeeringConnectionRead(ctx, d, m)...)
+}
+
+// resourceTransitGatewayVPCAttachmentCustomizeDiff checks that the peer network ranges do not
+// overlap with each other, with the project VPC or with the ranges of its other peering connections
+func resourceTransitGatewayVPCAttachmentCustomizeDiff(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("vpc_id") || !d.NewValueKnown("user_peer_network_cidrs") {
+		return nil
+	}
+
+	cidrs := schemautil.FlattenToString(d.Get("user_peer_network_cidrs").([]interface{}))
+	for i := range cidrs {
+		if !d.NewValueKnown(fmt.Sprintf("user_peer_network_cidrs.%d", i)) {
+			return nil
+		}
+	}
+
+	networks, err := schemautil.CheckCIDRsDoNotOverlap("user_peer_network_cidrs", cidrs)
+	if err != nil {
+		return err
+	}
+
+	projectName, vpcID, err := schemautil.SplitResourceID2(d.Get("vpc_id").(string))
+	if err != nil {
+		return err
+	}
+
+	client := m.(*aiven.Client)
+	vpc, err := client.VPCs.Get(projectName, vpcID)
 	if err != nil {
-		return diag.Errorf("cannot update transit gateway vpc attachment %s", err)
+		if aiven.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("unable to get project VPC %s: %w", vpcID, err)
+	}
+
+	return checkPeerNetworkCIDROverlap(networks, vpc, d.Get("peer_cloud_account").(string), d.Get("peer_vpc").(string))
+}
+
+// checkPeerNetworkCIDROverlap checks the peer network ranges of a peering connection against the
+// project VPC range and the peer network ranges of the other peering connections of the VPC
+func checkPeerNetworkCIDROverlap(networks []*net.IPNet, vpc *aiven.VPC, peerCloudAccount, peerVPC string) error {
+	var errs []string
+
+	if vpcNetwork, err := schemautil.ParseIPv4CIDR(vpc.NetworkCIDR); err == nil {
+		for i, n := range networks {
+			if schemautil.CIDRsOverlap(n, vpcNetwork) {
+				errs = append(errs, fmt.Sprintf("user_peer_network_cidrs.%d: %s overlaps with %s of the project VPC",
+					i, n, vpc.NetworkCIDR))
+			}
+		}
+	}
+
+	for _, pc := range vpc.PeeringConnections {
+		if pc.PeerCloudAccount == peerCloudAccount && pc.PeerVPC == peerVPC {
+			continue
+		}
+		if pc.State == "DELETING" || pc.State == "DELETED" || pc.State == "DELETED_BY_PEER" {
+			continue
+		}
+
+		for _, c := range pc.UserPeerNetworkCIDRs {
+			other, err := schemautil.ParseIPv4CIDR(c)
+			if err != nil {
+				continue
+			}
+			for i, n := range networks {
+				if schemautil.CIDRsOverlap(n, other) {
+					errs = append(errs, fmt.Sprintf("user_peer_network_cidrs.%d: %s overlaps with %s of the peering connection to %s/%s",
+						i, n, c, pc.PeerCloudAccount, pc.PeerVPC))
+				}
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "\n"))
 	}
 
-	return resourceVPCPeeringConnectionRead(ctx, d, m)
+	return nil
 }
@@ -2,8 +2,9 @@ package vpc
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -89,7 +90,7 @@ func ResourceVPCPeeringConnection() *schema.Resource {
 		ReadContext:   resourceVPCPeeringConnectionRead,
 		DeleteContext: resourceVPCPeeringConnectionDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: resourceVPCPeeringConnectionImport,
+			StateContext: importVPCPeeringConnection(vpcPeeringConnectionImportOptions{withResourceGroup: true}),
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(2 * time.Minute),
@@ -156,17 +157,10 @@ func resourceVPCPeeringConnectionCreate(ctx context.Context, d *schema.ResourceD
 		return diag.Errorf("error waiting for VPC peering connection creation: %s", err)
 	}
 
+	pending, target := vpcPeeringConnectionCreateStates(d)
 	stateChangeConf := &resource.StateChangeConf{
-		Pending: []string{"APPROVED"},
-		Target: []string{
-			"ACTIVE",
-			"REJECTED_BY_PEER",
-			"PENDING_PEER",
-			"INVALID_SPECIFICATION",
-			"DELETING",
-			"DELETED",
-			"DELETED_BY_PEER",
-		},
+		Pending: pending,
+		Target:  target,
 		Refresh: func() (interface{}, string, error) {
 			pc, err := client.VPCPeeringConnections.GetVPCPeering(
 				projectName,
@@ -207,25 +201,24 @@ func resourceVPCPeeringConnectionCreate(ctx context.Context, d *schema.ResourceD
 	return append(diags, resourceVPCPeeringConnectionRead(ctx, d, m)...)
 }
 
-// stateInfoToString converts VPC peering connection state_info to a string
+// stateInfoToString converts VPC peering connection state_info to a string, the message first
 func stateInfoToString(s *map[string]interface{}) string {
-	if len(*s) == 0 {
+	info := ConvertStateInfoToMap(s)
+	if len(info) == 0 {
 		return ""
 	}
 
-	var str string
-	// Print message first
-	if m, ok := (*s)["message"]; ok {
-		str = fmt.Sprintf("%s", m)
-		delete(*s, "message")
+	str := info["message"]
+	keys := make([]string, 0, len(info))
+	for k := range info {
+		if k != "message" {
+			keys = append(keys, k)
+		}
 	}
+	sort.Strings(keys)
 
-	for k, v := range *s {
-		if _, ok := v.(string); ok {
-			str += fmt.Sprintf("\n %q:%q", k, v)
-		} else {
-			str += fmt.Sprintf("\n %q:`%+v`", k, v)
-		}
+	for _, k := range keys {
+		str += fmt.Sprintf("\n %q:%q", k, info[k])
 	}
 
 	return str
@@ -282,6 +275,13 @@ func resourceVPCPeeringConnectionRead(_ context.Context, d *schema.ResourceData,
 		return diag.Errorf("error parsing peering VPC ID: %s", err)
 	}
 	isAzure, err := isAzureVPCPeeringConnection(d, client)
+	if aiven.IsNotFound(err) {
+		if _, err = findMigratedVPCPeering(client, p, err); err != nil {
+			return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
+		}
+		d.SetId(p.resourceID())
+		isAzure, err = isAzureVPCPeeringConnection(d, client)
+	}
 	if err != nil {
 		return diag.Errorf("Error checking if it Azure VPC peering connection: %s", err)
 	}
@@ -399,30 +399,6 @@ func resourceVPCPeeringConnectionDelete(ctx context.Context, d *schema.ResourceD
 	return nil
 }
 
-func resourceVPCPeeringConnectionImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-	if len(strings.Split(d.Id(), "/")) != 4 {
-		return nil, fmt.Errorf("invalid identifier %v, expected <project_name>/<vpc_id>/<peer_cloud_account>/<peer_vpc>", d.Id())
-	}
-
-	client := m.(*aiven.Client)
-
-	p, err := parsePeerVPCID(d.Id())
-	if err != nil {
-		return nil, fmt.Errorf("error parsing peering VPC ID: %s", err)
-	}
-	_, err = client.VPCPeeringConnections.GetVPCPeering(p.projectName, p.vpcID, p.peerCloudAccount, p.peerVPC, p.peerRegion)
-	if err != nil && schemautil.IsUnknownResource(err) {
-		return nil, errors.New("cannot find specified VPC peering connection")
-	}
-
-	dig := resourceVPCPeeringConnectionRead(ctx, d, m)
-	if dig.HasError() {
-		return nil, errors.New("cannot get VPC peering connection")
-	}
-
-	return []*schema.ResourceData{d}, nil
-}
-
 func copyAzureSpecificVPCPeeringConnectionPropertiesFromAPIResponseToTerraform(
 	d *schema.ResourceData,
 	peeringConnection *aiven.VPCPeeringConnection,
@@ -542,6 +518,8 @@ func copyVPCPeeringConnectionPropertiesFromAPIResponseToTerraform(
 	return diags
 }
 
+// ConvertStateInfoToMap flattens the state info into a map, the keys of nested objects and lists
+// are joined with dots, e.g. `warnings.0.message`
 func ConvertStateInfoToMap(s *map[string]interface{}) map[string]string {
 	if s == nil || len(*s) == 0 {
 		return nil
@@ -549,16 +527,32 @@ func ConvertStateInfoToMap(s *map[string]interface{}) map[string]string {
 
 	r := make(map[string]string)
 	for k, v := range *s {
-		if _, ok := v.(string); ok {
-			r[k] = v.(string)
-		} else {
-			r[k] = fmt.Sprintf("%+v", v)
-		}
+		flattenStateInfo(r, k, v)
 	}
 
 	return r
 }
 
+func flattenStateInfo(r map[string]string, key string, v interface{}) {
+	switch v := v.(type) {
+	case nil:
+	case string:
+		r[key] = v
+	case float64:
+		r[key] = strconv.FormatFloat(v, 'f', -1, 64)
+	case map[string]interface{}:
+		for k, item := range v {
+			flattenStateInfo(r, key+"."+k, item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			flattenStateInfo(r, key+"."+strconv.Itoa(i), item)
+		}
+	default:
+		r[key] = fmt.Sprintf("%v", v)
+	}
+}
+
 // isAzureVPCPeeringConnection checking if peered VPC is in the Azure cloud
 func isAzureVPCPeeringConnection(d *schema.ResourceData, c *aiven.Client) (bool, error) {
 	p, err := parsePeerVPCID(d.Id())
@@ -595,11 +589,11 @@ func getDiagnosticsFromState(pc *aiven.VPCPeeringConnection) diag.Diagnostics {
 		case "PENDING_PEER":
 			return diag.Diagnostics{{
 				Severity: diag.Warning,
-				Summary: fmt.Sprintf("Aiven platform has created a connection to the specified "+
-					"peer successfully in the cloud, but the connection is not active until the user "+
-					"completes the setup in their cloud account. The steps needed in the user cloud "+
-					"account depend on the used cloud provider. Find more in the state info: %s",
-					stateInfoToString(pc.StateInfo))}}
+				Summary: "Aiven platform has created a connection to the specified peer successfully in " +
+					"the cloud, but the connection is not active until the user completes the setup in " +
+					"their cloud account",
+				Detail: fmt.Sprintf("%s Find more in the state info: %s",
+					pendingPeerHint(pc), stateInfoToString(pc.StateInfo))}}
 		case "DELETED":
 			return diag.Errorf("A user has deleted the peering connection through the Aiven " +
 				"Terraform provider, or Aiven Web Console or directly via Aiven API. There are no " +
@@ -2,8 +2,16 @@ package vpc
 
 import (
 	"errors"
+	"net"
 	"reflect"
+	"strings"
 	"testing"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
 )
 
 func Test_validateVPCID(t *testing.T) {
@@ -42,3 +50,87 @@ func Test_validateVPCID(t *testing.T) {
 		})
 	}
 }
+
+func Test_checkProjectVPCCIDROverlap(t *testing.T) {
+	vpcs := []*aiven.VPC{
+		{ProjectVPCID: "current", CloudName: "aws-eu-west-1", NetworkCIDR: "10.0.0.0/24", State: "ACTIVE"},
+		{ProjectVPCID: "deleting", CloudName: "aws-eu-west-1", NetworkCIDR: "10.1.0.0/24", State: "DELETING"},
+		{ProjectVPCID: "other", CloudName: "google-europe-west1", NetworkCIDR: "10.2.0.0/24", State: "ACTIVE"},
+	}
+
+	for cidr, wantErr := range map[string]string{
+		"10.0.0.0/14":   "network_cidr: 10.0.0.0/14 overlaps with 10.2.0.0/24 of project VPC other in google-europe-west1",
+		"10.0.0.0/24":   "",
+		"10.1.0.0/24":   "",
+		"10.2.0.128/25": "network_cidr: 10.2.0.128/25 overlaps with 10.2.0.0/24 of project VPC other in google-europe-west1",
+		"10.3.0.0/24":   "",
+	} {
+		_, network, _ := net.ParseCIDR(cidr)
+		err := checkProjectVPCCIDROverlap(network, "current", vpcs)
+		if wantErr == "" {
+			assert.NoError(t, err, cidr)
+			continue
+		}
+		assert.EqualError(t, err, wantErr, cidr)
+	}
+}
+
+func Test_checkPeerNetworkCIDROverlap(t *testing.T) {
+	vpc := &aiven.VPC{
+		NetworkCIDR: "10.0.0.0/24",
+		PeeringConnections: []*aiven.VPCPeeringConnection{
+			{PeerCloudAccount: "123", PeerVPC: "tgw-self", State: "ACTIVE", UserPeerNetworkCIDRs: []string{"172.16.0.0/16"}},
+			{PeerCloudAccount: "123", PeerVPC: "tgw-other", State: "ACTIVE", UserPeerNetworkCIDRs: []string{"172.17.0.0/16"}},
+			{PeerCloudAccount: "123", PeerVPC: "tgw-gone", State: "DELETED", UserPeerNetworkCIDRs: []string{"172.18.0.0/16"}},
+		},
+	}
+
+	networks, err := schemautil.CheckCIDRsDoNotOverlap("user_peer_network_cidrs", []string{"172.16.0.0/16", "172.18.0.0/16"})
+	require.NoError(t, err)
+	assert.NoError(t, checkPeerNetworkCIDROverlap(networks, vpc, "123", "tgw-self"))
+
+	networks, err = schemautil.CheckCIDRsDoNotOverlap("user_peer_network_cidrs", []string{"10.0.0.128/25", "172.17.5.0/24"})
+	require.NoError(t, err)
+	assert.EqualError(t, checkPeerNetworkCIDROverlap(networks, vpc, "123", "tgw-self"),
+		"user_peer_network_cidrs.0: 10.0.0.128/25 overlaps with 10.0.0.0/24 of the project VPC\n"+
+			"user_peer_network_cidrs.1: 172.17.5.0/24 overlaps with 172.17.0.0/16 of the peering connection to 123/tgw-other")
+}
+
+func Test_findVPCPeeringConnection(t *testing.T) {
+	region := "eu-west-1"
+	otherRegion := "eu-north-1"
+	vpc := &aiven.VPC{
+		PeeringConnections: []*aiven.VPCPeeringConnection{
+			{PeerCloudAccount: "123", PeerVPC: "vpc-a", PeerRegion: &otherRegion, State: "ACTIVE"},
+			{PeerCloudAccount: "123", PeerVPC: "vpc-a", PeerRegion: &region, State: "DELETED"},
+			{PeerCloudAccount: "123", PeerVPC: "vpc-a", PeerRegion: &region, State: "PENDING_PEER"},
+			{PeerCloudAccount: "123", PeerVPC: "vpc-b", State: "ACTIVE"},
+			{PeerCloudAccount: "sub", PeerVPC: "vnet", PeerResourceGroup: "rg", State: "ACTIVE"},
+		},
+	}
+
+	tests := []struct {
+		name string
+		pc   *aiven.VPCPeeringConnection
+		want *aiven.VPCPeeringConnection
+	}{
+		{"region", &aiven.VPCPeeringConnection{PeerCloudAccount: "123", PeerVPC: "vpc-a", PeerRegion: &region}, vpc.PeeringConnections[2]},
+		{"no region", &aiven.VPCPeeringConnection{PeerCloudAccount: "123", PeerVPC: "vpc-b"}, vpc.PeeringConnections[3]},
+		{"region mismatch", &aiven.VPCPeeringConnection{PeerCloudAccount: "123", PeerVPC: "vpc-b", PeerRegion: &region}, nil},
+		{"resource group", &aiven.VPCPeeringConnection{PeerCloudAccount: "sub", PeerVPC: "vnet", PeerResourceGroup: "rg"}, vpc.PeeringConnections[4]},
+		{"resource group mismatch", &aiven.VPCPeeringConnection{PeerCloudAccount: "sub", PeerVPC: "vnet", PeerResourceGroup: "other"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Same(t, tt.want, findVPCPeeringConnection(vpc, tt.pc))
+		})
+	}
+}
+
+func Test_peeringVPCIDResourceID(t *testing.T) {
+	for _, id := range []string{"project/vpc/123/vpc-a", "project/vpc/123/vpc-a/eu-west-1"} {
+		p, err := parsePeerVPCIDSized(id, peeringVPCIDSizeType(len(strings.Split(id, "/"))))
+		require.NoError(t, err)
+		assert.Equal(t, id, p.resourceID())
+	}
+}
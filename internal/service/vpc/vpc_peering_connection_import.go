@@ -0,0 +1,116 @@
+package vpc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+)
+
+// vpcPeeringConnectionImportOptions describes the resource ID and the import-only fields of a
+// peering connection resource
+type vpcPeeringConnectionImportOptions struct {
+	// withRegion is set for the resources whose ID ends with the peer region
+	withRegion bool
+	// withResourceGroup is set for the resources which need peer_resource_group to read an Azure peering
+	withResourceGroup bool
+}
+
+// importVPCPeeringConnection imports a peering connection by its resource ID or by its peer attributes:
+//   - <project_name>/<vpc_id>/<peer_vpc>
+//   - <project_name>/<vpc_id>/<peer_cloud_account>/<peer_vpc>
+//   - <project_name>/<vpc_id>/<peer_cloud_account>/<peer_vpc>/<peer_region>
+//
+// The peering is looked up in the project VPC and the ID is rewritten to the resource ID format,
+// which also makes the IDs of the deprecated aiven_vpc_peering_connection importable into the
+// cloud specific resources.
+func importVPCPeeringConnection(opts vpcPeeringConnectionImportOptions) schema.StateContextFunc {
+	return func(_ context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+		client := m.(*aiven.Client)
+
+		chunks := strings.Split(d.Id(), "/")
+		if len(chunks) < 3 || len(chunks) > 5 {
+			return nil, fmt.Errorf("invalid identifier %v, expected <project_name>/<vpc_id>/<peer_vpc>, "+
+				"<project_name>/<vpc_id>/<peer_cloud_account>/<peer_vpc> or "+
+				"<project_name>/<vpc_id>/<peer_cloud_account>/<peer_vpc>/<peer_region>", d.Id())
+		}
+
+		peer := &aiven.VPCPeeringConnection{PeerVPC: chunks[len(chunks)-1]}
+		if len(chunks) >= 4 {
+			peer.PeerCloudAccount = chunks[2]
+			peer.PeerVPC = chunks[3]
+		}
+		if len(chunks) == 5 {
+			peer.PeerRegion = &chunks[4]
+		}
+
+		vpc, err := client.VPCs.Get(chunks[0], chunks[1])
+		if err != nil {
+			return nil, fmt.Errorf("cannot get project VPC %s/%s: %w", chunks[0], chunks[1], err)
+		}
+
+		pc, err := findVPCPeeringConnectionByPeer(vpc, peer)
+		if err != nil {
+			return nil, err
+		}
+
+		p := &peeringVPCID{
+			projectName:      chunks[0],
+			vpcID:            chunks[1],
+			peerCloudAccount: pc.PeerCloudAccount,
+			peerVPC:          pc.PeerVPC,
+		}
+		if opts.withRegion {
+			if pc.PeerRegion == nil || *pc.PeerRegion == "" {
+				return nil, fmt.Errorf("peer region of the peering connection to %s/%s is unknown", pc.PeerCloudAccount, pc.PeerVPC)
+			}
+			p.peerRegion = pc.PeerRegion
+		}
+		d.SetId(p.resourceID())
+
+		if opts.withResourceGroup && pc.PeerResourceGroup != "" {
+			if err := d.Set("peer_resource_group", pc.PeerResourceGroup); err != nil {
+				return nil, err
+			}
+		}
+
+		return []*schema.ResourceData{d}, nil
+	}
+}
+
+// findVPCPeeringConnectionByPeer looks up the peering connection of a project VPC by the peer
+// attributes which are set, the peer cloud account and region are optional
+func findVPCPeeringConnectionByPeer(vpc *aiven.VPC, peer *aiven.VPCPeeringConnection) (*aiven.VPCPeeringConnection, error) {
+	var found []*aiven.VPCPeeringConnection
+	for _, pc := range vpc.PeeringConnections {
+		if vpcPeeringConnectionIsGone(pc) || pc.PeerVPC != peer.PeerVPC {
+			continue
+		}
+		if peer.PeerCloudAccount != "" && pc.PeerCloudAccount != peer.PeerCloudAccount {
+			continue
+		}
+		if peer.PeerRegion != nil && (pc.PeerRegion == nil || *pc.PeerRegion != *peer.PeerRegion) {
+			continue
+		}
+		found = append(found, pc)
+	}
+
+	switch len(found) {
+	case 0:
+		return nil, fmt.Errorf("cannot find a peering connection to %s in project VPC %s", peer.PeerVPC, vpc.ProjectVPCID)
+	case 1:
+		return found[0], nil
+	}
+
+	candidates := make([]string, len(found))
+	for i, pc := range found {
+		candidates[i] = schemautil.BuildResourceID(pc.PeerCloudAccount, pc.PeerVPC)
+	}
+	return nil, fmt.Errorf("found %d peering connections to %s in project VPC %s, import with "+
+		"<project_name>/<vpc_id>/<peer_cloud_account>/<peer_vpc> using one of: %s",
+		len(found), peer.PeerVPC, vpc.ProjectVPCID, strings.Join(candidates, ", "))
+}
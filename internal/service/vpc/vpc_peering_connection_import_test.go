@@ -0,0 +1,102 @@
+package vpc
+
+import (
+	"testing"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_findVPCPeeringConnectionByPeer(t *testing.T) {
+	vpc := &aiven.VPC{
+		ProjectVPCID: "vpc",
+		CloudName:    "aws-eu-west-1",
+		PeeringConnections: []*aiven.VPCPeeringConnection{
+			{PeerCloudAccount: "111", PeerVPC: "vpc-a", PeerRegion: aiven.ToStringPointer("eu-west-1"), State: "ACTIVE"},
+			{PeerCloudAccount: "222", PeerVPC: "vpc-a", PeerRegion: aiven.ToStringPointer("eu-north-1"), State: "PENDING_PEER"},
+			{PeerCloudAccount: "111", PeerVPC: "vpc-b", PeerRegion: aiven.ToStringPointer("eu-west-1"), State: "ACTIVE"},
+			{PeerCloudAccount: "111", PeerVPC: "vpc-c", PeerRegion: aiven.ToStringPointer("eu-west-1"), State: "DELETED"},
+		},
+	}
+
+	pc, err := findVPCPeeringConnectionByPeer(vpc, &aiven.VPCPeeringConnection{PeerVPC: "vpc-b"})
+	require.NoError(t, err)
+	assert.Equal(t, "111", pc.PeerCloudAccount)
+
+	pc, err = findVPCPeeringConnectionByPeer(vpc, &aiven.VPCPeeringConnection{PeerCloudAccount: "222", PeerVPC: "vpc-a"})
+	require.NoError(t, err)
+	assert.Equal(t, "eu-north-1", *pc.PeerRegion)
+
+	pc, err = findVPCPeeringConnectionByPeer(vpc, &aiven.VPCPeeringConnection{
+		PeerCloudAccount: "111", PeerVPC: "vpc-a", PeerRegion: aiven.ToStringPointer("eu-west-1"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "ACTIVE", pc.State)
+
+	_, err = findVPCPeeringConnectionByPeer(vpc, &aiven.VPCPeeringConnection{PeerVPC: "vpc-a"})
+	assert.ErrorContains(t, err, "found 2 peering connections to vpc-a in project VPC vpc, import with "+
+		"<project_name>/<vpc_id>/<peer_cloud_account>/<peer_vpc> using one of: 111/vpc-a, 222/vpc-a")
+
+	_, err = findVPCPeeringConnectionByPeer(vpc, &aiven.VPCPeeringConnection{PeerVPC: "vpc-c"})
+	assert.ErrorContains(t, err, "cannot find a peering connection to vpc-c")
+
+	_, err = findVPCPeeringConnectionByPeer(vpc, &aiven.VPCPeeringConnection{
+		PeerCloudAccount: "111", PeerVPC: "vpc-a", PeerRegion: aiven.ToStringPointer("us-east-1"),
+	})
+	assert.Error(t, err)
+}
+
+func Test_vpcPeeringConnectionResourceType(t *testing.T) {
+	tests := []struct {
+		name         string
+		cloudName    string
+		pc           *aiven.VPCPeeringConnection
+		resourceType string
+		importID     string
+	}{
+		{
+			name:         "aws",
+			cloudName:    "aws-eu-west-1",
+			pc:           &aiven.VPCPeeringConnection{PeerCloudAccount: "111", PeerVPC: "vpc-a", PeerRegion: aiven.ToStringPointer("eu-west-1")},
+			resourceType: "aiven_aws_vpc_peering_connection",
+			importID:     "project/vpc/111/vpc-a/eu-west-1",
+		},
+		{
+			name:      "transit gateway",
+			cloudName: "aws-eu-west-1",
+			pc: &aiven.VPCPeeringConnection{PeerCloudAccount: "111", PeerVPC: "tgw-a", PeerRegion: aiven.ToStringPointer("eu-west-1"),
+				UserPeerNetworkCIDRs: []string{"10.0.0.0/16"}},
+			resourceType: "aiven_transit_gateway_vpc_attachment",
+			importID:     "project/vpc/111/tgw-a",
+		},
+		{
+			name:         "gcp",
+			cloudName:    "google-europe-west1",
+			pc:           &aiven.VPCPeeringConnection{PeerCloudAccount: "my-project", PeerVPC: "my-network"},
+			resourceType: "aiven_gcp_vpc_peering_connection",
+			importID:     "project/vpc/my-project/my-network",
+		},
+		{
+			name:         "azure",
+			cloudName:    "azure-westeurope",
+			pc:           &aiven.VPCPeeringConnection{PeerCloudAccount: "sub", PeerVPC: "vnet", PeerResourceGroup: "rg"},
+			resourceType: "aiven_azure_vpc_peering_connection",
+			importID:     "project/vpc/sub/vnet",
+		},
+		{
+			name:         "unknown cloud",
+			cloudName:    "do-ams",
+			pc:           &aiven.VPCPeeringConnection{PeerCloudAccount: "acc", PeerVPC: "net"},
+			resourceType: "aiven_vpc_peering_connection",
+			importID:     "project/vpc/acc/net",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resourceType, importID := vpcPeeringConnectionResourceType("project", &aiven.VPC{ProjectVPCID: "vpc", CloudName: tt.cloudName}, tt.pc)
+			assert.Equal(t, tt.resourceType, resourceType)
+			assert.Equal(t, tt.importID, importID)
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package vpc
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+)
+
+// azurePeeringRoleDefinitionName is the role the peer app registration needs on the peer VNet to
+// create the peering back to the Aiven VNet
+const azurePeeringRoleDefinitionName = "Network Contributor"
+
+var vpcPeeringConnectionWaitForActiveSchema = &schema.Schema{
+	Optional: true,
+	Type:     schema.TypeBool,
+	Default:  false,
+	Description: schemautil.Complex("Wait until the peer side accepts the peering connection and it becomes `ACTIVE` " +
+		"instead of returning once it is `PENDING_PEER`. The `create` and `update` timeouts default to 20 minutes, use a " +
+		"`timeouts` block to give the peer more time, and only enable it when the peering is accepted outside of the " +
+		"same Terraform run.").DefaultValue(false).Build(),
+}
+
+// withVPCPeeringConnectionWaitForActive adds `wait_for_active` to a peering connection resource
+// schema, the data sources share the schema but have nothing to wait for
+func withVPCPeeringConnectionWaitForActive(s map[string]*schema.Schema) map[string]*schema.Schema {
+	r := make(map[string]*schema.Schema, len(s)+1)
+	for k, v := range s {
+		r[k] = v
+	}
+	r["wait_for_active"] = vpcPeeringConnectionWaitForActiveSchema
+	return r
+}
+
+// vpcPeeringConnectionCreateStates returns the states to wait through after creating a peering
+// connection, `PENDING_PEER` is only waited through with `wait_for_active`
+func vpcPeeringConnectionCreateStates(d *schema.ResourceData) (pending []string, target []string) {
+	pending = []string{"APPROVED"}
+	target = []string{
+		"ACTIVE",
+		"REJECTED_BY_PEER",
+		"INVALID_SPECIFICATION",
+		"DELETING",
+		"DELETED",
+		"DELETED_BY_PEER",
+	}
+
+	// the deprecated resource has no wait_for_active
+	if v, ok := d.GetOk("wait_for_active"); ok && v.(bool) {
+		return append(pending, "PENDING_PEER"), target
+	}
+	return pending, append(target, "PENDING_PEER")
+}
+
+// updateVPCPeeringConnectionWaitForActive waits for a pending peering connection to become active
+// when `wait_for_active` gets enabled, the other fields of the peering resources force a new one
+func updateVPCPeeringConnectionWaitForActive(
+	ctx context.Context,
+	d *schema.ResourceData,
+	get func() (*aiven.VPCPeeringConnection, error),
+) diag.Diagnostics {
+	if !d.HasChange("wait_for_active") || !d.Get("wait_for_active").(bool) {
+		return nil
+	}
+
+	pending, target := vpcPeeringConnectionCreateStates(d)
+	stateChangeConf := &resource.StateChangeConf{
+		Pending: pending,
+		Target:  target,
+		Refresh: func() (interface{}, string, error) {
+			pc, err := get()
+			if err != nil {
+				return nil, "", err
+			}
+			log.Printf("[DEBUG] Got %s state while waiting for VPC peering connection to be active.", pc.State)
+			return pc, pc.State, nil
+		},
+		Delay:      10 * time.Second,
+		Timeout:    d.Timeout(schema.TimeoutUpdate),
+		MinTimeout: 2 * time.Second,
+	}
+
+	res, err := stateChangeConf.WaitForStateContext(ctx)
+	if err != nil {
+		return diag.Errorf("Error waiting for VPC peering connection to be ACTIVE: %s", err)
+	}
+
+	return getDiagnosticsFromState(res.(*aiven.VPCPeeringConnection))
+}
+
+// stateInfoValue returns the first of the state info keys which is set, the Azure keys come
+// both with dashes and with underscores
+func stateInfoValue(pc *aiven.VPCPeeringConnection, keys ...string) string {
+	if pc.StateInfo == nil {
+		return ""
+	}
+	for _, k := range keys {
+		if v, ok := (*pc.StateInfo)[k].(string); ok && v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// gcpPeerNetworkSelfLink returns the Aiven VPC network the peer GCP network has to be peered
+// back to, it's only known while the peering connection is pending
+func gcpPeerNetworkSelfLink(pc *aiven.VPCPeeringConnection) string {
+	toProjectID := stateInfoValue(pc, "to_project_id")
+	toVPCNetwork := stateInfoValue(pc, "to_vpc_network")
+	if toProjectID == "" || toVPCNetwork == "" {
+		return ""
+	}
+	return fmt.Sprintf("https://www.googleapis.com/compute/v1/projects/%s/global/networks/%s", toProjectID, toVPCNetwork)
+}
+
+func azureVNetID(subscriptionID, resourceGroup, vnetName string) string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/virtualNetworks/%s",
+		subscriptionID, resourceGroup, vnetName)
+}
+
+// pendingPeerHint describes what has to be done in the peer cloud account to activate a pending
+// peering connection
+func pendingPeerHint(pc *aiven.VPCPeeringConnection) string {
+	if id := stateInfoValue(pc, "aws_vpc_peering_connection_id"); id != "" {
+		return fmt.Sprintf("Accept the VPC peering connection %s in AWS account %s, e.g. with the "+
+			"`aws_vpc_peering_connection_accepter` resource.", id, pc.PeerCloudAccount)
+	}
+
+	if link := gcpPeerNetworkSelfLink(pc); link != "" {
+		return fmt.Sprintf("Create a peering from the network %s of GCP project %s to %s, e.g. with the "+
+			"`google_compute_network_peering` resource.", pc.PeerVPC, pc.PeerCloudAccount, link)
+	}
+
+	tenantID := stateInfoValue(pc, "to-tenant-id", "to_tenant_id")
+	networkID := stateInfoValue(pc, "to-network-id", "to_network_id")
+	if tenantID != "" && networkID != "" {
+		return fmt.Sprintf("Sign in to the Azure tenant %s with the app registration %s, which needs the %q "+
+			"role on %s, and create a peering from it to %s, e.g. with the `azurerm_virtual_network_peering` resource.",
+			tenantID, pc.PeerAzureAppId, azurePeeringRoleDefinitionName,
+			azureVNetID(pc.PeerCloudAccount, pc.PeerResourceGroup, pc.PeerVPC), networkID)
+	}
+
+	return "The steps needed in the peer cloud account depend on the cloud provider, find more in the state info."
+}
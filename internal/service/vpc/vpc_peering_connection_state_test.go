@@ -0,0 +1,127 @@
+package vpc
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testStateInfo(t *testing.T, s string) *map[string]interface{} {
+	var info map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(s), &info))
+	return &info
+}
+
+func TestConvertStateInfoToMap(t *testing.T) {
+	info := testStateInfo(t, `{
+		"message": "Peering rejected",
+		"type": "error",
+		"warnings": [{"message": "Overlapping CIDR", "conflicting_aws_vpc_id": "vpc-1"}],
+		"retries": 3,
+		"ignored": null
+	}`)
+
+	assert.Equal(t, map[string]string{
+		"message":                           "Peering rejected",
+		"type":                              "error",
+		"warnings.0.message":                "Overlapping CIDR",
+		"warnings.0.conflicting_aws_vpc_id": "vpc-1",
+		"retries":                           "3",
+	}, ConvertStateInfoToMap(info))
+	assert.Nil(t, ConvertStateInfoToMap(nil))
+	assert.Nil(t, ConvertStateInfoToMap(&map[string]interface{}{}))
+}
+
+func Test_stateInfoToString(t *testing.T) {
+	info := testStateInfo(t, `{"type": "error", "message": "Peering rejected", "code": 1}`)
+
+	assert.Equal(t, "Peering rejected\n \"code\":\"1\"\n \"type\":\"error\"", stateInfoToString(info))
+	assert.Contains(t, *info, "message", "the state info must not be modified")
+}
+
+func Test_vpcPeeringConnectionCreateStates(t *testing.T) {
+	s := withVPCPeeringConnectionWaitForActive(aivenGCPVPCPeeringConnectionSchema)
+
+	pending, target := vpcPeeringConnectionCreateStates(schema.TestResourceDataRaw(t, s, map[string]interface{}{}))
+	assert.Equal(t, []string{"APPROVED"}, pending)
+	assert.Contains(t, target, "PENDING_PEER")
+
+	pending, target = vpcPeeringConnectionCreateStates(schema.TestResourceDataRaw(t, s, map[string]interface{}{
+		"wait_for_active": true,
+	}))
+	assert.Equal(t, []string{"APPROVED", "PENDING_PEER"}, pending)
+	assert.NotContains(t, target, "PENDING_PEER")
+	assert.Contains(t, target, "ACTIVE")
+
+	// the deprecated resource has no wait_for_active
+	pending, _ = vpcPeeringConnectionCreateStates(schema.TestResourceDataRaw(t, aivenVPCPeeringConnectionSchema, map[string]interface{}{}))
+	assert.Equal(t, []string{"APPROVED"}, pending)
+}
+
+func Test_pendingPeerHint(t *testing.T) {
+	tests := []struct {
+		name string
+		pc   *aiven.VPCPeeringConnection
+		want string
+	}{
+		{
+			name: "aws",
+			pc: &aiven.VPCPeeringConnection{
+				PeerCloudAccount: "123456789012",
+				StateInfo:        testStateInfo(t, `{"aws_vpc_peering_connection_id": "pcx-1"}`),
+			},
+			want: "Accept the VPC peering connection pcx-1 in AWS account 123456789012",
+		},
+		{
+			name: "gcp",
+			pc: &aiven.VPCPeeringConnection{
+				PeerCloudAccount: "my-project",
+				PeerVPC:          "my-network",
+				StateInfo:        testStateInfo(t, `{"to_project_id": "aiven-project", "to_vpc_network": "aiven-network"}`),
+			},
+			want: "to https://www.googleapis.com/compute/v1/projects/aiven-project/global/networks/aiven-network",
+		},
+		{
+			name: "azure",
+			pc: &aiven.VPCPeeringConnection{
+				PeerCloudAccount:  "sub",
+				PeerVPC:           "vnet",
+				PeerResourceGroup: "rg",
+				PeerAzureAppId:    "app",
+				StateInfo:         testStateInfo(t, `{"to-tenant-id": "tenant", "to-network-id": "aiven-vnet"}`),
+			},
+			want: "Sign in to the Azure tenant tenant with the app registration app, which needs the \"Network Contributor\" " +
+				"role on /subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/virtualNetworks/vnet, " +
+				"and create a peering from it to aiven-vnet",
+		},
+		{
+			name: "unknown",
+			pc:   &aiven.VPCPeeringConnection{},
+			want: "find more in the state info",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Contains(t, pendingPeerHint(tt.pc), tt.want)
+		})
+	}
+}
+
+func Test_getDiagnosticsFromState(t *testing.T) {
+	assert.Nil(t, getDiagnosticsFromState(&aiven.VPCPeeringConnection{State: "ACTIVE"}))
+
+	diags := getDiagnosticsFromState(&aiven.VPCPeeringConnection{
+		State:     "PENDING_PEER",
+		StateInfo: testStateInfo(t, `{"message": "Pending", "aws_vpc_peering_connection_id": "pcx-1"}`),
+	})
+	require.Len(t, diags, 1)
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+	assert.Contains(t, diags[0].Detail, "pcx-1")
+
+	assert.True(t, getDiagnosticsFromState(&aiven.VPCPeeringConnection{State: "REJECTED_BY_PEER"}).HasError())
+}